@@ -0,0 +1,100 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include "version.h"
+*/
+import "C"
+
+// bndArena is a per-Stmt bump allocator of the scratch buffers handed to OCI
+// by slice binds: null indicators, actual lengths, return codes and OCINumbers.
+//
+// The arena is reset at the start of each Stmt.bind. Once an arena has grown
+// to fit the binds of a statement, re-executing the statement with params of
+// a similar shape allocates nothing. Buffers returned by the arena are zeroed
+// and remain valid until the next reset.
+type bndArena struct {
+	sb2Buf    []C.sb2
+	sb2Off    int
+	alenBuf   []C.ACTUAL_LENGTH_TYPE
+	alenOff   int
+	ub2Buf    []C.ub2
+	ub2Off    int
+	numberBuf []C.OCINumber
+	numberOff int
+}
+
+// arenaCap returns the capacity of a grown arena buffer.
+func arenaCap(size, need int) int {
+	if size *= 2; size < need {
+		return need
+	}
+	return size
+}
+
+// reset makes the arena's buffers available for reuse.
+func (a *bndArena) reset() {
+	a.sb2Off, a.alenOff, a.ub2Off, a.numberOff = 0, 0, 0, 0
+}
+
+// release drops the arena's buffers.
+func (a *bndArena) release() {
+	*a = bndArena{}
+}
+
+// sb2s returns n zeroed null indicators.
+func (a *bndArena) sb2s(n int) []C.sb2 {
+	if a.sb2Off+n > len(a.sb2Buf) {
+		// earlier buffers stay referenced by their binds
+		a.sb2Buf, a.sb2Off = make([]C.sb2, arenaCap(len(a.sb2Buf), a.sb2Off+n)), 0
+	}
+	s := a.sb2Buf[a.sb2Off : a.sb2Off+n : a.sb2Off+n]
+	a.sb2Off += n
+	for i := range s {
+		s[i] = 0
+	}
+	return s
+}
+
+// alens returns n zeroed actual lengths.
+func (a *bndArena) alens(n int) []C.ACTUAL_LENGTH_TYPE {
+	if a.alenOff+n > len(a.alenBuf) {
+		a.alenBuf, a.alenOff = make([]C.ACTUAL_LENGTH_TYPE, arenaCap(len(a.alenBuf), a.alenOff+n)), 0
+	}
+	s := a.alenBuf[a.alenOff : a.alenOff+n : a.alenOff+n]
+	a.alenOff += n
+	for i := range s {
+		s[i] = 0
+	}
+	return s
+}
+
+// ub2s returns n zeroed return codes.
+func (a *bndArena) ub2s(n int) []C.ub2 {
+	if a.ub2Off+n > len(a.ub2Buf) {
+		a.ub2Buf, a.ub2Off = make([]C.ub2, arenaCap(len(a.ub2Buf), a.ub2Off+n)), 0
+	}
+	s := a.ub2Buf[a.ub2Off : a.ub2Off+n : a.ub2Off+n]
+	a.ub2Off += n
+	for i := range s {
+		s[i] = 0
+	}
+	return s
+}
+
+// ociNumbers returns n zeroed OCINumbers.
+func (a *bndArena) ociNumbers(n int) []C.OCINumber {
+	if a.numberOff+n > len(a.numberBuf) {
+		a.numberBuf, a.numberOff = make([]C.OCINumber, arenaCap(len(a.numberBuf), a.numberOff+n)), 0
+	}
+	s := a.numberBuf[a.numberOff : a.numberOff+n : a.numberOff+n]
+	a.numberOff += n
+	for i := range s {
+		s[i] = C.OCINumber{}
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestBndArena tests the buffers of bndArena.
+func TestBndArena(t *testing.T) {
+	var a bndArena
+	first, second := a.sb2s(3), a.sb2s(2)
+	if len(first) != 3 || cap(first) != 3 || len(second) != 2 {
+		t.Fatalf("got len %d cap %d and len %d, wanted 3, 3 and 2", len(first), cap(first), len(second))
+	}
+	first[0], second[0] = 1, 2
+	if first[0] != 1 {
+		t.Error("buffers overlap")
+	}
+	held := &second[0] // first outgrew the initial buffer; second starts the current one
+
+	// after a reset, buffers of the same shape reuse the memory, zeroed
+	a.reset()
+	again := a.sb2s(3)
+	if &again[0] != held {
+		t.Error("the buffer isn't reused after reset")
+	}
+	if again[0] != 0 {
+		t.Errorf("got %d, wanted a zeroed buffer", again[0])
+	}
+	// a larger buffer is allocated anew, leaving earlier buffers to their binds
+	a.reset()
+	if larger := a.sb2s(10); len(larger) != 10 || &larger[0] == held {
+		t.Error("wanted a new buffer of 10")
+	}
+
+	a.reset()
+	if s := a.alens(4); len(s) != 4 {
+		t.Errorf("alens: got len %d, wanted 4", len(s))
+	}
+	if s := a.ub2s(4); len(s) != 4 {
+		t.Errorf("ub2s: got len %d, wanted 4", len(s))
+	}
+	if s := a.ociNumbers(4); len(s) != 4 {
+		t.Errorf("ociNumbers: got len %d, wanted 4", len(s))
+	}
+	a.release()
+	if a.sb2Buf != nil || a.numberBuf != nil {
+		t.Error("release kept the buffers")
+	}
+}
+
+// TestArenaCap tests arenaCap.
+func TestArenaCap(t *testing.T) {
+	for i, tc := range []struct{ size, need, want int }{
+		{0, 3, 3},
+		{4, 5, 8},
+		{4, 20, 20},
+	} {
+		if got := arenaCap(tc.size, tc.need); got != tc.want {
+			t.Errorf("%d. got %d, want %d.", i, got, tc.want)
+		}
+	}
+}
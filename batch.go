@@ -0,0 +1,93 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// BatchStmt is a SQL statement and its parameters executed by Ses.ExeBatch.
+type BatchStmt struct {
+	// Sql is a DML statement or an anonymous PL/SQL block.
+	Sql string
+	// Params are bound by position to the placeholders of Sql.
+	Params []interface{}
+}
+
+// ExeBatch executes independent SQL statements in a single server round trip,
+// returning the number of rows affected by each statement.
+//
+// The statements are composed into one anonymous PL/SQL block. Each statement
+// must be a DML statement (INSERT, UPDATE, DELETE or MERGE) or an anonymous
+// PL/SQL block; DDL and SELECT statements are not supported. The placeholders
+// of each statement are renamed so that statements don't share binds.
+//
+// Statements execute in order. When a statement fails, the block stops and
+// the error is returned; the effects of the preceding statements are not
+// rolled back unless the Ses rolls back the transaction.
+func (ses *Ses) ExeBatch(batch ...BatchStmt) (rowsAffected []uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.ExeBatch)
+	err = ses.checkClosed()
+	if err != nil {
+		return nil, errE(err)
+	}
+	if len(batch) == 0 {
+		return nil, nil
+	}
+	sql, params, counts, err := composeBatch(batch)
+	if err != nil {
+		return nil, errE(err)
+	}
+	if _, err = ses.PrepAndExe(sql, params...); err != nil {
+		return nil, errE(err)
+	}
+	rowsAffected = make([]uint64, len(counts))
+	for n := range counts {
+		rowsAffected[n] = uint64(counts[n])
+	}
+	return rowsAffected, nil
+}
+
+// composeBatch composes batch into an anonymous PL/SQL block, returning the
+// block, its params and the row counts bound as out params.
+func composeBatch(batch []BatchStmt) (sql string, params []interface{}, counts []int64, err error) {
+	counts = make([]int64, len(batch))
+	var buf bytes.Buffer
+	buf.WriteString("BEGIN\n")
+	for n, bs := range batch {
+		text := strings.TrimSpace(bs.Sql)
+		isBlock := hasKeywordPrefix(text, "BEGIN") || hasKeywordPrefix(text, "DECLARE")
+		if !isBlock {
+			text = strings.TrimRight(text, "; \t\r\n")
+		}
+		var count int
+		text = rewritePlaceholders(text, func(m int, name string) string {
+			count++
+			return fmt.Sprintf(":b%d_%d", n, m)
+		})
+		if count != len(bs.Params) {
+			return "", nil, nil, errF("BatchStmt %d has %d placeholders and %d params.", n, count, len(bs.Params))
+		}
+		buf.WriteString(text)
+		if !isBlock {
+			buf.WriteString(";")
+		}
+		fmt.Fprintf(&buf, "\n:c%d := SQL%%ROWCOUNT;\n", n)
+		params = append(params, bs.Params...)
+		params = append(params, &counts[n])
+	}
+	buf.WriteString("END;")
+	return buf.String(), params, counts, nil
+}
+
+// hasKeywordPrefix reports whether s starts with the SQL keyword kw, ignoring case.
+func hasKeywordPrefix(s, kw string) bool {
+	if len(s) < len(kw) || !strings.EqualFold(s[:len(kw)], kw) {
+		return false
+	}
+	return len(s) == len(kw) || !isPlaceholderChar(s[len(kw)])
+}
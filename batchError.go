@@ -0,0 +1,89 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"unsafe"
+)
+
+// RowError is the error of one row of an array DML statement.
+type RowError struct {
+	// Row is the zero-based index of the failed row in the bound arrays.
+	Row int
+	// Err is the Oracle error of the row.
+	Err error
+}
+
+// BatchError reports the failed rows of an array DML statement executed
+// with StmtCfg.BatchErrors. The rows which did not fail are processed.
+type BatchError struct {
+	Errors []RowError
+}
+
+// Error returns a description of each failed row.
+func (e *BatchError) Error() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d rows failed:", len(e.Errors))
+	for _, re := range e.Errors {
+		fmt.Fprintf(&buf, "\nrow %d: %v", re.Row, re.Err)
+	}
+	return buf.String()
+}
+
+// batchErrors returns a *BatchError holding the row errors of the last
+// execution with OCI_BATCH_ERRORS, or nil when no row failed.
+func (stmt *Stmt) batchErrors() error {
+	var num C.ub4
+	err := stmt.attr(unsafe.Pointer(&num), 4, C.OCI_ATTR_NUM_DML_ERRORS)
+	if err != nil || num == 0 {
+		return err
+	}
+	env := stmt.ses.srv.env
+	ocierr, err := env.allocOciHandle(C.OCI_HTYPE_ERROR)
+	if err != nil {
+		return err
+	}
+	defer env.freeOciHandle(ocierr, C.OCI_HTYPE_ERROR)
+	batchErr := &BatchError{Errors: make([]RowError, 0, int(num))}
+	for n := C.ub4(0); n < num; n++ {
+		r := C.OCIParamGet(
+			unsafe.Pointer(stmt.ses.ocierr), //const void        *hndlp,
+			C.OCI_HTYPE_ERROR,               //ub4               htype,
+			stmt.ses.ocierr,                 //OCIError          *errhp,
+			&ocierr,                         //void              **parmdpp,
+			n)                               //ub4               pos );
+		if r == C.OCI_ERROR {
+			return stmt.ses.ociError()
+		}
+		var offset C.ub4
+		r = C.OCIAttrGet(
+			ocierr,                    //const void     *trgthndlp,
+			C.OCI_HTYPE_ERROR,         //ub4            trghndltyp,
+			unsafe.Pointer(&offset),   //void           *attributep,
+			nil,                       //ub4            *sizep,
+			C.OCI_ATTR_DML_ROW_OFFSET, //ub4            attrtype,
+			stmt.ses.ocierr)           //OCIError       *errhp );
+		if r == C.OCI_ERROR {
+			return stmt.ses.ociError()
+		}
+		var errcode C.sb4
+		var errBuf [512]C.char
+		C.OCIErrorGet(
+			ocierr,
+			1, nil,
+			&errcode,
+			(*C.OraText)(unsafe.Pointer(&errBuf[0])),
+			C.ub4(len(errBuf)),
+			C.OCI_HTYPE_ERROR)
+		batchErr.Errors = append(batchErr.Errors, RowError{Row: int(offset), Err: errNew(C.GoString(&errBuf[0]))})
+	}
+	return batchErr
+}
@@ -0,0 +1,48 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestComposeBatch tests composeBatch.
+func TestComposeBatch(t *testing.T) {
+	sql, params, counts, err := composeBatch([]BatchStmt{
+		{Sql: "INSERT INTO T1 (C1) VALUES (:c1);", Params: []interface{}{int64(1)}},
+		{Sql: "UPDATE T1 SET C1 = :c1 WHERE C1 = :c1", Params: []interface{}{int64(2), int64(1)}},
+		{Sql: "begin P1(:a); end;", Params: []interface{}{"x"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "BEGIN\n" +
+		"INSERT INTO T1 (C1) VALUES (:b0_0);\n:c0 := SQL%ROWCOUNT;\n" +
+		"UPDATE T1 SET C1 = :b1_0 WHERE C1 = :b1_1;\n:c1 := SQL%ROWCOUNT;\n" +
+		"begin P1(:b2_0); end;\n:c2 := SQL%ROWCOUNT;\n" +
+		"END;"
+	if sql != want {
+		t.Errorf("got %q, want %q.", sql, want)
+	}
+	if len(params) != 7 || len(counts) != 3 {
+		t.Fatalf("got %d params and %d counts, want 7 and 3.", len(params), len(counts))
+	}
+	if p, ok := params[1].(*int64); !ok || p != &counts[0] {
+		t.Errorf("params[1] is %#v, want &counts[0].", params[1])
+	}
+}
+
+// TestComposeBatch_qLiteral tests that composeBatch keeps the placeholders of
+// q'...' literals.
+func TestComposeBatch_qLiteral(t *testing.T) {
+	sql, params, _, err := composeBatch([]BatchStmt{
+		{Sql: "UPDATE T1 SET C1 = q'[it's :c1]' WHERE C2 = :c2", Params: []interface{}{int64(1)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "BEGIN\nUPDATE T1 SET C1 = q'[it's :c1]' WHERE C2 = :b0_0;\n:c0 := SQL%ROWCOUNT;\nEND;"
+	if sql != want || len(params) != 2 {
+		t.Errorf("got %q and %d params, want %q and 2.", sql, len(params), want)
+	}
+}
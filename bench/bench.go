@@ -0,0 +1,120 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+// Package bench holds reproducible benchmarks of the ora package against a
+// test schema, so that performance regressions are measurable.
+//
+// The benchmarks connect with the environment variables of the package
+// tests: GO_ORA_DRV_TEST_DB, GO_ORA_DRV_TEST_USERNAME and
+// GO_ORA_DRV_TEST_PASSWORD. They're skipped when GO_ORA_DRV_TEST_DB isn't
+// set. Setup creates the tables and package of the schema, and Teardown
+// drops them.
+//
+// Profile a benchmark with the driver's pprof labels:
+//
+//	go test -bench WideSelect -cpuprofile cpu.out gopkg.in/rana/ora.v3/bench
+//	go tool pprof -tagfocus ora=fetch cpu.out
+package bench // import "gopkg.in/rana/ora.v3/bench"
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/rana/ora.v3"
+	"gopkg.in/rana/ora.v3/testsupport"
+)
+
+// Names of the objects of the test schema.
+const (
+	BulkTable = "ORA_BENCH_BULK"
+	WideTable = "ORA_BENCH_WIDE"
+	LobTable  = "ORA_BENCH_LOB"
+	Package   = "ORA_BENCH_PKG"
+)
+
+// WideColumns is the number of VARCHAR2 and NUMBER columns of WideTable.
+const WideColumns = 50
+
+// Cfg configures the connection and the size of the test schema.
+type Cfg struct {
+	Dblink   string
+	Username string
+	Password string
+
+	// WideRows is the number of rows of WideTable.
+	WideRows int
+
+	// LobSize is the size in bytes of the BLOB of LobTable.
+	LobSize int
+}
+
+// CfgFromEnv returns a Cfg read from the environment variables of the
+// package tests, with 10,000 wide rows and a 16 MiB LOB.
+func CfgFromEnv() Cfg {
+	return Cfg{
+		Dblink:   os.Getenv("GO_ORA_DRV_TEST_DB"),
+		Username: os.Getenv("GO_ORA_DRV_TEST_USERNAME"),
+		Password: os.Getenv("GO_ORA_DRV_TEST_PASSWORD"),
+		WideRows: 10000,
+		LobSize:  16 << 20,
+	}
+}
+
+// Open connects with cfg.
+func Open(cfg Cfg) (*testsupport.Conn, error) {
+	inst := &testsupport.Instance{Dblink: cfg.Dblink, Username: cfg.Username, Password: cfg.Password}
+	return inst.Open()
+}
+
+// Setup creates the test schema, dropping objects left by an earlier run.
+func Setup(ses *ora.Ses, cfg Cfg) error {
+	Teardown(ses)
+	var cols, exprs []string
+	for n := 1; n <= WideColumns; n++ {
+		cols = append(cols, fmt.Sprintf("S%d VARCHAR2(40)", n), fmt.Sprintf("N%d NUMBER(12,2)", n))
+		exprs = append(exprs, fmt.Sprintf("'value %d ' || LEVEL", n), fmt.Sprintf("LEVEL + %d.25", n))
+	}
+	stmts := []string{
+		"CREATE TABLE " + BulkTable + " (ID NUMBER(10), NAME VARCHAR2(40), AMOUNT NUMBER(12,2))",
+		"CREATE TABLE " + WideTable + " (" + strings.Join(cols, ", ") + ")",
+		"INSERT INTO " + WideTable + " SELECT " + strings.Join(exprs, ", ") +
+			fmt.Sprintf(" FROM DUAL CONNECT BY LEVEL <= %d", cfg.WideRows),
+		"CREATE TABLE " + LobTable + " (ID NUMBER(10), DATA BLOB)",
+		"CREATE OR REPLACE PACKAGE " + Package + " AS\n" +
+			"  PROCEDURE calc(p_in IN NUMBER, p_name IN VARCHAR2, p_out OUT NUMBER);\n" +
+			"END;",
+		"CREATE OR REPLACE PACKAGE BODY " + Package + " AS\n" +
+			"  PROCEDURE calc(p_in IN NUMBER, p_name IN VARCHAR2, p_out OUT NUMBER) IS\n" +
+			"  BEGIN\n" +
+			"    p_out := p_in * 2 + LENGTH(p_name);\n" +
+			"  END;\n" +
+			"END;",
+	}
+	for _, sql := range stmts {
+		if _, err := ses.PrepAndExe(sql); err != nil {
+			return fmt.Errorf("%s: %v", sql, err)
+		}
+	}
+	data := make([]byte, cfg.LobSize)
+	for n := range data {
+		data[n] = byte(n)
+	}
+	if _, err := ses.PrepAndExe("INSERT INTO "+LobTable+" (ID, DATA) VALUES (1, :1)", data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Teardown drops the test schema, ignoring objects which don't exist.
+func Teardown(ses *ora.Ses) {
+	for _, sql := range []string{
+		"DROP TABLE " + BulkTable + " PURGE",
+		"DROP TABLE " + WideTable + " PURGE",
+		"DROP TABLE " + LobTable + " PURGE",
+		"DROP PACKAGE " + Package,
+	} {
+		ses.PrepAndExe(sql)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package bench
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gopkg.in/rana/ora.v3"
+	"gopkg.in/rana/ora.v3/testsupport"
+)
+
+var (
+	testCfg  = CfgFromEnv()
+	testConn *testsupport.Conn
+)
+
+func TestMain(m *testing.M) {
+	if testCfg.Dblink == "" {
+		fmt.Println("GO_ORA_DRV_TEST_DB isn't set; skipping the benchmarks")
+		os.Exit(0)
+	}
+	cfg := *ora.Cfg()
+	cfg.ProfileLabels = true
+	ora.SetCfg(cfg)
+	var err error
+	if testConn, err = Open(testCfg); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err = Setup(testConn.Ses, testCfg); err != nil {
+		fmt.Println(err)
+		testConn.Close()
+		os.Exit(1)
+	}
+	code := m.Run()
+	Teardown(testConn.Ses)
+	testConn.Close()
+	os.Exit(code)
+}
+
+func BenchmarkBulkInsert(b *testing.B) {
+	const rows = 10000
+	ids := make([]int64, rows)
+	names := make([]string, rows)
+	amounts := make([]float64, rows)
+	for n := range ids {
+		ids[n] = int64(n)
+		names[n] = fmt.Sprintf("name %d", n)
+		amounts[n] = float64(n) + 0.5
+	}
+	stmt, err := testConn.Ses.Prep("INSERT INTO " + BulkTable + " (ID, NAME, AMOUNT) VALUES (:1, :2, :3)")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stmt.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = stmt.Exe(ids, names, amounts); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	testConn.Ses.PrepAndExe("TRUNCATE TABLE " + BulkTable)
+}
+
+func BenchmarkWideSelect(b *testing.B) {
+	stmt, err := testConn.Ses.Prep("SELECT * FROM " + WideTable)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stmt.Close()
+	stmt.Cfg().SetPrefetchRowCount(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rset, err := stmt.Qry()
+		if err != nil {
+			b.Fatal(err)
+		}
+		rows := 0
+		for rset.Next() {
+			rows++
+		}
+		if rset.Err != nil {
+			b.Fatal(rset.Err)
+		}
+		if rows != testCfg.WideRows {
+			b.Fatalf("got %d rows, want %d", rows, testCfg.WideRows)
+		}
+	}
+}
+
+func BenchmarkLobStream(b *testing.B) {
+	stmt, err := testConn.Ses.Prep("SELECT DATA FROM "+LobTable+" WHERE ID = 1", ora.OraLobD)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stmt.Close()
+	b.SetBytes(int64(testCfg.LobSize))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rset, err := stmt.Qry()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !rset.Next() {
+			b.Fatal(rset.Err)
+		}
+		r, ok := rset.Row[0].(io.Reader)
+		if !ok {
+			b.Fatalf("got %T, want an io.Reader", rset.Row[0])
+		}
+		n, err := io.Copy(ioutil.Discard, r)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if n != int64(testCfg.LobSize) {
+			b.Fatalf("read %d bytes, want %d", n, testCfg.LobSize)
+		}
+	}
+}
+
+func BenchmarkPlsqlCall(b *testing.B) {
+	stmt, err := testConn.Ses.Prep("BEGIN " + Package + ".calc(:1, :2, :3); END;")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer stmt.Close()
+	var out int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = stmt.Exe(int64(i), "bench", &out); err != nil {
+			b.Fatal(err)
+		}
+		if want := int64(i)*2 + 5; out != want {
+			b.Fatalf("got %d, want %d", out, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "unsafe"
+
+// BindName is a placeholder of a SQL statement.
+type BindName struct {
+	// Name is the placeholder name without the leading colon, such as C1 or
+	// 1. Oracle reports unquoted names in upper case.
+	Name string
+	// Duplicate is true when the placeholder repeats an earlier name and
+	// receives the value bound to it.
+	Duplicate bool
+}
+
+// bindInfoChunk is the number of placeholders requested from each
+// OCIStmtGetBindInfo call.
+const bindInfoChunk = 32
+
+// BindNames returns the placeholders of the Stmt in order of appearance.
+//
+// Positional parameters passed to Stmt.Exe and Stmt.Qry are bound to the
+// placeholders which aren't duplicates, in order.
+func (stmt *Stmt) BindNames() (names []BindName, err error) {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.log(_drv.cfg().Log.Stmt.BindNames)
+	err = stmt.checkClosed()
+	if err != nil {
+		return nil, errE(err)
+	}
+	err = stmt.prepare()
+	if err != nil {
+		return nil, errE(err)
+	}
+	names, err = stmt.bindNames()
+	if err != nil {
+		return nil, errE(err)
+	}
+	return names, nil
+}
+
+// bindNames returns the placeholders of the prepared Stmt. No locking occurs.
+func (stmt *Stmt) bindNames() (names []BindName, err error) {
+	var (
+		bvnp  [bindInfoChunk]*C.OraText
+		bvnl  [bindInfoChunk]C.ub1
+		invp  [bindInfoChunk]*C.OraText
+		inpl  [bindInfoChunk]C.ub1
+		dupl  [bindInfoChunk]C.ub1
+		hndl  [bindInfoChunk]*C.OCIBind
+		found C.sb4
+	)
+	for start := 1; ; start += bindInfoChunk {
+		r := C.OCIStmtGetBindInfo(
+			stmt.ocistmt,         //OCIStmt      *stmtp,
+			stmt.ses.ocierr,      //OCIError     *errhp,
+			C.ub4(bindInfoChunk), //ub4          size,
+			C.ub4(start),         //ub4          startloc,
+			&found,               //sb4          *found,
+			&bvnp[0],             //OraText      *bvnp[],
+			&bvnl[0],             //ub1          bvnl[],
+			&invp[0],             //OraText      *invp[],
+			&inpl[0],             //ub1          inpl[],
+			&dupl[0],             //ub1          dupl[],
+			&hndl[0])             //OCIBind      **hndl );
+		if r == C.OCI_NO_DATA {
+			return names, nil // no placeholders
+		}
+		if r == C.OCI_ERROR {
+			return nil, stmt.ses.ociError()
+		}
+		// a negative found is the total count when more remain
+		total := int(found)
+		if total < 0 {
+			total = -total
+		}
+		count := total - start + 1
+		if count > bindInfoChunk {
+			count = bindInfoChunk
+		}
+		for n := 0; n < count; n++ {
+			names = append(names, BindName{
+				Name:      C.GoStringN((*C.char)(unsafe.Pointer(bvnp[n])), C.int(bvnl[n])),
+				Duplicate: dupl[n] != 0,
+			})
+		}
+		if start+count > total {
+			return names, nil
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"fmt"
+	"reflect"
+)
+
+// traceBinds logs the binds of an execution when LogStmtCfg.BindTrace is
+// true: the position and placeholder name, Go type, OCI bind type, length and
+// null indicator of each bind. Values are logged when LogStmtCfg.BindValues is
+// true, masked as configured by LogDrvCfg.Redact. No locking occurs.
+func (stmt *Stmt) traceBinds(params []interface{}) {
+	if !_drv.cfg().Log.Stmt.BindTrace {
+		return
+	}
+	names := placeholderNames(stmt.sql)
+	plsql := stmt.stmtType == C.OCI_STMT_BEGIN || stmt.stmtType == C.OCI_STMT_DECLARE
+	for n, param := range params {
+		var dty C.ub2
+		if n < len(stmt.bnds) {
+			dty = bndDty(stmt.bnds[n])
+		}
+		msg := fmt.Sprintf("bind %d", n+1)
+		if name := bindName(names, n, plsql); name != "" {
+			msg += " :" + name
+		}
+		msg += fmt.Sprintf(" %T dty=%v len=%v", param, dtyName(dty), bindLength(dty, param))
+		if rows := bindRows(param); rows >= 0 {
+			msg += fmt.Sprintf(" rows=%d", rows)
+		}
+		msg += fmt.Sprintf(" null=%v", bindIsNull(param))
+		if _drv.cfg().Log.Stmt.BindValues {
+			msg += fmt.Sprintf(" value=%v", stmt.redactBind(n, param))
+		}
+		lgr.Infof("%v %v", stmt.sysName(), msg)
+	}
+}
+
+// bndDtyRec records the OCI data type passed to OCIBindByPos by a bnd.
+type bndDtyRec struct {
+	dty C.ub2
+}
+
+// bndDty returns the OCI data type a bnd was bound with, or zero when unknown.
+func bndDty(b bnd) C.ub2 {
+	if rec, ok := b.(interface {
+		bindDty() C.ub2
+	}); ok {
+		return rec.bindDty()
+	}
+	return 0
+}
+
+func (rec *bndDtyRec) bindDty() C.ub2 {
+	return rec.dty
+}
+
+// dtyName returns the name of an OCI bind data type, such as SQLT_VNU(6).
+func dtyName(dty C.ub2) string {
+	var name string
+	switch dty {
+	case C.SQLT_VNU:
+		name = "SQLT_VNU"
+	case C.SQLT_BDOUBLE:
+		name = "SQLT_BDOUBLE"
+	case C.SQLT_BFLOAT:
+		name = "SQLT_BFLOAT"
+	case C.SQLT_CHR:
+		name = "SQLT_CHR"
+	case C.SQLT_AFC:
+		name = "SQLT_AFC"
+	case C.SQLT_BIN:
+		name = "SQLT_BIN"
+	case C.SQLT_LBI:
+		name = "SQLT_LBI"
+	case C.SQLT_BLOB:
+		name = "SQLT_BLOB"
+	case C.SQLT_CLOB:
+		name = "SQLT_CLOB"
+	case C.SQLT_TIMESTAMP_TZ:
+		name = "SQLT_TIMESTAMP_TZ"
+	case C.SQLT_INTERVAL_YM:
+		name = "SQLT_INTERVAL_YM"
+	case C.SQLT_INTERVAL_DS:
+		name = "SQLT_INTERVAL_DS"
+	case C.SQLT_FILE:
+		name = "SQLT_FILE"
+	case C.SQLT_RSET:
+		name = "SQLT_RSET"
+	case 0:
+		return "unknown"
+	default:
+		name = "SQLT"
+	}
+	return fmt.Sprintf("%v(%d)", name, dty)
+}
+
+// bindLength returns the bound length in bytes of a value: the byte length
+// of character and binary data, or the size of a number. The longest element
+// is returned for a slice. "-" is returned for descriptors and handles.
+func bindLength(dty C.ub2, value interface{}) string {
+	switch dty {
+	case C.SQLT_VNU:
+		return fmt.Sprint(C.sizeof_OCINumber)
+	case C.SQLT_BDOUBLE:
+		return "8"
+	case C.SQLT_BFLOAT:
+		return "4"
+	case C.SQLT_CHR, C.SQLT_AFC, C.SQLT_BIN, C.SQLT_LBI:
+		return fmt.Sprint(valueLength(reflect.ValueOf(value)))
+	}
+	return "-"
+}
+
+// valueLength returns the byte length of a string or []byte value, of the
+// Value field of a nullable type, or of the longest element of a slice.
+func valueLength(v reflect.Value) (length int) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+	case reflect.Bool:
+		return 1
+	case reflect.Struct:
+		if f := v.FieldByName("Value"); f.IsValid() {
+			return valueLength(f)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Len()
+		}
+		for n := 0; n < v.Len(); n++ {
+			if l := valueLength(v.Index(n)); l > length {
+				length = l
+			}
+		}
+	}
+	return length
+}
+
+// bindRows returns the number of elements of an array bind, or -1 for a
+// scalar bind. []byte is a scalar.
+func bindRows(value interface{}) int {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return -1
+	}
+	return v.Len()
+}
+
+// bindIsNull reports whether a value binds NULL: nil, a nil pointer, or a
+// nullable type with IsNull set. An array bind reports false.
+func bindIsNull(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Struct:
+		if f := v.FieldByName("IsNull"); f.IsValid() && f.Kind() == reflect.Bool {
+			return f.Bool()
+		}
+	}
+	return false
+}
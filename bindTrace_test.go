@@ -0,0 +1,59 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBindTraceValues tests valueLength, bindRows and bindIsNull.
+func TestBindTraceValues(t *testing.T) {
+	s := "abc"
+	var nilS *string
+	for _, tc := range []struct {
+		value  interface{}
+		length int
+		rows   int
+		null   bool
+	}{
+		{"héllo", 6, -1, false},
+		{&s, 3, -1, false},
+		{nilS, 0, -1, true},
+		{[]byte{1, 2}, 2, -1, false},
+		{[]string{"a", "abcd", "ab"}, 4, 3, false},
+		{String{Value: "xy"}, 2, -1, false},
+		{String{IsNull: true}, 0, -1, true},
+		{true, 1, -1, false},
+		{nil, 0, -1, true},
+	} {
+		if got := valueLength(reflect.ValueOf(tc.value)); got != tc.length {
+			t.Errorf("%#v: length got %d, wanted %d", tc.value, got, tc.length)
+		}
+		if got := bindRows(tc.value); got != tc.rows {
+			t.Errorf("%#v: rows got %d, wanted %d", tc.value, got, tc.rows)
+		}
+		if got := bindIsNull(tc.value); got != tc.null {
+			t.Errorf("%#v: null got %v, wanted %v", tc.value, got, tc.null)
+		}
+	}
+}
+
+// TestBndDty tests that bndDty returns the data type recorded by a bnd.
+func TestBndDty(t *testing.T) {
+	lobs := &bndLobSlice{}
+	if got := bndDty(lobs); got != 0 {
+		t.Errorf("unbound: got %d, wanted 0", got)
+	}
+	lobs.dty = 112 // SQLT_CLOB
+	if got := bndDty(lobs); got != 112 {
+		t.Errorf("bndLobSlice: got %d, wanted 112", got)
+	}
+	nilBnd := &bndNil{}
+	nilBnd.dty = 1 // SQLT_CHR
+	if got := bndDty(nilBnd); got != 1 {
+		t.Errorf("bndNil: got %d, wanted 1", got)
+	}
+}
@@ -18,6 +18,7 @@ import (
 // setting a single file name with OCIBindArrayOfStruct call
 
 type bndBfile struct {
+	bndDtyRec
 	stmt            *Stmt
 	ocibnd          *C.OCIBind
 	ociLobLocator   *C.OCILobLocator
@@ -46,32 +47,35 @@ func (bnd *bndBfile) bind(value Bfile, position int, stmt *Stmt) error {
 		0,                                                     //size_t        xtramem_sz,
 		nil)                                                   //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci lob handle during bind")
 	}
 
 	bnd.cDirectoryAlias = C.CString(value.DirectoryAlias)
 	bnd.cFilename = C.CString(value.Filename)
-	r = C.OCILobFileSetName(
-		bnd.stmt.ses.srv.env.ocienv,                       //OCIEnv             *envhp,
-		bnd.stmt.ses.srv.env.ocierr,                       //OCIError           *errhp,
-		&bnd.ociLobLocator,                                //OCILobLocator      **filepp,
-		(*C.OraText)(unsafe.Pointer(bnd.cDirectoryAlias)), //const OraText      *dir_alias,
-		C.ub2(len(value.DirectoryAlias)),                  //ub2                d_length,
-		(*C.OraText)(unsafe.Pointer(bnd.cFilename)),       //const OraText      *filename,
-		C.ub2(len(value.Filename)))                        //ub2                f_length );
+	r = bnd.stmt.ses.poll(nil, func() C.sword {
+		return C.OCILobFileSetName(
+			bnd.stmt.ses.srv.env.ocienv,                       //OCIEnv             *envhp,
+			bnd.stmt.ses.ocierr,                               //OCIError           *errhp,
+			&bnd.ociLobLocator,                                //OCILobLocator      **filepp,
+			(*C.OraText)(unsafe.Pointer(bnd.cDirectoryAlias)), //const OraText      *dir_alias,
+			C.ub2(len(value.DirectoryAlias)),                  //ub2                d_length,
+			(*C.OraText)(unsafe.Pointer(bnd.cFilename)),       //const OraText      *filename,
+			C.ub2(len(value.Filename)))                        //ub2                f_length );
+	})
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_FILE
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                      //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                     //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                             //OCIError     *errhp,
 		C.ub4(position),                                 //ub4          position,
 		unsafe.Pointer(&bnd.ociLobLocator),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociLobLocator)), //sb8          value_sz,
-		C.SQLT_FILE,   //ub2          dty,
+		bnd.dty,       //ub2          dty,
 		nil,           //void         *indp,
 		nil,           //ub2          *alenp,
 		nil,           //ub2          *rcodep,
@@ -79,7 +83,7 @@ func (bnd *bndBfile) bind(value Bfile, position int, stmt *Stmt) error {
 		nil,           //ub4          *curelep,
 		C.OCI_DEFAULT) //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -108,6 +112,7 @@ func (bnd *bndBfile) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociLobLocator = nil
 	bnd.cDirectoryAlias = nil
 	bnd.cFilename = nil
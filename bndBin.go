@@ -12,28 +12,38 @@ import "C"
 import "unsafe"
 
 type bndBin struct {
+	bndDtyRec
 	stmt   *Stmt
 	ocibnd *C.OCIBind
+	uuid   UUID
+}
+
+// bindUUID binds a copy of value held by the bnd, as the caller's
+// array does not outlive the bind call.
+func (bnd *bndBin) bindUUID(value UUID, position int, stmt *Stmt) error {
+	bnd.uuid = value
+	return bnd.bind(bnd.uuid[:], position, stmt)
 }
 
 func (bnd *bndBin) bind(value []byte, position int, stmt *Stmt) (err error) {
 	bnd.stmt = stmt
+	bnd.dty = C.SQLT_LBI
 	r := C.OCIBINDBYPOS(
-		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
-		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
-		C.ub4(position),             //ub4          position,
-		unsafe.Pointer(&value[0]),   //void         *valuep,
-		C.LENGTH_TYPE(len(value)),   //sb8          value_sz,
-		C.SQLT_LBI,                  //ub2          dty,
-		nil,                         //void         *indp,
-		nil,                         //ub2          *alenp,
-		nil,                         //ub2          *rcodep,
-		0,                           //ub4          maxarr_len,
-		nil,                         //ub4          *curelep,
-		C.OCI_DEFAULT)               //ub4          mode );
+		bnd.stmt.ocistmt,           //OCIStmt      *stmtp,
+		(**C.OCIBind)(&bnd.ocibnd), //OCIBind      **bindpp,
+		bnd.stmt.ses.ocierr,        //OCIError     *errhp,
+		C.ub4(position),            //ub4          position,
+		unsafe.Pointer(&value[0]),  //void         *valuep,
+		C.LENGTH_TYPE(len(value)),  //sb8          value_sz,
+		bnd.dty,                    //ub2          dty,
+		nil,                        //void         *indp,
+		nil,                        //ub2          *alenp,
+		nil,                        //ub2          *rcodep,
+		0,                          //ub4          maxarr_len,
+		nil,                        //ub4          *curelep,
+		C.OCI_DEFAULT)              //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
@@ -51,6 +61,8 @@ func (bnd *bndBin) close() (err error) {
 	}()
 	stmt := bnd.stmt
 	bnd.stmt = nil
+	bnd.uuid = UUID{}
+	bnd.dty = 0
 	stmt.putBnd(bndIdxBin, bnd)
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 )
 
 type bndBinSlice struct {
+	bndDtyRec
 	stmt   *Stmt
 	ocibnd *C.OCIBind
 	buf    []byte
@@ -21,7 +22,7 @@ type bndBinSlice struct {
 
 func (bnd *bndBinSlice) bindOra(values []Raw, position int, lobBufferSize int, stmt *Stmt) error {
 	binValues := make([][]byte, len(values))
-	nullInds := make([]C.sb2, len(values))
+	nullInds := stmt.arena.sb2s(len(values))
 	for i := range values {
 		if values[i].IsNull {
 			nullInds[i] = C.sb2(-1)
@@ -35,10 +36,10 @@ func (bnd *bndBinSlice) bindOra(values []Raw, position int, lobBufferSize int, s
 func (bnd *bndBinSlice) bind(values [][]byte, nullInds []C.sb2, position int, lobBufferSize int, stmt *Stmt) error {
 	bnd.stmt = stmt
 	if nullInds == nil {
-		nullInds = make([]C.sb2, len(values))
+		nullInds = stmt.arena.sb2s(len(values))
 	}
-	alenp := make([]C.ACTUAL_LENGTH_TYPE, len(values))
-	rcodep := make([]C.ub2, len(values))
+	alenp := stmt.arena.alens(len(values))
+	rcodep := stmt.arena.ub2s(len(values))
 	var maxLen int
 	for _, b := range values {
 		if len(b) > maxLen {
@@ -59,14 +60,15 @@ func (bnd *bndBinSlice) bind(values [][]byte, nullInds []C.sb2, position int, lo
 		copy(bnd.buf[i*maxLen:], b)
 		alenp[i] = C.ACTUAL_LENGTH_TYPE(len(b))
 	}
+	bnd.dty = C.SQLT_LBI
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,             //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),   //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,  //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,          //OCIError     *errhp,
 		C.ub4(position),              //ub4          position,
 		unsafe.Pointer(&bnd.buf[0]),  //void         *valuep,
 		C.LENGTH_TYPE(maxLen),        //sb8          value_sz,
-		C.SQLT_LBI,                   //ub2          dty,
+		bnd.dty,                      //ub2          dty,
 		unsafe.Pointer(&nullInds[0]), //void         *indp,
 		&alenp[0],                    //ub4          *alenp,
 		&rcodep[0],                   //ub2          *rcodep,
@@ -74,17 +76,17 @@ func (bnd *bndBinSlice) bind(values [][]byte, nullInds []C.sb2, position int, lo
 		nil,                          //ub4          *curelep,
 		C.OCI_DEFAULT)                //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	r = C.OCIBindArrayOfStruct(
 		bnd.ocibnd,
-		bnd.stmt.ses.srv.env.ocierr,
+		bnd.stmt.ses.ocierr,
 		C.ub4(maxLen),       //ub4         pvskip,
 		C.ub4(C.sizeof_sb2), //ub4         indskip,
 		C.ub4(C.sizeof_ub4), //ub4         alskip,
 		C.ub4(C.sizeof_ub2)) //ub4         rcskip
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -102,6 +104,7 @@ func (bnd *bndBinSlice) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	stmt.putBnd(bndIdxBinSlice, bnd)
 	return nil
 }
@@ -16,6 +16,7 @@ import (
 )
 
 type bndBool struct {
+	bndDtyRec
 	stmt    *Stmt
 	ocibnd  *C.OCIBind
 	cString *C.char
@@ -34,14 +35,15 @@ func (bnd *bndBool) bind(value bool, position int, c StmtCfg, stmt *Stmt) (err e
 		return err
 	}
 	bnd.cString = C.CString(str)
+	bnd.dty = C.SQLT_AFC
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,         //OCIError     *errhp,
 		C.ub4(position),             //ub4          position,
 		unsafe.Pointer(bnd.cString), //void         *valuep,
 		C.LENGTH_TYPE(1),            //sb8          value_sz,
-		C.SQLT_AFC,                  //ub2          dty,
+		bnd.dty,                     //ub2          dty,
 		nil,                         //void         *indp,
 		nil,                         //ub2          *alenp,
 		nil,                         //ub2          *rcodep,
@@ -49,7 +51,7 @@ func (bnd *bndBool) bind(value bool, position int, c StmtCfg, stmt *Stmt) (err e
 		nil,                         //ub4          *curelep,
 		C.OCI_DEFAULT)               //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -69,6 +71,7 @@ func (bnd *bndBool) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.cString = nil
 	stmt.putBnd(bndIdxBool, bnd)
 	return nil
@@ -15,6 +15,7 @@ import (
 )
 
 type bndBoolPtr struct {
+	bndDtyRec
 	stmt     *Stmt
 	ocibnd   *C.OCIBind
 	isNull   C.sb2
@@ -32,22 +33,23 @@ func (bnd *bndBoolPtr) bind(value *bool, position int, trueRune rune, stmt *Stmt
 		bnd.buf = make([]byte, 2)
 	}
 	// FIXME(tgulacsi): bnd.buf should be populated with *value!
+	bnd.dty = C.SQLT_CHR
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,         //OCIError     *errhp,
 		C.ub4(position),             //ub4          position,
 		unsafe.Pointer(&bnd.buf[0]), //void         *valuep,
 		C.LENGTH_TYPE(len(bnd.buf)), //sb8          value_sz,
-		C.SQLT_CHR,                  //ub2          dty,
+		bnd.dty,                     //ub2          dty,
 		unsafe.Pointer(&bnd.isNull), //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		nil,                         //ub2          *alenp,
+		nil,                         //ub2          *rcodep,
+		0,                           //ub4          maxarr_len,
+		nil,                         //ub4          *curelep,
+		C.OCI_DEFAULT)               //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -73,6 +75,7 @@ func (bnd *bndBoolPtr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.value = nil
 	clear(bnd.buf, 0)
 	stmt.putBnd(bndIdxBoolPtr, bnd)
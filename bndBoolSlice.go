@@ -15,6 +15,7 @@ import (
 )
 
 type bndBoolSlice struct {
+	bndDtyRec
 	stmt   *Stmt
 	ocibnd *C.OCIBind
 	buf    bytes.Buffer
@@ -23,7 +24,7 @@ type bndBoolSlice struct {
 
 func (bnd *bndBoolSlice) bindOra(values []Bool, position int, falseRune rune, trueRune rune, stmt *Stmt) error {
 	boolValues := make([]bool, len(values))
-	nullInds := make([]C.sb2, len(values))
+	nullInds := stmt.arena.sb2s(len(values))
 	for n, _ := range values {
 		if values[n].IsNull {
 			nullInds[n] = C.sb2(-1)
@@ -37,10 +38,10 @@ func (bnd *bndBoolSlice) bindOra(values []Bool, position int, falseRune rune, tr
 func (bnd *bndBoolSlice) bind(values []bool, nullInds []C.sb2, position int, falseRune rune, trueRune rune, stmt *Stmt) (err error) {
 	bnd.stmt = stmt
 	if nullInds == nil {
-		nullInds = make([]C.sb2, len(values))
+		nullInds = stmt.arena.sb2s(len(values))
 	}
-	alenp := make([]C.ACTUAL_LENGTH_TYPE, len(values))
-	rcodep := make([]C.ub2, len(values))
+	alenp := stmt.arena.alens(len(values))
+	rcodep := stmt.arena.ub2s(len(values))
 	var maxLen int = 1
 	for n, bValue := range values {
 		if bValue {
@@ -58,14 +59,15 @@ func (bnd *bndBoolSlice) bind(values []bool, nullInds []C.sb2, position int, fal
 	}
 	bnd.bytes = bnd.buf.Bytes()
 
+	bnd.dty = C.SQLT_CHR
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,              //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),    //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,   //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,           //OCIError     *errhp,
 		C.ub4(position),               //ub4          position,
 		unsafe.Pointer(&bnd.bytes[0]), //void         *valuep,
 		C.LENGTH_TYPE(maxLen),         //sb8          value_sz,
-		C.SQLT_CHR,                    //ub2          dty,
+		bnd.dty,                       //ub2          dty,
 		unsafe.Pointer(&nullInds[0]),  //void         *indp,
 		&alenp[0],                     //ub4          *alenp,
 		&rcodep[0],                    //ub2          *rcodep,
@@ -73,18 +75,18 @@ func (bnd *bndBoolSlice) bind(values []bool, nullInds []C.sb2, position int, fal
 		nil,                           //ub4          *curelep,
 		C.OCI_DEFAULT)                 //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	r = C.OCIBindArrayOfStruct(
-		bnd.ocibnd,                  //OCIBind     *bindp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(maxLen),               //ub4         pvskip,
-		C.ub4(C.sizeof_sb2),         //ub4         indskip,
-		C.ub4(C.sizeof_ub4),         //ub4         alskip,
-		C.ub4(C.sizeof_ub2))         //ub4         rcskip
+		bnd.ocibnd,          //OCIBind     *bindp,
+		bnd.stmt.ses.ocierr, //OCIError    *errhp,
+		C.ub4(maxLen),       //ub4         pvskip,
+		C.ub4(C.sizeof_sb2), //ub4         indskip,
+		C.ub4(C.sizeof_ub4), //ub4         alskip,
+		C.ub4(C.sizeof_ub2)) //ub4         rcskip
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
@@ -104,6 +106,7 @@ func (bnd *bndBoolSlice) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.bytes = nil
 	bnd.buf.Reset()
 	stmt.putBnd(bndIdxBoolSlice, bnd)
@@ -14,29 +14,61 @@ import (
 )
 
 type bndFloat32 struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
+	null      C.sb2
+	native    bool // bound as SQLT_BFLOAT
+	real      C.float
 }
 
+// bind binds value as an OCINumber or, with StmtCfg.NativeFloats or for NaN
+// and infinity which an OCINumber can't represent, as a SQLT_BFLOAT.
 func (bnd *bndFloat32) bind(value float32, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
+	bnd.native = isNativeFloat(stmt, float64(value))
+	if bnd.native {
+		if err := bnd.setReal(value); err != nil {
+			return err
+		}
+		bnd.dty = C.SQLT_BFLOAT
+		r := C.OCIBINDBYPOS(
+			bnd.stmt.ocistmt,           //OCIStmt      *stmtp,
+			(**C.OCIBind)(&bnd.ocibnd), //OCIBind      **bindpp,
+			bnd.stmt.ses.ocierr,        //OCIError     *errhp,
+			C.ub4(position),            //ub4          position,
+			unsafe.Pointer(&bnd.real),  //void         *valuep,
+			C.LENGTH_TYPE(4),           //sb8          value_sz,
+			bnd.dty,                    //ub2          dty,
+			unsafe.Pointer(&bnd.null),  //void         *indp,
+			nil,                        //ub2          *alenp,
+			nil,                        //ub2          *rcodep,
+			0,                          //ub4          maxarr_len,
+			nil,                        //ub4          *curelep,
+			C.OCI_DEFAULT)              //ub4          mode );
+		if r == C.OCI_ERROR {
+			return bnd.stmt.ses.ociError()
+		}
+		return nil
+	}
 	r := C.OCINumberFromReal(
-		bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-		unsafe.Pointer(&value),      //const void          *rnum,
-		4,              //uword               rnum_length,
-		&bnd.ociNumber) //OCINumber           *number );
+		bnd.stmt.ses.ocierr,    //OCIError            *err,
+		unsafe.Pointer(&value), //const void          *rnum,
+		4,                      //uword               rnum_length,
+		&bnd.ociNumber)         //OCINumber           *number );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_VNU
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		nil,                               //void         *indp,
 		nil,                               //ub2          *alenp,
 		nil,                               //ub2          *rcodep,
@@ -44,7 +76,31 @@ func (bnd *bndFloat32) bind(value float32, position int, stmt *Stmt) error {
 		nil,                               //ub4          *curelep,
 		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
+	}
+	return nil
+}
+
+func (bnd *bndFloat32) rebind(value interface{}) (bool, error) {
+	v, ok := value.(float32)
+	if !ok || isNativeFloat(bnd.stmt, float64(v)) != bnd.native {
+		return false, nil
+	}
+	if bnd.native {
+		return true, bnd.setReal(v)
+	}
+	return true, numberFromReal(bnd.stmt, unsafe.Pointer(&v), 4, &bnd.ociNumber)
+}
+
+// setReal sets the native bind value, applying StmtCfg.NaN.
+func (bnd *bndFloat32) setReal(value float32) error {
+	null, err := bnd.stmt.cfg.NaN.check(float64(value))
+	if err != nil {
+		return err
+	}
+	bnd.real, bnd.null = C.float(value), 0
+	if null {
+		bnd.null = -1
 	}
 	return nil
 }
@@ -63,6 +119,7 @@ func (bnd *bndFloat32) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	stmt.putBnd(bndIdxFloat32, bnd)
 	return nil
 }
@@ -10,33 +10,66 @@ package ora
 */
 import "C"
 import (
+	"math"
 	"unsafe"
 )
 
 type bndFloat64 struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
+	null      C.sb2
+	native    bool // bound as SQLT_BDOUBLE
+	real      C.double
 }
 
+// bind binds value as an OCINumber or, with StmtCfg.NativeFloats or for NaN
+// and infinity which an OCINumber can't represent, as a SQLT_BDOUBLE.
 func (bnd *bndFloat64) bind(value float64, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
+	bnd.native = isNativeFloat(stmt, float64(value))
+	if bnd.native {
+		if err := bnd.setReal(value); err != nil {
+			return err
+		}
+		bnd.dty = C.SQLT_BDOUBLE
+		r := C.OCIBINDBYPOS(
+			bnd.stmt.ocistmt,           //OCIStmt      *stmtp,
+			(**C.OCIBind)(&bnd.ocibnd), //OCIBind      **bindpp,
+			bnd.stmt.ses.ocierr,        //OCIError     *errhp,
+			C.ub4(position),            //ub4          position,
+			unsafe.Pointer(&bnd.real),  //void         *valuep,
+			C.LENGTH_TYPE(8),           //sb8          value_sz,
+			bnd.dty,                    //ub2          dty,
+			unsafe.Pointer(&bnd.null),  //void         *indp,
+			nil,                        //ub2          *alenp,
+			nil,                        //ub2          *rcodep,
+			0,                          //ub4          maxarr_len,
+			nil,                        //ub4          *curelep,
+			C.OCI_DEFAULT)              //ub4          mode );
+		if r == C.OCI_ERROR {
+			return bnd.stmt.ses.ociError()
+		}
+		return nil
+	}
 	r := C.OCINumberFromReal(
-		bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-		unsafe.Pointer(&value),      //const void          *rnum,
-		8,              //uword               rnum_length,
-		&bnd.ociNumber) //OCINumber           *number );
+		bnd.stmt.ses.ocierr,    //OCIError            *err,
+		unsafe.Pointer(&value), //const void          *rnum,
+		8,                      //uword               rnum_length,
+		&bnd.ociNumber)         //OCINumber           *number );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_VNU
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		nil,                               //void         *indp,
 		nil,                               //ub2          *alenp,
 		nil,                               //ub2          *rcodep,
@@ -44,7 +77,31 @@ func (bnd *bndFloat64) bind(value float64, position int, stmt *Stmt) error {
 		nil,                               //ub4          *curelep,
 		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
+	}
+	return nil
+}
+
+func (bnd *bndFloat64) rebind(value interface{}) (bool, error) {
+	v, ok := value.(float64)
+	if !ok || isNativeFloat(bnd.stmt, float64(v)) != bnd.native {
+		return false, nil
+	}
+	if bnd.native {
+		return true, bnd.setReal(v)
+	}
+	return true, numberFromReal(bnd.stmt, unsafe.Pointer(&v), 8, &bnd.ociNumber)
+}
+
+// setReal sets the native bind value, applying StmtCfg.NaN.
+func (bnd *bndFloat64) setReal(value float64) error {
+	null, err := bnd.stmt.cfg.NaN.check(float64(value))
+	if err != nil {
+		return err
+	}
+	bnd.real, bnd.null = C.double(value), 0
+	if null {
+		bnd.null = -1
 	}
 	return nil
 }
@@ -63,6 +120,13 @@ func (bnd *bndFloat64) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	stmt.putBnd(bndIdxFloat64, bnd)
 	return nil
 }
+
+// isNativeFloat returns true when value is bound as a BINARY_DOUBLE or
+// BINARY_FLOAT rather than as an OCINumber.
+func isNativeFloat(stmt *Stmt, value float64) bool {
+	return stmt.cfg.NativeFloats || math.IsNaN(value) || math.IsInf(value, 0)
+}
@@ -14,6 +14,7 @@ import (
 )
 
 type bndFloat64Ptr struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
@@ -28,30 +29,31 @@ func (bnd *bndFloat64Ptr) bind(value *float64, position int, stmt *Stmt) error {
 		bnd.isNull = C.sb2(-1)
 	} else {
 		r := C.OCINumberFromReal(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-			unsafe.Pointer(value),       //const void          *rnum,
-			8,              //uword               rnum_length,
-			&bnd.ociNumber) //OCINumber           *number );
+			bnd.stmt.ses.ocierr,   //OCIError            *err,
+			unsafe.Pointer(value), //const void          *rnum,
+			8,                     //uword               rnum_length,
+			&bnd.ociNumber)        //OCINumber           *number );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
 	}
+	bnd.dty = C.SQLT_VNU
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		unsafe.Pointer(&bnd.isNull),       //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		nil,                               //ub2          *alenp,
+		nil,                               //ub2          *rcodep,
+		0,                                 //ub4          maxarr_len,
+		nil,                               //ub4          *curelep,
+		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -59,12 +61,12 @@ func (bnd *bndFloat64Ptr) bind(value *float64, position int, stmt *Stmt) error {
 func (bnd *bndFloat64Ptr) setPtr() error {
 	if bnd.isNull > C.sb2(-1) {
 		r := C.OCINumberToReal(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError              *err,
-			&bnd.ociNumber,              //const OCINumber     *number,
-			C.uword(8),                  //uword               rsl_length,
-			unsafe.Pointer(bnd.value))   //void                *rsl );
+			bnd.stmt.ses.ocierr,       //OCIError              *err,
+			&bnd.ociNumber,            //const OCINumber     *number,
+			C.uword(8),                //uword               rsl_length,
+			unsafe.Pointer(bnd.value)) //void                *rsl );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
 	}
 	return nil
@@ -80,6 +82,7 @@ func (bnd *bndFloat64Ptr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.value = nil
 	stmt.putBnd(bndIdxFloat64Ptr, bnd)
 	return nil
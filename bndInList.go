@@ -0,0 +1,200 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+#include "version.h"
+*/
+import "C"
+import (
+	"time"
+	"unsafe"
+)
+
+// inListSchema is the schema of the collection types bound by bndInList.
+const inListSchema = "SYS"
+
+// bndInList binds the elements of an InList as a single collection of a
+// SYS.ODCI*LIST type.
+type bndInList struct {
+	bndDtyRec
+	stmt   *Stmt
+	ocibnd *C.OCIBind
+	tdo    *C.OCIType
+	coll   *C.OCIColl
+}
+
+func (bnd *bndInList) bind(value inListColl, position int, stmt *Stmt) error {
+	bnd.stmt = stmt
+	ses := stmt.ses
+	env := ses.srv.env
+	cSchema := C.CString(inListSchema)
+	defer C.free(unsafe.Pointer(cSchema))
+	cType := C.CString(value.typeName)
+	defer C.free(unsafe.Pointer(cType))
+	r := ses.poll(nil, func() C.sword {
+		return C.OCITypeByName(
+			env.ocienv,                              //OCIEnv          *env,
+			ses.ocierr,                              //OCIError        *err,
+			ses.ocisvcctx,                           //const OCISvcCtx *svc,
+			(*C.OraText)(unsafe.Pointer(cSchema)),   //const oratext   *schema_name,
+			C.ub4(len(inListSchema)),                //ub4             s_length,
+			(*C.OraText)(unsafe.Pointer(cType)),     //const oratext   *type_name,
+			C.ub4(len(value.typeName)),              //ub4             t_length,
+			nil,                                     //const oratext   *version_name,
+			0,                                       //ub4             v_length,
+			C.OCI_DURATION_SESSION,                  //OCIDuration     pin_duration,
+			C.OCI_TYPEGET_HEADER,                    //OCITypeGetOpt   get_option,
+			(**C.OCIType)(unsafe.Pointer(&bnd.tdo))) //OCIType         **tdo );
+	})
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	r = C.OCIObjectNew(
+		env.ocienv,             //OCIEnv          *env,
+		ses.ocierr,             //OCIError        *err,
+		ses.ocisvcctx,          //const OCISvcCtx *svc,
+		C.OCI_TYPECODE_VARRAY,  //OCITypeCode     typecode,
+		bnd.tdo,                //OCIType         *tdo,
+		nil,                    //dvoid           *table,
+		C.OCI_DURATION_SESSION, //OCIDuration     duration,
+		C.TRUE,                 //boolean         value,
+		(*unsafe.Pointer)(unsafe.Pointer(&bnd.coll))) //dvoid           **instance );
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	if err := bnd.append(value.values); err != nil {
+		return err
+	}
+	bnd.dty = C.SQLT_NTY
+	r = C.OCIBINDBYPOS(
+		bnd.stmt.ocistmt,           //OCIStmt      *stmtp,
+		(**C.OCIBind)(&bnd.ocibnd), //OCIBind      **bindpp,
+		ses.ocierr,                 //OCIError     *errhp,
+		C.ub4(position),            //ub4          position,
+		nil,                        //void         *valuep,
+		0,                          //sb8          value_sz,
+		bnd.dty,                    //ub2          dty,
+		nil,                        //void         *indp,
+		nil,                        //ub2          *alenp,
+		nil,                        //ub2          *rcodep,
+		0,                          //ub4          maxarr_len,
+		nil,                        //ub4          *curelep,
+		C.OCI_DEFAULT)              //ub4          mode );
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	r = C.OCIBindObject(
+		bnd.ocibnd, //OCIBind         *bindp,
+		ses.ocierr, //OCIError        *errhp,
+		bnd.tdo,    //const OCIType   *type,
+		(*unsafe.Pointer)(unsafe.Pointer(&bnd.coll)), //dvoid           **pgvpp,
+		nil, //ub4             *pvszsp,
+		nil, //dvoid           **indpp,
+		nil) //ub4             *indszp );
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	return nil
+}
+
+// append appends values, of the element type of the collection as returned
+// by inListColl, to the collection.
+func (bnd *bndInList) append(values []interface{}) error {
+	ses := bnd.stmt.ses
+	env := ses.srv.env
+	var ociString *C.OCIString
+	defer func() {
+		if ociString != nil {
+			C.OCIStringResize(env.ocienv, ses.ocierr, 0, &ociString)
+		}
+	}()
+	for _, value := range values {
+		var elem unsafe.Pointer
+		var ociNumber C.OCINumber
+		var ociDate C.OCIDate
+		var r C.sword
+		switch v := value.(type) {
+		case int64:
+			r = C.OCINumberFromInt(ses.ocierr, unsafe.Pointer(&v), 8, C.OCI_NUMBER_SIGNED, &ociNumber)
+			elem = unsafe.Pointer(&ociNumber)
+		case uint64:
+			r = C.OCINumberFromInt(ses.ocierr, unsafe.Pointer(&v), 8, C.OCI_NUMBER_UNSIGNED, &ociNumber)
+			elem = unsafe.Pointer(&ociNumber)
+		case float64:
+			r = C.OCINumberFromReal(ses.ocierr, unsafe.Pointer(&v), 8, &ociNumber)
+			elem = unsafe.Pointer(&ociNumber)
+		case string:
+			cValue := C.CString(v)
+			r = C.OCIStringAssignText(
+				env.ocienv,                           //OCIEnv        *env,
+				ses.ocierr,                           //OCIError      *err,
+				(*C.OraText)(unsafe.Pointer(cValue)), //const oratext *rhs,
+				C.ub4(len(v)),                        //ub4           rhs_len,
+				&ociString)                           //OCIString     **lhs );
+			C.free(unsafe.Pointer(cValue))
+			elem = unsafe.Pointer(ociString)
+		case time.Time:
+			ociDate.OCIDateYYYY = C.sb2(v.Year())
+			ociDate.OCIDateMM = C.ub1(v.Month())
+			ociDate.OCIDateDD = C.ub1(v.Day())
+			ociDate.OCIDateTime.OCITimeHH = C.ub1(v.Hour())
+			ociDate.OCIDateTime.OCITimeMI = C.ub1(v.Minute())
+			ociDate.OCIDateTime.OCITimeSS = C.ub1(v.Second())
+			elem = unsafe.Pointer(&ociDate)
+		default:
+			return errF("InList element of type %T can't be bound as a collection.", value)
+		}
+		if r == C.OCI_ERROR {
+			return ses.ociError()
+		}
+		// the collection copies the element
+		r = C.OCICollAppend(
+			env.ocienv, //OCIEnv         *env,
+			ses.ocierr, //OCIError       *err,
+			elem,       //const void     *elem,
+			nil,        //const void     *elemind,
+			bnd.coll)   //OCIColl        *coll );
+		if r == C.OCI_ERROR {
+			return ses.ociError()
+		}
+	}
+	return nil
+}
+
+func (bnd *bndInList) setPtr() error {
+	return nil
+}
+
+func (bnd *bndInList) close() (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = errR(value)
+		}
+	}()
+	stmt := bnd.stmt
+	if bnd.coll != nil {
+		C.OCIObjectFree(
+			stmt.ses.srv.env.ocienv,  //OCIEnv   *env,
+			stmt.ses.ocierr,          //OCIError *err,
+			unsafe.Pointer(bnd.coll), //void    *instance,
+			C.OCI_OBJECTFREE_FORCE)   //ub2     flags );
+	}
+	if bnd.tdo != nil {
+		C.OCIObjectUnpin(
+			stmt.ses.srv.env.ocienv, //OCIEnv   *env,
+			stmt.ses.ocierr,         //OCIError *err,
+			unsafe.Pointer(bnd.tdo)) //void     *object );
+	}
+	bnd.stmt = nil
+	bnd.ocibnd = nil
+	bnd.dty = 0
+	bnd.tdo = nil
+	bnd.coll = nil
+	stmt.putBnd(bndIdxInList, bnd)
+	return nil
+}
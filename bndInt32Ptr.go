@@ -14,47 +14,51 @@ import (
 )
 
 type bndInt32Ptr struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
 	isNull    C.sb2
 	value     *int32
+	position  int
 }
 
 func (bnd *bndInt32Ptr) bind(value *int32, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
 	bnd.value = value
+	bnd.position = position
 	if value == nil {
 		bnd.isNull = C.sb2(-1)
 	} else {
 		r := C.OCINumberFromInt(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-			unsafe.Pointer(value),       //const void          *inum,
-			4,                   //uword               inum_length,
-			C.OCI_NUMBER_SIGNED, //uword               inum_s_flag,
-			&bnd.ociNumber)      //OCINumber           *number
+			bnd.stmt.ses.ocierr,   //OCIError            *err,
+			unsafe.Pointer(value), //const void          *inum,
+			4,                     //uword               inum_length,
+			C.OCI_NUMBER_SIGNED,   //uword               inum_s_flag,
+			&bnd.ociNumber)        //OCINumber           *number
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
-		bnd.stmt.logF(_drv.cfg.Log.Stmt.Bind,
-			"Int32Ptr.bind(%d) value=%d => number=%#v", position, *value, bnd.ociNumber)
+		bnd.stmt.logF(_drv.cfg().Log.Stmt.Bind,
+			"Int32Ptr.bind(%d) value=%v", position, bnd.stmt.redactBind(position-1, *value))
 	}
+	bnd.dty = C.SQLT_VNU
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		unsafe.Pointer(&bnd.isNull),       //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		nil,                               //ub2          *alenp,
+		nil,                               //ub2          *rcodep,
+		0,                                 //ub4          maxarr_len,
+		nil,                               //ub4          *curelep,
+		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -62,16 +66,16 @@ func (bnd *bndInt32Ptr) bind(value *int32, position int, stmt *Stmt) error {
 func (bnd *bndInt32Ptr) setPtr() error {
 	if bnd.isNull > C.sb2(-1) {
 		r := C.OCINumberToInt(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError              *err,
-			&bnd.ociNumber,              //const OCINumber       *number,
-			C.uword(4),                  //uword                 rsl_length,
-			C.OCI_NUMBER_SIGNED,         //uword                 rsl_flag,
-			unsafe.Pointer(bnd.value))   //void                  *rsl );
+			bnd.stmt.ses.ocierr,       //OCIError              *err,
+			&bnd.ociNumber,            //const OCINumber       *number,
+			C.uword(4),                //uword                 rsl_length,
+			C.OCI_NUMBER_SIGNED,       //uword                 rsl_flag,
+			unsafe.Pointer(bnd.value)) //void                  *rsl );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
-		bnd.stmt.logF(_drv.cfg.Log.Stmt.Bind,
-			"Int32Ptr.setPtr number=%#v => value=%d", bnd.ociNumber, *bnd.value)
+		bnd.stmt.logF(_drv.cfg().Log.Stmt.Bind,
+			"Int32Ptr.setPtr value=%v", bnd.stmt.redactBind(bnd.position-1, *bnd.value))
 	}
 	return nil
 }
@@ -86,7 +90,9 @@ func (bnd *bndInt32Ptr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.value = nil
+	bnd.position = 0
 	stmt.putBnd(bndIdxInt32Ptr, bnd)
 	return nil
 }
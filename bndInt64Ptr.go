@@ -14,6 +14,7 @@ import (
 )
 
 type bndInt64Ptr struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
@@ -28,33 +29,34 @@ func (bnd *bndInt64Ptr) bind(value *int64, position int, stmt *Stmt) error {
 		bnd.isNull = C.sb2(-1)
 	} else {
 		r := C.OCINumberFromInt(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-			unsafe.Pointer(value),       //const void          *inum,
-			8,                   //uword               inum_length,
-			C.OCI_NUMBER_SIGNED, //uword               inum_s_flag,
-			&bnd.ociNumber)      //OCINumber           *number );
+			bnd.stmt.ses.ocierr,   //OCIError            *err,
+			unsafe.Pointer(value), //const void          *inum,
+			8,                     //uword               inum_length,
+			C.OCI_NUMBER_SIGNED,   //uword               inum_s_flag,
+			&bnd.ociNumber)        //OCINumber           *number );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
-		bnd.stmt.logF(_drv.cfg.Log.Stmt.Bind,
-			"Int64Ptr.bind(%d) value=%d => number=%#v", position, *value, bnd.ociNumber)
+		bnd.stmt.logF(_drv.cfg().Log.Stmt.Bind,
+			"Int64Ptr.bind(%d) value=%v", position, bnd.stmt.redactBind(position-1, *value))
 	}
+	bnd.dty = C.SQLT_VNU
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		unsafe.Pointer(&bnd.isNull),       //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		nil,                               //ub2          *alenp,
+		nil,                               //ub2          *rcodep,
+		0,                                 //ub4          maxarr_len,
+		nil,                               //ub4          *curelep,
+		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -62,13 +64,13 @@ func (bnd *bndInt64Ptr) bind(value *int64, position int, stmt *Stmt) error {
 func (bnd *bndInt64Ptr) setPtr() error {
 	if bnd.isNull > C.sb2(-1) {
 		r := C.OCINumberToInt(
-			bnd.stmt.ses.srv.env.ocierr, //OCIError              *err,
-			&bnd.ociNumber,              //const OCINumber       *number,
-			C.uword(8),                  //uword                 rsl_length,
-			C.OCI_NUMBER_SIGNED,         //uword                 rsl_flag,
-			unsafe.Pointer(bnd.value))   //void                  *rsl );
+			bnd.stmt.ses.ocierr,       //OCIError              *err,
+			&bnd.ociNumber,            //const OCINumber       *number,
+			C.uword(8),                //uword                 rsl_length,
+			C.OCI_NUMBER_SIGNED,       //uword                 rsl_flag,
+			unsafe.Pointer(bnd.value)) //void                  *rsl );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
 	}
 	return nil
@@ -84,6 +86,7 @@ func (bnd *bndInt64Ptr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.value = nil
 	stmt.putBnd(bndIdxInt64Ptr, bnd)
 	return nil
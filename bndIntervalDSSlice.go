@@ -14,18 +14,55 @@ import (
 )
 
 type bndIntervalDSSlice struct {
+	bndDtyRec
 	stmt         *Stmt
 	ocibnd       *C.OCIBind
 	ociIntervals []*C.OCIInterval
+	nullInds     []C.sb2
+	curlen       C.ub4            // elements of a PL/SQL associative array
+	ptr          *IntervalDSTable // OUT PL/SQL associative array, or nil
 }
 
 func (bnd *bndIntervalDSSlice) bind(values []IntervalDS, position int, stmt *Stmt) error {
+	return bnd.bindArray(values, 0, position, stmt)
+}
+
+// bindPlsql binds values as a PL/SQL associative array, a TABLE OF INTERVAL
+// DAY TO SECOND INDEX BY PLS_INTEGER. When ptr isn't nil, the array is IN OUT:
+// up to cap(*ptr) elements are returned to *ptr after execution.
+func (bnd *bndIntervalDSSlice) bindPlsql(values IntervalDSTable, ptr *IntervalDSTable, position int, stmt *Stmt) error {
+	maxLen := len(values)
+	if ptr != nil {
+		maxLen = cap(*ptr)
+	}
+	if maxLen == 0 {
+		return errF("PL/SQL associative array bind %d requires a capacity of at least one element.", position)
+	}
+	bnd.ptr = ptr
+	return bnd.bindArray(values, maxLen, position, stmt)
+}
+
+// bindArray binds values as an array of iterations, or as a PL/SQL
+// associative array of up to maxLen elements when maxLen isn't zero.
+func (bnd *bndIntervalDSSlice) bindArray(values []IntervalDS, maxLen int, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
-	bnd.ociIntervals = make([]*C.OCIInterval, len(values))
-	nullInds := make([]C.sb2, len(values))
-	alenp := make([]C.ACTUAL_LENGTH_TYPE, len(values))
-	rcodep := make([]C.ub2, len(values))
-	for n, value := range values {
+	length := len(values)
+	if maxLen > length {
+		length = maxLen
+	}
+	bnd.ociIntervals = make([]*C.OCIInterval, length)
+	nullInds := stmt.arena.sb2s(length)
+	alenp := stmt.arena.alens(length)
+	rcodep := stmt.arena.ub2s(length)
+	bnd.nullInds = nullInds
+	bnd.curlen = C.ub4(len(values))
+	for n := 0; n < length; n++ {
+		var value IntervalDS
+		if n < len(values) {
+			value = values[n]
+		} else {
+			value.IsNull = true
+		}
 		r := C.OCIDescriptorAlloc(
 			unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv),             //CONST dvoid   *parenth,
 			(*unsafe.Pointer)(unsafe.Pointer(&bnd.ociIntervals[n])), //dvoid         **descpp,
@@ -33,13 +70,13 @@ func (bnd *bndIntervalDSSlice) bind(values []IntervalDS, position int, stmt *Stm
 			0,   //size_t        xtramem_sz,
 			nil) //dvoid         **usrmempp);
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		} else if r == C.OCI_INVALID_HANDLE {
 			return errNew("unable to allocate oci interval handle during bind")
 		}
 		r = C.OCIIntervalSetDaySecond(
 			unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //void               *hndl,
-			bnd.stmt.ses.srv.env.ocierr,                 //OCIError           *err,
+			bnd.stmt.ses.ocierr,                         //OCIError           *err,
 			C.sb4(value.Day),                            //sb4                dy,
 			C.sb4(value.Hour),                           //sb4                hr,
 			C.sb4(value.Minute),                         //sb4                mm,
@@ -47,46 +84,76 @@ func (bnd *bndIntervalDSSlice) bind(values []IntervalDS, position int, stmt *Stm
 			C.sb4(value.Nanosecond),                     //sb4                fsec,
 			bnd.ociIntervals[n])                         //OCIInterval        *result );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
-		if values[n].IsNull {
+		if value.IsNull {
 			nullInds[n] = C.sb2(-1)
 		} else {
 			nullInds[n] = C.sb2(0)
 		}
 		alenp[n] = C.ACTUAL_LENGTH_TYPE(unsafe.Sizeof(bnd.ociIntervals[n]))
 	}
+	var curelep *C.ub4
+	if maxLen > 0 {
+		curelep = &bnd.curlen
+	}
+	bnd.dty = C.SQLT_INTERVAL_DS
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                               //OCIError     *errhp,
 		C.ub4(position),                                   //ub4          position,
 		unsafe.Pointer(&bnd.ociIntervals[0]),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociIntervals[0])), //sb8          value_sz,
-		C.SQLT_INTERVAL_DS,                                //ub2          dty,
-		unsafe.Pointer(&nullInds[0]),                      //void         *indp,
-		&alenp[0],                                         //ub2          *alenp,
-		&rcodep[0],                                        //ub2          *rcodep,
-		0,                                                 //ub4          maxarr_len,
-		nil,                                               //ub4          *curelep,
-		C.OCI_DEFAULT)                                     //ub4          mode );
+		bnd.dty,                      //ub2          dty,
+		unsafe.Pointer(&nullInds[0]), //void         *indp,
+		&alenp[0],                    //ub2          *alenp,
+		&rcodep[0],                   //ub2          *rcodep,
+		C.ub4(maxLen),                //ub4          maxarr_len,
+		curelep,                      //ub4          *curelep,
+		C.OCI_DEFAULT)                //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	r = C.OCIBindArrayOfStruct(
 		bnd.ocibnd,
-		bnd.stmt.ses.srv.env.ocierr,
+		bnd.stmt.ses.ocierr,
 		C.ub4(unsafe.Sizeof(bnd.ociIntervals[0])), //ub4         pvskip,
 		C.ub4(C.sizeof_sb2),                       //ub4         indskip,
 		C.ub4(C.sizeof_ub4),                       //ub4         alskip,
 		C.ub4(C.sizeof_ub2))                       //ub4         rcskip
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (bnd *bndIntervalDSSlice) setPtr() error {
+	if bnd.ptr == nil {
+		return nil
+	}
+	values := (*bnd.ptr)[:int(bnd.curlen)]
+	for n := range values {
+		if bnd.nullInds[n] < 0 {
+			values[n] = IntervalDS{IsNull: true}
+			continue
+		}
+		var day, hour, minute, second, nanosecond C.sb4
+		r := C.OCIIntervalGetDaySecond(
+			unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //void               *hndl,
+			bnd.stmt.ses.ocierr,                         //OCIError           *err,
+			&day,                                        //sb4                *dy,
+			&hour,                                       //sb4                *hr,
+			&minute,                                     //sb4                *mm,
+			&second,                                     //sb4                *ss,
+			&nanosecond,                                 //sb4                *fsec,
+			bnd.ociIntervals[n])                         //const OCIInterval  *interval );
+		if r == C.OCI_ERROR {
+			return bnd.stmt.ses.ociError()
+		}
+		values[n] = IntervalDS{Day: int32(day), Hour: int32(hour), Minute: int32(minute), Second: int32(second), Nanosecond: int32(nanosecond)}
+	}
+	*bnd.ptr = values
 	return nil
 }
 
@@ -105,7 +172,11 @@ func (bnd *bndIntervalDSSlice) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociIntervals = nil
+	bnd.nullInds = nil
+	bnd.curlen = 0
+	bnd.ptr = nil
 	stmt.putBnd(bndIdxIntervalDSSlice, bnd)
 	return nil
 }
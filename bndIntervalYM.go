@@ -14,6 +14,7 @@ import (
 )
 
 type bndIntervalYM struct {
+	bndDtyRec
 	stmt        *Stmt
 	ocibnd      *C.OCIBind
 	ociInterval *C.OCIInterval
@@ -28,35 +29,36 @@ func (bnd *bndIntervalYM) bind(value IntervalYM, position int, stmt *Stmt) error
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci interval handle during bind")
 	}
 	r = C.OCIIntervalSetYearMonth(
 		unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //void               *hndl,
-		bnd.stmt.ses.srv.env.ocierr,                 //OCIError           *err,
+		bnd.stmt.ses.ocierr,                         //OCIError           *err,
 		C.sb4(value.Year),                           //sb4                yr,
 		C.sb4(value.Month),                          //sb4                mnth,
 		bnd.ociInterval)                             //OCIInterval        *result );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_INTERVAL_YM
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                              //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                    //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                   //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                           //OCIError     *errhp,
 		C.ub4(position),                               //ub4          position,
 		unsafe.Pointer(&bnd.ociInterval),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociInterval)), //sb8          value_sz,
-		C.SQLT_INTERVAL_YM,                            //ub2          dty,
-		nil,                                           //void         *indp,
-		nil,                                           //ub2          *alenp,
-		nil,                                           //ub2          *rcodep,
-		0,                                             //ub4          maxarr_len,
-		nil,                                           //ub4          *curelep,
-		C.OCI_DEFAULT)                                 //ub4          mode );
+		bnd.dty,       //ub2          dty,
+		nil,           //void         *indp,
+		nil,           //ub2          *alenp,
+		nil,           //ub2          *rcodep,
+		0,             //ub4          maxarr_len,
+		nil,           //ub4          *curelep,
+		C.OCI_DEFAULT) //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -78,6 +80,7 @@ func (bnd *bndIntervalYM) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociInterval = nil
 	stmt.putBnd(bndIdxIntervalYM, bnd)
 	return nil
@@ -11,13 +11,16 @@ package ora
 import "C"
 import (
 	"io"
+	"strings"
 	"unsafe"
 )
 
 type bndLob struct {
+	bndDtyRec
 	stmt          *Stmt
 	ocibnd        *C.OCIBind
 	ociLobLocator *C.OCILobLocator
+	clob          bool // bound as SQLT_CLOB
 }
 
 // bindReader binds an io.Reader: reads from rdr, and writes to a temprary LOB,
@@ -36,7 +39,7 @@ type bndLob struct {
 func (bnd *bndLob) bindReader(rdr io.Reader, position int, lobBufferSize int, stmt *Stmt) (err error) {
 	bnd.stmt = stmt
 	if lobBufferSize <= 0 {
-		lobBufferSize = lobChunkSize
+		lobBufferSize = stmt.ses.lobChunk()
 	}
 
 	finish, err := bnd.allocTempLob()
@@ -45,7 +48,7 @@ func (bnd *bndLob) bindReader(rdr io.Reader, position int, lobBufferSize int, st
 	}
 
 	if err = writeLob(bnd.ociLobLocator, bnd.stmt, rdr, lobBufferSize); err != nil {
-		bnd.stmt.ses.Break()
+		bnd.stmt.ses.breakCall()
 		finish()
 		return err
 	}
@@ -57,6 +60,30 @@ func (bnd *bndLob) bindReader(rdr io.Reader, position int, lobBufferSize int, st
 	return nil
 }
 
+// bindClob binds value as a temporary CLOB.
+func (bnd *bndLob) bindClob(value string, position int, lobBufferSize int, stmt *Stmt) (err error) {
+	bnd.stmt = stmt
+	bnd.clob = true
+	if lobBufferSize <= 0 {
+		lobBufferSize = stmt.ses.lobChunk()
+	}
+	var finish func()
+	bnd.ociLobLocator, finish, err = allocTempLobType(bnd.stmt, C.OCI_TEMP_CLOB)
+	if err != nil {
+		return err
+	}
+	if err = writeLob(bnd.ociLobLocator, bnd.stmt, strings.NewReader(value), lobBufferSize); err != nil {
+		bnd.stmt.ses.breakCall()
+		finish()
+		return err
+	}
+	if err = bnd.bindByPos(position); err != nil {
+		finish()
+		return err
+	}
+	return nil
+}
+
 func (bnd *bndLob) setPtr() error {
 	return nil
 }
@@ -76,10 +103,7 @@ func (bnd *bndLob) close() (err error) {
 
 	// no need to clear bnd.buf
 	// free temporary lob
-	C.OCILobFreeTemporary(
-		bnd.stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-		bnd.ociLobLocator)           //OCILobLocator      *locp,
+	bnd.stmt.ses.freeTempLob(bnd.ociLobLocator)
 	// free lob locator handle
 	C.OCIDescriptorFree(
 		unsafe.Pointer(bnd.ociLobLocator), //void     *descp,
@@ -87,7 +111,9 @@ func (bnd *bndLob) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociLobLocator = nil
+	bnd.clob = false
 	stmt.putBnd(bndIdxLob, bnd)
 	return nil
 }
@@ -98,14 +124,18 @@ func (bnd *bndLob) allocTempLob() (finish func(), err error) {
 }
 
 func (bnd *bndLob) bindByPos(position int) error {
+	bnd.dty = C.SQLT_BLOB
+	if bnd.clob {
+		bnd.dty = C.SQLT_CLOB
+	}
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                      //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                     //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                             //OCIError     *errhp,
 		C.ub4(position),                                 //ub4          position,
 		unsafe.Pointer(&bnd.ociLobLocator),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociLobLocator)), //sb8          value_sz,
-		C.SQLT_BLOB,   //ub2          dty,
+		bnd.dty,       //ub2          dty,
 		nil,           //void         *indp,
 		nil,           //ub2          *alenp,
 		nil,           //ub2          *rcodep,
@@ -113,25 +143,21 @@ func (bnd *bndLob) bindByPos(position int) error {
 		nil,           //ub4          *curelep,
 		C.OCI_DEFAULT) //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
 }
 
 func writeLob(ociLobLocator *C.OCILobLocator, stmt *Stmt, r io.Reader, lobBufferSize int) error {
-	var actBuf, nextBuf []byte
-	if lobChunkSize >= lobBufferSize {
-		arr := lobChunkPool.Get().([lobChunkSize]byte)
-		defer lobChunkPool.Put(arr)
-		actBuf = arr[:lobBufferSize]
-		arr = lobChunkPool.Get().([lobChunkSize]byte)
-		defer lobChunkPool.Put(arr)
-		nextBuf = arr[:lobBufferSize]
-	} else {
-		actBuf = make([]byte, lobBufferSize)
-		nextBuf = make([]byte, lobBufferSize)
+	// align the pieces to the chunks of the LOB
+	if chunkSize, err := stmt.tempLobChunkSize(ociLobLocator); err == nil {
+		lobBufferSize = alignLobPiece(lobBufferSize, chunkSize)
 	}
+	pActBuf, pNextBuf := getLobBuf(lobBufferSize), getLobBuf(lobBufferSize)
+	defer putLobBuf(pActBuf)
+	defer putLobBuf(pNextBuf)
+	actBuf, nextBuf := *pActBuf, *pNextBuf
 
 	// write bytes to lob locator - at once, as we already have all bytes in memory
 	var n int
@@ -180,24 +206,26 @@ func writeLob(ociLobLocator *C.OCILobLocator, stmt *Stmt, r io.Reader, lobBuffer
 			byte_amtp = C.oraub8(n)
 		}
 		// Write to Oracle
-		if C.OCILobWrite2(
-			stmt.ses.ocisvcctx,         //OCISvcCtx          *svchp,
-			stmt.ses.srv.env.ocierr,    //OCIError           *errhp,
-			ociLobLocator,              //OCILobLocator      *locp,
-			&byte_amtp,                 //oraub8          *byte_amtp,
-			nil,                        //oraub8          *char_amtp,
-			off+1,                      //oraub8          offset, starting position is 1
-			unsafe.Pointer(&actBuf[0]), //void            *bufp,
-			C.oraub8(n),
-			actPiece,         //ub1             piece,
-			nil,              //void            *ctxp,
-			nil,              //OCICallbackLobWrite2 (cbfp)
-			C.ub2(0),         //ub2             csid,
-			C.SQLCS_IMPLICIT, //ub1             csfrm );
-		//fmt.Printf("r %v, current %v, buffer %v\n", r, current, buffer)
-		//fmt.Printf("C.OCI_NEED_DATA %v, C.OCI_SUCCESS %v\n", C.OCI_NEED_DATA, C.OCI_SUCCESS)
-		) == C.OCI_ERROR {
-			return stmt.ses.srv.env.ociError()
+		if stmt.ses.poll(nil, func() C.sword {
+			return C.OCILobWrite2(
+				stmt.ses.ocisvcctx,         //OCISvcCtx          *svchp,
+				stmt.ses.ocierr,            //OCIError           *errhp,
+				ociLobLocator,              //OCILobLocator      *locp,
+				&byte_amtp,                 //oraub8          *byte_amtp,
+				nil,                        //oraub8          *char_amtp,
+				off+1,                      //oraub8          offset, starting position is 1
+				unsafe.Pointer(&actBuf[0]), //void            *bufp,
+				C.oraub8(n),
+				actPiece,         //ub1             piece,
+				nil,              //void            *ctxp,
+				nil,              //OCICallbackLobWrite2 (cbfp)
+				C.ub2(0),         //ub2             csid,
+				C.SQLCS_IMPLICIT, //ub1             csfrm );
+			//fmt.Printf("r %v, current %v, buffer %v\n", r, current, buffer)
+			//fmt.Printf("C.OCI_NEED_DATA %v, C.OCI_SUCCESS %v\n", C.OCI_NEED_DATA, C.OCI_SUCCESS)
+			)
+		}) == C.OCI_ERROR {
+			return stmt.ses.ociError()
 		}
 		off += byte_amtp
 
@@ -213,6 +241,16 @@ func allocTempLob(stmt *Stmt) (
 	ociLobLocator *C.OCILobLocator,
 	finish func(),
 	err error,
+) {
+	return allocTempLobType(stmt, C.OCI_TEMP_BLOB)
+}
+
+// allocTempLobType allocates a temporary LOB of lobtype, OCI_TEMP_BLOB or
+// OCI_TEMP_CLOB.
+func allocTempLobType(stmt *Stmt, lobtype C.ub1) (
+	ociLobLocator *C.OCILobLocator,
+	finish func(),
+	err error,
 ) {
 	// Allocate lob locator handle
 	r := C.OCIDescriptorAlloc(
@@ -222,34 +260,34 @@ func allocTempLob(stmt *Stmt) (
 		0,                                                 //size_t        xtramem_sz,
 		nil)                                               //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return nil, nil, stmt.ses.srv.env.ociError()
+		return nil, nil, stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return nil, nil, errNew("unable to allocate oci lob handle during bind")
 	}
 
 	// Create temporary lob
-	r = C.OCILobCreateTemporary(
-		stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-		ociLobLocator,           //OCILobLocator      *locp,
-		C.OCI_DEFAULT,           //ub2                csid,
-		C.SQLCS_IMPLICIT,        //ub1                csfrm,
-		C.OCI_TEMP_BLOB,         //ub1                lobtype,
-		C.TRUE,                  //boolean            cache,
-		C.OCI_DURATION_SESSION)  //OCIDuration        duration);
+	r = stmt.ses.poll(nil, func() C.sword {
+		return C.OCILobCreateTemporary(
+			stmt.ses.ocisvcctx,     //OCISvcCtx          *svchp,
+			stmt.ses.ocierr,        //OCIError           *errhp,
+			ociLobLocator,          //OCILobLocator      *locp,
+			C.OCI_DEFAULT,          //ub2                csid,
+			C.SQLCS_IMPLICIT,       //ub1                csfrm,
+			lobtype,                //ub1                lobtype,
+			C.TRUE,                 //boolean            cache,
+			C.OCI_DURATION_SESSION) //OCIDuration        duration);
+	})
 	if r == C.OCI_ERROR {
 		// free lob locator handle
 		C.OCIDescriptorFree(
 			unsafe.Pointer(ociLobLocator), //void     *descp,
 			C.OCI_DTYPE_LOB)               //ub4      type );
-		return nil, nil, stmt.ses.srv.env.ociError()
+		return nil, nil, stmt.ses.ociError()
 	}
+	stmt.ses.addTempLob(ociLobLocator)
 
 	return ociLobLocator, func() {
-		C.OCILobFreeTemporary(
-			stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-			stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-			ociLobLocator)           //OCILobLocator      *locp,
+		stmt.ses.freeTempLob(ociLobLocator)
 		// free lob locator handle
 		C.OCIDescriptorFree(
 			unsafe.Pointer(ociLobLocator), //void     *descp,
@@ -12,6 +12,7 @@ import "C"
 import "unsafe"
 
 type bndLobPtr struct {
+	bndDtyRec
 	stmt          *Stmt
 	ocibnd        *C.OCIBind
 	ociLobLocator *C.OCILobLocator
@@ -22,7 +23,7 @@ func (bnd *bndLobPtr) bindLob(lob *Lob, position int, lobBufferSize int, stmt *S
 	bnd.stmt = stmt
 	bnd.value = lob
 	if lobBufferSize <= 0 {
-		lobBufferSize = lobChunkSize
+		lobBufferSize = stmt.ses.lobChunk()
 	}
 
 	finish, err := bnd.allocTempLob()
@@ -32,7 +33,7 @@ func (bnd *bndLobPtr) bindLob(lob *Lob, position int, lobBufferSize int, stmt *S
 
 	if lob != nil && lob.Reader != nil {
 		if err = writeLob(bnd.ociLobLocator, bnd.stmt, lob.Reader, lobBufferSize); err != nil {
-			bnd.stmt.ses.Break()
+			bnd.stmt.ses.breakCall()
 			finish()
 			return err
 		}
@@ -78,10 +79,7 @@ func (bnd *bndLobPtr) close() (err error) {
 
 	// no need to clear bnd.buf
 	// free temporary lob
-	C.OCILobFreeTemporary(
-		bnd.stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-		bnd.ociLobLocator)           //OCILobLocator      *locp,
+	bnd.stmt.ses.freeTempLob(bnd.ociLobLocator)
 	// free lob locator handle
 	C.OCIDescriptorFree(
 		unsafe.Pointer(bnd.ociLobLocator), //void     *descp,
@@ -90,6 +88,7 @@ func (bnd *bndLobPtr) close() (err error) {
 	bnd.stmt = nil
 	bnd.value = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociLobLocator = nil
 	stmt.putBnd(bndIdxLobPtr, bnd)
 	return nil
@@ -101,14 +100,15 @@ func (bnd *bndLobPtr) allocTempLob() (finish func(), err error) {
 }
 
 func (bnd *bndLobPtr) bindByPos(position int) error {
+	bnd.dty = C.SQLT_BLOB
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                      //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                     //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                             //OCIError     *errhp,
 		C.ub4(position),                                 //ub4          position,
 		unsafe.Pointer(&bnd.ociLobLocator),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociLobLocator)), //sb8          value_sz,
-		C.SQLT_BLOB,   //ub2          dty,
+		bnd.dty,       //ub2          dty,
 		nil,           //void         *indp,
 		nil,           //ub2          *alenp,
 		nil,           //ub2          *rcodep,
@@ -116,7 +116,7 @@ func (bnd *bndLobPtr) bindByPos(position int) error {
 		nil,           //ub4          *curelep,
 		C.OCI_DEFAULT) //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
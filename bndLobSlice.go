@@ -15,6 +15,7 @@ import (
 )
 
 type bndLobSlice struct {
+	bndDtyRec
 	stmt           *Stmt
 	ocibnd         *C.OCIBind
 	ociLobLocators []*C.OCILobLocator
@@ -23,7 +24,7 @@ type bndLobSlice struct {
 
 func (bnd *bndLobSlice) bindOra(values []Lob, position int, lobBufferSize int, stmt *Stmt) error {
 	binValues := make([]io.Reader, len(values))
-	nullInds := make([]C.sb2, len(values))
+	nullInds := stmt.arena.sb2s(len(values))
 	for n, _ := range values {
 		if values[n].Reader == nil {
 			nullInds[n] = C.sb2(-1)
@@ -46,10 +47,10 @@ func (bnd *bndLobSlice) bindReaders(
 	bnd.stmt = stmt
 	bnd.ociLobLocators = make([]*C.OCILobLocator, len(values))
 	if nullInds == nil {
-		nullInds = make([]C.sb2, len(values))
+		nullInds = stmt.arena.sb2s(len(values))
 	}
-	alenp := make([]C.ACTUAL_LENGTH_TYPE, len(values))
-	rcodep := make([]C.ub2, len(values))
+	alenp := stmt.arena.alens(len(values))
+	rcodep := stmt.arena.ub2s(len(values))
 	if len(bnd.buf) < lobBufferSize {
 		bnd.buf = make([]byte, lobBufferSize)
 	}
@@ -77,19 +78,20 @@ func (bnd *bndLobSlice) bindReaders(
 			continue
 		}
 		if err = writeLob(bnd.ociLobLocators[i], bnd.stmt, r, lobBufferSize); err != nil {
-			bnd.stmt.ses.Break()
+			bnd.stmt.ses.breakCall()
 			return err
 		}
 	}
 
+	bnd.dty = C.SQLT_BLOB
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                    //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                          //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                         //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                                 //OCIError     *errhp,
 		C.ub4(position),                                     //ub4          position,
 		unsafe.Pointer(&bnd.ociLobLocators[0]),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociLobLocators[0])), //sb8          value_sz,
-		C.SQLT_BLOB,                  //ub2          dty,
+		bnd.dty,                      //ub2          dty,
 		unsafe.Pointer(&nullInds[0]), //void         *indp,
 		&alenp[0],                    //ub4          *alenp,
 		&rcodep[0],                   //ub2          *rcodep,
@@ -97,18 +99,18 @@ func (bnd *bndLobSlice) bindReaders(
 		nil,                          //ub4          *curelep,
 		C.OCI_DEFAULT)                //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	r = C.OCIBindArrayOfStruct(
 		bnd.ocibnd,
-		bnd.stmt.ses.srv.env.ocierr,
+		bnd.stmt.ses.ocierr,
 		C.ub4(unsafe.Sizeof(bnd.ociLobLocators[0])), //ub4         pvskip,
 		C.ub4(C.sizeof_sb2),                         //ub4         indskip,
 		C.ub4(C.sizeof_ub4),                         //ub4         alskip,
 		C.ub4(C.sizeof_ub2))                         //ub4         rcskip
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
@@ -127,10 +129,7 @@ func (bnd *bndLobSlice) close() (err error) {
 
 	for n := 0; n < len(bnd.ociLobLocators); n++ {
 		// free temporary lob
-		C.OCILobFreeTemporary(
-			bnd.stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-			bnd.stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-			bnd.ociLobLocators[n])       //OCILobLocator      *locp,
+		bnd.stmt.ses.freeTempLob(bnd.ociLobLocators[n])
 		// free lob locator handle
 		C.OCIDescriptorFree(
 			unsafe.Pointer(bnd.ociLobLocators[n]), //void     *descp,
@@ -139,6 +138,7 @@ func (bnd *bndLobSlice) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociLobLocators = nil
 	stmt.putBnd(bndIdxBinSlice, bnd)
 	return nil
@@ -14,6 +14,7 @@ import (
 )
 
 type bndNil struct {
+	bndDtyRec
 	stmt   *Stmt
 	ocibnd *C.OCIBind
 }
@@ -21,22 +22,23 @@ type bndNil struct {
 func (bnd *bndNil) bind(position int, sqlt C.ub2, stmt *Stmt) error {
 	bnd.stmt = stmt
 	indp := C.sb2(-1)
+	bnd.dty = sqlt
 	r := C.OCIBINDBYPOS(
-		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
-		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
-		C.ub4(position),             //ub4          position,
-		nil,                         //void         *valuep,
-		0,                           //sb8          value_sz,
-		sqlt,                        //C.SQLT_CHR,                                          //ub2          dty,
-		unsafe.Pointer(&indp), //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		bnd.stmt.ocistmt,           //OCIStmt      *stmtp,
+		(**C.OCIBind)(&bnd.ocibnd), //OCIBind      **bindpp,
+		bnd.stmt.ses.ocierr,        //OCIError     *errhp,
+		C.ub4(position),            //ub4          position,
+		nil,                        //void         *valuep,
+		0,                          //sb8          value_sz,
+		bnd.dty,                    //ub2          dty,
+		unsafe.Pointer(&indp),      //void         *indp,
+		nil,                        //ub2          *alenp,
+		nil,                        //ub2          *rcodep,
+		0,                          //ub4          maxarr_len,
+		nil,                        //ub4          *curelep,
+		C.OCI_DEFAULT)              //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
@@ -56,6 +58,7 @@ func (bnd *bndNil) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	stmt.putBnd(bndIdxNil, bnd)
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 )
 
 type bndRset struct {
+	bndDtyRec
 	stmt    *Stmt
 	ocibnd  *C.OCIBind
 	ocistmt *C.OCIStmt
@@ -30,22 +31,23 @@ func (bnd *bndRset) bind(value *Rset, position int, stmt *Stmt) error {
 	if err != nil {
 		return err
 	}
+	bnd.dty = C.SQLT_RSET
 	r := C.OCIBINDBYPOS(
 		stmt.ocistmt,                 //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),   //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,  //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,          //OCIError     *errhp,
 		C.ub4(position),              //ub4          position,
 		unsafe.Pointer(&bnd.ocistmt), //void         *valuep,
-		0,                           //sb8          value_sz,
-		C.SQLT_RSET,                 //ub2          dty,
-		unsafe.Pointer(&bnd.isNull), //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		0,                            //sb8          value_sz,
+		bnd.dty,                      //ub2          dty,
+		unsafe.Pointer(&bnd.isNull),  //void         *indp,
+		nil,                          //ub2          *alenp,
+		nil,                          //ub2          *rcodep,
+		0,                            //ub4          maxarr_len,
+		nil,                          //ub4          *curelep,
+		C.OCI_DEFAULT)                //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 
 	return nil
@@ -71,6 +73,7 @@ func (bnd *bndRset) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ocistmt = nil
 	bnd.value = nil
 	stmt.putBnd(bndIdxRset, bnd)
@@ -15,22 +15,26 @@ import (
 )
 
 type bndString struct {
+	bndDtyRec
 	stmt    *Stmt
 	ocibnd  *C.OCIBind
 	cString *C.char
+	length  int
 }
 
 func (bnd *bndString) bind(value string, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
 	bnd.cString = C.CString(value)
+	bnd.length = len(value)
+	bnd.dty = C.SQLT_CHR
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,         //OCIError     *errhp,
 		C.ub4(position),             //ub4          position,
 		unsafe.Pointer(bnd.cString), //void         *valuep,
 		C.LENGTH_TYPE(len(value)),   //sb8          value_sz,
-		C.SQLT_CHR,                  //ub2          dty,
+		bnd.dty,                     //ub2          dty,
 		nil,                         //void         *indp,
 		nil,                         //ub2          *alenp,
 		nil,                         //ub2          *rcodep,
@@ -38,11 +42,26 @@ func (bnd *bndString) bind(value string, position int, stmt *Stmt) error {
 		nil,                         //ub4          *curelep,
 		C.OCI_DEFAULT)               //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
 
+// rebind copies a string of the bound length into the C string buffer.
+func (bnd *bndString) rebind(value interface{}) (bool, error) {
+	v, ok := value.(string)
+	if ok {
+		v = bnd.stmt.cfg.EmptyString.bindValue(v)
+	}
+	if !ok || len(v) != bnd.length {
+		return false, nil
+	}
+	if bnd.length > 0 {
+		copy((*[1 << 30]byte)(unsafe.Pointer(bnd.cString))[:bnd.length:bnd.length], v)
+	}
+	return true, nil
+}
+
 func (bnd *bndString) setPtr() error {
 	return nil
 }
@@ -57,7 +76,9 @@ func (bnd *bndString) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.cString = nil
+	bnd.length = 0
 	stmt.putBnd(bndIdxString, bnd)
 	return nil
 }
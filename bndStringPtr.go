@@ -14,6 +14,7 @@ import (
 )
 
 type bndStringPtr struct {
+	bndDtyRec
 	stmt   *Stmt
 	ocibnd *C.OCIBind
 	isNull C.sb2
@@ -47,16 +48,17 @@ func (bnd *bndStringPtr) bind(value *string, position int, stringPtrBufferSize i
 		bnd.alen = bnd.alen[:1]
 		bnd.alen[0] = C.ACTUAL_LENGTH_TYPE(length)
 	}
-	bnd.stmt.logF(_drv.cfg.Log.Stmt.Bind,
+	bnd.stmt.logF(_drv.cfg().Log.Stmt.Bind,
 		"StringPtr.bind(%d) cap=%d len=%d alen=%d", position, cap(bnd.buf), len(bnd.buf), bnd.alen[0])
+	bnd.dty = C.SQLT_CHR
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,            //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),  //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr, //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,         //OCIError     *errhp,
 		C.ub4(position),             //ub4          position,
 		unsafe.Pointer(&bnd.buf[0]), //void         *valuep,
 		C.LENGTH_TYPE(cap(bnd.buf)), //sb8          value_sz,
-		C.SQLT_CHR,                  //ub2          dty,
+		bnd.dty,                     //ub2          dty,
 		unsafe.Pointer(&bnd.isNull), //void         *indp,
 		&bnd.alen[0],                //ub2          *alenp,
 		nil,                         //ub2          *rcodep,
@@ -64,13 +66,13 @@ func (bnd *bndStringPtr) bind(value *string, position int, stringPtrBufferSize i
 		nil,                         //ub4          *curelep,
 		C.OCI_DEFAULT)               //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (bnd *bndStringPtr) setPtr() error {
-	bnd.stmt.logF(_drv.cfg.Log.Stmt.Bind,
+	bnd.stmt.logF(_drv.cfg().Log.Stmt.Bind,
 		"StringPtr.setPtr isNull=%d alen=%d", bnd.isNull, bnd.alen[0])
 	if bnd.isNull > C.sb2(-1) {
 		*bnd.value = string(bnd.buf[:bnd.alen[0]])
@@ -89,6 +91,7 @@ func (bnd *bndStringPtr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.value = nil
 	bnd.alen = bnd.alen[:0]
 	bnd.buf = bnd.buf[:0]
@@ -18,6 +18,7 @@ import (
 )
 
 type bndTime struct {
+	bndDtyRec
 	stmt        *Stmt
 	ocibnd      *C.OCIBind
 	ociDateTime *C.OCIDateTime
@@ -27,6 +28,7 @@ type bndTime struct {
 
 func (bnd *bndTime) bind(value time.Time, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
+	value = stmt.cfg.bindTime(value)
 	zone := zoneOffset(value, &bnd.zoneBuf)
 	bnd.cZone = C.CString(zone)
 	r := C.OCIDescriptorAlloc(
@@ -36,13 +38,13 @@ func (bnd *bndTime) bind(value time.Time, position int, stmt *Stmt) error {
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci timestamp handle during bind")
 	}
 	r = C.OCIDateTimeConstruct(
 		unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //dvoid         *hndl,
-		bnd.stmt.ses.srv.env.ocierr,                 //OCIError      *err,
+		bnd.stmt.ses.ocierr,                         //OCIError      *err,
 		bnd.ociDateTime,                             //OCIDateTime   *datetime,
 		C.sb2(value.Year()),                         //sb2           year,
 		C.ub1(int32(value.Month())),                 //ub1           month,
@@ -54,24 +56,25 @@ func (bnd *bndTime) bind(value time.Time, position int, stmt *Stmt) error {
 		(*C.OraText)(unsafe.Pointer(bnd.cZone)),     //OraText       *timezone,
 		C.size_t(len(zone)))                         //size_t        timezone_length );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_TIMESTAMP_TZ
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                              //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                    //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                   //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                           //OCIError     *errhp,
 		C.ub4(position),                               //ub4          position,
 		unsafe.Pointer(&bnd.ociDateTime),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociDateTime)), //sb8          value_sz,
-		C.SQLT_TIMESTAMP_TZ,                           //ub2          dty,
-		nil,                                           //void         *indp,
-		nil,                                           //ub2          *alenp,
-		nil,                                           //ub2          *rcodep,
-		0,                                             //ub4          maxarr_len,
-		nil,                                           //ub4          *curelep,
-		C.OCI_DEFAULT)                                 //ub4          mode );
+		bnd.dty,       //ub2          dty,
+		nil,           //void         *indp,
+		nil,           //ub2          *alenp,
+		nil,           //ub2          *rcodep,
+		0,             //ub4          maxarr_len,
+		nil,           //ub4          *curelep,
+		C.OCI_DEFAULT) //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -115,6 +118,7 @@ func (bnd *bndTime) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociDateTime = nil
 	bnd.zoneBuf.Reset()
 	stmt.putBnd(bndIdxTime, bnd)
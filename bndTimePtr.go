@@ -17,6 +17,7 @@ import (
 )
 
 type bndTimePtr struct {
+	bndDtyRec
 	stmt        *Stmt
 	ocibnd      *C.OCIBind
 	ociDateTime *C.OCIDateTime
@@ -36,55 +37,57 @@ func (bnd *bndTimePtr) bind(value *time.Time, position int, stmt *Stmt) error {
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci timestamp handle during bind")
 	}
 	if value == nil {
 		bnd.isNull = C.sb2(-1)
 	} else {
-		zone := zoneOffset(*value, &bnd.zoneBuf)
+		t := stmt.cfg.bindTime(*value)
+		zone := zoneOffset(t, &bnd.zoneBuf)
 		bnd.cZone = C.CString(zone)
 		r = C.OCIDateTimeConstruct(
 			unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //dvoid         *hndl,
-			bnd.stmt.ses.srv.env.ocierr,                 //OCIError      *err,
+			bnd.stmt.ses.ocierr,                         //OCIError      *err,
 			bnd.ociDateTime,                             //OCIDateTime   *datetime,
-			C.sb2(value.Year()),                         //sb2           year,
-			C.ub1(int32(value.Month())),                 //ub1           month,
-			C.ub1(value.Day()),                          //ub1           day,
-			C.ub1(value.Hour()),                         //ub1           hour,
-			C.ub1(value.Minute()),                       //ub1           min,
-			C.ub1(value.Second()),                       //ub1           sec,
-			C.ub4(value.Nanosecond()),                   //ub4           fsec,
+			C.sb2(t.Year()),                             //sb2           year,
+			C.ub1(int32(t.Month())),                     //ub1           month,
+			C.ub1(t.Day()),                              //ub1           day,
+			C.ub1(t.Hour()),                             //ub1           hour,
+			C.ub1(t.Minute()),                           //ub1           min,
+			C.ub1(t.Second()),                           //ub1           sec,
+			C.ub4(t.Nanosecond()),                       //ub4           fsec,
 			(*C.OraText)(unsafe.Pointer(bnd.cZone)),     //OraText       *timezone,
 			C.size_t(len(zone)))                         //size_t        timezone_length );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
 	}
+	bnd.dty = C.SQLT_TIMESTAMP_TZ
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                              //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                    //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                   //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                           //OCIError     *errhp,
 		C.ub4(position),                               //ub4          position,
 		unsafe.Pointer(&bnd.ociDateTime),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociDateTime)), //sb8          value_sz,
-		C.SQLT_TIMESTAMP_TZ,                           //ub2          dty,
-		unsafe.Pointer(&bnd.isNull),                   //void         *indp,
-		nil,           //ub2          *alenp,
-		nil,           //ub2          *rcodep,
-		0,             //ub4          maxarr_len,
-		nil,           //ub4          *curelep,
-		C.OCI_DEFAULT) //ub4          mode );
+		bnd.dty,                     //ub2          dty,
+		unsafe.Pointer(&bnd.isNull), //void         *indp,
+		nil,                         //ub2          *alenp,
+		nil,                         //ub2          *rcodep,
+		0,                           //ub4          maxarr_len,
+		nil,                         //ub4          *curelep,
+		C.OCI_DEFAULT)               //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (bnd *bndTimePtr) setPtr() (err error) {
 	if bnd.value != nil && bnd.isNull > C.sb2(-1) {
-		*bnd.value, err = getTime(bnd.stmt.ses.srv.env, bnd.ociDateTime)
+		*bnd.value, err = getTime(bnd.stmt.ses.srv.env, bnd.stmt.ses.ocierr, bnd.ociDateTime)
 	}
 	return err
 }
@@ -106,6 +109,7 @@ func (bnd *bndTimePtr) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociDateTime = nil
 	bnd.value = nil
 	bnd.zoneBuf.Reset()
@@ -17,6 +17,7 @@ import (
 )
 
 type bndTimeSlice struct {
+	bndDtyRec
 	stmt         *Stmt
 	ocibnd       *C.OCIBind
 	ociDateTimes []*C.OCIDateTime
@@ -25,7 +26,7 @@ type bndTimeSlice struct {
 
 func (bnd *bndTimeSlice) bindOra(values []Time, position int, stmt *Stmt) error {
 	timeValues := make([]time.Time, len(values))
-	nullInds := make([]C.sb2, len(values))
+	nullInds := stmt.arena.sb2s(len(values))
 	for n, _ := range values {
 		if values[n].IsNull {
 			nullInds[n] = C.sb2(-1)
@@ -40,11 +41,12 @@ func (bnd *bndTimeSlice) bind(values []time.Time, nullInds []C.sb2, position int
 	bnd.stmt = stmt
 	bnd.ociDateTimes = make([]*C.OCIDateTime, len(values))
 	if nullInds == nil {
-		nullInds = make([]C.sb2, len(values))
+		nullInds = stmt.arena.sb2s(len(values))
 	}
-	alenp := make([]C.ACTUAL_LENGTH_TYPE, len(values))
-	rcodep := make([]C.ub2, len(values))
+	alenp := stmt.arena.alens(len(values))
+	rcodep := stmt.arena.ub2s(len(values))
 	for n, timeValue := range values {
+		timeValue = stmt.cfg.bindTime(timeValue)
 		timezoneStr := zoneOffset(timeValue, &bnd.zoneBuf)
 		cTimezoneStr := C.CString(timezoneStr)
 		defer func() {
@@ -57,13 +59,13 @@ func (bnd *bndTimeSlice) bind(values []time.Time, nullInds []C.sb2, position int
 			0,   //size_t        xtramem_sz,
 			nil) //dvoid         **usrmempp);
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		} else if r == C.OCI_INVALID_HANDLE {
 			return errNew("unable to allocate oci timestamp handle during bind")
 		}
 		r = C.OCIDateTimeConstruct(
 			unsafe.Pointer(bnd.stmt.ses.srv.env.ocienv), //dvoid         *hndl,
-			bnd.stmt.ses.srv.env.ocierr,                 //OCIError      *err,
+			bnd.stmt.ses.ocierr,                         //OCIError      *err,
 			bnd.ociDateTimes[n],                         //OCIDateTime   *datetime,
 			C.sb2(timeValue.Year()),                     //sb2           year,
 			C.ub1(int32(timeValue.Month())),             //ub1           month,
@@ -75,37 +77,38 @@ func (bnd *bndTimeSlice) bind(values []time.Time, nullInds []C.sb2, position int
 			(*C.OraText)(unsafe.Pointer(cTimezoneStr)),  //OraText       *timezone,
 			C.size_t(len(timezoneStr)))                  //size_t        timezone_length );
 		if r == C.OCI_ERROR {
-			return bnd.stmt.ses.srv.env.ociError()
+			return bnd.stmt.ses.ociError()
 		}
 		alenp[n] = C.ACTUAL_LENGTH_TYPE(unsafe.Sizeof(bnd.ociDateTimes[n]))
 	}
 
+	bnd.dty = C.SQLT_TIMESTAMP_TZ
 	r := C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),                        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,                       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,                               //OCIError     *errhp,
 		C.ub4(position),                                   //ub4          position,
 		unsafe.Pointer(&bnd.ociDateTimes[0]),              //void         *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(bnd.ociDateTimes[0])), //sb8          value_sz,
-		C.SQLT_TIMESTAMP_TZ,                               //ub2          dty,
-		unsafe.Pointer(&nullInds[0]),                      //void         *indp,
-		&alenp[0],                                         //ub2          *alenp,
-		&rcodep[0],                                        //ub2          *rcodep,
-		0,                                                 //ub4          maxarr_len,
-		nil,                                               //ub4          *curelep,
-		C.OCI_DEFAULT)                                     //ub4          mode );
+		bnd.dty,                      //ub2          dty,
+		unsafe.Pointer(&nullInds[0]), //void         *indp,
+		&alenp[0],                    //ub2          *alenp,
+		&rcodep[0],                   //ub2          *rcodep,
+		0,                            //ub4          maxarr_len,
+		nil,                          //ub4          *curelep,
+		C.OCI_DEFAULT)                //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	r = C.OCIBindArrayOfStruct(
 		bnd.ocibnd,
-		bnd.stmt.ses.srv.env.ocierr,
+		bnd.stmt.ses.ocierr,
 		C.ub4(unsafe.Sizeof(bnd.ociDateTimes[0])), //ub4         pvskip,
 		C.ub4(C.sizeof_sb2),                       //ub4         indskip,
 		C.ub4(C.sizeof_ub4),                       //ub4         alskip,
 		C.ub4(C.sizeof_ub2))                       //ub4         rcskip
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -136,6 +139,7 @@ func (bnd *bndTimeSlice) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	bnd.ociDateTimes = nil
 	bnd.zoneBuf.Reset()
 	stmt.putBnd(bndIdxTimeSlice, bnd)
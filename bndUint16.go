@@ -14,6 +14,7 @@ import (
 )
 
 type bndUint16 struct {
+	bndDtyRec
 	stmt      *Stmt
 	ocibnd    *C.OCIBind
 	ociNumber C.OCINumber
@@ -22,22 +23,23 @@ type bndUint16 struct {
 func (bnd *bndUint16) bind(value uint16, position int, stmt *Stmt) error {
 	bnd.stmt = stmt
 	r := C.OCINumberFromInt(
-		bnd.stmt.ses.srv.env.ocierr, //OCIError            *err,
-		unsafe.Pointer(&value),      //const void          *inum,
-		2, //uword               inum_length,
-		C.OCI_NUMBER_UNSIGNED, //uword               inum_s_flag,
-		&bnd.ociNumber)        //OCINumber           *number );
+		bnd.stmt.ses.ocierr,    //OCIError            *err,
+		unsafe.Pointer(&value), //const void          *inum,
+		2,                      //uword               inum_length,
+		C.OCI_NUMBER_UNSIGNED,  //uword               inum_s_flag,
+		&bnd.ociNumber)         //OCINumber           *number );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
+	bnd.dty = C.SQLT_VNU
 	r = C.OCIBINDBYPOS(
 		bnd.stmt.ocistmt,                  //OCIStmt      *stmtp,
 		(**C.OCIBind)(&bnd.ocibnd),        //OCIBind      **bindpp,
-		bnd.stmt.ses.srv.env.ocierr,       //OCIError     *errhp,
+		bnd.stmt.ses.ocierr,               //OCIError     *errhp,
 		C.ub4(position),                   //ub4          position,
 		unsafe.Pointer(&bnd.ociNumber),    //void         *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8          value_sz,
-		C.SQLT_VNU,                        //ub2          dty,
+		bnd.dty,                           //ub2          dty,
 		nil,                               //void         *indp,
 		nil,                               //ub2          *alenp,
 		nil,                               //ub2          *rcodep,
@@ -45,11 +47,19 @@ func (bnd *bndUint16) bind(value uint16, position int, stmt *Stmt) error {
 		nil,                               //ub4          *curelep,
 		C.OCI_DEFAULT)                     //ub4          mode );
 	if r == C.OCI_ERROR {
-		return bnd.stmt.ses.srv.env.ociError()
+		return bnd.stmt.ses.ociError()
 	}
 	return nil
 }
 
+func (bnd *bndUint16) rebind(value interface{}) (bool, error) {
+	v, ok := value.(uint16)
+	if !ok {
+		return false, nil
+	}
+	return true, numberFromInt(bnd.stmt, unsafe.Pointer(&v), 2, C.OCI_NUMBER_UNSIGNED, &bnd.ociNumber)
+}
+
 func (bnd *bndUint16) setPtr() error {
 	return nil
 }
@@ -64,6 +74,7 @@ func (bnd *bndUint16) close() (err error) {
 	stmt := bnd.stmt
 	bnd.stmt = nil
 	bnd.ocibnd = nil
+	bnd.dty = 0
 	stmt.putBnd(bndIdxUint16, bnd)
 	return nil
 }
@@ -0,0 +1,65 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// BoolConvention is a pair of single-character column values representing
+// the Go bool values false and true, such as '0' and '1' in a NUMBER(1)
+// column or 'N' and 'Y' in a CHAR(1) column.
+type BoolConvention struct {
+	False rune
+	True  rune
+}
+
+// Common BoolConventions.
+var (
+	BoolZeroOne = BoolConvention{False: '0', True: '1'}
+	BoolNY      = BoolConvention{False: 'N', True: 'Y'}
+)
+
+// numberBoolSize is the define buffer size of a NUMBER column fetched as a
+// bool; the NUMBER is converted to text.
+const numberBoolSize = 40
+
+// parse returns the bool value of the column text, ignoring blank and NUL
+// padding. A value other than c.False and c.True is an error.
+func (c BoolConvention) parse(text string) (bool, error) {
+	text = strings.Trim(text, " \x00")
+	r, size := utf8.DecodeRuneInString(text)
+	if size == len(text) {
+		switch r {
+		case c.True:
+			return true, nil
+		case c.False:
+			return false, nil
+		}
+	}
+	return false, errF("Column value %q is neither %q nor %q.", text, c.False, c.True)
+}
+
+// SetBoolConvention sets the bool values sent to an Oracle server during a
+// parameter bind, and the true value of character select-list columns
+// fetched as B or OraB.
+func (c *StmtCfg) SetBoolConvention(conv BoolConvention) {
+	c.FalseRune = conv.False
+	c.TrueRune = conv.True
+	c.Rset.TrueRune = conv.True
+}
+
+// boolCol returns the BoolConvention of the select-list column named name in
+// BoolCols.
+func (c *RsetCfg) boolCol(name string) (conv BoolConvention, ok bool) {
+	if c.BoolCols == nil {
+		return conv, false
+	}
+	if conv, ok = c.BoolCols[name]; !ok {
+		conv, ok = c.BoolCols[strings.ToUpper(name)]
+	}
+	return conv, ok
+}
@@ -0,0 +1,30 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestBoolConventionParse tests BoolConvention.parse.
+func TestBoolConventionParse(t *testing.T) {
+	for i, tc := range []struct {
+		conv BoolConvention
+		text string
+		want bool
+		err  bool
+	}{
+		{conv: BoolZeroOne, text: "1", want: true},
+		{conv: BoolZeroOne, text: "0   \x00\x00"},
+		{conv: BoolNY, text: "Y", want: true},
+		{conv: BoolNY, text: "N "},
+		{conv: BoolNY, text: "y", err: true},
+		{conv: BoolZeroOne, text: "10", err: true},
+		{conv: BoolZeroOne, text: "", err: true},
+	} {
+		got, err := tc.conv.parse(tc.text)
+		if got != tc.want || (err != nil) != tc.err {
+			t.Errorf("%d. %q: got %v, %v; wanted %v, error %v", i, tc.text, got, err, tc.want, tc.err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "context"
+
+// CallOption overrides the StmtCfg of a Stmt for a single call of
+// Stmt.ExeContext or Stmt.QryContext; see WithCallOptions.
+type CallOption func(cfg *StmtCfg) error
+
+// WithPrefetchRows returns a CallOption setting the number of rows to
+// prefetch; see StmtCfg.SetPrefetchRowCount.
+func WithPrefetchRows(n uint32) CallOption {
+	return func(cfg *StmtCfg) error { return cfg.SetPrefetchRowCount(n) }
+}
+
+// WithPrefetchMemory returns a CallOption setting the prefetch memory size in
+// bytes; see StmtCfg.SetPrefetchMemorySize.
+func WithPrefetchMemory(size uint32) CallOption {
+	return func(cfg *StmtCfg) error { return cfg.SetPrefetchMemorySize(size) }
+}
+
+// WithLobChunk returns a CallOption setting the LOB buffer size in bytes; see
+// StmtCfg.SetLobBufferSize.
+func WithLobChunk(size int) CallOption {
+	return func(cfg *StmtCfg) error { return cfg.SetLobBufferSize(size) }
+}
+
+// WithLongBufferSize returns a CallOption setting the LONG buffer size in
+// bytes; see StmtCfg.SetLongBufferSize.
+func WithLongBufferSize(size uint32) CallOption {
+	return func(cfg *StmtCfg) error { return cfg.SetLongBufferSize(size) }
+}
+
+// callOptionsKey is the context key of the options set by WithCallOptions.
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx carrying opts, which Stmt.ExeContext
+// and Stmt.QryContext apply to a copy of the StmtCfg for the call, after the
+// options already carried by ctx. The StmtCfg of the Stmt is unchanged.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	prev := callOptionsFrom(ctx)
+	all := append(prev[:len(prev):len(prev)], opts...)
+	return context.WithValue(ctx, callOptionsKey{}, all)
+}
+
+// callOptionsFrom returns the options carried by ctx, or nil.
+func callOptionsFrom(ctx context.Context) []CallOption {
+	if ctx == nil {
+		return nil
+	}
+	opts, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+	return opts
+}
+
+// applyCallOptions returns cfg changed by opts.
+func applyCallOptions(cfg StmtCfg, opts []CallOption) (StmtCfg, error) {
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// overrideCfg applies the call options of ctx to the StmtCfg of the Stmt,
+// and returns a func restoring it. The caller holds Stmt.mu for the whole
+// call, so no other call observes the overridden cfg; an Rset opened by the
+// call keeps its own copy of the call's cfg.
+func (stmt *Stmt) overrideCfg(ctx context.Context) (restore func(), err error) {
+	opts := callOptionsFrom(ctx)
+	if len(opts) == 0 {
+		return func() {}, nil
+	}
+	cfg, err := applyCallOptions(stmt.cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	prev := stmt.cfg
+	stmt.cfg = cfg
+	return func() { stmt.cfg = prev }, nil
+}
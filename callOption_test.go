@@ -0,0 +1,32 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCallOptions(t *testing.T) {
+	ctx := WithCallOptions(context.Background(), WithPrefetchRows(10))
+	ctx = WithCallOptions(ctx, WithLobChunk(1<<10), WithPrefetchRows(20))
+	base := *NewStmtCfg()
+	cfg, err := applyCallOptions(base, callOptionsFrom(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.PrefetchRowCount() != 20 || cfg.LobBufferSize() != 1<<10 {
+		t.Errorf("got prefetch rows %d, lob buffer size %d", cfg.PrefetchRowCount(), cfg.LobBufferSize())
+	}
+	if base.PrefetchRowCount() != 0 || base.LobBufferSize() != 1<<24 {
+		t.Error("base cfg changed")
+	}
+	if _, err = applyCallOptions(base, []CallOption{WithLobChunk(2147483643)}); err == nil {
+		t.Error("expected an error for a too large lob buffer size")
+	}
+	if opts := callOptionsFrom(nil); opts != nil {
+		t.Errorf("got %d options from a nil context", len(opts))
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "time"
+
+// The CfgCopy methods return copies made by the funcs below, so that changing
+// a returned cfg never changes the cfg observed by calls in progress.
+
+// copy returns a copy of c sharing no maps with c.
+func (c RsetCfg) copy() RsetCfg {
+	if c.BoolCols != nil {
+		boolCols := make(map[string]BoolConvention, len(c.BoolCols))
+		for name, conv := range c.BoolCols {
+			boolCols[name] = conv
+		}
+		c.BoolCols = boolCols
+	}
+	if c.TimeLocationCols != nil {
+		timeLocationCols := make(map[string]*time.Location, len(c.TimeLocationCols))
+		for name, loc := range c.TimeLocationCols {
+			timeLocationCols[name] = loc
+		}
+		c.TimeLocationCols = timeLocationCols
+	}
+	return c
+}
+
+// copy returns a copy of c sharing no maps with c.
+func (c StmtCfg) copy() *StmtCfg {
+	c.Rset = c.Rset.copy()
+	return &c
+}
+
+// copy returns a copy of c sharing no StmtCfg with c.
+func (c SesCfg) copy() *SesCfg {
+	if c.StmtCfg != nil {
+		c.StmtCfg = c.StmtCfg.copy()
+	}
+	return &c
+}
+
+// copy returns a copy of c sharing no StmtCfg, TLSCfg or ReconnectCfg with c.
+func (c SrvCfg) copy() *SrvCfg {
+	if c.StmtCfg != nil {
+		c.StmtCfg = c.StmtCfg.copy()
+	}
+	if c.TLS != nil {
+		tls := *c.TLS
+		tls.CipherSuites = append([]string(nil), c.TLS.CipherSuites...)
+		c.TLS = &tls
+	}
+	if c.Reconnect != nil {
+		reconnect := *c.Reconnect
+		c.Reconnect = &reconnect
+	}
+	return &c
+}
+
+// copy returns a copy of c sharing no StmtCfg or NetCfg with c.
+func (c EnvCfg) copy() *EnvCfg {
+	if c.StmtCfg != nil {
+		c.StmtCfg = c.StmtCfg.copy()
+	}
+	if c.Net != nil {
+		net := *c.Net
+		net.EncryptionTypes = append([]string(nil), c.Net.EncryptionTypes...)
+		net.ChecksumTypes = append([]string(nil), c.Net.ChecksumTypes...)
+		net.AuthServices = append([]string(nil), c.Net.AuthServices...)
+		c.Net = &net
+	}
+	return &c
+}
+
+// copy returns a copy of c sharing no EnvCfg with c.
+func (c DrvCfg) copy() *DrvCfg {
+	if c.Env != nil {
+		c.Env = c.Env.copy()
+	}
+	return &c
+}
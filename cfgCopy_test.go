@@ -0,0 +1,48 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestCfgCopy tests that copies of cfgs share no state with the cfg copied.
+func TestCfgCopy(t *testing.T) {
+	stmtCfg := NewStmtCfg()
+	stmtCfg.Rset.BoolCols = map[string]BoolConvention{"A": {}}
+	drvCfg := DrvCfg{Env: NewEnvCfg()}
+	drvCfg.Env.StmtCfg = stmtCfg
+	drvCfg.Env.Net = &NetCfg{EncryptionTypes: []string{"AES256"}}
+
+	c := drvCfg.copy()
+	c.Env.StmtCfg.TrueRune = 'Y'
+	c.Env.StmtCfg.Rset.BoolCols["B"] = BoolConvention{}
+	c.Env.Net.EncryptionTypes[0] = "AES128"
+	c.Env.LobChunkSize = 1
+	if drvCfg.Env.LobChunkSize == 1 || stmtCfg.TrueRune == 'Y' {
+		t.Error("changing the copy changed the DrvCfg")
+	}
+	if _, ok := stmtCfg.Rset.BoolCols["B"]; ok {
+		t.Error("the copy shares BoolCols")
+	}
+	if drvCfg.Env.Net.EncryptionTypes[0] != "AES256" {
+		t.Error("the copy shares the NetCfg")
+	}
+
+	sesCfg := SesCfg{StmtCfg: stmtCfg}
+	if sesCfg.copy().StmtCfg == stmtCfg {
+		t.Error("the SesCfg copy shares the StmtCfg")
+	}
+	srvCfg := SrvCfg{StmtCfg: stmtCfg, Reconnect: NewReconnectCfg()}
+	if c := srvCfg.copy(); c.StmtCfg == stmtCfg || c.Reconnect == srvCfg.Reconnect {
+		t.Error("the SrvCfg copy shares the StmtCfg or ReconnectCfg")
+	}
+
+	// Cfg returns the live cfg, CfgCopy a copy of it
+	if Cfg() != _drv.cfg() {
+		t.Error("Cfg: expected the driver's cfg")
+	}
+	if CfgCopy() == _drv.cfg() {
+		t.Error("CfgCopy: expected a copy of the driver's cfg")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"time"
+)
+
+// ChunkCfg configures the execution of a DELETE or UPDATE statement in chunks
+// with Ses.ExeChunked.
+type ChunkCfg struct {
+	// Size is the maximum number of rows affected by each chunk.
+	//
+	// The default is 10,000.
+	Size int
+
+	// Sleep is the duration slept between chunks, limiting the rate at which
+	// undo and redo are generated.
+	//
+	// The default is 0.
+	Sleep time.Duration
+
+	// Progress is called after each chunk is committed with the one-based
+	// number of the chunk, the rows affected by the chunk and the rows
+	// affected so far. A non-nil error stops the execution and is returned by
+	// ExeChunked.
+	//
+	// The default is nil.
+	Progress func(chunk int, rows, total uint64) error
+}
+
+// NewChunkCfg creates a ChunkCfg with default values.
+func NewChunkCfg() ChunkCfg {
+	c := ChunkCfg{}
+	c.Size = 10000
+	c.Sleep = 0
+	return c
+}
+
+// ExeChunked executes a DELETE or UPDATE statement repeatedly, each time
+// limited to ChunkCfg.Size rows by ROWNUM and committed, until fewer rows are
+// affected, and returns the number of rows affected.
+//
+// The WHERE clause of sql is extended with "AND ROWNUM <= :ora_chunk", or
+// "WHERE ROWNUM <= :ora_chunk" is appended, so an UPDATE must change the rows
+// so that they're no longer selected by its WHERE clause. ExeChunked returns
+// an error during a transaction, as each chunk is committed. Rows affected by
+// committed chunks are kept when an error occurs.
+func (ses *Ses) ExeChunked(sql string, cfg ChunkCfg, params ...interface{}) (rows uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.ExeChunked)
+	if err = ses.checkClosed(); err != nil {
+		return 0, errE(err)
+	}
+	if cfg.Size <= 0 {
+		return 0, er("Parameter 'cfg.Size' must be greater than zero.")
+	}
+	if ses.openTxs.len() > 0 {
+		return 0, er("ExeChunked commits each chunk and can't be called during a transaction.")
+	}
+	stmt, err := ses.Prep(chunkSql(sql))
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer func() {
+		if err0 := stmt.Close(); err == nil {
+			err = err0
+		}
+	}()
+	stmt.Cfg().IsAutoCommitting = true
+	params = append(params[:len(params):len(params)], int64(cfg.Size))
+	for chunk := 1; ; chunk++ {
+		affected, err := stmt.Exe(params...)
+		if err != nil {
+			return rows, errE(err)
+		}
+		rows += affected
+		ses.logF(_drv.cfg().Log.Ses.ExeChunked, "chunk %d affected %d rows (%d total)", chunk, affected, rows)
+		if cfg.Progress != nil {
+			if err = cfg.Progress(chunk, affected, rows); err != nil {
+				return rows, err
+			}
+		}
+		if affected < uint64(cfg.Size) {
+			return rows, nil
+		}
+		if cfg.Sleep > 0 {
+			time.Sleep(cfg.Sleep)
+		}
+	}
+}
+
+// chunkSql returns sql limited by a ROWNUM placeholder: the top-level WHERE
+// condition is parenthesized and extended, or a WHERE clause is appended.
+func chunkSql(sql string) string {
+	sql = trimSql(sql)
+	i := topLevelWhere(sql)
+	if i < 0 {
+		return sql + "\nWHERE ROWNUM <= :ora_chunk"
+	}
+	i += len("WHERE")
+	return sql[:i] + " (" + sql[i:] + "\n) AND ROWNUM <= :ora_chunk"
+}
+
+// topLevelWhere returns the index of the WHERE keyword of sql outside
+// parentheses, literals, including q'[...]' literals, quoted identifiers and
+// comments, or -1.
+func topLevelWhere(sql string) int {
+	depth := 0
+	for _, tok := range scanSql(sql) {
+		switch {
+		case tok.text == "(":
+			depth++
+		case tok.text == ")":
+			depth--
+		case tok.kind == sqlTokWord && depth == 0 && strings.EqualFold(tok.text, "WHERE"):
+			return tok.pos
+		}
+	}
+	return -1
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestChunkSql tests chunkSql.
+func TestChunkSql(t *testing.T) {
+	for _, tc := range []struct {
+		sql, want string
+	}{
+		{"DELETE FROM t;", "DELETE FROM t\nWHERE ROWNUM <= :ora_chunk"},
+		{"DELETE FROM t WHERE a = 1 OR b = 2",
+			"DELETE FROM t WHERE ( a = 1 OR b = 2\n) AND ROWNUM <= :ora_chunk"},
+		{"UPDATE t SET a = (SELECT x FROM u WHERE u.id = t.id) WHERE a IS NULL",
+			"UPDATE t SET a = (SELECT x FROM u WHERE u.id = t.id) WHERE ( a IS NULL\n) AND ROWNUM <= :ora_chunk"},
+		{"DELETE FROM t /* where */ where c = 'where'",
+			"DELETE FROM t /* where */ where ( c = 'where'\n) AND ROWNUM <= :ora_chunk"},
+		{"DELETE FROM nowhere_t", "DELETE FROM nowhere_t\nWHERE ROWNUM <= :ora_chunk"},
+		{"UPDATE t SET c = q'[it's (where]' WHERE d = 1",
+			"UPDATE t SET c = q'[it's (where]' WHERE ( d = 1\n) AND ROWNUM <= :ora_chunk"},
+	} {
+		if got := chunkSql(tc.sql); got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.sql, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SnakeToCamel returns a snake_case column name in CamelCase, such as
+// OrderId for ORDER_ID or order_id. It may be used as RsetCfg.ColumnName.
+func SnakeToCamel(name string) string {
+	var buf []byte
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(part)
+		buf = append(buf, string(unicode.ToUpper(r))...)
+		buf = append(buf, strings.ToLower(part[size:])...)
+	}
+	if len(buf) == 0 {
+		return name
+	}
+	return string(buf)
+}
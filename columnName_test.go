@@ -0,0 +1,23 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestSnakeToCamel tests SnakeToCamel.
+func TestSnakeToCamel(t *testing.T) {
+	for name, want := range map[string]string{
+		"ORDER_ID":    "OrderId",
+		"order_id":    "OrderId",
+		"ID":          "Id",
+		"_LEAD__TAIL": "LeadTail",
+		"_":           "_",
+		"NAME1":       "Name1",
+	} {
+		if got := SnakeToCamel(name); got != want {
+			t.Errorf("%q: got %q, wanted %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ColumnTypes selects the GoColumnType of each select-list column of a single
+// database/sql query, as the gcts of Ses.Prep do. Pass ColumnTypes as an
+// argument of sql.DB.Query, sql.Stmt.Query or their QueryRow variants; it
+// isn't bound and isn't counted as a placeholder:
+//
+//	rows, err := db.Query("SELECT ID, DOC FROM T WHERE ID = :1", ora.ColumnTypes{ora.I64, ora.S}, 7)
+//
+// S reads a CLOB as a string and Bin reads a BLOB as a []byte, whatever the
+// LOB mapping of the RsetCfg. A column without a ColumnTypes entry, or with
+// D, takes the mapping of the RsetCfg. ColumnTypes passed to Exec is ignored.
+type ColumnTypes []GoColumnType
+
+// columnTypeTag is the struct field tag read by ColumnTypesOf.
+const columnTypeTag = "ora"
+
+// ColumnTypesOf returns the ColumnTypes of a query whose select-list columns
+// are scanned, in order, into the exported fields of the struct v, or a
+// pointer to it.
+//
+// A field tagged `ora:"-"` is skipped. A field tagged with the name of a
+// GoColumnType, such as `ora:"S"` or `ora:"Bin"`, takes that type. Otherwise
+// the type follows the field: S for a string, Bin for a []byte, T for a
+// time.Time, B for a bool, I64 for a signed integer, F64 for a float, and D
+// for any other type, including pointers, which may be scanned from NULL.
+func ColumnTypesOf(v interface{}) (ColumnTypes, error) {
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, errF("Parameter 'v' is a %v; expected a struct or a pointer to a struct.", typ)
+	}
+	var gcts ColumnTypes
+	for n := 0; n < typ.NumField(); n++ {
+		f := typ.Field(n)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		tag := strings.TrimSpace(f.Tag.Get(columnTypeTag))
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			gcts = append(gcts, driverGct(f.Type))
+			continue
+		}
+		gct, ok := parseGct(tag)
+		if !ok {
+			return nil, errF("Field '%v' has an unknown GoColumnType tag %q.", f.Name, tag)
+		}
+		gcts = append(gcts, gct)
+	}
+	return gcts, nil
+}
+
+// driverGct returns the GoColumnType of a field of type rt whose define
+// value is a driver.Value assignable to the field.
+func driverGct(rt reflect.Type) GoColumnType {
+	switch rt.Kind() {
+	case reflect.String:
+		return S
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return Bin
+		}
+	case reflect.Bool:
+		return B
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		return I64
+	case reflect.Float64, reflect.Float32:
+		return F64
+	case reflect.Struct:
+		if rt == reflect.TypeOf(time.Time{}) {
+			return T
+		}
+	}
+	return D
+}
+
+// parseGct returns the GoColumnType named name, as named by GctName.
+func parseGct(name string) (GoColumnType, bool) {
+	for gct := D; gct <= SBorrow; gct++ {
+		if GctName(gct) == name {
+			return gct, true
+		}
+	}
+	return D, false
+}
+
+// CheckNamedValue removes a ColumnTypes argument, keeping it for the next
+// Query, and leaves other arguments to the default conversion.
+//
+// CheckNamedValue is a member of the driver.NamedValueChecker interface.
+func (ds *DrvStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if gcts, ok := nv.Value.(ColumnTypes); ok {
+		ds.gcts = gcts
+		return driver.ErrRemoveArgument
+	}
+	return driver.ErrSkip
+}
+
+// swapGcts sets the gcts of the Stmt, and returns a func restoring them.
+func (stmt *Stmt) swapGcts(gcts []GoColumnType) (restore func()) {
+	stmt.mu.Lock()
+	prev := stmt.gcts
+	stmt.gcts = gcts
+	stmt.mu.Unlock()
+	return func() {
+		stmt.mu.Lock()
+		stmt.gcts = prev
+		stmt.mu.Unlock()
+	}
+}
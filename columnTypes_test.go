@@ -0,0 +1,79 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestColumnTypesOf tests ColumnTypesOf with tagged and untagged fields.
+func TestColumnTypesOf(t *testing.T) {
+	type row struct {
+		Id      int64
+		Name    string
+		Doc     string `ora:"OraS"`
+		Skipped string `ora:"-"`
+		Data    []byte
+		At      time.Time
+		Ok      bool
+		Amount  float32
+		Note    *string
+		hidden  int
+	}
+	got, err := ColumnTypesOf(&row{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ColumnTypes{I64, S, OraS, Bin, T, B, F64, D}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+	if _, err = ColumnTypesOf(struct {
+		Doc string `ora:"clob"`
+	}{}); err == nil {
+		t.Error("wanted an error for an unknown tag")
+	}
+	if _, err = ColumnTypesOf(1); err == nil {
+		t.Error("wanted an error for a non-struct")
+	}
+}
+
+// TestCheckNamedValue tests that a ColumnTypes argument is kept and removed.
+func TestCheckNamedValue(t *testing.T) {
+	ds := &DrvStmt{}
+	if err := ds.CheckNamedValue(&driver.NamedValue{Value: ColumnTypes{S}}); err != driver.ErrRemoveArgument {
+		t.Errorf("got %v, wanted driver.ErrRemoveArgument", err)
+	}
+	if !reflect.DeepEqual(ds.gcts, ColumnTypes{S}) {
+		t.Errorf("got %v, wanted [S]", ds.gcts)
+	}
+	if err := ds.CheckNamedValue(&driver.NamedValue{Value: int64(1)}); err != driver.ErrSkip {
+		t.Errorf("got %v, wanted driver.ErrSkip", err)
+	}
+}
+
+// TestSBorrowColumn tests that SBorrow is accepted for character columns and
+// rejected for LOB columns.
+func TestSBorrowColumn(t *testing.T) {
+	if gct, ok := parseGct("SBorrow"); !ok || gct != SBorrow {
+		t.Errorf("parseGct(SBorrow): got %v, %v", GctName(gct), ok)
+	}
+	c := NewRsetCfg()
+	if err := c.SetVarchar(SBorrow); err != nil {
+		t.Errorf("SetVarchar(SBorrow): %v", err)
+	}
+	if err := c.SetChar1(SBorrow); err != nil {
+		t.Errorf("SetChar1(SBorrow): %v", err)
+	}
+	if err := c.SetClob(SBorrow); err == nil {
+		t.Error("SetClob(SBorrow): wanted an error")
+	}
+	if c := NewRsetCfg(); c.Varchar() != S || c.Char() != S {
+		t.Errorf("defaults: got %v, %v, wanted S", GctName(c.Varchar()), GctName(c.Char()))
+	}
+}
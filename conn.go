@@ -5,6 +5,7 @@
 package ora
 
 import (
+	"context"
 	"database/sql/driver"
 	"fmt"
 )
@@ -26,6 +27,11 @@ type LogConCfg struct {
 	// The default is true.
 	Begin bool
 
+	// BeginTx determines whether the Con.BeginTx method is logged.
+	//
+	// The default is true.
+	BeginTx bool
+
 	// Ping determines whether the Con.Ping method is logged.
 	//
 	// The default is true.
@@ -38,6 +44,7 @@ func NewLogConCfg() LogConCfg {
 	c.Close = true
 	c.Prepare = true
 	c.Begin = true
+	c.BeginTx = true
 	c.Ping = true
 	return c
 }
@@ -82,7 +89,7 @@ func (con *Con) Close() (err error) {
 // close ends a session and disconnects from an Oracle server.
 // does not remove Con from Ses.openCons
 func (con *Con) close() (err error) {
-	con.log(_drv.cfg.Log.Con.Close)
+	con.log(_drv.cfg().Log.Con.Close)
 	if err := con.checkIsOpen(); err != nil {
 		return err
 	}
@@ -110,22 +117,32 @@ func (con *Con) close() (err error) {
 //
 // Prepare is a member of the driver.Conn interface.
 func (con *Con) Prepare(sql string) (driver.Stmt, error) {
-	con.log(_drv.cfg.Log.Con.Prepare)
+	con.log(_drv.cfg().Log.Con.Prepare)
 	if err := con.checkIsOpen(); err != nil {
 		return nil, err
 	}
-	stmt, err := con.ses.Prep(sql)
+	con.ses.log(_drv.cfg().Log.Ses.Prep, sql)
+	sql, order, err := _drv.cfg().convertSql(sql)
 	if err != nil {
 		return nil, err
 	}
-	return &DrvStmt{stmt: stmt}, err
+	var insertId bool
+	if cfg := con.ses.cfg.StmtCfg; cfg != nil {
+		sql, insertId = insertIdSql(sql, cfg.InsertIdColumn)
+	}
+	stmt, err := con.ses.prep(sql, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt.paramOrder = order
+	return &DrvStmt{stmt: stmt, insertId: insertId}, err
 }
 
 // Begin starts a transaction.
 //
 // Begin is a member of the driver.Conn interface.
 func (con *Con) Begin() (driver.Tx, error) {
-	con.log(_drv.cfg.Log.Con.Begin)
+	con.log(_drv.cfg().Log.Con.Begin)
 	if err := con.checkIsOpen(); err != nil {
 		return nil, err
 	}
@@ -136,13 +153,25 @@ func (con *Con) Begin() (driver.Tx, error) {
 	return tx, nil
 }
 
-// Ping makes a round-trip call to an Oracle server to confirm that the connection is active.
-func (con *Con) Ping() error {
-	con.log(_drv.cfg.Log.Con.Ping)
+// Ping makes a round-trip call to an Oracle server to confirm that the
+// connection is active, interrupted when ctx is done or SesCfg.PingTimeout
+// elapses; see Ses.PingContext.
+// driver.ErrBadConn is returned when the connection to the server is lost,
+// so that database/sql discards the Con.
+//
+// Ping is a member of the driver.Pinger interface, whose signature requires
+// ctx; callers of the former Ping() pass context.Background().
+func (con *Con) Ping(ctx context.Context) error {
+	con.log(_drv.cfg().Log.Con.Ping)
 	if err := con.checkIsOpen(); err != nil {
-		return err
+		return driver.ErrBadConn
 	}
-	return con.ses.Ping()
+	err := con.ses.PingContext(ctx)
+	if err != nil && isConnLost(err) {
+		con.logF(_drv.cfg().Log.Con.Ping, "connection lost: %v", err)
+		return driver.ErrBadConn
+	}
+	return err
 }
 
 // sysName returns a string representing the Con.
@@ -154,9 +183,9 @@ func (con *Con) sysName() string {
 func (con *Con) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", con.sysName(), callInfo(1))
+			lgr.Infof("%v %v", con.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", con.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", con.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -165,9 +194,9 @@ func (con *Con) log(enabled bool, v ...interface{}) {
 func (con *Con) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", con.sysName(), callInfo(1))
+			lgr.Infof("%v %v", con.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", con.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", con.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
@@ -0,0 +1,90 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+)
+
+// ConnectError describes a failed attach to an Oracle server, decoding the
+// TNS error reported by Oracle Net.
+type ConnectError struct {
+	// Code is the Oracle error code, such as 12154, or zero when the message
+	// has none.
+	Code int
+
+	// Message is the message of the Oracle error.
+	Message string
+
+	// Dblink is SrvCfg.Dblink.
+	Dblink string
+
+	// Descriptor is the dblink attached, after the rewriting of SrvCfg.TLS.
+	// With SrvCfg.Dial, Descriptor has the address of the listener rather
+	// than the local end of the tunnel attached. A net service name isn't
+	// resolved; Descriptor is the name.
+	Descriptor string
+
+	// Addresses are the host:port listener addresses of Descriptor. A net
+	// service name, which Oracle Net resolves with tnsnames.ora, has no
+	// addresses.
+	Addresses []string
+
+	// Suggestion is a likely remedy of the error, or an empty string.
+	Suggestion string
+}
+
+// Error returns the message of the Oracle error, the attempted addresses
+// and the suggestion.
+func (e *ConnectError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString(e.Message)
+	fmt.Fprintf(&buf, "\nconnecting to %v", e.Descriptor)
+	if len(e.Addresses) > 0 {
+		fmt.Fprintf(&buf, "\naddresses: %v", e.Addresses)
+	}
+	if e.Suggestion != "" {
+		buf.WriteString("\nsuggestion: " + e.Suggestion)
+	}
+	return buf.String()
+}
+
+// oraCode matches the first Oracle error code of a message.
+var oraCode = regexp.MustCompile(`ORA-(\d{5})`)
+
+// tnsSuggestions are remedies of common TNS errors.
+var tnsSuggestions = map[int]string{
+	12154: "The net service name isn't defined; check tnsnames.ora in TNS_ADMIN, or use an easy connect string or a connect descriptor.",
+	12505: "The listener doesn't know the SID; connect with a SERVICE_NAME, or check the SID registered with the listener.",
+	12514: "The listener doesn't know the service; check the service name, and the services registered with the listener (lsnrctl services).",
+	12541: "No listener is running at the address; check the host and port, and that the listener is started.",
+	12545: "The host can't be resolved or reached; check the host name.",
+	12170: "The connection timed out; check that a firewall allows the host and port.",
+	12535: "The operation timed out; check that a firewall allows the host and port.",
+	12560: "The protocol adapter failed; check the protocol and the address of the descriptor.",
+}
+
+// connectError returns a *ConnectError decoding msg, the message of a failed
+// attach to descriptor.
+func connectError(dblink, descriptor, msg string) *ConnectError {
+	e := &ConnectError{Message: msg, Dblink: dblink, Descriptor: descriptor}
+	if m := oraCode.FindStringSubmatch(msg); m != nil {
+		e.Code, _ = strconv.Atoi(m[1])
+	}
+	for _, m := range descriptorAddress.FindAllStringSubmatch(descriptor, -1) {
+		e.Addresses = append(e.Addresses, net.JoinHostPort(m[1], m[2]))
+	}
+	if len(e.Addresses) == 0 {
+		if address, _, ok := resolveEasyConnect(descriptor); ok {
+			e.Addresses = []string{address}
+		}
+	}
+	e.Suggestion = tnsSuggestions[e.Code]
+	return e
+}
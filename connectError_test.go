@@ -0,0 +1,36 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestConnectError tests connectError.
+func TestConnectError(t *testing.T) {
+	descriptor := "(DESCRIPTION=(ADDRESS_LIST=(ADDRESS=(PROTOCOL=TCP)(HOST=db1)(PORT=1521))" +
+		"(ADDRESS=(PROTOCOL=TCP)(HOST=db2)(PORT=1522)))(CONNECT_DATA=(SERVICE_NAME=orcl)))"
+	e := connectError("orcl", descriptor, "ORA-12514: TNS:listener does not currently know of service requested in connect descriptor")
+	if e.Code != 12514 {
+		t.Errorf("got code %d, wanted 12514", e.Code)
+	}
+	if want := []string{"db1:1521", "db2:1522"}; !reflect.DeepEqual(e.Addresses, want) {
+		t.Errorf("got addresses %v, wanted %v", e.Addresses, want)
+	}
+	if e.Suggestion == "" {
+		t.Errorf("got no suggestion")
+	}
+
+	e = connectError("db:1530/orcl", "db:1530/orcl", "ORA-12541: TNS:no listener")
+	if e.Code != 12541 || !reflect.DeepEqual(e.Addresses, []string{"db:1530"}) {
+		t.Errorf("got %+v", e)
+	}
+
+	e = connectError("orcl", "orcl", "ORA-12154: TNS:could not resolve the connect identifier specified")
+	if e.Code != 12154 || len(e.Addresses) != 0 || e.Suggestion == "" {
+		t.Errorf("got %+v", e)
+	}
+}
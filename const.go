@@ -67,6 +67,24 @@ const (
 	Bin
 	// OraBin defines a sql select column as a nullable Go ora.Binary.
 	OraBin
+	// OraLobD defines a sql select CLOB, NCLOB or BLOB column as an ora.LobD,
+	// deferring the read of the content.
+	OraLobD
+	// OraUUID defines a sql select RAW(16) column as an ora.UUID.
+	// A NULL value is returned as nil.
+	OraUUID
+	// JSON defines a sql select native JSON column, or a CLOB or BLOB column
+	// holding JSON text, as a Go json.RawMessage. A NULL value is returned as nil.
+	JSON
+	// JSONAny defines a sql select native JSON column, or a CLOB or BLOB column
+	// holding JSON text, as the Go value decoded by encoding/json into an
+	// interface{}. A NULL value is returned as nil.
+	JSONAny
+	// SBorrow defines a sql select VARCHAR2, NVARCHAR2, CHAR, NCHAR or LONG
+	// column as a Go byte slice of the define buffer, which is overwritten by
+	// the next fetch; copy the slice to keep the value. A NULL value is
+	// returned as nil.
+	SBorrow
 )
 
 // bind pool indexes
@@ -129,6 +147,7 @@ const (
 
 	bndIdxBfile
 	bndIdxRset
+	bndIdxInList
 	bndIdxNil
 )
 
@@ -157,4 +176,5 @@ const (
 	defIdxIntervalDS
 	defIdxBfile
 	defIdxRowid
+	defIdxPiece
 )
@@ -0,0 +1,157 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+)
+
+// CopyRows inserts the rows fetched from src into table on dst with
+// array-bound INSERTs of batchSize rows, and returns the number of rows
+// inserted.
+//
+// The table columns are named by the select-list column names of src, before
+// any RsetCfg.ColumnName renaming; alias the select-list columns of src when
+// the names differ. Each column is bound as an array of
+// the Go type fetched from src, so src and dst may be sessions of different
+// databases. BLOB values are read and bound as byte slices; define CLOB
+// columns of src as S or OraS.
+//
+// A batchSize of zero or less uses 1000. CopyRows stops on the first failed
+// batch; rows inserted before the error are kept unless dst rolls back an
+// open transaction.
+//
+// The interfaces are satisfied by *Ses and *Rset; the column names of any
+// other Rsetter are those returned by its Columns method.
+func CopyRows(dst Sessioner, table string, src Rsetter, batchSize int) (rows uint64, err error) {
+	if ses, ok := dst.(*Ses); ok {
+		ses.log(_drv.cfg().Log.Ses.CopyRows)
+	} else {
+		log(_drv.cfg().Log.Ses.CopyRows)
+	}
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	names := src.Columns()
+	if rset, ok := src.(*Rset); ok {
+		names = rset.describedNames
+	}
+	if len(names) == 0 {
+		return 0, er("Rset has no columns.")
+	}
+	placeholders := make([]string, len(names))
+	for n := range placeholders {
+		placeholders[n] = fmt.Sprintf(":%d", n+1)
+	}
+	sql := fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v)",
+		table, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	stmt, err := dst.PrepStmter(sql)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer func() {
+		if err0 := stmt.Close(); err == nil {
+			err = err0
+		}
+	}()
+	columnNames := src.Columns()
+	cols := make([][]interface{}, len(columnNames))
+	flush := func() error {
+		size := len(cols[0])
+		if size == 0 {
+			return nil
+		}
+		params := make([]interface{}, len(cols))
+		for n := range cols {
+			if params[n], err = copySlice(cols[n]); err != nil {
+				return errF("Column %v: %v", columnNames[n], err)
+			}
+			cols[n] = cols[n][:0]
+		}
+		if _, err = stmt.Exe(params...); err != nil {
+			return errE(err)
+		}
+		rows += uint64(size)
+		return nil
+	}
+	for src.Next() {
+		for n, value := range src.Values() {
+			if value, err = copyValue(value); err != nil {
+				return rows, errF("Column %v: %v", columnNames[n], err)
+			}
+			cols[n] = append(cols[n], value)
+		}
+		if len(cols[0]) == batchSize {
+			if err = flush(); err != nil {
+				return rows, err
+			}
+		}
+	}
+	if err = src.LastErr(); err != nil {
+		return rows, errE(err)
+	}
+	if err = flush(); err != nil {
+		return rows, err
+	}
+	return rows, nil
+}
+
+// copyValue returns a fetched value in a form which may be bound in an array.
+// LOB content is read so that the source locator is released.
+func copyValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case Lob:
+		if v.Reader == nil {
+			return []byte(nil), nil
+		}
+		defer v.Close()
+		return ioutil.ReadAll(v)
+	case LobD:
+		if v.IsNull {
+			return []byte(nil), nil
+		}
+		return v.Bytes()
+	case io.Reader:
+		if c, ok := v.(io.Closer); ok {
+			defer c.Close()
+		}
+		return ioutil.ReadAll(v)
+	}
+	return value, nil
+}
+
+// copySlice returns a slice of the type of the non-nil values. A column of
+// nil values is returned as a slice of null Strings.
+func copySlice(values []interface{}) (interface{}, error) {
+	var typ reflect.Type
+	for _, value := range values {
+		if value != nil {
+			typ = reflect.TypeOf(value)
+			break
+		}
+	}
+	if typ == nil {
+		nulls := make([]String, len(values))
+		for n := range nulls {
+			nulls[n].IsNull = true
+		}
+		return nulls, nil
+	}
+	slice := reflect.MakeSlice(reflect.SliceOf(typ), len(values), len(values))
+	for n, value := range values {
+		if value == nil {
+			continue // only Bin columns fetch nil; a nil []byte is null
+		}
+		if reflect.TypeOf(value) != typ {
+			return nil, fmt.Errorf("row value %T differs from %v", value, typ)
+		}
+		slice.Index(n).Set(reflect.ValueOf(value))
+	}
+	return slice.Interface(), nil
+}
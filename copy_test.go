@@ -0,0 +1,38 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCopySlice tests copySlice.
+func TestCopySlice(t *testing.T) {
+	got, err := copySlice([]interface{}{int64(1), int64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+	got, err = copySlice([]interface{}{nil, []byte("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]byte{nil, []byte("a")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+	got, err = copySlice([]interface{}{nil, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []String{{IsNull: true}, {IsNull: true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+	if _, err = copySlice([]interface{}{int64(1), "a"}); err == nil {
+		t.Error("wanted error for mixed types")
+	}
+}
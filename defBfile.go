@@ -25,19 +25,19 @@ type defBfile struct {
 func (def *defBfile) define(position int, rset *Rset) error {
 	def.rset = rset
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                                //OCIStmt     *stmtp,
-		&def.ocidef,                                     //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,                //OCIError    *errhp,
-		C.ub4(position),                                 //ub4         position,
-		unsafe.Pointer(&def.ociLobLocator),              //void        *valuep,
+		def.rset.ocistmt,                   //OCIStmt     *stmtp,
+		&def.ocidef,                        //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,           //OCIError    *errhp,
+		C.ub4(position),                    //ub4         position,
+		unsafe.Pointer(&def.ociLobLocator), //void        *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(def.ociLobLocator)), //sb8         value_sz,
 		C.SQLT_FILE,               //ub2         dty,
 		unsafe.Pointer(&def.null), //void        *indp,
-		nil,           //ub4         *rlenp,
-		nil,           //ub2         *rcodep,
-		C.OCI_DEFAULT) //ub4         mode );
+		nil,                       //ub4         *rlenp,
+		nil,                       //ub2         *rcodep,
+		C.OCI_DEFAULT)             //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -48,16 +48,18 @@ func (def *defBfile) value() (value interface{}, err error) {
 		// Get directory alias and filename
 		dLength := C.ub2(len(def.directoryAlias))
 		fLength := C.ub2(len(def.filename))
-		r := C.OCILobFileGetName(
-			def.rset.stmt.ses.srv.env.ocienv,                     //OCIEnv                   *envhp,
-			def.rset.stmt.ses.srv.env.ocierr,                     //OCIError                 *errhp,
-			def.ociLobLocator,                                    //const OCILobLocator      *filep,
-			(*C.OraText)(unsafe.Pointer(&def.directoryAlias[0])), //OraText                  *dir_alias,
-			&dLength, //ub2                      *d_length,
-			(*C.OraText)(unsafe.Pointer(&def.filename[0])), //OraText                  *filename,
-			&fLength) //ub2                      *f_length );
+		r := def.rset.stmt.ses.poll(nil, func() C.sword {
+			return C.OCILobFileGetName(
+				def.rset.stmt.ses.srv.env.ocienv,                     //OCIEnv                   *envhp,
+				def.rset.stmt.ses.ocierr,                             //OCIError                 *errhp,
+				def.ociLobLocator,                                    //const OCILobLocator      *filep,
+				(*C.OraText)(unsafe.Pointer(&def.directoryAlias[0])), //OraText                  *dir_alias,
+				&dLength, //ub2                      *d_length,
+				(*C.OraText)(unsafe.Pointer(&def.filename[0])), //OraText                  *filename,
+				&fLength) //ub2                      *f_length );
+		})
 		if r == C.OCI_ERROR {
-			return value, def.rset.stmt.ses.srv.env.ociError()
+			return value, def.rset.stmt.ses.ociError()
 		}
 		bfileValue.DirectoryAlias = string(def.directoryAlias[:int(dLength)])
 		bfileValue.Filename = string(def.filename[:int(fLength)])
@@ -75,7 +77,7 @@ func (def *defBfile) alloc() error {
 		0,                                                     //size_t        xtramem_sz,
 		nil)                                                   //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci lob handle during define")
 	}
@@ -20,6 +20,7 @@ type defBool struct {
 	null       C.sb2
 	isNullable bool
 	buf        []byte
+	conv       *BoolConvention // nil compares to RsetCfg.TrueRune
 }
 
 func (def *defBool) define(position int, columnSize int, isNullable bool, rset *Rset) error {
@@ -31,36 +32,49 @@ func (def *defBool) define(position int, columnSize int, isNullable bool, rset *
 	//Log.Infof("defBool.define(position=%d, columnSize=%d)", position, columnSize)
 	// Create oci define handle
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                 //OCIStmt     *stmtp,
-		&def.ocidef,                      //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(position),                  //ub4         position,
-		unsafe.Pointer(&def.buf[0]),      //void        *valuep,
-		C.LENGTH_TYPE(columnSize),        //sb8         value_sz,
-		C.SQLT_AFC,                       //ub2         dty,
-		unsafe.Pointer(&def.null),        //void        *indp,
-		nil,           //ub2         *rlenp,
-		nil,           //ub2         *rcodep,
-		C.OCI_DEFAULT) //ub4         mode );
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		unsafe.Pointer(&def.buf[0]), //void        *valuep,
+		C.LENGTH_TYPE(columnSize),   //sb8         value_sz,
+		C.SQLT_AFC,                  //ub2         dty,
+		unsafe.Pointer(&def.null),   //void        *indp,
+		nil,                         //ub2         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DEFAULT)               //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (def *defBool) value() (value interface{}, err error) {
 	//Log.Infof("%v.value", def)
+	if def.conv != nil {
+		isNull := def.null < C.sb2(0)
+		var boolValue bool
+		if !isNull {
+			if boolValue, err = def.conv.parse(string(def.buf)); err != nil {
+				return nil, err
+			}
+		}
+		if def.isNullable {
+			return Bool{IsNull: isNull, Value: boolValue}, nil
+		}
+		return boolValue, nil
+	}
 	if def.isNullable {
 		oraBoolValue := Bool{IsNull: def.null < C.sb2(0)}
 		if !oraBoolValue.IsNull {
 			r, _ := utf8.DecodeRune(def.buf)
-			oraBoolValue.Value = r == def.rset.stmt.cfg.Rset.TrueRune
+			oraBoolValue.Value = r == def.rset.cfg.Rset.TrueRune
 		}
 		return oraBoolValue, nil
 	}
 	if def.null > C.sb2(-1) {
 		r, _ := utf8.DecodeRune(def.buf)
-		return r == def.rset.stmt.cfg.Rset.TrueRune, nil
+		return r == def.rset.cfg.Rset.TrueRune, nil
 	}
 	// NULL is false, too
 	return false, nil
@@ -84,6 +98,7 @@ func (def *defBool) close() (err error) {
 	rset := def.rset
 	def.rset = nil
 	def.ocidef = nil
+	def.conv = nil
 	clear(def.buf, 0)
 	rset.putDef(defIdxBool, def)
 	return nil
@@ -19,15 +19,38 @@ type defFloat32 struct {
 	ociNumber  C.OCINumber
 	null       C.sb2
 	isNullable bool
+	native     bool    // defined as SQLT_BFLOAT
+	real       C.float // value when native
 }
 
-func (def *defFloat32) define(position int, isNullable bool, rset *Rset) error {
+// define defines the column as an OCINumber or, when native is true, as a
+// SQLT_BFLOAT which preserves NaN and infinity.
+func (def *defFloat32) define(position int, isNullable bool, native bool, rset *Rset) error {
 	def.rset = rset
 	def.isNullable = isNullable
+	def.native = native
+	if native {
+		r := C.OCIDEFINEBYPOS(
+			def.rset.ocistmt,          //OCIStmt     *stmtp,
+			&def.ocidef,               //OCIDefine   **defnpp,
+			def.rset.stmt.ses.ocierr,  //OCIError    *errhp,
+			C.ub4(position),           //ub4         position,
+			unsafe.Pointer(&def.real), //void        *valuep,
+			C.LENGTH_TYPE(4),          //sb8         value_sz,
+			C.SQLT_BFLOAT,             //ub2         dty,
+			unsafe.Pointer(&def.null), //void        *indp,
+			nil,                       //ub2         *rlenp,
+			nil,                       //ub2         *rcodep,
+			C.OCI_DEFAULT)             //ub4         mode );
+		if r == C.OCI_ERROR {
+			return def.rset.stmt.ses.ociError()
+		}
+		return nil
+	}
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                  //OCIStmt     *stmtp,
 		&def.ocidef,                       //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,  //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,          //OCIError    *errhp,
 		C.ub4(position),                   //ub4         position,
 		unsafe.Pointer(&def.ociNumber),    //void        *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8         value_sz,
@@ -37,22 +60,37 @@ func (def *defFloat32) define(position int, isNullable bool, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
 func (def *defFloat32) value() (value interface{}, err error) {
+	if def.native {
+		isNull := def.null < C.sb2(0)
+		if !isNull {
+			if isNull, err = def.rset.cfg.NaN.check(float64(def.real)); err != nil {
+				return nil, err
+			}
+		}
+		if def.isNullable {
+			return Float32{IsNull: isNull, Value: float32(def.real)}, nil
+		}
+		if !isNull {
+			return float32(def.real), nil
+		}
+		return nil, nil
+	}
 	if def.isNullable {
 		oraFloat32Value := Float32{IsNull: def.null < C.sb2(0)}
 		if !oraFloat32Value.IsNull {
 			var float32Value float32
 			r := C.OCINumberToReal(
-				def.rset.stmt.ses.srv.env.ocierr,       //OCIError              *err,
+				def.rset.stmt.ses.ocierr,               //OCIError              *err,
 				&def.ociNumber,                         //const OCINumber     *number,
 				C.uword(4),                             //uword               rsl_length,
 				unsafe.Pointer(&oraFloat32Value.Value)) //void                *rsl );
 			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+				err = def.rset.stmt.ses.ociError()
 			}
 			value = float32Value
 		}
@@ -61,12 +99,12 @@ func (def *defFloat32) value() (value interface{}, err error) {
 		if def.null > C.sb2(-1) {
 			var float32Value float32
 			r := C.OCINumberToReal(
-				def.rset.stmt.ses.srv.env.ocierr, //OCIError              *err,
-				&def.ociNumber,                   //const OCINumber     *number,
-				C.uword(4),                       //uword               rsl_length,
-				unsafe.Pointer(&float32Value))    //void                *rsl );
+				def.rset.stmt.ses.ocierr,      //OCIError              *err,
+				&def.ociNumber,                //const OCINumber     *number,
+				C.uword(4),                    //uword               rsl_length,
+				unsafe.Pointer(&float32Value)) //void                *rsl );
 			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+				err = def.rset.stmt.ses.ociError()
 			}
 			value = float32Value
 		}
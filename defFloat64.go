@@ -19,15 +19,38 @@ type defFloat64 struct {
 	ociNumber  C.OCINumber
 	null       C.sb2
 	isNullable bool
+	native     bool     // defined as SQLT_BDOUBLE
+	real       C.double // value when native
 }
 
-func (def *defFloat64) define(position int, isNullable bool, rset *Rset) error {
+// define defines the column as an OCINumber or, when native is true, as a
+// SQLT_BDOUBLE which preserves NaN and infinity.
+func (def *defFloat64) define(position int, isNullable bool, native bool, rset *Rset) error {
 	def.rset = rset
 	def.isNullable = isNullable
+	def.native = native
+	if native {
+		r := C.OCIDEFINEBYPOS(
+			def.rset.ocistmt,          //OCIStmt     *stmtp,
+			&def.ocidef,               //OCIDefine   **defnpp,
+			def.rset.stmt.ses.ocierr,  //OCIError    *errhp,
+			C.ub4(position),           //ub4         position,
+			unsafe.Pointer(&def.real), //void        *valuep,
+			C.LENGTH_TYPE(8),          //sb8         value_sz,
+			C.SQLT_BDOUBLE,            //ub2         dty,
+			unsafe.Pointer(&def.null), //void        *indp,
+			nil,                       //ub2         *rlenp,
+			nil,                       //ub2         *rcodep,
+			C.OCI_DEFAULT)             //ub4         mode );
+		if r == C.OCI_ERROR {
+			return def.rset.stmt.ses.ociError()
+		}
+		return nil
+	}
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                  //OCIStmt     *stmtp,
 		&def.ocidef,                       //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,  //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,          //OCIError    *errhp,
 		C.ub4(position),                   //ub4         position,
 		unsafe.Pointer(&def.ociNumber),    //void        *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8         value_sz,
@@ -37,22 +60,37 @@ func (def *defFloat64) define(position int, isNullable bool, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
 func (def *defFloat64) value() (value interface{}, err error) {
+	if def.native {
+		isNull := def.null < C.sb2(0)
+		if !isNull {
+			if isNull, err = def.rset.cfg.NaN.check(float64(def.real)); err != nil {
+				return nil, err
+			}
+		}
+		if def.isNullable {
+			return Float64{IsNull: isNull, Value: float64(def.real)}, nil
+		}
+		if !isNull {
+			return float64(def.real), nil
+		}
+		return nil, nil
+	}
 	if def.isNullable {
 		oraFloat64Value := Float64{IsNull: def.null < C.sb2(0)}
 		if !oraFloat64Value.IsNull {
 			var float64Value float64
 			r := C.OCINumberToReal(
-				def.rset.stmt.ses.srv.env.ocierr,       //OCIError              *err,
+				def.rset.stmt.ses.ocierr,               //OCIError              *err,
 				&def.ociNumber,                         //const OCINumber     *number,
 				C.uword(8),                             //uword               rsl_length,
 				unsafe.Pointer(&oraFloat64Value.Value)) //void                *rsl );
 			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+				err = def.rset.stmt.ses.ociError()
 			}
 			value = float64Value
 		}
@@ -61,12 +99,12 @@ func (def *defFloat64) value() (value interface{}, err error) {
 		if def.null > C.sb2(-1) {
 			var float64Value float64
 			r := C.OCINumberToReal(
-				def.rset.stmt.ses.srv.env.ocierr, //OCIError              *err,
-				&def.ociNumber,                   //const OCINumber     *number,
-				C.uword(8),                       //uword               rsl_length,
-				unsafe.Pointer(&float64Value))    //void                *rsl );
+				def.rset.stmt.ses.ocierr,      //OCIError              *err,
+				&def.ociNumber,                //const OCINumber     *number,
+				C.uword(8),                    //uword               rsl_length,
+				unsafe.Pointer(&float64Value)) //void                *rsl );
 			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+				err = def.rset.stmt.ses.ociError()
 			}
 			value = float64Value
 		}
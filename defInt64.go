@@ -27,7 +27,7 @@ func (def *defInt64) define(position int, isNullable bool, rset *Rset) error {
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                  //OCIStmt     *stmtp,
 		&def.ocidef,                       //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,  //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,          //OCIError    *errhp,
 		C.ub4(position),                   //ub4         position,
 		unsafe.Pointer(&def.ociNumber),    //void        *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8         value_sz,
@@ -37,7 +37,7 @@ func (def *defInt64) define(position int, isNullable bool, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -46,28 +46,20 @@ func (def *defInt64) value() (value interface{}, err error) {
 	if def.isNullable {
 		oraInt64Value := Int64{IsNull: def.null < C.sb2(0)}
 		if !oraInt64Value.IsNull {
-			r := C.OCINumberToInt(
-				def.rset.stmt.ses.srv.env.ocierr,     //OCIError              *err,
-				&def.ociNumber,                       //const OCINumber       *number,
-				C.uword(8),                           //uword                 rsl_length,
-				C.OCI_NUMBER_SIGNED,                  //uword                 rsl_flag,
-				unsafe.Pointer(&oraInt64Value.Value)) //void                  *rsl );
-			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+			var over interface{}
+			over, err = def.rset.numberToInt(&def.ociNumber, 8, true, true, unsafe.Pointer(&oraInt64Value.Value))
+			if over != nil {
+				return over, nil
 			}
 		}
 		value = oraInt64Value
 	} else {
 		if def.null > C.sb2(-1) {
 			var int64Value int64
-			r := C.OCINumberToInt(
-				def.rset.stmt.ses.srv.env.ocierr, //OCIError              *err,
-				&def.ociNumber,                   //const OCINumber       *number,
-				C.uword(8),                       //uword                 rsl_length,
-				C.OCI_NUMBER_SIGNED,              //uword                 rsl_flag,
-				unsafe.Pointer(&int64Value))      //void                  *rsl );
-			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+			var over interface{}
+			over, err = def.rset.numberToInt(&def.ociNumber, 8, true, false, unsafe.Pointer(&int64Value))
+			if over != nil {
+				return over, nil
 			}
 			value = int64Value
 		}
@@ -27,7 +27,7 @@ func (def *defIntervalDS) define(position int, rset *Rset) error {
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                              //OCIStmt     *stmtp,
 		&def.ocidef,                                   //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,              //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,                      //OCIError    *errhp,
 		C.ub4(position),                               //ub4         position,
 		unsafe.Pointer(&def.ociInterval),              //void        *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(def.ociInterval)), //sb8         value_sz,
@@ -37,7 +37,7 @@ func (def *defIntervalDS) define(position int, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -52,7 +52,7 @@ func (def *defIntervalDS) value() (value interface{}, err error) {
 		var nanosecond C.sb4
 		r := C.OCIIntervalGetDaySecond(
 			unsafe.Pointer(def.rset.stmt.ses.srv.env.ocienv), //void               *hndl,
-			def.rset.stmt.ses.srv.env.ocierr,                 //OCIError           *err,
+			def.rset.stmt.ses.ocierr,                         //OCIError           *err,
 			&day,            //sb4                *dy,
 			&hour,           //sb4                *hr,
 			&minute,         //sb4                *mm,
@@ -60,7 +60,7 @@ func (def *defIntervalDS) value() (value interface{}, err error) {
 			&nanosecond,     //sb4                *fsec,
 			def.ociInterval) //const OCIInterval  *interval );
 		if r == C.OCI_ERROR {
-			err = def.rset.stmt.ses.srv.env.ociError()
+			err = def.rset.stmt.ses.ociError()
 		}
 		intervalDS.Day = int32(day)
 		intervalDS.Hour = int32(hour)
@@ -79,7 +79,7 @@ func (def *defIntervalDS) alloc() error {
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci interval handle during define")
 	}
@@ -25,7 +25,7 @@ func (def *defIntervalYM) define(position int, rset *Rset) error {
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                              //OCIStmt     *stmtp,
 		&def.ocidef,                                   //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,              //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,                      //OCIError    *errhp,
 		C.ub4(position),                               //ub4         position,
 		unsafe.Pointer(&def.ociInterval),              //void        *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(def.ociInterval)), //sb8         value_sz,
@@ -35,7 +35,7 @@ func (def *defIntervalYM) define(position int, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -47,12 +47,12 @@ func (def *defIntervalYM) value() (value interface{}, err error) {
 		var month C.sb4
 		r := C.OCIIntervalGetYearMonth(
 			unsafe.Pointer(def.rset.stmt.ses.srv.env.ocienv), //void               *hndl,
-			def.rset.stmt.ses.srv.env.ocierr,                 //OCIError           *err,
+			def.rset.stmt.ses.ocierr,                         //OCIError           *err,
 			&year,           //sb4                *yr,
 			&month,          //sb4                *mnth,
 			def.ociInterval) //const OCIInterval  *interval );
 		if r == C.OCI_ERROR {
-			err = def.rset.stmt.ses.srv.env.ociError()
+			err = def.rset.stmt.ses.ociError()
 		}
 		intervalYM.Year = int32(year)
 		intervalYM.Month = int32(month)
@@ -68,7 +68,7 @@ func (def *defIntervalYM) alloc() error {
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci interval handle during define")
 	}
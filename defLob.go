@@ -10,21 +10,12 @@ package ora
 */
 import "C"
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"sync"
 	"unsafe"
 )
 
-const lobChunkSize = 16 << 20 // 16Mb
-
-var lobChunkPool = sync.Pool{
-	New: func() interface{} {
-		var b [lobChunkSize]byte
-		return b
-	},
-}
-
 type defLob struct {
 	rset          *Rset
 	ocidef        *C.OCIDefine
@@ -42,25 +33,32 @@ func (def *defLob) define(position int, charsetForm C.ub1, sqlt C.ub2, gct GoCol
 	def.charsetForm = charsetForm
 	def.ociLobLocator = nil
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                                //OCIStmt     *stmtp,
-		&def.ocidef,                                     //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,                //OCIError    *errhp,
-		C.ub4(position),                                 //ub4         position,
-		unsafe.Pointer(&def.ociLobLocator),              //void        *valuep,
+		def.rset.ocistmt,                   //OCIStmt     *stmtp,
+		&def.ocidef,                        //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,           //OCIError    *errhp,
+		C.ub4(position),                    //ub4         position,
+		unsafe.Pointer(&def.ociLobLocator), //void        *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(def.ociLobLocator)), //sb8         value_sz,
-		sqlt, //ub2         dty,
+		sqlt,                      //ub2         dty,
 		unsafe.Pointer(&def.null), //void        *indp,
-		nil,           //ub2         *rlenp,
-		nil,           //ub2         *rcodep,
-		C.OCI_DEFAULT) //ub4         mode );
+		nil,                       //ub2         *rlenp,
+		nil,                       //ub2         *rcodep,
+		C.OCI_DEFAULT)             //ub4         mode );
 	if r != C.OCI_SUCCESS {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	prefetchLength := C.boolean(C.TRUE)
-	return def.rset.stmt.ses.srv.env.setAttr(unsafe.Pointer(def.ocidef), C.OCI_HTYPE_DEFINE, unsafe.Pointer(&prefetchLength), 0, C.OCI_ATTR_LOBPREFETCH_LENGTH)
+	return def.rset.stmt.ses.setAttr(unsafe.Pointer(def.ocidef), C.OCI_HTYPE_DEFINE, unsafe.Pointer(&prefetchLength), 0, C.OCI_ATTR_LOBPREFETCH_LENGTH)
 }
 
 func (def *defLob) Bytes() (value []byte, err error) {
+	// The length is prefetched with the row; an empty LOB isn't opened
+	if length, err := lobLength(def.rset.stmt.ses, def.ociLobLocator); err != nil || length == 0 {
+		return nil, err
+	} else if def.sqlt == C.SQLT_CLOB {
+		// the length is in characters, which may be several bytes each
+		return def.clobBytes(int(length))
+	}
 	// Open the lob to obtain length; round-trip to database
 	//Log.Infof("Bytes OCILobOpen %p", def.ociLobLocator)
 	lobLength, err := lobOpen(def.rset.stmt.ses, def.ociLobLocator, C.OCI_LOB_READONLY)
@@ -81,25 +79,28 @@ func (def *defLob) Bytes() (value []byte, err error) {
 
 	// Allocate []byte the length of the lob
 	value = make([]byte, int(lobLength))
+	bufl := C.oraub8(def.rset.stmt.ses.lobChunk())
 	for off, byte_amtp := 0, lobLength; byte_amtp > 0; byte_amtp = lobLength - C.oraub8(off) {
 		//Log.Infof("LobRead2 off=%d amt=%d", off, byte_amtp)
-		r := C.OCILobRead2(
-			def.rset.stmt.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-			def.rset.stmt.ses.srv.env.ocierr, //OCIError           *errhp,
-			def.ociLobLocator,                //OCILobLocator      *locp,
-			&byte_amtp,                       //oraub8             *byte_amtp,
-			nil,                              //oraub8             *char_amtp,
-			C.oraub8(off+1),                  //oraub8             offset, offset is 1-based
-			unsafe.Pointer(&value[off]),      //void               *bufp,
-			C.oraub8(lobChunkSize),           //oraub8             bufl,
-			C.OCI_ONE_PIECE,                  //ub1                piece,
-			nil,                              //void               *ctxp,
-			nil,                              //OCICallbackLobRead2 (cbfp)
-			C.ub2(0),                         //ub2                csid,
-			def.charsetForm)                  //ub1                csfrm );
+		r := def.rset.stmt.ses.pollOp(nil, "lob read", func() C.sword {
+			return C.OCILobRead2(
+				def.rset.stmt.ses.ocisvcctx, //OCISvcCtx          *svchp,
+				def.rset.stmt.ses.ocierr,    //OCIError           *errhp,
+				def.ociLobLocator,           //OCILobLocator      *locp,
+				&byte_amtp,                  //oraub8             *byte_amtp,
+				nil,                         //oraub8             *char_amtp,
+				C.oraub8(off+1),             //oraub8             offset, offset is 1-based
+				unsafe.Pointer(&value[off]), //void               *bufp,
+				bufl,                        //oraub8             bufl,
+				C.OCI_ONE_PIECE,             //ub1                piece,
+				nil,                         //void               *ctxp,
+				nil,                         //OCICallbackLobRead2 (cbfp)
+				C.ub2(0),                    //ub2                csid,
+				def.charsetForm)             //ub1                csfrm );
+		})
 
 		if r == C.OCI_ERROR {
-			return nil, def.rset.stmt.ses.srv.env.ociError()
+			return nil, def.rset.stmt.ses.ociError()
 		}
 		// byte_amtp represents the amount copied into buffer by oci
 		off += int(byte_amtp)
@@ -107,6 +108,27 @@ func (def *defLob) Bytes() (value []byte, err error) {
 
 	return value, nil
 }
+// clobBytes reads the content of a CLOB of length characters.
+func (def *defLob) clobBytes(length int) ([]byte, error) {
+	r, err := def.Reader()
+	if err != nil {
+		return nil, err
+	}
+	lr := r.(*lobReader)
+	var buf bytes.Buffer
+	if length < lobChunkSize {
+		buf.Grow(length)
+	}
+	_, err = buf.ReadFrom(lr)
+	if closeErr := lr.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (def *defLob) String() (value string, err error) {
 	var bytes []byte
 	bytes, err = def.Bytes()
@@ -117,6 +139,13 @@ func (def *defLob) String() (value string, err error) {
 // Reader returns an io.Reader for the underlying LOB.
 // Also dissociates this def from the LOB!
 func (def *defLob) Reader() (io.Reader, error) {
+	// The length is prefetched with the row; an empty LOB isn't opened, and
+	// its locator is freed with the row
+	if length, err := lobLength(def.rset.stmt.ses, def.ociLobLocator); err != nil {
+		return nil, err
+	} else if length == 0 {
+		return &lobReader{}, nil
+	}
 	// Open the lob to obtain length; round-trip to database
 	//Log.Infof("Reader OCILobOpen %p", def.ociLobLocator)
 	lobLength, err := lobOpen(def.rset.stmt.ses, def.ociLobLocator, C.OCI_LOB_READONLY)
@@ -131,8 +160,10 @@ func (def *defLob) Reader() (io.Reader, error) {
 		charsetForm:   def.charsetForm,
 		piece:         C.OCI_FIRST_PIECE,
 		Length:        lobLength,
+		clob:          def.sqlt == C.SQLT_CLOB,
 	}
 	def.ociLobLocator = nil
+	lr.ses.leaks.track(lr, "Lob", def.rset.sysName())
 	return lr, nil
 }
 
@@ -145,6 +176,22 @@ func (def *defLob) value() (value interface{}, err error) {
 		}
 		return value, err
 	}
+	if def.gct == JSON || def.gct == JSONAny {
+		if def.null < C.sb2(0) {
+			return nil, nil
+		}
+		var text []byte
+		if text, err = def.Bytes(); err != nil {
+			return nil, err
+		}
+		return jsonDefValue(text, def.gct)
+	}
+	if def.gct == OraLobD {
+		if def.null < C.sb2(0) {
+			return LobD{IsNull: true}, nil
+		}
+		return def.lobD()
+	}
 	if def.null < C.sb2(0) {
 		return Lob{}, nil
 	}
@@ -164,7 +211,7 @@ func (def *defLob) alloc() error {
 		0,                                                     //size_t        xtramem_sz,
 		nil)                                                   //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci lob handle during define")
 	}
@@ -198,25 +245,40 @@ func (def *defLob) close() (err error) {
 
 func lobOpen(ses *Ses, lob *C.OCILobLocator, mode C.ub1) (length C.oraub8, err error) {
 	//Log.Infof("OCILobOpen %p\n%s", lob, getStack(1))
-	r := C.OCILobOpen(
-		ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		ses.srv.env.ocierr, //OCIError           *errhp,
-		lob,                //OCILobLocator      *locp,
-		mode)               //ub1              mode );
+	r := ses.poll(nil, func() C.sword {
+		return C.OCILobOpen(
+			ses.ocisvcctx, //OCISvcCtx          *svchp,
+			ses.ocierr,    //OCIError           *errhp,
+			lob,           //OCILobLocator      *locp,
+			mode)          //ub1              mode );
+	})
 	//Log.Infof("OCILobOpen %p returned %d", lob, r)
 	if r != C.OCI_SUCCESS {
 		lobClose(ses, lob)
-		return 0, ses.srv.env.ociError()
+		return 0, ses.ociError()
 	}
 	// get the length of the lob
-	r = C.OCILobGetLength2(
-		ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		ses.srv.env.ocierr, //OCIError           *errhp,
-		lob,                //OCILobLocator      *locp,
-		&length)            //oraub8 *lenp)
-	if r == C.OCI_ERROR {
+	if length, err = lobLength(ses, lob); err != nil {
 		lobClose(ses, lob)
-		return length, ses.srv.env.ociError()
+		return length, err
+	}
+	return length, nil
+}
+
+// lobLength returns the length of the LOB; in bytes for a BLOB and characters
+// for a CLOB. The length of a selected LOB is prefetched with the row (see
+// OCI_ATTR_LOBPREFETCH_LENGTH in defLob.define), so no round trip occurs
+// unless the OCI client or server doesn't prefetch it.
+func lobLength(ses *Ses, lob *C.OCILobLocator) (length C.oraub8, err error) {
+	r := ses.poll(nil, func() C.sword {
+		return C.OCILobGetLength2(
+			ses.ocisvcctx, //OCISvcCtx          *svchp,
+			ses.ocierr,    //OCIError           *errhp,
+			lob,           //OCILobLocator      *locp,
+			&length)       //oraub8 *lenp)
+	})
+	if r == C.OCI_ERROR {
+		return length, ses.ociError()
 	}
 	return length, nil
 }
@@ -226,21 +288,27 @@ func lobClose(ses *Ses, lob *C.OCILobLocator) error {
 		return nil
 	}
 	//Log.Infof("OCILobClose %p\n%s", lob, getStack(1))
-	r := C.OCILobClose(
-		ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		ses.srv.env.ocierr, //OCIError           *errhp,
-		lob,                //OCILobLocator      *locp,
-	)
+	r := ses.poll(nil, func() C.sword {
+		return C.OCILobClose(
+			ses.ocisvcctx, //OCISvcCtx          *svchp,
+			ses.ocierr,    //OCIError           *errhp,
+			lob,           //OCILobLocator      *locp,
+		)
+	})
+	// free the temporary LOB of an out bind
+	ses.freeTempLob(lob)
 	C.OCIDescriptorFree(unsafe.Pointer(lob), //void     *descp,
 		C.OCI_DTYPE_LOB) //ub4      type );
 	if r == C.OCI_ERROR {
-		return ses.srv.env.ociError()
+		return ses.ociError()
 	}
 	return nil
 }
 
 var _ = io.Reader((*lobReader)(nil))
 var _ = io.WriterTo((*lobReader)(nil))
+var _ = io.ReaderAt((*lobReader)(nil))
+var _ = io.Seeker((*lobReader)(nil))
 
 type lobReader struct {
 	ses           *Ses
@@ -249,7 +317,10 @@ type lobReader struct {
 	piece         C.ub1
 	off           C.oraub8
 	interrupted   bool
-	Length        C.oraub8
+	Length        C.oraub8 // in characters for a CLOB
+	clob          bool
+	chars         C.oraub8 // characters read from a CLOB
+	random        bool     // Seek was called; Read reads at off in one piece
 }
 
 // Close the LOB reader.
@@ -259,8 +330,9 @@ func (lr *lobReader) Close() error {
 	}
 	lob, ses := lr.ociLobLocator, lr.ses
 	lr.ociLobLocator, lr.ses = nil, nil
+	ses.leaks.untrack(lr)
 	if lr.interrupted {
-		ses.Break()
+		ses.breakCall()
 	}
 	//Log.Infof("lobReader OCILobClose %p", lr.ociLobLocator)
 	return lobClose(ses, lob)
@@ -271,34 +343,44 @@ func (lr *lobReader) Read(p []byte) (n int, err error) {
 	if lr.ociLobLocator == nil {
 		return 0, io.EOF
 	}
+	if lr.random {
+		n, err = lr.ReadAt(p, int64(lr.off))
+		lr.off += C.oraub8(n)
+		if err == io.EOF && n > 0 {
+			err = nil
+		}
+		return n, err
+	}
 	defer func() {
 		if err != nil {
 			lr.Close()
 		}
 	}()
 
-	var byte_amtp C.oraub8 // zero
+	var byte_amtp, char_amtp C.oraub8 // zero
 	//Log.Infof("LobRead2 piece=%d off=%d amt=%d", lr.piece, lr.off, len(p))
-	r := C.OCILobRead2(
-		lr.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		lr.ses.srv.env.ocierr, //OCIError           *errhp,
-		lr.ociLobLocator,      //OCILobLocator      *locp,
-		&byte_amtp,            //oraub8             *byte_amtp,
-		nil,                   //oraub8             *char_amtp,
-		lr.off+1,              //oraub8             offset, offset is 1-based
-		unsafe.Pointer(&p[0]), //void               *bufp,
-		C.oraub8(len(p)),      //oraub8             bufl,
-		lr.piece,              //ub1                piece,
-		nil,                   //void               *ctxp,
-		nil,                   //OCICallbackLobRead2 (cbfp)
-		C.ub2(0),              //ub2                csid,
-		lr.charsetForm,        //ub1                csfrm );
-	)
+	r := lr.ses.pollOp(nil, "lob read", func() C.sword {
+		return C.OCILobRead2(
+			lr.ses.ocisvcctx,      //OCISvcCtx          *svchp,
+			lr.ses.ocierr,         //OCIError           *errhp,
+			lr.ociLobLocator,      //OCILobLocator      *locp,
+			&byte_amtp,            //oraub8             *byte_amtp,
+			&char_amtp,            //oraub8             *char_amtp,
+			lr.off+1,              //oraub8             offset, offset is 1-based
+			unsafe.Pointer(&p[0]), //void               *bufp,
+			C.oraub8(len(p)),      //oraub8             bufl,
+			lr.piece,              //ub1                piece,
+			nil,                   //void               *ctxp,
+			nil,                   //OCICallbackLobRead2 (cbfp)
+			C.ub2(0),              //ub2                csid,
+			lr.charsetForm,        //ub1                csfrm );
+		)
+	})
 	//Log.Infof("LobRead2 returned %d amt=%d", r, byte_amtp)
 	switch r {
 	case C.OCI_ERROR:
 		lr.interrupted = true
-		return 0, lr.ses.srv.env.ociError()
+		return 0, lr.ses.ociError()
 	case C.OCI_NO_DATA:
 		return int(byte_amtp), io.EOF
 	case C.OCI_INVALID_HANDLE:
@@ -307,7 +389,8 @@ func (lr *lobReader) Read(p []byte) (n int, err error) {
 	// byte_amtp represents the amount copied into buffer by oci
 	if byte_amtp != 0 {
 		lr.off += byte_amtp
-		if lr.off == lr.Length {
+		lr.chars += char_amtp
+		if lr.done() {
 			return int(byte_amtp), io.EOF
 		}
 		if lr.piece == C.OCI_FIRST_PIECE {
@@ -317,54 +400,176 @@ func (lr *lobReader) Read(p []byte) (n int, err error) {
 	return int(byte_amtp), nil
 }
 
+// lobLength returns the length of the LOB.
+func (lr *lobReader) lobLength() uint64 {
+	return uint64(lr.Length)
+}
+
+// ReadAt reads len(p) bytes of a BLOB starting at off, in one round trip,
+// without changing the offset of Read. ReadAt returns an error for a CLOB,
+// whose offsets are in characters, and while a streaming Read is in progress.
+func (lr *lobReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if err = lr.checkRandom(); err != nil {
+		return 0, err
+	}
+	if off < 0 {
+		return 0, er("Parameter 'off' may not be negative.")
+	}
+	if C.oraub8(off) >= lr.Length {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	amount := C.oraub8(len(p))
+	if rest := lr.Length - C.oraub8(off); amount > rest {
+		amount = rest
+	}
+	byte_amtp := amount
+	r := lr.ses.pollOp(nil, "lob read", func() C.sword {
+		return C.OCILobRead2(
+			lr.ses.ocisvcctx,      //OCISvcCtx          *svchp,
+			lr.ses.ocierr,         //OCIError           *errhp,
+			lr.ociLobLocator,      //OCILobLocator      *locp,
+			&byte_amtp,            //oraub8             *byte_amtp,
+			nil,                   //oraub8             *char_amtp,
+			C.oraub8(off)+1,       //oraub8             offset, offset is 1-based
+			unsafe.Pointer(&p[0]), //void               *bufp,
+			amount,                //oraub8             bufl,
+			C.OCI_ONE_PIECE,       //ub1                piece,
+			nil,                   //void               *ctxp,
+			nil,                   //OCICallbackLobRead2 (cbfp)
+			C.ub2(0),              //ub2                csid,
+			lr.charsetForm,        //ub1                csfrm );
+		)
+	})
+	switch r {
+	case C.OCI_ERROR:
+		return 0, lr.ses.ociError()
+	case C.OCI_INVALID_HANDLE:
+		return 0, fmt.Errorf("Invalid handle %v", lr.ociLobLocator)
+	}
+	if n = int(byte_amtp); n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Seek sets the offset of the next Read of a BLOB; see io.Seeker. After
+// Seek, Read reads in one round trip per call at the offset, as for HTTP
+// range requests, rather than streaming the rest of the LOB. Seek returns an
+// error for a CLOB, and for a change of offset while a streaming Read is in
+// progress.
+func (lr *lobReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(lr.off) + offset
+	case io.SeekEnd:
+		abs = int64(lr.Length) + offset
+	default:
+		return 0, errF("Invalid whence %d.", whence)
+	}
+	if abs < 0 {
+		return 0, er("Seek to a negative offset.")
+	}
+	if C.oraub8(abs) == lr.off {
+		return abs, nil
+	}
+	if err := lr.checkRandom(); err != nil {
+		return 0, err
+	}
+	lr.off, lr.random = C.oraub8(abs), true
+	return abs, nil
+}
+
+// checkRandom returns an error when the LOB can't be read at an offset.
+func (lr *lobReader) checkRandom() error {
+	if lr.clob {
+		return er("A CLOB can't be read at an offset; its offsets are in characters.")
+	}
+	if lr.ociLobLocator == nil {
+		if lr.Length == 0 {
+			return nil // an empty LOB isn't opened
+		}
+		return er("Lob is closed.")
+	}
+	if lr.piece != C.OCI_FIRST_PIECE {
+		return er("Lob is being streamed by Read.")
+	}
+	return nil
+}
+
+// readAmounts returns the bytes and characters read.
+func (lr *lobReader) readAmounts() (nBytes, nChars uint64) {
+	return uint64(lr.off), uint64(lr.chars)
+}
+
+// done returns true when the whole LOB is read; the Length of a CLOB is in
+// characters and the offset in bytes.
+func (lr *lobReader) done() bool {
+	if lr.clob {
+		return lr.chars == lr.Length
+	}
+	return lr.off == lr.Length
+}
+
 // WriteTo writes all data from the LOB into the given Writer.
 func (lr *lobReader) WriteTo(w io.Writer) (n int64, err error) {
+	if lr.ociLobLocator == nil {
+		return 0, nil
+	}
 	defer func() {
 		if closeErr := lr.Close(); closeErr != nil && err == nil {
 			err = closeErr
 		}
 	}()
 
-	var byte_amtp C.oraub8 // zero
-	arr := lobChunkPool.Get().([lobChunkSize]byte)
-	defer lobChunkPool.Put(arr)
-	buf := arr[:]
+	var byte_amtp, char_amtp C.oraub8 // zero
+	pbuf := getLobBuf(lr.ses.lobChunk())
+	defer putLobBuf(pbuf)
+	buf := *pbuf
 
 	var k int
 	for {
 		//Log.Infof("WriteTo LobRead2 off=%d amt=%d", lr.off, len(buf))
-		r := C.OCILobRead2(
-			lr.ses.ocisvcctx,        //OCISvcCtx          *svchp,
-			lr.ses.srv.env.ocierr,   //OCIError           *errhp,
-			lr.ociLobLocator,        //OCILobLocator      *locp,
-			&byte_amtp,              //oraub8             *byte_amtp,
-			nil,                     //oraub8             *char_amtp,
-			lr.off+1,                //oraub8             offset, offset is 1-based
-			unsafe.Pointer(&buf[0]), //void               *bufp,
-			C.oraub8(len(buf)),      //oraub8             bufl,
-			lr.piece,                //ub1                piece,
-			nil,                     //void               *ctxp,
-			nil,                     //OCICallbackLobRead2 (cbfp)
-			C.ub2(0),                //ub2                csid,
-			lr.charsetForm,          //ub1                csfrm );
-		)
+		r := lr.ses.pollOp(nil, "lob read", func() C.sword {
+			return C.OCILobRead2(
+				lr.ses.ocisvcctx,        //OCISvcCtx          *svchp,
+				lr.ses.ocierr,           //OCIError           *errhp,
+				lr.ociLobLocator,        //OCILobLocator      *locp,
+				&byte_amtp,              //oraub8             *byte_amtp,
+				&char_amtp,              //oraub8             *char_amtp,
+				lr.off+1,                //oraub8             offset, offset is 1-based
+				unsafe.Pointer(&buf[0]), //void               *bufp,
+				C.oraub8(len(buf)),      //oraub8             bufl,
+				lr.piece,                //ub1                piece,
+				nil,                     //void               *ctxp,
+				nil,                     //OCICallbackLobRead2 (cbfp)
+				C.ub2(0),                //ub2                csid,
+				lr.charsetForm,          //ub1                csfrm );
+			)
+		})
 		//Log.Infof("WriteTo LobRead2 returned %d amt=%d piece=%d", r, byte_amtp, lr.piece)
 		switch r {
 		case C.OCI_SUCCESS:
 		case C.OCI_NO_DATA:
 			break
 		default:
-			return 0, lr.ses.srv.env.ociError()
+			return 0, lr.ses.ociError()
 		}
 		// byte_amtp represents the amount copied into buffer by oci
 		lr.off += byte_amtp
+		lr.chars += char_amtp
 
 		if byte_amtp != 0 {
 			if k, err = w.Write(buf[:int(byte_amtp)]); err != nil {
 				return n, err
 			}
 			n += int64(k)
-			if lr.off == lr.Length {
+			if lr.done() {
 				break
 			}
 		}
@@ -404,13 +609,15 @@ func (lrw *lobReadWriter) Close() error {
 
 // Truncate the lob to the given length.
 func (lrw *lobReadWriter) Truncate(length int64) error {
-	if C.OCILobTrim2(
-		lrw.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		lrw.ses.srv.env.ocierr, //OCIError           *errhp,
-		lrw.ociLobLocator,      //OCILobLocator      *locp,
-		C.oraub8(length),       //oraub8             *newlen)
-	) == C.OCI_ERROR {
-		return lrw.ses.srv.env.ociError()
+	if lrw.ses.poll(nil, func() C.sword {
+		return C.OCILobTrim2(
+			lrw.ses.ocisvcctx, //OCISvcCtx          *svchp,
+			lrw.ses.ocierr,    //OCIError           *errhp,
+			lrw.ociLobLocator, //OCILobLocator      *locp,
+			C.oraub8(length),  //oraub8             *newlen)
+		)
+	}) == C.OCI_ERROR {
+		return lrw.ses.ociError()
 	}
 	return nil
 }
@@ -419,25 +626,27 @@ func (lrw *lobReadWriter) Truncate(length int64) error {
 func (lrw *lobReadWriter) ReadAt(p []byte, off int64) (n int, err error) {
 	byte_amtp := C.oraub8(len(p))
 	//Log.Infof("LobRead2 off=%d amt=%d", off, len(p))
-	r := C.OCILobRead2(
-		lrw.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		lrw.ses.srv.env.ocierr, //OCIError           *errhp,
-		lrw.ociLobLocator,      //OCILobLocator      *locp,
-		&byte_amtp,             //oraub8             *byte_amtp,
-		nil,                    //oraub8             *char_amtp,
-		C.oraub8(off)+1,        //oraub8             offset, offset is 1-based
-		unsafe.Pointer(&p[0]),  //void               *bufp,
-		C.oraub8(len(p)),       //oraub8             bufl,
-		C.OCI_ONE_PIECE,        //ub1                piece,
-		nil,                    //void               *ctxp,
-		nil,                    //OCICallbackLobRead2 (cbfp)
-		C.ub2(0),               //ub2                csid,
-		lrw.charsetForm,        //ub1                csfrm );
-	)
+	r := lrw.ses.pollOp(nil, "lob read", func() C.sword {
+		return C.OCILobRead2(
+			lrw.ses.ocisvcctx,     //OCISvcCtx          *svchp,
+			lrw.ses.ocierr,        //OCIError           *errhp,
+			lrw.ociLobLocator,     //OCILobLocator      *locp,
+			&byte_amtp,            //oraub8             *byte_amtp,
+			nil,                   //oraub8             *char_amtp,
+			C.oraub8(off)+1,       //oraub8             offset, offset is 1-based
+			unsafe.Pointer(&p[0]), //void               *bufp,
+			C.oraub8(len(p)),      //oraub8             bufl,
+			C.OCI_ONE_PIECE,       //ub1                piece,
+			nil,                   //void               *ctxp,
+			nil,                   //OCICallbackLobRead2 (cbfp)
+			C.ub2(0),              //ub2                csid,
+			lrw.charsetForm,       //ub1                csfrm );
+		)
+	})
 	//Log.Infof("LobRead2 returned %d amt=%d", r, byte_amtp)
 	switch r {
 	case C.OCI_ERROR:
-		return 0, lrw.ses.srv.env.ociError()
+		return 0, lrw.ses.ociError()
 	case C.OCI_NO_DATA:
 		return int(byte_amtp), io.EOF
 	case C.OCI_INVALID_HANDLE:
@@ -451,24 +660,26 @@ func (lrw *lobReadWriter) WriteAt(p []byte, off int64) (n int, err error) {
 	//Log.Infof("LobWrite2 off=%d len=%d", off, n)
 	byte_amtp := C.oraub8(len(p))
 	// Write to Oracle
-	if C.OCILobWrite2(
-		lrw.ses.ocisvcctx,      //OCISvcCtx          *svchp,
-		lrw.ses.srv.env.ocierr, //OCIError           *errhp,
-		lrw.ociLobLocator,      //OCILobLocator      *locp,
-		&byte_amtp,             //oraub8          *byte_amtp,
-		nil,                    //oraub8          *char_amtp,
-		C.oraub8(off)+1,        //oraub8          offset, starting position is 1
-		unsafe.Pointer(&p[0]),  //void            *bufp,
-		C.oraub8(len(p)),
-		C.OCI_ONE_PIECE,  //ub1             piece,
-		nil,              //void            *ctxp,
-		nil,              //OCICallbackLobWrite2 (cbfp)
-		C.ub2(0),         //ub2             csid,
-		C.SQLCS_IMPLICIT, //ub1             csfrm );
-	//fmt.Printf("r %v, current %v, buffer %v\n", r, current, buffer)
-	//fmt.Printf("C.OCI_NEED_DATA %v, C.OCI_SUCCESS %v\n", C.OCI_NEED_DATA, C.OCI_SUCCESS)
-	) == C.OCI_ERROR {
-		return 0, lrw.ses.srv.env.ociError()
+	if lrw.ses.pollOp(nil, "lob write", func() C.sword {
+		return C.OCILobWrite2(
+			lrw.ses.ocisvcctx,     //OCISvcCtx          *svchp,
+			lrw.ses.ocierr,        //OCIError           *errhp,
+			lrw.ociLobLocator,     //OCILobLocator      *locp,
+			&byte_amtp,            //oraub8          *byte_amtp,
+			nil,                   //oraub8          *char_amtp,
+			C.oraub8(off)+1,       //oraub8          offset, starting position is 1
+			unsafe.Pointer(&p[0]), //void            *bufp,
+			C.oraub8(len(p)),
+			C.OCI_ONE_PIECE,  //ub1             piece,
+			nil,              //void            *ctxp,
+			nil,              //OCICallbackLobWrite2 (cbfp)
+			C.ub2(0),         //ub2             csid,
+			C.SQLCS_IMPLICIT, //ub1             csfrm );
+		//fmt.Printf("r %v, current %v, buffer %v\n", r, current, buffer)
+		//fmt.Printf("C.OCI_NEED_DATA %v, C.OCI_SUCCESS %v\n", C.OCI_NEED_DATA, C.OCI_SUCCESS)
+		)
+	}) == C.OCI_ERROR {
+		return 0, lrw.ses.ociError()
 	}
 	if C.oraub8(off)+byte_amtp > lrw.size {
 		lrw.size = C.oraub8(off) + byte_amtp
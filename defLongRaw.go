@@ -28,19 +28,19 @@ func (def *defLongRaw) define(position int, bufSize uint32, isNullable bool, rse
 	def.buf = make([]byte, int(bufSize))
 	//logF(true, "position %v, def.buf %v", position, len(def.buf))
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                 //OCIStmt     *stmtp,
-		&def.ocidef,                      //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(position),                  //ub4         position,
-		unsafe.Pointer(&def.buf[0]),      //void        *valuep,
-		C.LENGTH_TYPE(len(def.buf)),      //sb8         value_sz,
-		C.SQLT_LBI,                       //ub2         dty,
-		unsafe.Pointer(&def.null),        //void        *indp,
-		&def.returnLength,                //ub4         *rlenp,
-		nil,                              //ub2         *rcodep,
-		C.OCI_DEFAULT)                    //ub4         mode );
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		unsafe.Pointer(&def.buf[0]), //void        *valuep,
+		C.LENGTH_TYPE(len(def.buf)), //sb8         value_sz,
+		C.SQLT_LBI,                  //ub2         dty,
+		unsafe.Pointer(&def.null),   //void        *indp,
+		&def.returnLength,           //ub4         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DEFAULT)               //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -0,0 +1,269 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include "version.h"
+*/
+import "C"
+import (
+	"sort"
+	"unsafe"
+)
+
+// pieceSize is the largest piece of a column fetched piecewise.
+const pieceSize = 1 << 16
+
+// minPieceSize is the smallest piece of a column fetched piecewise.
+const minPieceSize = 1 << 9
+
+// pieceMaxSize is the maximum size of a column fetched piecewise.
+const pieceMaxSize = 1<<31 - 1
+
+// defPiece defines a character or binary column fetched piecewise with
+// OCI_DYNAMIC_FETCH, bounding the define buffer to the piece size of the
+// column however large its values.
+type defPiece struct {
+	rset       *Rset
+	ocidef     *C.OCIDefine
+	null       C.sb2
+	rcode      C.ub2
+	alen       C.ub4
+	isNullable bool
+	binary     bool
+	piece      []byte
+	buf        []byte
+}
+
+func (def *defPiece) define(position int, dty C.ub2, binary, isNullable bool, size int, rset *Rset) error {
+	def.rset = rset
+	def.binary = binary
+	def.isNullable = isNullable
+	if cap(def.piece) < size {
+		def.piece = make([]byte, size)
+	}
+	def.piece = def.piece[:size]
+	r := C.OCIDEFINEBYPOS(
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		nil,                         //void        *valuep,
+		C.LENGTH_TYPE(pieceMaxSize), //sb8         value_sz,
+		dty,                         //ub2         dty,
+		nil,                         //void        *indp,
+		nil,                         //ub2         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DYNAMIC_FETCH)         //ub4         mode );
+	if r == C.OCI_ERROR {
+		return def.rset.stmt.ses.ociError()
+	}
+	return nil
+}
+
+// setPiece provides the piece buffer for the next piece of the column.
+func (def *defPiece) setPiece(hndl unsafe.Pointer, htype C.ub4, piece C.ub1) error {
+	def.alen = C.ub4(len(def.piece))
+	r := C.OCIStmtSetPieceInfo(
+		hndl,                          //void        *hndlp,
+		htype,                         //ub4         type,
+		def.rset.stmt.ses.ocierr,      //OCIError    *errhp,
+		unsafe.Pointer(&def.piece[0]), //const void  *bufp,
+		&def.alen,                     //ub4         *alenp,
+		piece,                         //ub1         piece,
+		unsafe.Pointer(&def.null),     //const void  *indp,
+		&def.rcode)                    //ub2         *rcodep );
+	if r == C.OCI_ERROR {
+		return def.rset.stmt.ses.ociError()
+	}
+	return nil
+}
+
+// appendPiece appends the fetched piece to the column value.
+func (def *defPiece) appendPiece() {
+	def.buf = append(def.buf, def.piece[:def.alen]...)
+}
+
+func (def *defPiece) value() (value interface{}, err error) {
+	isNull := def.null < C.sb2(0)
+	if def.binary {
+		var result []byte
+		if !isNull {
+			result = make([]byte, len(def.buf))
+			copy(result, def.buf)
+		}
+		if def.isNullable {
+			return Raw{IsNull: isNull, Value: result}, nil
+		}
+		return result, nil
+	}
+	if def.isNullable {
+		oraStringValue := String{IsNull: isNull}
+		if !isNull {
+			oraStringValue.Value = string(def.buf)
+		}
+		return oraStringValue, nil
+	}
+	if isNull {
+		return "", nil
+	}
+	return string(def.buf), nil
+}
+
+func (def *defPiece) alloc() error {
+	def.buf = def.buf[:0]
+	def.null = 0
+	return nil
+}
+
+func (def *defPiece) free() {
+
+}
+
+func (def *defPiece) close() (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = errR(value)
+		}
+	}()
+
+	rset := def.rset
+	def.rset = nil
+	def.ocidef = nil
+	def.buf = nil
+	rset.putDef(defIdxPiece, def)
+	return nil
+}
+
+// pieceCandidate is a column which may be fetched piecewise.
+type pieceCandidate struct {
+	n    int // select-list position, zero-based
+	size int // define buffer size
+}
+
+// piecesBySize sorts candidates largest first.
+type piecesBySize []pieceCandidate
+
+func (s piecesBySize) Len() int           { return len(s) }
+func (s piecesBySize) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s piecesBySize) Less(i, j int) bool { return s[i].size > s[j].size }
+
+// columnPieceSize returns the piece size of a column fetched piecewise whose
+// define buffer is size bytes: an eighth of the buffer, from minPieceSize to
+// pieceSize, so that a VARCHAR2, CHAR or RAW column fetched piecewise shrinks
+// too.
+func columnPieceSize(size int) int {
+	piece := size / 8
+	if piece > pieceSize {
+		return pieceSize
+	}
+	if piece < minPieceSize {
+		return minPieceSize
+	}
+	return piece
+}
+
+// piecewiseColumns returns the positions of the largest candidates to fetch
+// piecewise so that the sum of the define buffer sizes doesn't exceed
+// maxRowSize. A piecewise column counts its columnPieceSize bytes.
+func piecewiseColumns(candidates []pieceCandidate, total, maxRowSize int) (positions []int) {
+	if maxRowSize <= 0 || total <= maxRowSize {
+		return nil
+	}
+	sorted := append([]pieceCandidate(nil), candidates...)
+	sort.Stable(piecesBySize(sorted))
+	for _, c := range sorted {
+		if total <= maxRowSize || c.size <= minPieceSize {
+			break
+		}
+		positions = append(positions, c.n)
+		total -= c.size - columnPieceSize(c.size)
+	}
+	return positions
+}
+
+// capRowSize redefines the largest character and binary columns to be fetched
+// piecewise when the define buffers of a row exceed RsetCfg.MaxRowSize.
+// No locking occurs.
+func (rset *Rset) capRowSize() error {
+	maxRowSize := rset.cfg.Rset.MaxRowSize
+	if maxRowSize <= 0 {
+		return nil
+	}
+	var candidates []pieceCandidate
+	total := 0
+	for n, d := range rset.defs {
+		var size int
+		switch d := d.(type) {
+		case *defString:
+			size = len(d.buf)
+		case *defRaw:
+			if !d.uuid {
+				size = len(d.buf)
+			}
+		case *defLongRaw:
+			size = len(d.buf)
+		}
+		if size > 0 {
+			candidates = append(candidates, pieceCandidate{n: n, size: size})
+			total += size
+		}
+	}
+	for _, n := range piecewiseColumns(candidates, total, maxRowSize) {
+		var dty C.ub2
+		var binary, isNullable bool
+		var size int
+		switch d := rset.defs[n].(type) {
+		case *defString:
+			dty, isNullable, size = C.SQLT_CHR, d.isNullable, len(d.buf)
+		case *defRaw:
+			dty, binary, isNullable, size = C.SQLT_BIN, true, d.isNullable, len(d.buf)
+		case *defLongRaw:
+			dty, binary, isNullable, size = C.SQLT_LBI, true, d.isNullable, len(d.buf)
+		}
+		rset.logF(_drv.cfg().Log.Rset.OpenDefs, "piecewise column %v", rset.ColumnNames[n])
+		def := rset.getDef(defIdxPiece).(*defPiece)
+		if err := def.define(n+1, dty, binary, isNullable, columnPieceSize(size), rset); err != nil {
+			def.close()
+			return err
+		}
+		rset.defs[n].close()
+		rset.defs[n] = def
+	}
+	return nil
+}
+
+// fetchPieces completes a fetch returning OCI_NEED_DATA by fetching the
+// pieces of the piecewise columns. No locking occurs.
+func (rset *Rset) fetchPieces(fetch func() C.sword) (r C.sword, err error) {
+	ocierr := rset.stmt.ses.ocierr
+	for r = C.OCI_NEED_DATA; r == C.OCI_NEED_DATA; {
+		var hndl unsafe.Pointer
+		var htype, iter, idx C.ub4
+		var inout, piece C.ub1
+		if C.OCIStmtGetPieceInfo(rset.ocistmt, ocierr, &hndl, &htype, &inout, &iter, &idx, &piece) == C.OCI_ERROR {
+			return C.OCI_ERROR, rset.stmt.ses.ociError()
+		}
+		var def *defPiece
+		for _, d := range rset.defs {
+			if d, ok := d.(*defPiece); ok && unsafe.Pointer(d.ocidef) == hndl {
+				def = d
+				break
+			}
+		}
+		if def == nil {
+			return C.OCI_ERROR, errNew("piece requested for an unknown define")
+		}
+		if err = def.setPiece(hndl, htype, piece); err != nil {
+			return C.OCI_ERROR, err
+		}
+		r = rset.stmt.ses.pollOp(rset.ctx, "fetch", fetch)
+		if r == C.OCI_NEED_DATA || r == C.OCI_SUCCESS || r == C.OCI_SUCCESS_WITH_INFO {
+			def.appendPiece()
+		}
+	}
+	return r, nil
+}
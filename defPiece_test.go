@@ -0,0 +1,52 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestPiecewiseColumns tests piecewiseColumns.
+func TestPiecewiseColumns(t *testing.T) {
+	candidates := []pieceCandidate{{0, 4000}, {1, 1 << 24}, {2, 1 << 20}, {3, 32767}}
+	total := 4000 + 1<<24 + 1<<20 + 32767
+	for _, tc := range []struct {
+		maxRowSize int
+		want       []int
+	}{
+		{0, nil},
+		{total, nil},
+		{1 << 22, []int{1}},
+		{1 << 19, []int{1, 2}},
+		{150000, []int{1, 2, 3}}, // a VARCHAR2 is fetched in smaller pieces
+		{1000, []int{1, 2, 3, 0}},
+	} {
+		if got := piecewiseColumns(candidates, total, tc.maxRowSize); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d: got %v, wanted %v", tc.maxRowSize, got, tc.want)
+		}
+	}
+	if got := piecewiseColumns([]pieceCandidate{{0, 400}, {1, 512}}, 912, 100); got != nil {
+		t.Errorf("columns of minPieceSize or less: got %v, wanted none", got)
+	}
+}
+
+// TestColumnPieceSize tests columnPieceSize.
+func TestColumnPieceSize(t *testing.T) {
+	for _, tc := range []struct {
+		size, want int
+	}{
+		{1000, minPieceSize},
+		{4000, minPieceSize},
+		{8000, 1000},
+		{32767, 4095},
+		{1 << 20, pieceSize},
+		{1 << 24, pieceSize},
+	} {
+		if got := columnPieceSize(tc.size); got != tc.want {
+			t.Errorf("%d: got %v, wanted %v", tc.size, got, tc.want)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type defRaw struct {
 	ociRaw     *C.OCIRaw
 	null       C.sb2
 	isNullable bool
+	uuid       bool
 	buf        []byte
 }
 
@@ -27,24 +28,32 @@ func (def *defRaw) define(position int, columnSize int, isNullable bool, rset *R
 	def.isNullable = isNullable
 	def.buf = make([]byte, columnSize)
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                 //OCIStmt     *stmtp,
-		&def.ocidef,                      //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(position),                  //ub4         position,
-		unsafe.Pointer(&def.buf[0]),      //void        *valuep,
-		C.LENGTH_TYPE(columnSize),        //sb8         value_sz,
-		C.SQLT_BIN,                       //ub2         dty,
-		unsafe.Pointer(&def.null),        //void        *indp,
-		nil,           //ub2         *rlenp,
-		nil,           //ub2         *rcodep,
-		C.OCI_DEFAULT) //ub4         mode );
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		unsafe.Pointer(&def.buf[0]), //void        *valuep,
+		C.LENGTH_TYPE(columnSize),   //sb8         value_sz,
+		C.SQLT_BIN,                  //ub2         dty,
+		unsafe.Pointer(&def.null),   //void        *indp,
+		nil,                         //ub2         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DEFAULT)               //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (def *defRaw) value() (value interface{}, err error) {
+	if def.uuid {
+		if def.null > C.sb2(-1) {
+			var u UUID
+			copy(u[:], def.buf)
+			value = u
+		}
+		return value, nil
+	}
 	if def.isNullable {
 		bytesValue := Raw{IsNull: def.null < C.sb2(0)}
 		if !bytesValue.IsNull {
@@ -78,6 +87,7 @@ func (def *defRaw) close() (err error) {
 	def.rset = nil
 	def.ocidef = nil
 	def.ociRaw = nil
+	def.uuid = false
 	def.buf = nil
 	rset.putDef(defIdxRaw, def)
 	return nil
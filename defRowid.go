@@ -29,19 +29,19 @@ func (def *defRowid) define(position int, rset *Rset) error {
 		def.buf = make([]byte, 4001)
 	}
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                 //OCIStmt     *stmtp,
-		&def.ocidef,                      //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(position),                  //ub4         position,
-		unsafe.Pointer(&def.buf[0]),      //void        *valuep,
-		C.LENGTH_TYPE(len(def.buf)),      //sb8         value_sz,
-		C.SQLT_STR,                       //ub2         dty,
-		nil,                              //void        *indp,
-		nil,                              //ub2         *rlenp,
-		nil,                              //ub2         *rcodep,
-		C.OCI_DEFAULT)                    //ub4         mode );
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		unsafe.Pointer(&def.buf[0]), //void        *valuep,
+		C.LENGTH_TYPE(len(def.buf)), //sb8         value_sz,
+		C.SQLT_STR,                  //ub2         dty,
+		nil,                         //void        *indp,
+		nil,                         //ub2         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DEFAULT)               //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -18,6 +18,9 @@ type defString struct {
 	isNullable bool
 	buf        []byte
 	rlen       C.ACTUAL_LENGTH_TYPE
+	borrow     bool   // value returns a []byte of buf; see SBorrow
+	packed     string // value packed by Rset.packStrings, valid when isPacked
+	isPacked   bool
 }
 
 func (def *defString) define(position int, columnSize int, isNullable bool, rset *Rset) error {
@@ -45,35 +48,50 @@ func (def *defString) define(position int, columnSize int, isNullable bool, rset
 	}
 	// Create oci define handle
 	r := C.OCIDEFINEBYPOS(
-		def.rset.ocistmt,                 //OCIStmt     *stmtp,
-		&def.ocidef,                      //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(position),                  //ub4         position,
-		unsafe.Pointer(&def.buf[0]),      //void        *valuep,
-		C.LENGTH_TYPE(n),                 //sb8         value_sz,
-		C.SQLT_CHR,                       //ub2         dty,
-		unsafe.Pointer(&def.null),        //void        *indp,
-		&def.rlen,                        //ub2         *rlenp,
-		nil,                              //ub2         *rcodep,
-		C.OCI_DEFAULT)                    //ub4         mode );
+		def.rset.ocistmt,            //OCIStmt     *stmtp,
+		&def.ocidef,                 //OCIDefine   **defnpp,
+		def.rset.stmt.ses.ocierr,    //OCIError    *errhp,
+		C.ub4(position),             //ub4         position,
+		unsafe.Pointer(&def.buf[0]), //void        *valuep,
+		C.LENGTH_TYPE(n),            //sb8         value_sz,
+		C.SQLT_CHR,                  //ub2         dty,
+		unsafe.Pointer(&def.null),   //void        *indp,
+		&def.rlen,                   //ub2         *rlenp,
+		nil,                         //ub2         *rcodep,
+		C.OCI_DEFAULT)               //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
 
 func (def *defString) value() (value interface{}, err error) {
+	if def.borrow {
+		if def.null < C.sb2(0) {
+			return []byte(nil), nil
+		}
+		return def.buf[:int(def.rlen):int(def.rlen)], nil
+	}
 	if def.isNullable {
 		oraStringValue := String{IsNull: def.null < C.sb2(0)}
 		if !oraStringValue.IsNull {
-			oraStringValue.Value = string(def.buf[:int(def.rlen)])
+			oraStringValue.Value = def.str()
 		}
 		return oraStringValue, nil
 	}
 	if def.null < C.sb2(0) {
 		return "", nil
 	}
-	return string(def.buf[:int(def.rlen)]), nil
+	return def.str(), nil
+}
+
+// str returns the fetched value, packed by Rset.packStrings when possible.
+func (def *defString) str() string {
+	if def.isPacked {
+		def.isPacked = false
+		return def.packed
+	}
+	return string(def.buf[:int(def.rlen)])
 }
 
 func (def *defString) alloc() error {
@@ -94,7 +112,40 @@ func (def *defString) close() (err error) {
 	rset := def.rset
 	def.rset = nil
 	def.ocidef = nil
+	def.borrow = false
+	def.packed, def.isPacked = "", false
 	clear(def.buf, 32)
 	rset.putDef(defIdxString, def)
 	return nil
 }
+
+// packStrings converts the string values of the fetched row with a single
+// allocation: the values are copied into one buffer, converted to one string
+// and sliced, so a row of many string columns allocates once rather than once
+// per column. A value kept after the row keeps the string of the whole row
+// reachable. No locking occurs.
+func (rset *Rset) packStrings() {
+	buf := rset.strBuf[:0]
+	n := 0
+	for _, define := range rset.defs {
+		if def, ok := define.(*defString); ok {
+			def.isPacked = false
+			if !def.borrow && def.null >= 0 {
+				buf = append(buf, def.buf[:int(def.rlen)]...)
+				n++
+			}
+		}
+	}
+	rset.strBuf = buf
+	if n < 2 {
+		return // no allocation to save
+	}
+	row, off := string(buf), 0
+	for _, define := range rset.defs {
+		if def, ok := define.(*defString); ok && !def.borrow && def.null >= 0 {
+			end := off + int(def.rlen)
+			def.packed, def.isPacked = row[off:end], true
+			off = end
+		}
+	}
+}
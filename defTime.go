@@ -23,15 +23,21 @@ type defTime struct {
 	ociDateTime *C.OCIDateTime
 	null        C.sb2
 	isNullable  bool
+	location    *time.Location // location of DATE and TIMESTAMP values, or nil
 }
 
 func (def *defTime) define(position int, isNullable bool, rset *Rset) error {
 	def.rset = rset
 	def.isNullable = isNullable
+	if def.ociDateTime == nil {
+		if err := def.allocDescriptor(); err != nil {
+			return err
+		}
+	}
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                              //OCIStmt     *stmtp,
 		&def.ocidef,                                   //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,              //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,                      //OCIError    *errhp,
 		C.ub4(position),                               //ub4         position,
 		unsafe.Pointer(&def.ociDateTime),              //void        *valuep,
 		C.LENGTH_TYPE(unsafe.Sizeof(def.ociDateTime)), //sb8         value_sz,
@@ -41,7 +47,7 @@ func (def *defTime) define(position int, isNullable bool, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -50,16 +56,52 @@ func (def *defTime) value() (value interface{}, err error) {
 	if def.isNullable {
 		oraTimeValue := Time{IsNull: def.null < C.sb2(0)}
 		if !oraTimeValue.IsNull {
-			oraTimeValue.Value, err = getTime(def.rset.stmt.ses.srv.env, def.ociDateTime)
+			oraTimeValue.Value, err = def.time()
 		}
 		value = oraTimeValue
 	} else {
-		value, err = getTime(def.rset.stmt.ses.srv.env, def.ociDateTime)
+		value, err = def.time()
 	}
 	return value, err
 }
 
+// time returns the fetched value, in def.location when set.
+func (def *defTime) time() (time.Time, error) {
+	t, err := getTime(def.rset.stmt.ses.srv.env, def.rset.stmt.ses.ocierr, def.ociDateTime)
+	if err != nil || def.location == nil {
+		return t, err
+	}
+	return inLocation(t, def.location), nil
+}
+
+// inLocation returns the date and time of t in loc, keeping the wall clock.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// timeLocation returns the location of the values of the DATE or TIMESTAMP
+// select-list column named name, from TimeLocationCols or TimeLocation, or
+// nil.
+func (c *RsetCfg) timeLocation(name string) *time.Location {
+	if loc, ok := c.TimeLocationCols[name]; ok {
+		return loc
+	}
+	if loc, ok := c.TimeLocationCols[strings.ToUpper(name)]; ok {
+		return loc
+	}
+	return c.TimeLocation
+}
+
+// alloc is a no-op: the OCIDateTime descriptor of a defTime is allocated once
+// by define and reused by each fetch of the Rset, rather than allocated and
+// freed for each row. The Rset fetches one row per OCIStmtFetch2, so one
+// descriptor serves the fetch array.
 func (def *defTime) alloc() error {
+	return nil
+}
+
+// allocDescriptor allocates the OCIDateTime descriptor of the define.
+func (def *defTime) allocDescriptor() error {
 	r := C.OCIDescriptorAlloc(
 		unsafe.Pointer(def.rset.stmt.ses.srv.env.ocienv),    //CONST dvoid   *parenth,
 		(*unsafe.Pointer)(unsafe.Pointer(&def.ociDateTime)), //dvoid         **descpp,
@@ -67,7 +109,7 @@ func (def *defTime) alloc() error {
 		0,   //size_t        xtramem_sz,
 		nil) //dvoid         **usrmempp);
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	} else if r == C.OCI_INVALID_HANDLE {
 		return errNew("unable to allocate oci timestamp handle during define")
 	}
@@ -75,13 +117,22 @@ func (def *defTime) alloc() error {
 
 }
 
+// free is a no-op; the descriptor is freed by close.
 func (def *defTime) free() {
+}
+
+// freeDescriptor frees the OCIDateTime descriptor of the define.
+func (def *defTime) freeDescriptor() {
 	defer func() {
 		recover()
 	}()
+	if def.ociDateTime == nil {
+		return
+	}
 	C.OCIDescriptorFree(
 		unsafe.Pointer(def.ociDateTime), //void     *descp,
 		C.OCI_DTYPE_TIMESTAMP_TZ)        //ub4      type );
+	def.ociDateTime = nil
 }
 
 func (def *defTime) close() (err error) {
@@ -92,13 +143,15 @@ func (def *defTime) close() (err error) {
 	}()
 
 	rset := def.rset
+	def.freeDescriptor()
 	def.rset = nil
 	def.ocidef = nil
+	def.location = nil
 	rset.putDef(defIdxTime, def)
 	return nil
 }
 
-func getTime(env *Env, ociDateTime *C.OCIDateTime) (result time.Time, err error) {
+func getTime(env *Env, ocierr *C.OCIError, ociDateTime *C.OCIDateTime) (result time.Time, err error) {
 	var year C.sb2
 	var month C.ub1
 	var day C.ub1
@@ -109,30 +162,30 @@ func getTime(env *Env, ociDateTime *C.OCIDateTime) (result time.Time, err error)
 	var location *time.Location
 	r := C.OCIDateTimeGetDate(
 		unsafe.Pointer(env.ocienv), //void               *hndl,
-		env.ocierr,                 //OCIError           *err,
+		ocierr,                     //OCIError           *err,
 		ociDateTime,                //const OCIDateTime  *datetime,
 		&year,                      //sb2                *year,
 		&month,                     //ub1                *month,
 		&day)                       //ub1                *day );
 	if r == C.OCI_ERROR {
-		return result, env.ociError()
+		return result, ociErrorGet(ocierr)
 	}
 	r = C.OCIDateTimeGetTime(
 		unsafe.Pointer(env.ocienv), //void               *hndl,
-		env.ocierr,                 //OCIError           *err,
+		ocierr,                     //OCIError           *err,
 		ociDateTime,                //OCIDateTime  *datetime,
 		&hour,                      //ub1           *hour,
 		&minute,                    //ub1           *min,
 		&second,                    //ub1           *sec,
 		&fsec)                      //ub4           *fsec );
 	if r == C.OCI_ERROR {
-		return result, env.ociError()
+		return result, ociErrorGet(ocierr)
 	}
 	var buf [32]byte
 	var buflen C.ub4 = 32
 	r = C.OCIDateTimeGetTimeZoneName(
 		unsafe.Pointer(env.ocienv), //void               *hndl,
-		env.ocierr,                 //OCIError           *err,
+		ocierr,                     //OCIError           *err,
 		ociDateTime,                //const OCIDateTime  *datetime,
 		(*C.ub1)(&buf[0]),          //ub1                *buf,
 		&buflen)                    //ub4                *buflen, );
@@ -152,12 +205,12 @@ func getTime(env *Env, ociDateTime *C.OCIDateTime) (result time.Time, err error)
 			if strings.ContainsAny(locName, "-0123456789") {
 				r = C.OCIDateTimeGetTimeZoneOffset(
 					unsafe.Pointer(env.ocienv), //void               *hndl,
-					env.ocierr,                 //OCIError           *err,
+					ocierr,                     //OCIError           *err,
 					ociDateTime,                //const OCIDateTime  *datetime,
 					&offsetHour,                //sb1                *hour,
 					&offsetMinute)              //sb1                *min, );
 				if r == C.OCI_ERROR {
-					return result, env.ociError()
+					return result, ociErrorGet(ocierr)
 				}
 				seconds := math.Abs(float64(offsetHour)) * 60 * 60
 				seconds += math.Abs(float64(offsetMinute)) * 60
@@ -27,7 +27,7 @@ func (def *defUint32) define(position int, isNullable bool, rset *Rset) error {
 	r := C.OCIDEFINEBYPOS(
 		def.rset.ocistmt,                  //OCIStmt     *stmtp,
 		&def.ocidef,                       //OCIDefine   **defnpp,
-		def.rset.stmt.ses.srv.env.ocierr,  //OCIError    *errhp,
+		def.rset.stmt.ses.ocierr,          //OCIError    *errhp,
 		C.ub4(position),                   //ub4         position,
 		unsafe.Pointer(&def.ociNumber),    //void        *valuep,
 		C.LENGTH_TYPE(C.sizeof_OCINumber), //sb8         value_sz,
@@ -37,7 +37,7 @@ func (def *defUint32) define(position int, isNullable bool, rset *Rset) error {
 		nil,           //ub2         *rcodep,
 		C.OCI_DEFAULT) //ub4         mode );
 	if r == C.OCI_ERROR {
-		return def.rset.stmt.ses.srv.env.ociError()
+		return def.rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -46,28 +46,20 @@ func (def *defUint32) value() (value interface{}, err error) {
 	if def.isNullable {
 		oraUint32Value := Uint32{IsNull: def.null < C.sb2(0)}
 		if !oraUint32Value.IsNull {
-			r := C.OCINumberToInt(
-				def.rset.stmt.ses.srv.env.ocierr,      //OCIError              *err,
-				&def.ociNumber,                        //const OCINumber       *number,
-				C.uword(4),                            //uword                 rsl_length,
-				C.OCI_NUMBER_UNSIGNED,                 //uword                 rsl_flag,
-				unsafe.Pointer(&oraUint32Value.Value)) //void                  *rsl );
-			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+			var over interface{}
+			over, err = def.rset.numberToInt(&def.ociNumber, 4, false, true, unsafe.Pointer(&oraUint32Value.Value))
+			if over != nil {
+				return over, nil
 			}
 		}
 		value = oraUint32Value
 	} else {
 		if def.null > C.sb2(-1) {
 			var uint32Value uint32
-			r := C.OCINumberToInt(
-				def.rset.stmt.ses.srv.env.ocierr, //OCIError              *err,
-				&def.ociNumber,                   //const OCINumber       *number,
-				C.uword(4),                       //uword                 rsl_length,
-				C.OCI_NUMBER_UNSIGNED,            //uword                 rsl_flag,
-				unsafe.Pointer(&uint32Value))     //void                  *rsl );
-			if r == C.OCI_ERROR {
-				err = def.rset.stmt.ses.srv.env.ociError()
+			var over interface{}
+			over, err = def.rset.numberToInt(&def.ociNumber, 4, false, false, unsafe.Pointer(&uint32Value))
+			if over != nil {
+				return over, nil
 			}
 			value = uint32Value
 		}
@@ -0,0 +1,166 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include "version.h"
+*/
+import "C"
+import "unsafe"
+
+// ColumnInfo describes a select-list column.
+type ColumnInfo struct {
+	// Name is the column name or alias.
+	Name string
+	// Type is the Oracle type name, such as VARCHAR2 or NUMBER.
+	Type string
+	// Size is the maximum size of the column in bytes.
+	Size int
+	// Precision and Scale describe a NUMBER column; both are zero otherwise.
+	Precision int
+	Scale     int
+	// Nullable is true when the column may be null.
+	Nullable bool
+}
+
+// Description describes a SQL statement without executing it.
+type Description struct {
+	// Columns describe the select-list of a query; it is empty for other
+	// statements.
+	Columns []ColumnInfo
+	// BindNames are the distinct placeholder names, without the leading colon,
+	// in order of first appearance.
+	BindNames []string
+	// NumBinds is the number of placeholders, counting repeated names.
+	NumBinds int
+}
+
+// Describe returns the select-list columns and placeholders of the Stmt
+// without executing it.
+//
+// The select-list of a query is described by the Oracle server in
+// describe-only mode; no rows are fetched and the Stmt may still be executed.
+func (stmt *Stmt) Describe() (desc Description, err error) {
+	stmt.race.enter("Stmt", stmt, "Describe")
+	defer stmt.race.leave()
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.log(_drv.cfg().Log.Stmt.Describe)
+	err = stmt.checkClosed()
+	if err != nil {
+		return desc, errE(err)
+	}
+	err = stmt.prepare()
+	if err != nil {
+		return desc, errE(err)
+	}
+	return stmt.describe()
+}
+
+// describe describes the prepared Stmt. No locking occurs.
+func (stmt *Stmt) describe() (desc Description, err error) {
+	names, err := stmt.bindNames()
+	if err != nil {
+		return desc, errE(err)
+	}
+	desc.NumBinds = len(names)
+	for _, name := range names {
+		if !name.Duplicate {
+			desc.BindNames = append(desc.BindNames, name.Name)
+		}
+	}
+	if stmt.stmtType != C.OCI_STMT_SELECT {
+		return desc, nil
+	}
+	r := stmt.ses.poll(nil, func() C.sword {
+		return C.OCIStmtExecute(
+			stmt.ses.ocisvcctx,  //OCISvcCtx           *svchp,
+			stmt.ocistmt,        //OCIStmt             *stmtp,
+			stmt.ses.ocierr,     //OCIError            *errhp,
+			C.ub4(0),            //ub4                 iters,
+			C.ub4(0),            //ub4                 rowoff,
+			nil,                 //const OCISnapshot   *snap_in,
+			nil,                 //OCISnapshot         *snap_out,
+			C.OCI_DESCRIBE_ONLY) //ub4                 mode );
+	})
+	if r == C.OCI_ERROR {
+		return desc, errE(stmt.exeError())
+	}
+	var paramCount C.ub4
+	err = stmt.attr(unsafe.Pointer(&paramCount), 4, C.OCI_ATTR_PARAM_COUNT)
+	if err != nil {
+		return desc, errE(err)
+	}
+	cols, err := stmt.ses.describeCols(stmt.ocistmt, int(paramCount))
+	if err != nil {
+		return desc, errE(err)
+	}
+	desc.Columns = make([]ColumnInfo, len(cols))
+	for n, col := range cols {
+		desc.Columns[n] = col.columnInfo()
+	}
+	return desc, nil
+}
+
+// columnInfo returns the ColumnInfo of col.
+func (col *colAttrs) columnInfo() ColumnInfo {
+	info := ColumnInfo{
+		Name:     col.name,
+		Type:     sqltName(col.dataType),
+		Size:     int(col.dataSize),
+		Nullable: col.isNull,
+	}
+	if col.dataType == C.SQLT_NUM {
+		info.Precision = int(col.precision)
+		info.Scale = int(col.scale)
+	}
+	return info
+}
+
+// sqltName returns the Oracle type name of a describe data type code.
+func sqltName(code C.ub2) string {
+	switch code {
+	case C.SQLT_CHR:
+		return "VARCHAR2"
+	case C.SQLT_AFC:
+		return "CHAR"
+	case C.SQLT_NUM:
+		return "NUMBER"
+	case C.SQLT_IBFLOAT:
+		return "BINARY_FLOAT"
+	case C.SQLT_IBDOUBLE:
+		return "BINARY_DOUBLE"
+	case C.SQLT_DAT:
+		return "DATE"
+	case C.SQLT_TIMESTAMP:
+		return "TIMESTAMP"
+	case C.SQLT_TIMESTAMP_TZ:
+		return "TIMESTAMP WITH TIME ZONE"
+	case C.SQLT_TIMESTAMP_LTZ:
+		return "TIMESTAMP WITH LOCAL TIME ZONE"
+	case C.SQLT_INTERVAL_YM:
+		return "INTERVAL YEAR TO MONTH"
+	case C.SQLT_INTERVAL_DS:
+		return "INTERVAL DAY TO SECOND"
+	case C.SQLT_LNG:
+		return "LONG"
+	case C.SQLT_BIN:
+		return "RAW"
+	case C.SQLT_LBI:
+		return "LONG RAW"
+	case C.SQLT_CLOB:
+		return "CLOB"
+	case C.SQLT_BLOB:
+		return "BLOB"
+	case C.SQLT_JSON:
+		return "JSON"
+	case C.SQLT_FILE:
+		return "BFILE"
+	case C.SQLT_RDD:
+		return "ROWID"
+	}
+	return "UNKNOWN"
+}
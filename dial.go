@@ -0,0 +1,187 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DialFunc establishes a connection to address, a host:port of an Oracle
+// listener, on the named network. net.Dial is a DialFunc; a DialFunc may
+// connect through a SOCKS proxy or an SSH tunnel.
+type DialFunc func(network, address string) (net.Conn, error)
+
+// descriptorAddress matches the HOST and PORT of the first ADDRESS of a
+// connect descriptor.
+var descriptorAddress = regexp.MustCompile(`(?i)\(\s*HOST\s*=\s*([^)\s]+)\s*\)\s*\(\s*PORT\s*=\s*(\d+)\s*\)`)
+
+// ResolveDblink returns the host:port of the listener of dblink, an easy
+// connect string such as "db.example.com:1521/orcl" or a connect descriptor,
+// and a function returning dblink with the listener replaced by another
+// host:port. A net service name defined in tnsnames.ora isn't resolved, and an
+// error is returned.
+func ResolveDblink(dblink string) (address string, replace func(address string) string, err error) {
+	dblink = strings.TrimSpace(dblink)
+	if strings.HasPrefix(dblink, "(") {
+		loc := descriptorAddress.FindStringSubmatchIndex(dblink)
+		if loc == nil {
+			return "", nil, errF("Connect descriptor %q has no HOST and PORT.", dblink)
+		}
+		address = net.JoinHostPort(dblink[loc[2]:loc[3]], dblink[loc[4]:loc[5]])
+		replace = func(address string) string {
+			host, port, _ := net.SplitHostPort(address)
+			return dblink[:loc[0]] + "(HOST=" + host + ")(PORT=" + port + ")" + dblink[loc[1]:]
+		}
+		return address, replace, nil
+	}
+	address, replace, ok := resolveEasyConnect(dblink)
+	if !ok {
+		return "", nil, errF("Dblink %q has no listener address; use an easy connect string or a connect descriptor.", dblink)
+	}
+	return address, replace, nil
+}
+
+// resolveEasyConnect returns the host:port of the listener of an easy connect
+// string, and a function returning dblink with the listener replaced.
+func resolveEasyConnect(dblink string) (address string, replace func(address string) string, ok bool) {
+	rest := dblink
+	for _, scheme := range []string{"tcp://", "tcps://", "//"} {
+		if len(rest) >= len(scheme) && strings.EqualFold(rest[:len(scheme)], scheme) {
+			rest = rest[len(scheme):]
+			break
+		}
+	}
+	end := len(rest)
+	if n := strings.IndexByte(rest, '/'); n >= 0 {
+		end = n
+	}
+	hostPort := rest[:end]
+	if hostPort == "" || end == len(rest) && !strings.Contains(hostPort, ":") {
+		return "", nil, false
+	}
+	host, port := hostPort, "1521"
+	if strings.HasPrefix(hostPort, "[") || strings.Count(hostPort, ":") == 1 {
+		var err error
+		if host, port, err = net.SplitHostPort(hostPort); err != nil {
+			return "", nil, false
+		}
+	}
+	prefix := dblink[:len(dblink)-len(rest)]
+	suffix := rest[end:]
+	replace = func(address string) string {
+		return prefix + address + suffix
+	}
+	return net.JoinHostPort(host, port), replace, true
+}
+
+// tunnel forwards the connections accepted on a loopback listener to an
+// address with a DialFunc, so that OCI connects through the DialFunc.
+type tunnel struct {
+	ln      net.Listener
+	address string
+	dial    DialFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+}
+
+// openTunnel starts a tunnel to address.
+func openTunnel(address string, dial DialFunc) (*tunnel, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	t := &tunnel{ln: ln, address: address, dial: dial, conns: make(map[net.Conn]struct{})}
+	t.wg.Add(1)
+	go t.accept()
+	return t, nil
+}
+
+// localAddress returns the host:port of the loopback listener.
+func (t *tunnel) localAddress() string {
+	return t.ln.Addr().String()
+}
+
+func (t *tunnel) accept() {
+	defer t.wg.Done()
+	for {
+		local, err := t.ln.Accept()
+		if err != nil {
+			return
+		}
+		t.wg.Add(1)
+		go t.forward(local)
+	}
+}
+
+// forward copies data between local and a connection to the address until
+// either side closes.
+func (t *tunnel) forward(local net.Conn) {
+	defer t.wg.Done()
+	remote, err := t.dial("tcp", t.address)
+	if err != nil {
+		lgr.Errorf("tunnel to %v: %v", t.address, err)
+		local.Close()
+		return
+	}
+	if !t.track(local, remote) {
+		local.Close()
+		remote.Close()
+		return
+	}
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+	local.Close()
+	remote.Close()
+	<-done
+	t.untrack(local, remote)
+}
+
+// track registers open connections, returning false once the tunnel is
+// closed.
+func (t *tunnel) track(conns ...net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conns == nil {
+		return false
+	}
+	for _, c := range conns {
+		t.conns[c] = struct{}{}
+	}
+	return true
+}
+
+func (t *tunnel) untrack(conns ...net.Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range conns {
+		delete(t.conns, c)
+	}
+}
+
+// close stops the listener and closes the forwarded connections.
+func (t *tunnel) close() error {
+	err := t.ln.Close()
+	t.mu.Lock()
+	for c := range t.conns {
+		c.Close()
+	}
+	t.conns = nil
+	t.mu.Unlock()
+	t.wg.Wait()
+	return err
+}
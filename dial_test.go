@@ -0,0 +1,85 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bufio"
+	"net"
+	"testing"
+)
+
+// TestResolveDblink tests ResolveDblink.
+func TestResolveDblink(t *testing.T) {
+	for _, tc := range []struct {
+		dblink, address, replaced string
+	}{
+		{"db.example.com:1522/orcl", "db.example.com:1522", "127.0.0.1:9/orcl"},
+		{"//db.example.com/orcl", "db.example.com:1521", "//127.0.0.1:9/orcl"},
+		{"[::1]:1521/orcl", "[::1]:1521", "127.0.0.1:9/orcl"},
+		{"tcps://db:2484/orcl", "db:2484", "tcps://127.0.0.1:9/orcl"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db)(PORT=1523))(CONNECT_DATA=(SERVICE_NAME=orcl)))",
+			"db:1523",
+			"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=127.0.0.1)(PORT=9))(CONNECT_DATA=(SERVICE_NAME=orcl)))"},
+	} {
+		address, replace, err := ResolveDblink(tc.dblink)
+		if err != nil {
+			t.Errorf("%q: %v", tc.dblink, err)
+			continue
+		}
+		if address != tc.address {
+			t.Errorf("%q: got address %q, wanted %q", tc.dblink, address, tc.address)
+		}
+		if got := replace("127.0.0.1:9"); got != tc.replaced {
+			t.Errorf("%q: got %q, wanted %q", tc.dblink, got, tc.replaced)
+		}
+	}
+	for _, dblink := range []string{"orcl", "(DESCRIPTION=(ADDRESS_LIST=))"} {
+		if _, _, err := ResolveDblink(dblink); err == nil {
+			t.Errorf("%q: wanted an error", dblink)
+		}
+	}
+}
+
+// TestTunnel tests that a tunnel forwards connections through its DialFunc.
+func TestTunnel(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		line, _ := bufio.NewReader(c).ReadString('\n')
+		c.Write([]byte("echo " + line))
+	}()
+	var dialed string
+	tun, err := openTunnel(ln.Addr().String(), func(network, address string) (net.Conn, error) {
+		dialed = address
+		return net.Dial(network, address)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := net.Dial("tcp", tun.localAddress())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Write([]byte("ping\n"))
+	got, err := bufio.NewReader(c).ReadString('\n')
+	c.Close()
+	if err != nil || got != "echo ping\n" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if err = tun.close(); err != nil {
+		t.Error(err)
+	}
+	if dialed != ln.Addr().String() {
+		t.Errorf("dialed %q, wanted %q", dialed, ln.Addr().String())
+	}
+}
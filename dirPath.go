@@ -0,0 +1,415 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+import (
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// DirPathCol describes a column loaded by a DirPathLoader.
+type DirPathCol struct {
+	// Name is the column name.
+	Name string
+
+	// Size is the maximum size, in bytes, of the text form of a value.
+	//
+	// The default is zero, which uses 4000.
+	Size int
+
+	// DateFormat is the Oracle datetime format model of the column's values,
+	// such as "YYYY-MM-DD HH24:MI:SS.FF". The values of a column with a
+	// DateFormat are strings in the format; DirPathLoader.Append returns an
+	// error for a time.Time value, which is formatted in the DirPathLoader's
+	// format.
+	//
+	// The default is empty, which uses the DirPathLoader's format,
+	// "YYYY-MM-DD HH24:MI:SS".
+	DateFormat string
+}
+
+// DirPathCfg configures a DirPathLoader.
+type DirPathCfg struct {
+	// Table is the name of the table loaded.
+	Table string
+
+	// Schema is the schema of the table.
+	//
+	// The default is empty, which uses the session's schema.
+	Schema string
+
+	// Partition is the name of the partition or subpartition loaded.
+	//
+	// The default is empty, which loads all partitions.
+	Partition string
+
+	// Cols are the loaded columns in the order of values passed to
+	// DirPathLoader.Append.
+	Cols []DirPathCol
+
+	// SaveRows is the number of rows loaded between data save points.
+	//
+	// The default is zero, which disables periodic save points.
+	SaveRows int
+}
+
+// dirPathDateFormat is the default datetime format of a DirPathLoader; time.Time
+// values are formatted with dirPathTimeLayout.
+const (
+	dirPathDateFormat = "YYYY-MM-DD HH24:MI:SS"
+	dirPathTimeLayout = "2006-01-02 15:04:05"
+	dirPathColSize    = 4000
+)
+
+// DirPathLoader loads rows into a table with the Oracle direct path API,
+// bypassing SQL processing.
+//
+// Rows passed to Append are buffered in a column array and converted to a
+// direct path stream when the array is full or Flush is called. Finish
+// completes the load; Abort discards it. A DirPathLoader is not safe for
+// concurrent use, and the Ses should not be used for other calls while the
+// load is in progress.
+type DirPathLoader struct {
+	ses     *Ses
+	cfg     DirPathCfg
+	dpctx   *C.OCIDirPathCtx
+	dpca    *C.OCIDirPathColArray
+	dpstr   *C.OCIDirPathStream
+	cStrs   []*C.char
+	bufs    []*C.ub1
+	sizes   []int
+	maxRows int
+	n       int    // buffered rows
+	rows    uint64 // loaded rows
+	unsaved int    // rows loaded since the last save point
+}
+
+// OpenDirPath prepares a direct path load of a table.
+func (ses *Ses) OpenDirPath(cfg DirPathCfg) (dp *DirPathLoader, err error) {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	ses.log(_drv.cfg().Log.Ses.OpenDirPath)
+	if err = ses.checkClosed(); err != nil {
+		return nil, errE(err)
+	}
+	if cfg.Table == "" || len(cfg.Cols) == 0 {
+		return nil, er("DirPathCfg requires a Table and Cols.")
+	}
+	env := ses.srv.env
+	dp = &DirPathLoader{ses: ses, cfg: cfg}
+	defer func() {
+		if err != nil {
+			dp.free()
+		}
+	}()
+	h, err := env.allocOciHandle(C.OCI_HTYPE_DIRPATH_CTX)
+	if err != nil {
+		return nil, errE(err)
+	}
+	dp.dpctx = (*C.OCIDirPathCtx)(h)
+	ctx := unsafe.Pointer(dp.dpctx)
+	if err = dp.setStrAttr(ctx, C.OCI_HTYPE_DIRPATH_CTX, cfg.Table, C.OCI_ATTR_NAME); err != nil {
+		return nil, errE(err)
+	}
+	if cfg.Schema != "" {
+		if err = dp.setStrAttr(ctx, C.OCI_HTYPE_DIRPATH_CTX, cfg.Schema, C.OCI_ATTR_SCHEMA_NAME); err != nil {
+			return nil, errE(err)
+		}
+	}
+	if cfg.Partition != "" {
+		if err = dp.setStrAttr(ctx, C.OCI_HTYPE_DIRPATH_CTX, cfg.Partition, C.OCI_ATTR_SUB_NAME); err != nil {
+			return nil, errE(err)
+		}
+	}
+	if err = dp.setStrAttr(ctx, C.OCI_HTYPE_DIRPATH_CTX, dirPathDateFormat, C.OCI_ATTR_DATEFORMAT); err != nil {
+		return nil, errE(err)
+	}
+	numCols := C.ub2(len(cfg.Cols))
+	if err = ses.setAttr(ctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(&numCols), 0, C.OCI_ATTR_NUM_COLS); err != nil {
+		return nil, errE(err)
+	}
+	// describe the columns
+	var colList unsafe.Pointer
+	r := C.OCIAttrGet(ctx, C.OCI_HTYPE_DIRPATH_CTX, unsafe.Pointer(&colList), nil, C.OCI_ATTR_LIST_COLUMNS, ses.ocierr)
+	if r == C.OCI_ERROR {
+		return nil, errE(ses.ociError())
+	}
+	dp.sizes = make([]int, len(cfg.Cols))
+	for n, col := range cfg.Cols {
+		var param unsafe.Pointer
+		r = C.OCIParamGet(colList, C.OCI_DTYPE_PARAM, ses.ocierr, &param, C.ub4(n+1))
+		if r == C.OCI_ERROR {
+			return nil, errE(ses.ociError())
+		}
+		dp.sizes[n] = col.Size
+		if dp.sizes[n] <= 0 {
+			dp.sizes[n] = dirPathColSize
+		}
+		dty, size := C.ub2(C.SQLT_CHR), C.ub4(dp.sizes[n])
+		err = dp.setStrAttr(param, C.OCI_DTYPE_PARAM, col.Name, C.OCI_ATTR_NAME)
+		if err == nil {
+			err = ses.setAttr(param, C.OCI_DTYPE_PARAM, unsafe.Pointer(&dty), 0, C.OCI_ATTR_DATA_TYPE)
+		}
+		if err == nil {
+			err = ses.setAttr(param, C.OCI_DTYPE_PARAM, unsafe.Pointer(&size), 0, C.OCI_ATTR_DATA_SIZE)
+		}
+		if err == nil && col.DateFormat != "" {
+			err = dp.setStrAttr(param, C.OCI_DTYPE_PARAM, col.DateFormat, C.OCI_ATTR_DATEFORMAT)
+		}
+		C.OCIDescriptorFree(param, C.OCI_DTYPE_PARAM)
+		if err != nil {
+			return nil, errE(err)
+		}
+	}
+	r = ses.poll(nil, func() C.sword {
+		return C.OCIDirPathPrepare(dp.dpctx, ses.ocisvcctx, ses.ocierr)
+	})
+	if r == C.OCI_ERROR {
+		return nil, errE(ses.ociError())
+	}
+	// column array and stream are children of the direct path context
+	r = C.OCIHandleAlloc(ctx, &h, C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, 0, nil)
+	if r != C.OCI_SUCCESS {
+		return nil, er("Unable to allocate direct path column array.")
+	}
+	dp.dpca = (*C.OCIDirPathColArray)(h)
+	r = C.OCIHandleAlloc(ctx, &h, C.OCI_HTYPE_DIRPATH_STREAM, 0, nil)
+	if r != C.OCI_SUCCESS {
+		return nil, er("Unable to allocate direct path stream.")
+	}
+	dp.dpstr = (*C.OCIDirPathStream)(h)
+	var maxRows C.ub4
+	r = C.OCIAttrGet(unsafe.Pointer(dp.dpca), C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, unsafe.Pointer(&maxRows), nil, C.OCI_ATTR_NUM_ROWS, ses.ocierr)
+	if r == C.OCI_ERROR {
+		return nil, errE(ses.ociError())
+	}
+	dp.maxRows = int(maxRows)
+	dp.bufs = make([]*C.ub1, len(cfg.Cols))
+	for n := range dp.bufs {
+		dp.bufs[n] = (*C.ub1)(C.malloc(C.size_t(dp.maxRows * dp.sizes[n])))
+	}
+	return dp, nil
+}
+
+// setStrAttr sets a string attribute, keeping the C string until the loader is freed.
+func (dp *DirPathLoader) setStrAttr(target unsafe.Pointer, targetType C.ub4, value string, attrType C.ub4) error {
+	cs := C.CString(value)
+	dp.cStrs = append(dp.cStrs, cs)
+	return dp.ses.setAttr(target, targetType, unsafe.Pointer(cs), C.ub4(len(value)), attrType)
+}
+
+// Append buffers a row, loading the buffered rows when the column array is
+// full. Each value is a string, []byte, integer, floating-point number, bool,
+// time.Time or nil for NULL, in the order of DirPathCfg.Cols.
+func (dp *DirPathLoader) Append(values ...interface{}) error {
+	if dp.dpctx == nil {
+		return er("DirPathLoader is closed.")
+	}
+	if len(values) != len(dp.cfg.Cols) {
+		return errF("DirPathLoader.Append received %d values for %d columns.", len(values), len(dp.cfg.Cols))
+	}
+	for n, value := range values {
+		if err := checkDirPathValue(dp.cfg.Cols[n], value); err != nil {
+			return errE(err)
+		}
+		text, null, err := dirPathText(value)
+		if err != nil {
+			return errE(err)
+		}
+		if len(text) > dp.sizes[n] {
+			return errF("DirPathCol %v value of %d bytes exceeds Size %d.", dp.cfg.Cols[n].Name, len(text), dp.sizes[n])
+		}
+		p := (*C.ub1)(unsafe.Pointer(uintptr(unsafe.Pointer(dp.bufs[n])) + uintptr(dp.n*dp.sizes[n])))
+		flag := C.ub1(C.OCI_DIRPATH_COL_COMPLETE)
+		if null {
+			flag = C.OCI_DIRPATH_COL_NULL
+		} else if len(text) > 0 {
+			C.memcpy(unsafe.Pointer(p), unsafe.Pointer(&text[0]), C.size_t(len(text)))
+		}
+		r := C.OCIDirPathColArrayEntrySet(dp.dpca, dp.ses.ocierr, C.ub4(dp.n), C.ub2(n), p, C.ub4(len(text)), flag)
+		if r == C.OCI_ERROR {
+			return errE(dp.ses.ociError())
+		}
+	}
+	if dp.n++; dp.n == dp.maxRows {
+		return dp.Flush()
+	}
+	return nil
+}
+
+// Flush converts the buffered rows to a stream and loads the stream, taking a
+// data save point when DirPathCfg.SaveRows rows have been loaded.
+func (dp *DirPathLoader) Flush() error {
+	if dp.dpctx == nil {
+		return er("DirPathLoader is closed.")
+	}
+	for rowOff := 0; rowOff < dp.n; {
+		r := C.OCIDirPathColArrayToStream(dp.dpca, dp.dpctx, dp.dpstr, dp.ses.ocierr, C.ub4(dp.n), C.ub4(rowOff))
+		if r != C.OCI_SUCCESS && r != C.OCI_CONTINUE {
+			return errE(dp.ses.ociError())
+		}
+		var converted C.ub4
+		if C.OCIAttrGet(unsafe.Pointer(dp.dpca), C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY, unsafe.Pointer(&converted), nil, C.OCI_ATTR_ROW_COUNT, dp.ses.ocierr) == C.OCI_ERROR {
+			return errE(dp.ses.ociError())
+		}
+		if r = dp.ses.poll(nil, func() C.sword {
+			return C.OCIDirPathLoadStream(dp.dpctx, dp.dpstr, dp.ses.ocierr)
+		}); r == C.OCI_ERROR {
+			return errE(dp.ses.ociError())
+		}
+		if C.OCIDirPathStreamReset(dp.dpstr, dp.ses.ocierr) == C.OCI_ERROR {
+			return errE(dp.ses.ociError())
+		}
+		rowOff += int(converted)
+	}
+	if C.OCIDirPathColArrayReset(dp.dpca, dp.ses.ocierr) == C.OCI_ERROR {
+		return errE(dp.ses.ociError())
+	}
+	dp.rows += uint64(dp.n)
+	dp.unsaved += dp.n
+	dp.n = 0
+	if dp.cfg.SaveRows > 0 && dp.unsaved >= dp.cfg.SaveRows {
+		return dp.Save()
+	}
+	return nil
+}
+
+// Save takes a data save point; rows loaded before the save point are kept
+// even if the load is later aborted.
+func (dp *DirPathLoader) Save() error {
+	if dp.dpctx == nil {
+		return er("DirPathLoader is closed.")
+	}
+	if r := dp.ses.poll(nil, func() C.sword {
+		return C.OCIDirPathDataSave(dp.dpctx, dp.ses.ocierr, C.OCI_DIRPATH_DATASAVE_SAVEONLY)
+	}); r == C.OCI_ERROR {
+		return errE(dp.ses.ociError())
+	}
+	dp.unsaved = 0
+	return nil
+}
+
+// Rows returns the number of rows loaded, excluding buffered rows.
+func (dp *DirPathLoader) Rows() uint64 {
+	return dp.rows
+}
+
+// Finish loads any buffered rows and completes the load, committing the
+// loaded data. The DirPathLoader is closed.
+func (dp *DirPathLoader) Finish() (err error) {
+	if dp.dpctx == nil {
+		return er("DirPathLoader is closed.")
+	}
+	defer dp.free()
+	if err = dp.Flush(); err != nil {
+		return err
+	}
+	if r := dp.ses.poll(nil, func() C.sword {
+		return C.OCIDirPathFinish(dp.dpctx, dp.ses.ocierr)
+	}); r == C.OCI_ERROR {
+		return errE(dp.ses.ociError())
+	}
+	return nil
+}
+
+// Abort discards rows loaded since the last save point and closes the
+// DirPathLoader.
+func (dp *DirPathLoader) Abort() error {
+	if dp.dpctx == nil {
+		return nil
+	}
+	defer dp.free()
+	if r := dp.ses.poll(nil, func() C.sword {
+		return C.OCIDirPathAbort(dp.dpctx, dp.ses.ocierr)
+	}); r == C.OCI_ERROR {
+		return errE(dp.ses.ociError())
+	}
+	return nil
+}
+
+// free releases the handles and buffers of the DirPathLoader.
+func (dp *DirPathLoader) free() {
+	if dp.dpstr != nil {
+		C.OCIHandleFree(unsafe.Pointer(dp.dpstr), C.OCI_HTYPE_DIRPATH_STREAM)
+		dp.dpstr = nil
+	}
+	if dp.dpca != nil {
+		C.OCIHandleFree(unsafe.Pointer(dp.dpca), C.OCI_HTYPE_DIRPATH_COLUMN_ARRAY)
+		dp.dpca = nil
+	}
+	if dp.dpctx != nil {
+		dp.ses.srv.env.freeOciHandle(unsafe.Pointer(dp.dpctx), C.OCI_HTYPE_DIRPATH_CTX)
+		dp.dpctx = nil
+	}
+	for _, buf := range dp.bufs {
+		C.free(unsafe.Pointer(buf))
+	}
+	dp.bufs = nil
+	for _, cs := range dp.cStrs {
+		C.free(unsafe.Pointer(cs))
+	}
+	dp.cStrs = nil
+}
+
+// checkDirPathValue returns an error when value can't be loaded into col: a
+// time.Time is formatted in the DirPathLoader's format, which a column with a
+// DateFormat doesn't accept.
+func checkDirPathValue(col DirPathCol, value interface{}) error {
+	if _, ok := value.(time.Time); ok && col.DateFormat != "" {
+		return errF("DirPathCol %v has a DateFormat; pass its values as strings in the format rather than time.Time.", col.Name)
+	}
+	return nil
+}
+
+// dirPathText returns the text form of a direct path value.
+func dirPathText(value interface{}) (text []byte, null bool, err error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true, nil
+	case string:
+		return []byte(v), false, nil
+	case []byte:
+		return v, v == nil, nil
+	case int64:
+		return strconv.AppendInt(nil, v, 10), false, nil
+	case int32:
+		return strconv.AppendInt(nil, int64(v), 10), false, nil
+	case int16:
+		return strconv.AppendInt(nil, int64(v), 10), false, nil
+	case int8:
+		return strconv.AppendInt(nil, int64(v), 10), false, nil
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), false, nil
+	case uint64:
+		return strconv.AppendUint(nil, v, 10), false, nil
+	case uint32:
+		return strconv.AppendUint(nil, uint64(v), 10), false, nil
+	case uint16:
+		return strconv.AppendUint(nil, uint64(v), 10), false, nil
+	case uint8:
+		return strconv.AppendUint(nil, uint64(v), 10), false, nil
+	case uint:
+		return strconv.AppendUint(nil, uint64(v), 10), false, nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), false, nil
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'f', -1, 32), false, nil
+	case bool:
+		if v {
+			return []byte{'1'}, false, nil
+		}
+		return []byte{'0'}, false, nil
+	case time.Time:
+		return []byte(v.Format(dirPathTimeLayout)), false, nil
+	}
+	return nil, false, errF("Unsupported direct path value type %T.", value)
+}
@@ -0,0 +1,47 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDirPathValue tests checkDirPathValue and dirPathText.
+func TestDirPathValue(t *testing.T) {
+	at := time.Date(2015, 6, 7, 8, 9, 10, 0, time.UTC)
+	plain := DirPathCol{Name: "AT"}
+	formatted := DirPathCol{Name: "AT", DateFormat: "YYYY-MM-DD HH24:MI:SS.FF"}
+	if err := checkDirPathValue(plain, at); err != nil {
+		t.Errorf("time.Time without a DateFormat: %v", err)
+	}
+	if err := checkDirPathValue(formatted, at); err == nil {
+		t.Error("time.Time with a DateFormat: wanted an error")
+	}
+	if err := checkDirPathValue(formatted, "2015-06-07 08:09:10.5"); err != nil {
+		t.Errorf("string with a DateFormat: %v", err)
+	}
+	for _, tc := range []struct {
+		value interface{}
+		text  string
+		null  bool
+	}{
+		{at, "2015-06-07 08:09:10", false},
+		{int64(-3), "-3", false},
+		{true, "1", false},
+		{"", "", false},
+		{nil, "", true},
+		{[]byte(nil), "", true},
+	} {
+		text, null, err := dirPathText(tc.value)
+		if err != nil {
+			t.Errorf("%#v: %v", tc.value, err)
+			continue
+		}
+		if string(text) != tc.text || null != tc.null {
+			t.Errorf("%#v: got %q, %v, wanted %q, %v", tc.value, text, null, tc.text, tc.null)
+		}
+	}
+}
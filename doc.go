@@ -606,6 +606,25 @@ Configuration of Stmt.Cfg takes effect prior to calls to Stmt.Exe and
 Stmt.Qry; consequently, any updates to Stmt.Cfg after a call to Stmt.Exe
 or Stmt.Qry are not observed.
 
+Env, Srv, Ses, Stmt and Rset methods are safe for concurrent use. Concurrent
+calls on the same Stmt or Rset are serialized by the handle's lock; OCI handles
+are never used by two goroutines at once. Sharing a Stmt or Rset between
+goroutines is usually a mistake, and setting DrvCfg.RaceDetect makes the
+simultaneous use of a Stmt or Rset panic with a message naming the handle:
+
+	cfg := ora.NewDrvCfg()
+	cfg.RaceDetect = true
+	ora.SetDrvCfg(cfg)
+
+Setting SrvCfg.NonBlocking puts the server handle in OCI non-blocking mode.
+A long-running call is then polled, letting the goroutine yield its OS thread
+between polls, and Stmt.ExeContext and Stmt.QryContext break the call when the
+context is done:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rset, err := stmt.QryContext(ctx)
+
 One configuration scenario may be to set a server's select statements to return
 nullable Go types by default:
 
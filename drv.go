@@ -12,13 +12,53 @@ import "C"
 import (
 	"database/sql/driver"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // DrvCfg represents configuration values for the ora package.
 type DrvCfg struct {
-	Env *EnvCfg
-	Log LogDrvCfg
+	Env  *EnvCfg
+	Log  LogDrvCfg
+	Leak LeakCfg
+
+	// RaceDetect determines whether the simultaneous use of a Stmt or Rset
+	// by two goroutines panics with a descriptive message.
+	//
+	// Without RaceDetect, concurrent calls on a Stmt or Rset are serialized.
+	// RaceDetect is a debugging aid for finding a handle shared between
+	// goroutines by mistake.
+	//
+	// The default is false.
+	RaceDetect bool
+
+	// ProfileLabels labels the cgo calls which dominate CPU profiles, such
+	// as statement execution, fetches, binds and LOB reads and writes, with
+	// the pprof label "ora", so that a profile attributes their time to the
+	// driver operation; see runtime/pprof.Do.
+	//
+	// The default is false.
+	ProfileLabels bool
+
+	// RewriteSql, when not nil, rewrites the text of each statement before
+	// it's prepared by Ses.Prep, the methods preparing through it, and
+	// database/sql, such as a shim converting the LIMIT clauses of another
+	// dialect; see ConvertPlaceholders for placeholders. Statements the
+	// driver derives from a prepared statement, such as the pages of
+	// Stmt.QryPage, and the driver's own statements, such as those of
+	// Ses.SaveState and Ses.WithParams, aren't rewritten.
+	//
+	// The default is nil.
+	RewriteSql func(sql string) string
+
+	// ConvertPlaceholders determines whether the '?' and '$n' placeholders of
+	// the statements prepared by Ses.Prep and database/sql are converted to
+	// Oracle placeholders, after RewriteSql, and the parameters of the
+	// statements are bound in the order of the converted placeholders; see
+	// ConvertPlaceholders.
+	//
+	// The default is false.
+	ConvertPlaceholders bool
 }
 
 // NewDrvCfg creates a DrvCfg with default values.
@@ -26,9 +66,32 @@ func NewDrvCfg() *DrvCfg {
 	c := &DrvCfg{}
 	c.Env = NewEnvCfg()
 	c.Log = NewLogDrvCfg()
+	c.Leak = NewLeakCfg()
+	c.RaceDetect = false
+	c.ProfileLabels = false
+	c.RewriteSql = nil
+	c.ConvertPlaceholders = false
 	return c
 }
 
+// rewriteSql returns sql rewritten by RewriteSql.
+func (c *DrvCfg) rewriteSql(sql string) string {
+	if c.RewriteSql == nil {
+		return sql
+	}
+	return c.RewriteSql(sql)
+}
+
+// convertSql returns sql rewritten by RewriteSql, with placeholders
+// converted when ConvertPlaceholders is set, and the order of its params.
+func (c *DrvCfg) convertSql(sql string) (string, []int, error) {
+	sql = c.rewriteSql(sql)
+	if !c.ConvertPlaceholders {
+		return sql, nil, nil
+	}
+	return ConvertPlaceholders(sql)
+}
+
 // LogDrvCfg represents package-level logging configuration values.
 type LogDrvCfg struct {
 	// Logger writes log messages.
@@ -48,6 +111,21 @@ type LogDrvCfg struct {
 	// The default is true.
 	OpenEnv bool
 
+	// OpenSes determines whether the ora.OpenSes method is logged.
+	//
+	// The default is true.
+	OpenSes bool
+
+	// OpenPool determines whether the ora.OpenPool method is logged.
+	//
+	// The default is true.
+	OpenPool bool
+
+	// RegisterName determines whether the ora.RegisterName method is logged.
+	//
+	// The default is true.
+	RegisterName bool
+
 	// Ins determines whether the ora.Ins method is logged.
 	//
 	// The default is true.
@@ -73,6 +151,10 @@ type LogDrvCfg struct {
 	// The default is true.
 	AddTbl bool
 
+	// Redact configures the masking of passwords and bind values in log
+	// messages and error messages.
+	Redact RedactCfg
+
 	Env  LogEnvCfg
 	Srv  LogSrvCfg
 	Ses  LogSesCfg
@@ -80,6 +162,7 @@ type LogDrvCfg struct {
 	Tx   LogTxCfg
 	Con  LogConCfg
 	Rset LogRsetCfg
+	Pool LogPoolCfg
 }
 
 // NewLogDrvCfg creates a LogDrvCfg with default values.
@@ -87,11 +170,15 @@ func NewLogDrvCfg() LogDrvCfg {
 	c := LogDrvCfg{}
 	c.Logger = EmpLgr{}
 	c.OpenEnv = true
+	c.OpenSes = true
+	c.OpenPool = true
+	c.RegisterName = true
 	c.Ins = true
 	c.Upd = true
 	c.Del = true
 	c.Sel = true
 	c.AddTbl = true
+	c.Redact = NewRedactCfg()
 	c.Env = NewLogEnvCfg()
 	c.Srv = NewLogSrvCfg()
 	c.Ses = NewLogSesCfg()
@@ -99,6 +186,7 @@ func NewLogDrvCfg() LogDrvCfg {
 	c.Tx = NewLogTxCfg()
 	c.Con = NewLogConCfg()
 	c.Rset = NewLogRsetCfg()
+	c.Pool = NewLogPoolCfg()
 	return c
 }
 
@@ -108,7 +196,7 @@ func NewLogDrvCfg() LogDrvCfg {
 //
 // Drv implements the driver.Driver interface.
 type Drv struct {
-	cfg      DrvCfg
+	cfgV     atomic.Value // *DrvCfg swapped by SetCfg; read with cfg
 	mu       sync.Mutex
 	insMu    sync.Mutex
 	updMu    sync.Mutex
@@ -123,6 +211,7 @@ type Drv struct {
 	txId   Id
 	stmtId Id
 	rsetId Id
+	poolId Id
 
 	listPool *pool
 	envPool  *pool
@@ -135,9 +224,19 @@ type Drv struct {
 	bndPools []*pool
 	defPools []*pool
 
-	locations map[string]*time.Location
-	sqlPkgEnv *Env // An environment for use by the database/sql package.
-	openEnvs  *envList
+	locations   map[string]*time.Location
+	netMu       sync.Mutex
+	netCfg      string // parameters applied by applyNetCfg
+	netApplied  bool
+	netRestore  func() // restores TNS_ADMIN after applyNetCfg
+	srvAttached bool   // Oracle Net has read its configuration
+	sqlPkgEnv   *Env   // An environment for use by the database/sql package.
+	openEnvs    *envList
+}
+
+// cfg returns the current DrvCfg snapshot.
+func (drv *Drv) cfg() *DrvCfg {
+	return drv.cfgV.Load().(*DrvCfg)
 }
 
 // Open opens a connection to an Oracle server with the database/sql environment.
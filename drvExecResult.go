@@ -31,6 +31,9 @@ type DrvExecResult struct {
 //	result, err := db.Exec("INSERT INTO T1 (C2) VALUES ('GO') RETURNING C1 INTO :C1", nil)
 //
 //	id, err := result.LastInsertId()
+//
+// Alternatively, set StmtCfg.InsertIdColumn to have single-row inserts
+// rewritten with the 'returning into' clause.
 func (er *DrvExecResult) LastInsertId() (int64, error) {
 	return er.lastInsertId, nil
 }
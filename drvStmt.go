@@ -15,7 +15,9 @@ import (
 //
 // DrvStmt implements the driver.Stmt interface.
 type DrvStmt struct {
-	stmt *Stmt
+	stmt     *Stmt
+	gcts     ColumnTypes // of the next Query; see CheckNamedValue
+	insertId bool        // a RETURNING clause was appended; see StmtCfg.InsertIdColumn
 }
 
 // checkIsOpen validates that the server is open.
@@ -48,6 +50,9 @@ func (ds *DrvStmt) NumInput() int {
 	if ds.stmt == nil {
 		return 0
 	}
+	if ds.insertId { // the hidden bind of the LastInsertId
+		return ds.stmt.NumInput() - 1
+	}
 	return ds.stmt.NumInput()
 }
 
@@ -60,11 +65,15 @@ func (ds *DrvStmt) Exec(values []driver.Value) (result driver.Result, err error)
 	if err := ds.checkIsOpen(); err != nil {
 		return nil, errE(err)
 	}
-	params := make([]interface{}, len(values))
+	params := make([]interface{}, len(values), len(values)+1)
 	for n, _ := range values {
 		params[n] = values[n]
 	}
-	rowsAffected, lastInsertId, err := ds.stmt.exe(params)
+	if ds.insertId {
+		params = append(params, nil) // replaced by the LastInsertId
+	}
+	ds.gcts = nil
+	rowsAffected, lastInsertId, err := ds.stmt.exe(nil, params, false)
 	if err != nil {
 		return nil, errE(err)
 	}
@@ -89,7 +98,11 @@ func (ds *DrvStmt) Query(values []driver.Value) (driver.Rows, error) {
 	for n, _ := range values {
 		params[n] = values[n]
 	}
-	rset, err := ds.stmt.qry(params)
+	if ds.gcts != nil {
+		defer ds.stmt.swapGcts(ds.gcts)()
+		ds.gcts = nil
+	}
+	rset, err := ds.stmt.qry(nil, params)
 	if err != nil {
 		return nil, errE(err)
 	}
@@ -105,9 +118,9 @@ func (ds *DrvStmt) sysName() string {
 func (ds *DrvStmt) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", ds.sysName(), callInfo(1))
+			lgr.Infof("%v %v", ds.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", ds.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", ds.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -116,9 +129,9 @@ func (ds *DrvStmt) log(enabled bool, v ...interface{}) {
 func (ds *DrvStmt) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", ds.sysName(), callInfo(1))
+			lgr.Infof("%v %v", ds.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", ds.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", ds.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DupColumns determines the handling of a result set with duplicate column
+// names, such as the ID columns of SELECT * FROM a JOIN b. Name-based access,
+// such as Rset.WriteJSON and struct mapping, would shadow all but one of them.
+type DupColumns int
+
+const (
+	// DupColumnsKeep keeps duplicate names; the columns are told apart by
+	// position only.
+	DupColumnsKeep DupColumns = iota
+	// DupColumnsError fails the query with an error naming the duplicate.
+	DupColumnsError
+	// DupColumnsSuffix renames the second and later columns of a name with a
+	// suffix of their occurrence, such as ID_2 and ID_3, skipping names of
+	// other columns.
+	DupColumnsSuffix
+)
+
+// dedupColumns applies policy to the duplicate names of names. When fold is
+// true, names differing only in case are duplicates.
+func dedupColumns(names []string, policy DupColumns, fold bool) ([]string, error) {
+	if policy == DupColumnsKeep {
+		return names, nil
+	}
+	key := func(name string) string {
+		if fold {
+			return strings.ToUpper(name)
+		}
+		return name
+	}
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[key(name)] = true
+	}
+	seen := make(map[string]int, len(names))
+	var result []string
+	for n, name := range names {
+		k := key(name)
+		seen[k]++
+		if seen[k] == 1 {
+			continue
+		}
+		if policy == DupColumnsError {
+			return nil, errF("Duplicate column name %v at position %d.", name, n+1)
+		}
+		if result == nil {
+			result = append([]string(nil), names...)
+		}
+		for occurrence := seen[k]; ; occurrence++ {
+			renamed := fmt.Sprintf("%v_%d", name, occurrence)
+			if !taken[key(renamed)] {
+				taken[key(renamed)] = true
+				result[n] = renamed
+				seen[k] = occurrence
+				break
+			}
+		}
+	}
+	if result == nil {
+		return names, nil
+	}
+	return result, nil
+}
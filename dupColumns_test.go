@@ -0,0 +1,39 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDedupColumns tests dedupColumns.
+func TestDedupColumns(t *testing.T) {
+	for _, tc := range []struct {
+		names  []string
+		policy DupColumns
+		fold   bool
+		want   []string
+		err    bool
+	}{
+		{[]string{"ID", "ID"}, DupColumnsKeep, false, []string{"ID", "ID"}, false},
+		{[]string{"ID", "NAME", "ID"}, DupColumnsError, false, nil, true},
+		{[]string{"ID", "NAME"}, DupColumnsError, false, []string{"ID", "NAME"}, false},
+		{[]string{"ID", "ID", "ID"}, DupColumnsSuffix, false, []string{"ID", "ID_2", "ID_3"}, false},
+		{[]string{"ID", "ID_2", "ID"}, DupColumnsSuffix, false, []string{"ID", "ID_2", "ID_3"}, false},
+		{[]string{"id", "ID"}, DupColumnsSuffix, false, []string{"id", "ID"}, false},
+		{[]string{"id", "ID"}, DupColumnsSuffix, true, []string{"id", "ID_2"}, false},
+		{[]string{"id", "ID"}, DupColumnsError, true, nil, true},
+	} {
+		got, err := dedupColumns(tc.names, tc.policy, tc.fold)
+		if (err != nil) != tc.err {
+			t.Errorf("%v/%v: got error %v", tc.names, tc.policy, err)
+			continue
+		}
+		if !tc.err && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v/%v: got %v, wanted %v", tc.names, tc.policy, got, tc.want)
+		}
+	}
+}
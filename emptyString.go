@@ -0,0 +1,57 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// EmptyStringPolicy determines how an empty Go string is bound. Oracle
+// treats a zero-length character value as NULL.
+type EmptyStringPolicy int
+
+const (
+	// EmptyStringNull binds an empty string unchanged, which Oracle stores
+	// and compares as NULL.
+	EmptyStringNull EmptyStringPolicy = iota
+	// EmptyStringSpace binds an empty string as a single space, a non-NULL
+	// sentinel.
+	EmptyStringSpace
+)
+
+// bindValue returns the value bound for s.
+func (p EmptyStringPolicy) bindValue(s string) string {
+	if s == "" && p == EmptyStringSpace {
+		return " "
+	}
+	return s
+}
+
+// bindValues returns the values bound for values, copying values when an
+// element is changed.
+func (p EmptyStringPolicy) bindValues(values []string) []string {
+	if p != EmptyStringSpace {
+		return values
+	}
+	for n, s := range values {
+		if s != "" {
+			continue
+		}
+		result := make([]string, len(values))
+		copy(result, values)
+		for m := n; m < len(result); m++ {
+			result[m] = p.bindValue(result[m])
+		}
+		return result
+	}
+	return values
+}
+
+// NullStringPolicy determines how NULL is fetched from a character column
+// defined as S.
+type NullStringPolicy int
+
+const (
+	// NullStringEmpty fetches NULL as an empty string.
+	NullStringEmpty NullStringPolicy = iota
+	// NullStringOra fetches the column as a String, with IsNull set for NULL.
+	NullStringOra
+)
@@ -0,0 +1,35 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestEmptyStringPolicy tests EmptyStringPolicy.bindValue and bindValues.
+func TestEmptyStringPolicy(t *testing.T) {
+	if got := EmptyStringNull.bindValue(""); got != "" {
+		t.Errorf("EmptyStringNull: got %q, wanted %q", got, "")
+	}
+	if got := EmptyStringSpace.bindValue(""); got != " " {
+		t.Errorf("EmptyStringSpace: got %q, wanted %q", got, " ")
+	}
+	if got := EmptyStringSpace.bindValue("a"); got != "a" {
+		t.Errorf("EmptyStringSpace: got %q, wanted %q", got, "a")
+	}
+
+	values := []string{"a", "", "b", ""}
+	if got := EmptyStringNull.bindValues(values); !reflect.DeepEqual(got, values) {
+		t.Errorf("EmptyStringNull: got %q, wanted %q", got, values)
+	}
+	want := []string{"a", " ", "b", " "}
+	if got := EmptyStringSpace.bindValues(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("EmptyStringSpace: got %q, wanted %q", got, want)
+	}
+	if values[1] != "" {
+		t.Errorf("EmptyStringSpace: values changed to %q", values)
+	}
+}
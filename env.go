@@ -21,12 +21,39 @@ import (
 type EnvCfg struct {
 	// StmtCfg configures new Stmts.
 	StmtCfg *StmtCfg
+
+	// Net configures native network encryption and data integrity. Net is
+	// applied when the Env is opened, which must precede the first Srv of
+	// the process; see NetCfg.
+	//
+	// The default is nil.
+	Net *NetCfg
+
+	// Events opens the Env in OCI_EVENTS mode, in which the client receives
+	// Fast Application Notification (FAN) high availability events of the
+	// servers.
+	//
+	// The default is false.
+	Events bool
+
+	// LobChunkSize is the size in bytes of the pieces in which the sessions
+	// of the Env read LOBs, of the LOB content prefetched with each selected
+	// row, and of the pieces of LOB binds when StmtCfg.LobBufferSize is zero.
+	// A SesCfg.LobChunkSize other than zero overrides it. Chunk buffers are
+	// pooled by power-of-two size, so sessions of different chunk sizes
+	// share them.
+	//
+	// The maximum is 1,073,741,824 bytes.
+	//
+	// The default is 16,777,216 bytes.
+	LobChunkSize int
 }
 
 // NewEnvCfg creates a EnvCfg with default values.
 func NewEnvCfg() *EnvCfg {
 	c := &EnvCfg{}
 	c.StmtCfg = NewStmtCfg()
+	c.LobChunkSize = lobChunkSize
 	return c
 }
 
@@ -46,6 +73,12 @@ type LogEnvCfg struct {
 	//
 	// The default is true.
 	OpenCon bool
+
+	// Interval determines whether the interval arithmetic methods of Env,
+	// such as Env.AddIntervalDS and Env.ShiftTimeYM, are logged.
+	//
+	// The default is true.
+	Interval bool
 }
 
 // NewLogEnvCfg creates a LogEnvCfg with default values.
@@ -54,6 +87,7 @@ func NewLogEnvCfg() LogEnvCfg {
 	c.Close = true
 	c.OpenSrv = true
 	c.OpenCon = true
+	c.Interval = true
 	return c
 }
 
@@ -64,18 +98,19 @@ type Env struct {
 	mu       sync.Mutex
 	ocienv   *C.OCIEnv
 	ocierr   *C.OCIError
-	errBuf   [512]C.char
 	ociHndMu sync.Mutex
 
 	openSrvs *srvList
 	openCons *conList
+	sqlPkg   bool // the Env opens connections for the database/sql package
+	named    bool // the Env of a driver registered by RegisterName keeps its cfg
 }
 
 // Close disconnects from servers and resets optional fields.
 func (env *Env) Close() (err error) {
 	env.mu.Lock()
 	defer env.mu.Unlock()
-	env.log(_drv.cfg.Log.Env.Close)
+	env.log(_drv.cfg().Log.Env.Close)
 	err = env.checkClosed()
 	if err != nil {
 		return errE(err)
@@ -90,6 +125,8 @@ func (env *Env) Close() (err error) {
 		env.ocierr = nil
 		env.openSrvs.clear()
 		env.openCons.clear()
+		env.sqlPkg = false
+		env.named = false
 		_drv.envPool.Put(env)
 
 		multiErr := newMultiErrL(errs)
@@ -115,7 +152,7 @@ func (env *Env) Close() (err error) {
 func (env *Env) OpenSrv(cfg *SrvCfg) (srv *Srv, err error) {
 	env.mu.Lock()
 	defer env.mu.Unlock()
-	env.log(_drv.cfg.Log.Env.OpenSrv)
+	env.log(_drv.cfg().Log.Env.OpenSrv)
 	err = env.checkClosed()
 	if err != nil {
 		return nil, errE(err)
@@ -128,22 +165,51 @@ func (env *Env) OpenSrv(cfg *SrvCfg) (srv *Srv, err error) {
 	if err != nil {
 		return nil, errE(err)
 	}
+	// generate a TCPS connect descriptor for cfg.TLS
+	dblink := cfg.Dblink
+	if cfg.TLS != nil {
+		if dblink, err = tlsDescriptor(dblink, *cfg.TLS); err != nil {
+			return nil, errE(err)
+		}
+	}
+	// forward connections through cfg.Dial; errors report the listener
+	// rather than the local end of the tunnel
+	descriptor := dblink
+	var tun *tunnel
+	if cfg.Dial != nil {
+		address, replace, err := ResolveDblink(dblink)
+		if err != nil {
+			return nil, errE(err)
+		}
+		if tun, err = openTunnel(address, cfg.Dial); err != nil {
+			return nil, errE(err)
+		}
+		dblink = replace(tun.localAddress())
+	}
 	// attach to server
-	cDblink := C.CString(cfg.Dblink)
+	env.logF(_drv.cfg().Log.Env.OpenSrv, "attach %v", dblink)
+	cDblink := C.CString(dblink)
 	defer C.free(unsafe.Pointer(cDblink))
 	r := C.OCIServerAttach(
 		(*C.OCIServer)(ocisrv),                //OCIServer     *srvhp,
 		env.ocierr,                            //OCIError      *errhp,
 		(*C.OraText)(unsafe.Pointer(cDblink)), //const OraText *dblink,
-		C.sb4(len(cfg.Dblink)),                //sb4           dblink_len,
+		C.sb4(len(dblink)),                    //sb4           dblink_len,
 		C.OCI_DEFAULT)                         //ub4           mode);
 	if r == C.OCI_ERROR {
-		return nil, errE(env.ociError())
+		err = connectError(cfg.Dblink, descriptor, env.ociError().Error())
+		if tun != nil {
+			tun.close()
+		}
+		return nil, err
 	}
+	netAttached()
 
 	srv = _drv.srvPool.Get().(*Srv) // set *Srv
 	srv.env = env
 	srv.ocisrv = (*C.OCIServer)(ocisrv)
+	srv.tunnel = tun
+	srv.dblink = dblink
 	if srv.id == 0 {
 		srv.id = _drv.srvId.nextId()
 	}
@@ -169,7 +235,7 @@ var (
 // The dblink may be defined in the client machine's tnsnames.ora file.
 func (env *Env) OpenCon(str string) (con *Con, err error) {
 	// do not lock; calls to env.OpenSrv will lock
-	env.log(_drv.cfg.Log.Env.OpenCon)
+	env.log(_drv.cfg().Log.Env.OpenCon)
 	err = env.checkClosed()
 	if err != nil {
 		return nil, errE(err)
@@ -216,7 +282,7 @@ func (env *Env) OpenCon(str string) (con *Con, err error) {
 		srv.dbIsUTF8 = cs == "AL32UTF8"
 		return con, nil
 	}
-	if rset, err := ses.PrepAndQry(
+	if rset, err := ses.qry(
 		`SELECT property_value FROM database_properties WHERE property_name = 'NLS_CHARACTERSET'`,
 	); err != nil {
 		//Log.Errorf("E%vS%vS%v] Determine database characterset: %v",
@@ -264,6 +330,15 @@ func (env *Env) Cfg() *EnvCfg {
 	return &env.cfg
 }
 
+// CfgCopy returns a copy of the Env's cfg, sharing no maps or nested cfgs
+// with it. Changes to the copy have no effect until it is applied with
+// Env.SetCfg.
+func (env *Env) CfgCopy() *EnvCfg {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	return env.cfg.copy()
+}
+
 // IsOpen returns true when the environment is open; otherwise, false.
 //
 // Calling Close will cause IsOpen to return false. Once closed, the environment
@@ -291,9 +366,9 @@ func (env *Env) sysName() string {
 func (env *Env) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", env.sysName(), callInfo(1))
+			lgr.Infof("%v %v", env.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", env.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", env.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -302,9 +377,9 @@ func (env *Env) log(enabled bool, v ...interface{}) {
 func (env *Env) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", env.sysName(), callInfo(1))
+			lgr.Infof("%v %v", env.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", env.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", env.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
@@ -366,13 +441,19 @@ func (env *Env) setAttr(
 
 // getOciError gets an error returned by an Oracle server. No locking occurs.
 func (env *Env) ociError() error {
+	return ociErrorGet(env.ocierr)
+}
+
+// ociErrorGet returns the error recorded in the error handle ocierr.
+func ociErrorGet(ocierr *C.OCIError) error {
 	var errcode C.sb4
+	var errBuf [512]C.char // per call; Env is shared by concurrent Ses
 	C.OCIErrorGet(
-		unsafe.Pointer(env.ocierr),
+		unsafe.Pointer(ocierr),
 		1, nil,
 		&errcode,
-		(*C.OraText)(unsafe.Pointer(&env.errBuf[0])),
-		C.ub4(len(env.errBuf)),
+		(*C.OraText)(unsafe.Pointer(&errBuf[0])),
+		C.ub4(len(errBuf)),
 		C.OCI_HTYPE_ERROR)
-	return er(C.GoString(&env.errBuf[0]))
+	return er(C.GoString(&errBuf[0]))
 }
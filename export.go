@@ -0,0 +1,250 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"time"
+)
+
+// ExportCfg configures Rset.WriteCSV and Rset.WriteJSON.
+type ExportCfg struct {
+	// Null is the CSV text of a NULL value. JSON NULLs are always null.
+	//
+	// The default is empty.
+	Null string
+
+	// TimeLayout is the time.Time layout of datetime values.
+	//
+	// The default is time.RFC3339Nano.
+	TimeLayout string
+
+	// Header determines whether WriteCSV writes a header record of the
+	// column names.
+	//
+	// The default is true.
+	Header bool
+
+	// Comma is the CSV field delimiter.
+	//
+	// The default is ','.
+	Comma rune
+
+	// InlineLobs determines whether LOB values read as ora.Lob or ora.LobD
+	// are read fully and written inline. When false, such values are written
+	// as NULL. Either way the export closes the LOBs.
+	//
+	// The default is true.
+	InlineLobs bool
+}
+
+// NewExportCfg creates an ExportCfg with default values.
+func NewExportCfg() ExportCfg {
+	c := ExportCfg{}
+	c.Null = ""
+	c.TimeLayout = time.RFC3339Nano
+	c.Header = true
+	c.Comma = ','
+	c.InlineLobs = true
+	return c
+}
+
+// WriteCSV writes the remaining rows of the Rset to w as CSV records,
+// returning the number of rows written.
+//
+// Binary values are written hex-encoded. Rows are fetched with Rset.Next;
+// StmtCfg.PrefetchRowCount determines the rows fetched per round trip.
+func (rset *Rset) WriteCSV(w io.Writer, cfg ExportCfg) (rows uint64, err error) {
+	if cfg.TimeLayout == "" {
+		cfg.TimeLayout = time.RFC3339Nano
+	}
+	cw := csv.NewWriter(w)
+	if cfg.Comma != 0 {
+		cw.Comma = cfg.Comma
+	}
+	if cfg.Header {
+		if err = cw.Write(rset.ColumnNames); err != nil {
+			return 0, errE(err)
+		}
+	}
+	record := make([]string, len(rset.ColumnNames))
+	for rset.Next() {
+		for n, v := range rset.Row {
+			value, err := exportValue(v, cfg)
+			if err != nil {
+				return rows, errE(err)
+			}
+			record[n] = exportText(value, cfg)
+		}
+		if err = cw.Write(record); err != nil {
+			return rows, errE(err)
+		}
+		rows++
+	}
+	if rset.Err != nil {
+		return rows, errE(rset.Err)
+	}
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return rows, errE(err)
+	}
+	return rows, nil
+}
+
+// WriteJSON writes the remaining rows of the Rset to w as a JSON array of
+// objects keyed by column name, returning the number of rows written.
+//
+// Rows are written as they are fetched; the result is never held in memory.
+func (rset *Rset) WriteJSON(w io.Writer, cfg ExportCfg) (rows uint64, err error) {
+	if cfg.TimeLayout == "" {
+		cfg.TimeLayout = time.RFC3339Nano
+	}
+	bw := bufio.NewWriter(w)
+	names := make([][]byte, len(rset.ColumnNames))
+	for n, name := range rset.ColumnNames {
+		if names[n], err = json.Marshal(name); err != nil {
+			return 0, errE(err)
+		}
+	}
+	bw.WriteByte('[')
+	for rset.Next() {
+		if rows > 0 {
+			bw.WriteByte(',')
+		}
+		bw.WriteByte('{')
+		for n, v := range rset.Row {
+			value, err := exportValue(v, cfg)
+			if err != nil {
+				return rows, errE(err)
+			}
+			if t, ok := value.(time.Time); ok {
+				value = t.Format(cfg.TimeLayout)
+			}
+			b, err := json.Marshal(value)
+			if err != nil {
+				return rows, errE(err)
+			}
+			if n > 0 {
+				bw.WriteByte(',')
+			}
+			bw.Write(names[n])
+			bw.WriteByte(':')
+			bw.Write(b)
+		}
+		bw.WriteByte('}')
+		rows++
+	}
+	if rset.Err != nil {
+		return rows, errE(rset.Err)
+	}
+	bw.WriteByte(']')
+	if err = bw.Flush(); err != nil {
+		return rows, errE(err)
+	}
+	return rows, nil
+}
+
+// exportValue returns the plain Go value of a Rset value; nil is NULL.
+func exportValue(v interface{}, cfg ExportCfg) (interface{}, error) {
+	switch v := v.(type) {
+	case Int64:
+		return nullOr(v.IsNull, v.Value), nil
+	case Int32:
+		return nullOr(v.IsNull, v.Value), nil
+	case Int16:
+		return nullOr(v.IsNull, v.Value), nil
+	case Int8:
+		return nullOr(v.IsNull, v.Value), nil
+	case Uint64:
+		return nullOr(v.IsNull, v.Value), nil
+	case Uint32:
+		return nullOr(v.IsNull, v.Value), nil
+	case Uint16:
+		return nullOr(v.IsNull, v.Value), nil
+	case Uint8:
+		return nullOr(v.IsNull, v.Value), nil
+	case Float64:
+		return nullOr(v.IsNull, v.Value), nil
+	case Float32:
+		return nullOr(v.IsNull, v.Value), nil
+	case Time:
+		return nullOr(v.IsNull, v.Value), nil
+	case String:
+		return nullOr(v.IsNull, v.Value), nil
+	case Bool:
+		return nullOr(v.IsNull, v.Value), nil
+	case Raw:
+		return nullOr(v.IsNull, v.Value), nil
+	case Bfile:
+		return nullOr(v.IsNull, v.DirectoryAlias+"/"+v.Filename), nil
+	case IntervalYM:
+		return nullOr(v.IsNull, fmt.Sprintf("%+d-%d", v.Year, abs32(v.Month))), nil
+	case IntervalDS:
+		return nullOr(v.IsNull, fmt.Sprintf("%+d %02d:%02d:%02d.%09d", v.Day,
+			abs32(v.Hour), abs32(v.Minute), abs32(v.Second), abs32(v.Nanosecond))), nil
+	case Lob:
+		if v.Reader == nil {
+			return nil, nil
+		}
+		if !cfg.InlineLobs {
+			return nil, v.Close()
+		}
+		defer v.Close()
+		return ioutil.ReadAll(v)
+	case LobD:
+		if v.IsNull || !cfg.InlineLobs {
+			return nil, v.Close()
+		}
+		return v.Bytes()
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+	}
+	return v, nil
+}
+
+// nullOr returns nil when isNull is true; otherwise, value.
+func nullOr(isNull bool, value interface{}) interface{} {
+	if isNull {
+		return nil
+	}
+	return value
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// exportText returns the CSV text of a value returned by exportValue.
+func exportText(value interface{}, cfg ExportCfg) string {
+	switch v := value.(type) {
+	case nil:
+		return cfg.Null
+	case string:
+		return v
+	case []byte:
+		return hex.EncodeToString(v)
+	case time.Time:
+		return v.Format(cfg.TimeLayout)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,73 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExportText tests exportValue and exportText.
+func TestExportText(t *testing.T) {
+	cfg := NewExportCfg()
+	cfg.Null = `\N`
+	for i, tc := range []struct {
+		v    interface{}
+		want string
+	}{
+		{int64(-7), "-7"},
+		{Int64{IsNull: true}, `\N`},
+		{Float64{Value: 1.5}, "1.5"},
+		{String{Value: "a,b"}, "a,b"},
+		{[]byte{0xCA, 0xFE}, "cafe"},
+		{Raw{IsNull: true}, `\N`},
+		{time.Date(2015, 1, 2, 3, 4, 5, 0, time.UTC), "2015-01-02T03:04:05Z"},
+		{IntervalYM{Year: 1, Month: 2}, "+1-2"},
+		{IntervalDS{Day: -1, Hour: -2, Minute: -3, Second: -4, Nanosecond: -5}, "-1 02:03:04.000000005"},
+		{Lob{}, `\N`},
+	} {
+		value, err := exportValue(tc.v, cfg)
+		if err != nil {
+			t.Errorf("%d. %v", i, err)
+			continue
+		}
+		if got := exportText(value, cfg); got != tc.want {
+			t.Errorf("%d. got %q, want %q.", i, got, tc.want)
+		}
+	}
+}
+
+// closeCounter counts the calls of Close.
+type closeCounter struct{ n int }
+
+func (c *closeCounter) Close() error {
+	c.n++
+	return nil
+}
+
+// TestExportLob tests that exportValue closes a Lob whether or not it is
+// inlined.
+func TestExportLob(t *testing.T) {
+	for _, inline := range []bool{true, false} {
+		cfg := NewExportCfg()
+		cfg.InlineLobs = inline
+		closer := &closeCounter{}
+		value, err := exportValue(Lob{Reader: strings.NewReader("abc"), Closer: closer}, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if closer.n != 1 {
+			t.Errorf("InlineLobs=%v: closed %d times, wanted once", inline, closer.n)
+		}
+		want := ""
+		if inline {
+			want = "616263" // hex, as other binary values
+		}
+		if got := exportText(value, cfg); got != want {
+			t.Errorf("InlineLobs=%v: got %q, want %q.", inline, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "sync/atomic"
+
+const (
+	// maxStringSize is the largest VARCHAR2 and RAW value of a database
+	// with standard data types.
+	maxStringSize = 4000
+	// maxExtendedStringSize is the largest VARCHAR2 and RAW value of a
+	// database with extended data types, and of PL/SQL.
+	maxExtendedStringSize = 32767
+)
+
+// ExtendedStrings reports whether the database server supports extended data
+// types (MAX_STRING_SIZE = EXTENDED), allowing VARCHAR2, NVARCHAR2 and RAW
+// values of up to 32,767 bytes.
+//
+// Support is detected when the first Ses of the Srv is opened; false is
+// returned when detection failed.
+func (srv *Srv) ExtendedStrings() bool {
+	srv.log(_drv.cfg().Log.Srv.ExtendedStrings)
+	return srv.maxStringSize() == maxExtendedStringSize
+}
+
+// maxStringSize returns the largest VARCHAR2 and RAW value of the database
+// server, maxStringSize until detected.
+func (srv *Srv) maxStringSize() int {
+	if size := atomic.LoadInt32(&srv.maxString); size > 0 {
+		return int(size)
+	}
+	return maxStringSize
+}
+
+// detectMaxStringSize caches the largest VARCHAR2 value of the database
+// server of ses. RPAD returns at most MAX_STRING_SIZE bytes, which doesn't
+// require the privileges of querying V$PARAMETER. A failed detection caches
+// the standard size.
+func (ses *Ses) detectMaxStringSize() {
+	size := int32(maxStringSize)
+	defer func() { atomic.StoreInt32(&ses.srv.maxString, size) }()
+	stmt, err := ses.prepSql("SELECT LENGTH(RPAD('x', :1, 'x')) FROM DUAL", I64)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry(int64(maxExtendedStringSize))
+	if err != nil {
+		return
+	}
+	for rset.Next() {
+		if length, ok := rset.Row[0].(int64); ok && length > maxStringSize {
+			size = maxExtendedStringSize
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "unsafe"
+
+// fixedDefSize is the approximate fetch size of a column of a fixed-size type
+// such as a NUMBER or a descriptor, including its indicator and length.
+const fixedDefSize = C.sizeof_OCINumber + 4
+
+// maxFetchRows is the largest number of rows fetched per round trip when
+// sized by RsetCfg.MaxFetchMemory.
+const maxFetchRows = 10000
+
+// rowWidth returns the approximate fetch size in bytes of a row of defs.
+func rowWidth(defs []def) (width int) {
+	for _, d := range defs {
+		switch d := d.(type) {
+		case *defString:
+			width += len(d.buf) + 4
+		case *defRaw:
+			width += len(d.buf) + 4
+		case *defLongRaw:
+			width += len(d.buf) + 4
+		case *defBool:
+			width += len(d.buf) + 4
+		case *defRowid:
+			width += len(d.buf) + 4
+		case *defPiece:
+			width += pieceSize + 4
+		default:
+			width += fixedDefSize
+		}
+	}
+	return width
+}
+
+// fetchRows returns the number of rows of width bytes fetched per round trip
+// within maxMemory bytes, at most limit rows when limit is positive, and
+// at least one row.
+func fetchRows(width, maxMemory, limit int) int {
+	if width <= 0 {
+		width = 1
+	}
+	rows := maxMemory / width
+	if limit <= 0 || limit > maxFetchRows {
+		limit = maxFetchRows
+	}
+	if rows > limit {
+		rows = limit
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// sizeFetchArray sets the number of rows prefetched per round trip from the
+// described row width when RsetCfg.MaxFetchMemory is set. A prefetch row
+// count set with StmtCfg.SetPrefetchRowCount is an upper bound.
+// No locking occurs.
+func (rset *Rset) sizeFetchArray() error {
+	maxMemory := rset.cfg.Rset.MaxFetchMemory
+	if maxMemory <= 0 {
+		return nil
+	}
+	width := rowWidth(rset.defs)
+	rows := C.ub4(fetchRows(width, maxMemory, int(rset.cfg.prefetchRowCount)))
+	memory := C.ub4(maxMemory)
+	rset.logF(_drv.cfg().Log.Rset.OpenDefs, "row width %d, fetch rows %d", width, rows)
+	ses := rset.stmt.ses
+	if err := ses.setAttr(unsafe.Pointer(rset.ocistmt), C.OCI_HTYPE_STMT, unsafe.Pointer(&rows), 4, C.OCI_ATTR_PREFETCH_ROWS); err != nil {
+		return err
+	}
+	return ses.setAttr(unsafe.Pointer(rset.ocistmt), C.OCI_HTYPE_STMT, unsafe.Pointer(&memory), 4, C.OCI_ATTR_PREFETCH_MEMORY)
+}
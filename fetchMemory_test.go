@@ -0,0 +1,25 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestFetchRows tests fetchRows.
+func TestFetchRows(t *testing.T) {
+	for _, tc := range []struct {
+		width, maxMemory, limit int
+		want                    int
+	}{
+		{200, 1 << 20, 0, 5242},
+		{10, 1 << 20, 0, maxFetchRows},
+		{100, 1 << 20, 500, 500},
+		{1 << 24, 1 << 20, 0, 1},
+		{0, 1000, 0, 1000},
+	} {
+		if got := fetchRows(tc.width, tc.maxMemory, tc.limit); got != tc.want {
+			t.Errorf("%d/%d/%d: got %d, wanted %d", tc.width, tc.maxMemory, tc.limit, got, tc.want)
+		}
+	}
+}
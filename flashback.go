@@ -0,0 +1,99 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "time"
+
+// flashbackPoint is the point in time of a flashback query: an SCN, a
+// timestamp, or neither.
+type flashbackPoint struct {
+	scn  uint64
+	time time.Time
+}
+
+// isZero reports whether the flashbackPoint is neither an SCN nor a
+// timestamp.
+func (p flashbackPoint) isZero() bool {
+	return p.scn == 0 && p.time.IsZero()
+}
+
+// enableSql returns the PL/SQL block entering flashback mode at the point,
+// and its parameter.
+func (p flashbackPoint) enableSql() (sql string, param interface{}) {
+	if p.scn != 0 {
+		return "BEGIN DBMS_FLASHBACK.ENABLE_AT_SYSTEM_CHANGE_NUMBER(:1); END;", p.scn
+	}
+	return "BEGIN DBMS_FLASHBACK.ENABLE_AT_TIME(:1); END;", p.time
+}
+
+// WithAsOfScn returns a CallOption running a query of Stmt.QryContext as of
+// scn, such as returned by Ses.CurrentScn: the query sees the data committed
+// when scn was current. Queries of several calls given the same scn read
+// consistent data.
+//
+// The query is executed in flashback mode with DBMS_FLASHBACK, which requires
+// the EXECUTE privilege on DBMS_FLASHBACK, and fails when the Ses has a
+// transaction with changes. The rows of the returned Rset are read as of scn
+// after flashback mode ends.
+func WithAsOfScn(scn uint64) CallOption {
+	return func(cfg *StmtCfg) error {
+		if scn == 0 {
+			return er("The SCN of WithAsOfScn must be positive.")
+		}
+		cfg.asOf = flashbackPoint{scn: scn}
+		return nil
+	}
+}
+
+// WithAsOfTime returns a CallOption running a query of Stmt.QryContext as of
+// t, mapped by the server to the SCN current at most 3 seconds around t; see
+// WithAsOfScn.
+func WithAsOfTime(t time.Time) CallOption {
+	return func(cfg *StmtCfg) error {
+		if t.IsZero() {
+			return er("The time of WithAsOfTime must not be zero.")
+		}
+		cfg.asOf = flashbackPoint{time: t}
+		return nil
+	}
+}
+
+// enableFlashback enters flashback mode at p for the execution of a query,
+// and returns a func leaving it. The func is nil when p is zero.
+func (ses *Ses) enableFlashback(p flashbackPoint) (disable func() error, err error) {
+	if p.isZero() {
+		return nil, nil
+	}
+	sql, param := p.enableSql()
+	if _, err = ses.exe(sql, param); err != nil {
+		return nil, err
+	}
+	return func() error {
+		_, err := ses.exe("BEGIN DBMS_FLASHBACK.DISABLE; END;")
+		return err
+	}, nil
+}
+
+// CurrentScn returns the current system change number (SCN) of the database,
+// with DBMS_FLASHBACK.GET_SYSTEM_CHANGE_NUMBER, for WithAsOfScn.
+func (ses *Ses) CurrentScn() (scn uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.CurrentScn)
+	stmt, err := ses.prepSql(`SELECT DBMS_FLASHBACK.GET_SYSTEM_CHANGE_NUMBER FROM DUAL`, U64)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry()
+	if err != nil {
+		return 0, errE(err)
+	}
+	for rset.Next() {
+		scn, _ = rset.Row[0].(uint64)
+	}
+	if rset.Err != nil {
+		return 0, errE(rset.Err)
+	}
+	return scn, nil
+}
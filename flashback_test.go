@@ -0,0 +1,38 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAsOfOptions tests the flashback points set by WithAsOfScn and
+// WithAsOfTime.
+func TestAsOfOptions(t *testing.T) {
+	var cfg StmtCfg
+	if !cfg.asOf.isZero() {
+		t.Fatal("wanted no flashback point by default")
+	}
+	if err := WithAsOfScn(42)(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if sql, param := cfg.asOf.enableSql(); sql != "BEGIN DBMS_FLASHBACK.ENABLE_AT_SYSTEM_CHANGE_NUMBER(:1); END;" || param != uint64(42) {
+		t.Errorf("got %q with %v", sql, param)
+	}
+	at := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := WithAsOfTime(at)(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if sql, param := cfg.asOf.enableSql(); sql != "BEGIN DBMS_FLASHBACK.ENABLE_AT_TIME(:1); END;" || param != at {
+		t.Errorf("got %q with %v", sql, param)
+	}
+	if err := WithAsOfScn(0)(&cfg); err == nil {
+		t.Error("wanted an error for a zero SCN")
+	}
+	if err := WithAsOfTime(time.Time{})(&cfg); err == nil {
+		t.Error("wanted an error for a zero time")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// maxInListLen is the maximum number of expressions in an Oracle IN-list
+// (ORA-01795).
+const maxInListLen = 1000
+
+// maxInListCollLen is the maximum number of elements of the SYS.ODCI*LIST
+// VARRAY types.
+const maxInListCollLen = 32767
+
+// InList is a bind parameter expanded to the elements of a Go slice, so that
+// a statement such as SELECT * FROM T1 WHERE ID IN (:ids) may be queried with a
+// slice of ids.
+//
+// Ses.PrepAndExe and Ses.PrepAndQry expand InList params; ExpandIn expands
+// them for statements prepared with Ses.Prep.
+type InList struct {
+	// Values is a slice of values bound in place of the placeholder.
+	Values interface{}
+
+	// Collection determines whether Values is bound as a single
+	// SYS.ODCINUMBERLIST, SYS.ODCIVARCHAR2LIST or SYS.ODCIDATELIST
+	// collection, queried by a subquery replacing the placeholder, rather
+	// than as a list of placeholders. The sql text of a collection doesn't
+	// depend on the number of elements, so its cursor is shared, and a
+	// collection holds up to 32767 elements. A collection is used regardless
+	// when Values has more than 1000 elements.
+	//
+	// A collection requires elements of a numeric, string or time.Time type;
+	// time.Time elements are compared as DATE values, without time zone.
+	Collection bool
+}
+
+// In returns an InList of values, which must be a slice.
+func In(values interface{}) InList {
+	return InList{Values: values}
+}
+
+// ExpandIn expands each InList of params into the elements of its slice,
+// rewriting the corresponding placeholder of sql.
+//
+// params are matched by position to the placeholders of sql. Each InList
+// placeholder is replaced by a list of placeholders, one per element, or by a
+// subquery over a single collection placeholder when InList.Collection is
+// set. An empty InList is
+// replaced by NULL, which matches no rows.
+func ExpandIn(sql string, params ...interface{}) (string, []interface{}, error) {
+	has := false
+	for _, param := range params {
+		if _, ok := param.(InList); ok {
+			has = true
+			break
+		}
+	}
+	if !has {
+		return sql, params, nil
+	}
+	var err error
+	var count int
+	expanded := make([]interface{}, 0, len(params))
+	sql = rewritePlaceholders(sql, func(n int, name string) string {
+		count++
+		if n >= len(params) || err != nil {
+			return ":" + name
+		}
+		list, ok := params[n].(InList)
+		if !ok {
+			expanded = append(expanded, params[n])
+			return ":" + name
+		}
+		var text string
+		var values []interface{}
+		text, values, err = list.expand(n, name)
+		expanded = append(expanded, values...)
+		return text
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if count < len(params) {
+		for _, param := range params[count:] {
+			if _, ok := param.(InList); ok {
+				return "", nil, errF("Sql has %d placeholders; InList param %d has no placeholder.", count, count+1)
+			}
+		}
+		expanded = append(expanded, params[count:]...)
+	}
+	return sql, expanded, nil
+}
+
+// expand returns the sql text replacing the placeholder n named name,
+// and the values bound to it.
+func (list InList) expand(n int, name string) (string, []interface{}, error) {
+	v := reflect.ValueOf(list.Values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", nil, errF("InList for placeholder :%v is %T; expected a slice.", name, list.Values)
+	}
+	if v.Len() == 0 {
+		return "NULL", nil, nil
+	}
+	if list.Collection || v.Len() > maxInListLen {
+		coll, err := newInListColl(v)
+		if err != nil {
+			return "", nil, errF("InList for placeholder :%v: %v", name, err)
+		}
+		return fmt.Sprintf("SELECT COLUMN_VALUE FROM TABLE(:i%d)", n), []interface{}{coll}, nil
+	}
+	values := make([]interface{}, v.Len())
+	var buf bytes.Buffer
+	for m := range values {
+		values[m] = inListValue(v.Index(m))
+		if m > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, ":i%d_%d", n, m)
+	}
+	return buf.String(), values, nil
+}
+
+// inListColl is the bind value of an InList bound as a collection: the name
+// of a SYS collection type, and the elements converted to int64, uint64,
+// float64, string or time.Time.
+type inListColl struct {
+	typeName string
+	values   []interface{}
+}
+
+// newInListColl returns the collection holding the elements of v, a slice.
+func newInListColl(v reflect.Value) (inListColl, error) {
+	if v.Len() > maxInListCollLen {
+		return inListColl{}, errF("%d elements exceed the %d elements of a collection.", v.Len(), maxInListCollLen)
+	}
+	t := v.Type().Elem()
+	typeName, err := inListCollection(t)
+	if err != nil {
+		return inListColl{}, err
+	}
+	values := make([]interface{}, v.Len())
+	for m := range values {
+		elem := v.Index(m)
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values[m] = elem.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values[m] = elem.Uint()
+		case reflect.Float32, reflect.Float64:
+			values[m] = elem.Float()
+		case reflect.String:
+			values[m] = elem.String()
+		default:
+			values[m] = elem.Interface()
+		}
+	}
+	return inListColl{typeName: typeName, values: values}, nil
+}
+
+// inListValue returns the bind value of an element. int and uint elements,
+// which have no bind of their own, are bound as int64 and uint64.
+func inListValue(v reflect.Value) interface{} {
+	switch v.Type() {
+	case reflect.TypeOf(int(0)):
+		return v.Int()
+	case reflect.TypeOf(uint(0)):
+		return v.Uint()
+	}
+	return v.Interface()
+}
+
+// inListCollection returns the name of the SYS collection type holding
+// elements of t.
+func inListCollection(t reflect.Type) (string, error) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "ODCIDATELIST", nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "ODCINUMBERLIST", nil
+	case reflect.String:
+		return "ODCIVARCHAR2LIST", nil
+	}
+	return "", errF("elements of type %v can't be bound as a collection.", t)
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandIn(t *testing.T) {
+	for i, tc := range []struct {
+		sql    string
+		params []interface{}
+		want   string
+		values []interface{}
+	}{
+		{"SELECT * FROM T1 WHERE C1 = :1", []interface{}{"a"},
+			"SELECT * FROM T1 WHERE C1 = :1", []interface{}{"a"}},
+		{"SELECT * FROM T1 WHERE C1 = :c1 AND ID IN (:ids)", []interface{}{"a", In([]int{1, 2, 3})},
+			"SELECT * FROM T1 WHERE C1 = :c1 AND ID IN (:i1_0, :i1_1, :i1_2)", []interface{}{"a", int64(1), int64(2), int64(3)}},
+		{"SELECT * FROM T1 WHERE ID IN (:ids) AND C1 = ':x'", []interface{}{In([]string{})},
+			"SELECT * FROM T1 WHERE ID IN (NULL) AND C1 = ':x'", []interface{}{}},
+		{"SELECT * FROM T1 WHERE C1 = q'[it's :x]' AND ID IN (:ids)", []interface{}{In([]int{1})},
+			"SELECT * FROM T1 WHERE C1 = q'[it's :x]' AND ID IN (:i0_0)", []interface{}{int64(1)}},
+		{"SELECT * FROM T1 WHERE C1 IN (:c)", []interface{}{InList{Values: []string{"a", "b"}, Collection: true}},
+			"SELECT * FROM T1 WHERE C1 IN (SELECT COLUMN_VALUE FROM TABLE(:i0))",
+			[]interface{}{inListColl{typeName: "ODCIVARCHAR2LIST", values: []interface{}{"a", "b"}}}},
+		{"SELECT * FROM T1 WHERE ID IN (:ids) AND C1 = :c1", []interface{}{InList{Values: []int32{1, 2}, Collection: true}, "a"},
+			"SELECT * FROM T1 WHERE ID IN (SELECT COLUMN_VALUE FROM TABLE(:i0)) AND C1 = :c1",
+			[]interface{}{inListColl{typeName: "ODCINUMBERLIST", values: []interface{}{int64(1), int64(2)}}, "a"}},
+	} {
+		sql, values, err := ExpandIn(tc.sql, tc.params...)
+		if err != nil {
+			t.Errorf("%d. %v", i, err)
+			continue
+		}
+		if sql != tc.want {
+			t.Errorf("%d. got %q, want %q.", i, sql, tc.want)
+		}
+		if !reflect.DeepEqual(values, tc.values) {
+			t.Errorf("%d. got values %#v, want %#v.", i, values, tc.values)
+		}
+	}
+
+	ids := make([]int64, maxInListLen+1)
+	sql, values, err := ExpandIn("SELECT * FROM T1 WHERE ID IN (:ids)", In(ids))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || sql != "SELECT * FROM T1 WHERE ID IN (SELECT COLUMN_VALUE FROM TABLE(:i0))" {
+		t.Fatalf("over %d elements: got %d values, sql %.80q", maxInListLen, len(values), sql)
+	}
+	if coll, ok := values[len(values)-1].(inListColl); !ok || len(coll.values) != len(ids) {
+		t.Errorf("over %d elements: got %T, wanted a collection of %d elements", maxInListLen, values[len(values)-1], len(ids))
+	}
+	if _, _, err = ExpandIn("SELECT * FROM T1 WHERE ID IN (:ids)", In(make([]int64, maxInListCollLen+1))); err == nil {
+		t.Errorf("over %d elements: wanted error", maxInListCollLen)
+	}
+	if _, _, err = ExpandIn("SELECT * FROM T1 WHERE ID IN (:ids)", InList{Values: []struct{}{{}}, Collection: true}); err == nil {
+		t.Errorf("struct collection: wanted error")
+	}
+	if _, _, err = ExpandIn("SELECT * FROM T1 WHERE ID IN (:ids)", In(1)); err == nil {
+		t.Errorf("non-slice: wanted error")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "strings"
+
+// insertIdPlaceholder is the placeholder of the RETURNING clause appended by
+// insertIdSql.
+const insertIdPlaceholder = ":ora_insert_id"
+
+// insertIdSql returns sql with a RETURNING clause capturing column into a
+// hidden bind, when sql is a single-row INSERT ... VALUES statement without
+// one; otherwise ok is false.
+func insertIdSql(sql, column string) (result string, ok bool) {
+	if column == "" {
+		return sql, false
+	}
+	words := topLevelWords(sql)
+	if len(words) < 3 || words[0].text != "INSERT" || words[1].text != "INTO" {
+		return sql, false // INSERT ALL, INSERT FIRST or not an INSERT
+	}
+	values := false
+	for _, word := range words[2:] {
+		switch word.text {
+		case "VALUES":
+			values = true
+		case "RETURN", "RETURNING", "LOG", "SELECT":
+			return sql, false
+		}
+	}
+	if !values {
+		return sql, false
+	}
+	// on a new line, so that a trailing -- comment doesn't swallow the clause
+	return trimSql(sql) + "\nRETURNING " + column + " INTO " + insertIdPlaceholder, true
+}
+
+// sqlWord is an upper-cased word of a SQL statement and its index.
+type sqlWord struct {
+	text string
+	pos  int
+}
+
+// topLevelWords returns the upper-cased words, commas and asterisks of sql
+// outside parentheses, literals, including q'[...]' literals, quoted
+// identifiers and comments.
+func topLevelWords(sql string) (words []sqlWord) {
+	depth := 0
+	for _, tok := range scanSql(sql) {
+		switch {
+		case tok.text == "(":
+			depth++
+		case tok.text == ")":
+			depth--
+		case depth != 0:
+		case tok.text == "," || tok.text == "*":
+			words = append(words, sqlWord{text: tok.text, pos: tok.pos})
+		case tok.kind == sqlTokWord:
+			words = append(words, sqlWord{text: strings.ToUpper(tok.text), pos: tok.pos})
+		}
+	}
+	return words
+}
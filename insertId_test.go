@@ -0,0 +1,38 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestInsertIdSql tests the RETURNING rewrite of single-row inserts.
+func TestInsertIdSql(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "INSERT INTO T (A, B) VALUES (:1, 'returning') ;", want: "INSERT INTO T (A, B) VALUES (:1, 'returning')\nRETURNING ID INTO :ora_insert_id"},
+		{sql: "/* x */ insert into t values ((SELECT 1 FROM DUAL))", want: "/* x */ insert into t values ((SELECT 1 FROM DUAL))\nRETURNING ID INTO :ora_insert_id"},
+		{sql: "INSERT INTO T VALUES (1) -- returning", want: "INSERT INTO T VALUES (1) -- returning\nRETURNING ID INTO :ora_insert_id"},
+		{sql: "INSERT INTO T (A) VALUES (q'[it's (returning]')", want: "INSERT INTO T (A) VALUES (q'[it's (returning]')\nRETURNING ID INTO :ora_insert_id"},
+		{sql: "INSERT INTO T (A) VALUES (1) RETURNING A INTO :a"},
+		{sql: "INSERT INTO T (A) SELECT A FROM U"},
+		{sql: "INSERT ALL INTO T VALUES (1) INTO U VALUES (2) SELECT * FROM DUAL"},
+		{sql: "INSERT INTO T VALUES (1) LOG ERRORS INTO ERR$_T"},
+		{sql: "UPDATE T SET A = 1"},
+	}
+	for _, test := range tests {
+		got, ok := insertIdSql(test.sql, "ID")
+		if test.want == "" {
+			if ok || got != test.sql {
+				t.Errorf("%q: got %q, wanted no rewrite", test.sql, got)
+			}
+		} else if !ok || got != test.want {
+			t.Errorf("%q: got %q, wanted %q", test.sql, got, test.want)
+		}
+	}
+	if _, ok := insertIdSql("INSERT INTO T VALUES (1)", ""); ok {
+		t.Error("wanted no rewrite without a column")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "context"
+
+// Sessioner is the interface of a session, implemented by *Ses and by fakes
+// such as those of package oramock, so that code written against it can be
+// unit tested without a database.
+//
+// The helper functions Ins, Upd, Del, Sel and CopyRows accept a Sessioner, so
+// middleware adding retries, metrics or tenancy may wrap a *Ses and its
+// Stmters and Rsetters:
+//
+//	type timedSes struct{ ora.Sessioner }
+//
+//	func (s timedSes) PrepAndExe(sql string, params ...interface{}) (uint64, error) {
+//		defer observe(sql, time.Now())
+//		return s.Sessioner.PrepAndExe(sql, params...)
+//	}
+type Sessioner interface {
+	PrepAndExe(sql string, params ...interface{}) (rowsAffected uint64, err error)
+	PrepStmter(sql string, gcts ...GoColumnType) (Stmter, error)
+	Ping() error
+	IsOpen() bool
+	Close() error
+}
+
+// Stmter is the interface of a prepared statement, implemented by *Stmt.
+type Stmter interface {
+	Exe(params ...interface{}) (rowsAffected uint64, err error)
+	ExeContext(ctx context.Context, params ...interface{}) (rowsAffected uint64, err error)
+	QryRsetter(params ...interface{}) (Rsetter, error)
+	QryRsetterContext(ctx context.Context, params ...interface{}) (Rsetter, error)
+	NumInput() int
+	IsOpen() bool
+	Close() error
+}
+
+// Rsetter is the interface of a result set, implemented by *Rset.
+type Rsetter interface {
+	Next() bool
+	NextRow() []interface{}
+	Values() []interface{}
+	Columns() []string
+	Len() int
+	IsOpen() bool
+	LastErr() error
+}
+
+var (
+	_ Sessioner = (*Ses)(nil)
+	_ Stmter    = (*Stmt)(nil)
+	_ Rsetter   = (*Rset)(nil)
+)
+
+// PrepStmter prepares a SQL statement as Prep does, returning the Stmt as a
+// Stmter.
+func (ses *Ses) PrepStmter(sql string, gcts ...GoColumnType) (Stmter, error) {
+	stmt, err := ses.Prep(sql, gcts...)
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// QryRsetter runs a query as Qry does, returning the Rset as a Rsetter.
+func (stmt *Stmt) QryRsetter(params ...interface{}) (Rsetter, error) {
+	rset, err := stmt.Qry(params...)
+	if err != nil {
+		return nil, err
+	}
+	return rset, nil
+}
+
+// QryRsetterContext runs a query as QryContext does, returning the Rset as a
+// Rsetter.
+func (stmt *Stmt) QryRsetterContext(ctx context.Context, params ...interface{}) (Rsetter, error) {
+	rset, err := stmt.QryContext(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	return rset, nil
+}
+
+// Values returns Rset.Row, the values of the current row.
+func (rset *Rset) Values() []interface{} {
+	return rset.Row
+}
+
+// Columns returns Rset.ColumnNames.
+func (rset *Rset) Columns() []string {
+	return rset.ColumnNames
+}
+
+// LastErr returns Rset.Err, the error of the last call to Next, if any.
+func (rset *Rset) LastErr() error {
+	return rset.Err
+}
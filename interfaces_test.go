@@ -0,0 +1,67 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestUpdSql tests updSql.
+func TestUpdSql(t *testing.T) {
+	sql, params, err := updSql("T1", []interface{}{"NAME", "a", "AGE", 3, "ID", 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE T1 SET NAME = :1, AGE = :2 WHERE ID = :WHERE_VAL"; sql != want {
+		t.Errorf("got %q, wanted %q", sql, want)
+	}
+	if want := []interface{}{"a", 3, 7}; !reflect.DeepEqual(params, want) {
+		t.Errorf("got %v, wanted %v", params, want)
+	}
+	if _, _, err = updSql("T1", []interface{}{"NAME", "a", "ID"}); err == nil {
+		t.Error("wanted error for an odd number of elements")
+	}
+	if _, _, err = updSql("", []interface{}{"NAME", "a"}); err == nil {
+		t.Error("wanted error for an empty table")
+	}
+}
+
+// execSes is a Sessioner recording the statements of PrepAndExe.
+type execSes struct {
+	Sessioner
+	sqls   []string
+	params [][]interface{}
+}
+
+func (s *execSes) PrepAndExe(sql string, params ...interface{}) (uint64, error) {
+	s.sqls = append(s.sqls, sql)
+	s.params = append(s.params, params)
+	return 1, nil
+}
+
+// TestSessionerHelpers tests that the ORM helpers run on a wrapped Sessioner.
+func TestSessionerHelpers(t *testing.T) {
+	type sessionerT struct {
+		Name string
+		ID   int64 `db:"pk"`
+	}
+	ses := &execSes{}
+	if err := Upd(sessionerT{Name: "a", ID: 7}, ses); err != nil {
+		t.Fatal(err)
+	}
+	if err := Del(&sessionerT{ID: 7}, ses); err != nil {
+		t.Fatal(err)
+	}
+	if len(ses.sqls) != 2 {
+		t.Fatalf("got %q, wanted an UPDATE and a DELETE", ses.sqls)
+	}
+	if want := []interface{}{"a", int64(7)}; !reflect.DeepEqual(ses.params[0], want) {
+		t.Errorf("got %v, wanted %v", ses.params[0], want)
+	}
+	if want := []interface{}{int64(7)}; !reflect.DeepEqual(ses.params[1], want) {
+		t.Errorf("got %v, wanted %v", ses.params[1], want)
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <stdlib.h>
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"time"
+	"unsafe"
+)
+
+// AddIntervalDS returns the sum of a and b computed by OCIIntervalAdd, with
+// the normalization and range checks of Oracle. The sum is null when a or b
+// is null.
+func (env *Env) AddIntervalDS(a, b IntervalDS) (IntervalDS, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	return env.intervalDSOp(a, b, func(x, y, result *C.OCIInterval) C.sword {
+		return C.OCIIntervalAdd(unsafe.Pointer(env.ocienv), env.ocierr, x, y, result)
+	})
+}
+
+// SubIntervalDS returns a minus b computed by OCIIntervalSubtract. The
+// difference is null when a or b is null.
+func (env *Env) SubIntervalDS(a, b IntervalDS) (IntervalDS, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	return env.intervalDSOp(a, b, func(x, y, result *C.OCIInterval) C.sword {
+		return C.OCIIntervalSubtract(unsafe.Pointer(env.ocienv), env.ocierr, x, y, result)
+	})
+}
+
+// CompareIntervalDS returns -1, 0 or 1 as a is less than, equal to or
+// greater than b, compared by OCIIntervalCompare. Null intervals can't be
+// compared.
+func (env *Env) CompareIntervalDS(a, b IntervalDS) (int, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	if a.IsNull || b.IsNull {
+		return 0, er("Null intervals can't be compared.")
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_DS, 2)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_DS)
+	if err = env.setIntervalDS(ivs[0], a); err == nil {
+		err = env.setIntervalDS(ivs[1], b)
+	}
+	if err != nil {
+		return 0, errE(err)
+	}
+	return env.compareIntervals(ivs[0], ivs[1])
+}
+
+// AddIntervalYM returns the sum of a and b computed by OCIIntervalAdd. The
+// sum is null when a or b is null.
+func (env *Env) AddIntervalYM(a, b IntervalYM) (IntervalYM, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	return env.intervalYMOp(a, b, func(x, y, result *C.OCIInterval) C.sword {
+		return C.OCIIntervalAdd(unsafe.Pointer(env.ocienv), env.ocierr, x, y, result)
+	})
+}
+
+// SubIntervalYM returns a minus b computed by OCIIntervalSubtract. The
+// difference is null when a or b is null.
+func (env *Env) SubIntervalYM(a, b IntervalYM) (IntervalYM, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	return env.intervalYMOp(a, b, func(x, y, result *C.OCIInterval) C.sword {
+		return C.OCIIntervalSubtract(unsafe.Pointer(env.ocienv), env.ocierr, x, y, result)
+	})
+}
+
+// CompareIntervalYM returns -1, 0 or 1 as a is less than, equal to or
+// greater than b, compared by OCIIntervalCompare. Null intervals can't be
+// compared.
+func (env *Env) CompareIntervalYM(a, b IntervalYM) (int, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	if a.IsNull || b.IsNull {
+		return 0, er("Null intervals can't be compared.")
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_YM, 2)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_YM)
+	if err = env.setIntervalYM(ivs[0], a); err == nil {
+		err = env.setIntervalYM(ivs[1], b)
+	}
+	if err != nil {
+		return 0, errE(err)
+	}
+	return env.compareIntervals(ivs[0], ivs[1])
+}
+
+// ShiftTimeDS returns t shifted by interval, computed by
+// OCIDateTimeIntervalAdd in the UTC offset of t. Unlike IntervalDS.ShiftTime,
+// the result is in the fixed UTC offset of t at the original time, as Oracle
+// computes with a TIMESTAMP WITH TIME ZONE. t is returned for a null interval.
+func (env *Env) ShiftTimeDS(t time.Time, interval IntervalDS) (time.Time, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	if interval.IsNull {
+		return t, nil
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_DS, 1)
+	if err != nil {
+		return t, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_DS)
+	if err = env.setIntervalDS(ivs[0], interval); err != nil {
+		return t, errE(err)
+	}
+	return env.shiftTime(t, ivs[0])
+}
+
+// ShiftTimeYM returns t shifted by interval, computed by
+// OCIDateTimeIntervalAdd. Unlike IntervalYM.ShiftTime, which normalizes
+// January 31 plus one month to March 3, a day which doesn't exist in the
+// resulting month is an error, ORA-01839, as in Oracle. t is returned for a
+// null interval.
+func (env *Env) ShiftTimeYM(t time.Time, interval IntervalYM) (time.Time, error) {
+	env.log(_drv.cfg().Log.Env.Interval)
+	if interval.IsNull {
+		return t, nil
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_YM, 1)
+	if err != nil {
+		return t, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_YM)
+	if err = env.setIntervalYM(ivs[0], interval); err != nil {
+		return t, errE(err)
+	}
+	return env.shiftTime(t, ivs[0])
+}
+
+// intervalDSOp returns the result of op applied to a and b.
+func (env *Env) intervalDSOp(a, b IntervalDS, op func(x, y, result *C.OCIInterval) C.sword) (IntervalDS, error) {
+	if a.IsNull || b.IsNull {
+		return IntervalDS{IsNull: true}, nil
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_DS, 3)
+	if err != nil {
+		return IntervalDS{}, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_DS)
+	if err = env.setIntervalDS(ivs[0], a); err == nil {
+		err = env.setIntervalDS(ivs[1], b)
+	}
+	if err != nil {
+		return IntervalDS{}, errE(err)
+	}
+	if op(ivs[0], ivs[1], ivs[2]) == C.OCI_ERROR {
+		return IntervalDS{}, errE(env.ociError())
+	}
+	result, err := env.getIntervalDS(ivs[2])
+	if err != nil {
+		return IntervalDS{}, errE(err)
+	}
+	return result, nil
+}
+
+// intervalYMOp returns the result of op applied to a and b.
+func (env *Env) intervalYMOp(a, b IntervalYM, op func(x, y, result *C.OCIInterval) C.sword) (IntervalYM, error) {
+	if a.IsNull || b.IsNull {
+		return IntervalYM{IsNull: true}, nil
+	}
+	ivs, err := env.allocIntervals(C.OCI_DTYPE_INTERVAL_YM, 3)
+	if err != nil {
+		return IntervalYM{}, errE(err)
+	}
+	defer freeIntervals(ivs, C.OCI_DTYPE_INTERVAL_YM)
+	if err = env.setIntervalYM(ivs[0], a); err == nil {
+		err = env.setIntervalYM(ivs[1], b)
+	}
+	if err != nil {
+		return IntervalYM{}, errE(err)
+	}
+	if op(ivs[0], ivs[1], ivs[2]) == C.OCI_ERROR {
+		return IntervalYM{}, errE(env.ociError())
+	}
+	result, err := env.getIntervalYM(ivs[2])
+	if err != nil {
+		return IntervalYM{}, errE(err)
+	}
+	return result, nil
+}
+
+// compareIntervals returns the OCIIntervalCompare result of x and y.
+func (env *Env) compareIntervals(x, y *C.OCIInterval) (int, error) {
+	var result C.sword
+	r := C.OCIIntervalCompare(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		x,                          //OCIInterval        *inter1,
+		y,                          //OCIInterval        *inter2,
+		&result)                    //sword              *result );
+	if r == C.OCI_ERROR {
+		return 0, errE(env.ociError())
+	}
+	switch {
+	case result < 0:
+		return -1, nil
+	case result > 0:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// shiftTime returns t shifted by interval with OCIDateTimeIntervalAdd.
+func (env *Env) shiftTime(t time.Time, interval *C.OCIInterval) (result time.Time, err error) {
+	var dts [2]*C.OCIDateTime
+	for n := range dts {
+		r := C.OCIDescriptorAlloc(
+			unsafe.Pointer(env.ocienv),                 //CONST dvoid   *parenth,
+			(*unsafe.Pointer)(unsafe.Pointer(&dts[n])), //dvoid         **descpp,
+			C.OCI_DTYPE_TIMESTAMP_TZ,                   //ub4           type,
+			0,                                          //size_t        xtramem_sz,
+			nil)                                        //dvoid         **usrmempp);
+		if r == C.OCI_ERROR {
+			return t, errE(env.ociError())
+		} else if r == C.OCI_INVALID_HANDLE {
+			return t, errNew("unable to allocate oci timestamp handle")
+		}
+		defer C.OCIDescriptorFree(unsafe.Pointer(dts[n]), C.OCI_DTYPE_TIMESTAMP_TZ)
+	}
+	var buf bytes.Buffer
+	zone := zoneOffset(t, &buf)
+	cZone := C.CString(zone)
+	defer C.free(unsafe.Pointer(cZone))
+	r := C.OCIDateTimeConstruct(
+		unsafe.Pointer(env.ocienv),          //dvoid         *hndl,
+		env.ocierr,                          //OCIError      *err,
+		dts[0],                              //OCIDateTime   *datetime,
+		C.sb2(t.Year()),                     //sb2           year,
+		C.ub1(int32(t.Month())),             //ub1           month,
+		C.ub1(t.Day()),                      //ub1           day,
+		C.ub1(t.Hour()),                     //ub1           hour,
+		C.ub1(t.Minute()),                   //ub1           min,
+		C.ub1(t.Second()),                   //ub1           sec,
+		C.ub4(t.Nanosecond()),               //ub4           fsec,
+		(*C.OraText)(unsafe.Pointer(cZone)), //OraText       *timezone,
+		C.size_t(len(zone)))                 //size_t        timezone_length );
+	if r == C.OCI_ERROR {
+		return t, errE(env.ociError())
+	}
+	r = C.OCIDateTimeIntervalAdd(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		dts[0],                     //OCIDateTime        *datetime,
+		interval,                   //OCIInterval        *inter,
+		dts[1])                     //OCIDateTime        *outdatetime );
+	if r == C.OCI_ERROR {
+		return t, errE(env.ociError())
+	}
+	result, err = getTime(env, env.ocierr, dts[1])
+	if err != nil {
+		return t, errE(err)
+	}
+	return result, nil
+}
+
+// allocIntervals allocates n interval descriptors of dtype.
+func (env *Env) allocIntervals(dtype C.ub4, n int) (ivs []*C.OCIInterval, err error) {
+	if err = env.checkClosed(); err != nil {
+		return nil, err
+	}
+	ivs = make([]*C.OCIInterval, n)
+	for n := range ivs {
+		r := C.OCIDescriptorAlloc(
+			unsafe.Pointer(env.ocienv),                 //CONST dvoid   *parenth,
+			(*unsafe.Pointer)(unsafe.Pointer(&ivs[n])), //dvoid         **descpp,
+			dtype, //ub4           type,
+			0,     //size_t        xtramem_sz,
+			nil)   //dvoid         **usrmempp);
+		if r == C.OCI_ERROR {
+			err = env.ociError()
+		} else if r == C.OCI_INVALID_HANDLE {
+			err = errNew("unable to allocate oci interval handle")
+		}
+		if err != nil {
+			freeIntervals(ivs[:n], dtype)
+			return nil, err
+		}
+	}
+	return ivs, nil
+}
+
+// freeIntervals frees interval descriptors of dtype.
+func freeIntervals(ivs []*C.OCIInterval, dtype C.ub4) {
+	for _, iv := range ivs {
+		C.OCIDescriptorFree(unsafe.Pointer(iv), dtype)
+	}
+}
+
+func (env *Env) setIntervalDS(iv *C.OCIInterval, value IntervalDS) error {
+	r := C.OCIIntervalSetDaySecond(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		C.sb4(value.Day),           //sb4                dy,
+		C.sb4(value.Hour),          //sb4                hr,
+		C.sb4(value.Minute),        //sb4                mm,
+		C.sb4(value.Second),        //sb4                ss,
+		C.sb4(value.Nanosecond),    //sb4                fsec,
+		iv)                         //OCIInterval        *result );
+	if r == C.OCI_ERROR {
+		return env.ociError()
+	}
+	return nil
+}
+
+func (env *Env) getIntervalDS(iv *C.OCIInterval) (value IntervalDS, err error) {
+	var day, hour, minute, second, nanosecond C.sb4
+	r := C.OCIIntervalGetDaySecond(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		&day,                       //sb4                *dy,
+		&hour,                      //sb4                *hr,
+		&minute,                    //sb4                *mm,
+		&second,                    //sb4                *ss,
+		&nanosecond,                //sb4                *fsec,
+		iv)                         //const OCIInterval  *interval );
+	if r == C.OCI_ERROR {
+		return value, env.ociError()
+	}
+	value.Day = int32(day)
+	value.Hour = int32(hour)
+	value.Minute = int32(minute)
+	value.Second = int32(second)
+	value.Nanosecond = int32(nanosecond)
+	return value, nil
+}
+
+func (env *Env) setIntervalYM(iv *C.OCIInterval, value IntervalYM) error {
+	r := C.OCIIntervalSetYearMonth(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		C.sb4(value.Year),          //sb4                yr,
+		C.sb4(value.Month),         //sb4                mnth,
+		iv)                         //OCIInterval        *result );
+	if r == C.OCI_ERROR {
+		return env.ociError()
+	}
+	return nil
+}
+
+func (env *Env) getIntervalYM(iv *C.OCIInterval) (value IntervalYM, err error) {
+	var year, month C.sb4
+	r := C.OCIIntervalGetYearMonth(
+		unsafe.Pointer(env.ocienv), //void               *hndl,
+		env.ocierr,                 //OCIError           *err,
+		&year,                      //sb4                *yr,
+		&month,                     //sb4                *mnth,
+		iv)                         //const OCIInterval  *interval );
+	if r == C.OCI_ERROR {
+		return value, env.ociError()
+	}
+	value.Year = int32(year)
+	value.Month = int32(month)
+	return value, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONValue binds a Go value as JSON text, encoded with encoding/json.
+//
+// A JSONValue binds to a VARCHAR2 or CLOB column with an IS JSON check
+// constraint, or to a native JSON column. A nil Value binds a NULL.
+// A json.RawMessage may also be bound directly, in which case it is sent as is.
+type JSONValue struct {
+	Value interface{}
+}
+
+// jsonBindText returns the JSON text of a json.RawMessage or JSONValue.
+// null is true when the value is to be bound as NULL.
+func jsonBindText(value interface{}) (text string, null bool, err error) {
+	switch v := value.(type) {
+	case json.RawMessage:
+		if len(v) == 0 {
+			return "", true, nil
+		}
+		return string(v), false, nil
+	case JSONValue:
+		if v.Value == nil {
+			return "", true, nil
+		}
+		b, err := json.Marshal(v.Value)
+		if err != nil {
+			return "", false, errF("Unable to marshal JSON bind value (%T): %v", v.Value, err)
+		}
+		return string(b), false, nil
+	case *JSONValue:
+		if v == nil {
+			return "", true, nil
+		}
+		return jsonBindText(*v)
+	}
+	return "", false, errF("Invalid JSON bind parameter (%T).", value)
+}
+
+// jsonDefValue converts fetched JSON text to the Go value of gct:
+// a json.RawMessage for JSON, or the value decoded by encoding/json for JSONAny.
+func jsonDefValue(text []byte, gct GoColumnType) (interface{}, error) {
+	text = bytes.TrimRight(text, "\x00")
+	if gct == JSON {
+		return json.RawMessage(text), nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(text, &v); err != nil {
+		return nil, errF("Unable to decode JSON column value: %v", err)
+	}
+	return v, nil
+}
+
+// checkJSONColumn returns an error when the column type is not JSON or JSONAny.
+func checkJSONColumn(gct GoColumnType) error {
+	switch gct {
+	case JSON, JSONAny:
+		return nil
+	}
+	return errF("Invalid go column type (%v) specified. Expected go column type JSON or JSONAny.", GctName(gct))
+}
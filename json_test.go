@@ -0,0 +1,59 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONBindText(t *testing.T) {
+	for i, tc := range []struct {
+		value interface{}
+		text  string
+		null  bool
+	}{
+		{value: json.RawMessage(`{"a":1}`), text: `{"a":1}`},
+		{value: json.RawMessage(nil), null: true},
+		{value: JSONValue{Value: map[string]int{"a": 1}}, text: `{"a":1}`},
+		{value: JSONValue{}, null: true},
+		{value: &JSONValue{Value: []int{1, 2}}, text: `[1,2]`},
+		{value: (*JSONValue)(nil), null: true},
+	} {
+		text, null, err := jsonBindText(tc.value)
+		if err != nil {
+			t.Errorf("%d. %v", i, err)
+			continue
+		}
+		if text != tc.text || null != tc.null {
+			t.Errorf("%d. got %q/%t, wanted %q/%t", i, text, null, tc.text, tc.null)
+		}
+	}
+	if _, _, err := jsonBindText(JSONValue{Value: make(chan int)}); err == nil {
+		t.Errorf("chan: wanted error")
+	}
+}
+
+func TestJSONDefValue(t *testing.T) {
+	raw, err := jsonDefValue([]byte(`{"a":[1,"b"]}`), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw.(json.RawMessage)) != `{"a":[1,"b"]}` {
+		t.Errorf("JSON: got %s", raw)
+	}
+	v, err := jsonDefValue([]byte(`{"a":[1,"b"]}`), JSONAny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"a": []interface{}{float64(1), "b"}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("JSONAny: got %#v, wanted %#v", v, want)
+	}
+	if _, err = jsonDefValue([]byte(`{`), JSONAny); err == nil {
+		t.Errorf("invalid: wanted error")
+	}
+}
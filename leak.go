@@ -0,0 +1,146 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// LeakCfg configures the detection of unclosed Stmt, Rset, Tx and Lob handles.
+//
+// Leaked statements and result sets hold server cursors, which eventually
+// surface as ORA-01000 (maximum open cursors exceeded). Enabling leak detection
+// records the creation stack of each handle so the leaking call site can be found.
+type LeakCfg struct {
+	// Enabled determines whether handles record their creation stack and
+	// are tracked until closed.
+	//
+	// The default is false.
+	Enabled bool
+
+	// Timeout is the duration a tracked handle may remain open before it is
+	// reported as a leak.
+	//
+	// The default is zero, which reports open handles only at Ses.Close.
+	Timeout time.Duration
+}
+
+// NewLeakCfg creates a LeakCfg with default values.
+func NewLeakCfg() LeakCfg {
+	c := LeakCfg{}
+	c.Enabled = false
+	c.Timeout = 0
+	return c
+}
+
+// Leak describes a tracked handle which has not been closed.
+type Leak struct {
+	// Kind is the kind of handle: "Stmt", "Rset", "Tx" or "Lob".
+	Kind string
+	// Name is the system name of the handle, such as E1S1S1S3.
+	Name string
+	// Opened is the time the handle was opened.
+	Opened time.Time
+	// Stack is the goroutine stack trace captured when the handle was opened.
+	Stack string
+}
+
+// String returns a description of the Leak.
+func (l Leak) String() string {
+	return fmt.Sprintf("%v %v opened %v ago, not closed:\n%v", l.Kind, l.Name, time.Since(l.Opened), l.Stack)
+}
+
+// leakRegistry tracks the open handles of a Ses.
+type leakRegistry struct {
+	mu    sync.Mutex
+	cfg   LeakCfg
+	items map[interface{}]*leakItem
+}
+
+type leakItem struct {
+	Leak
+	timer *time.Timer
+}
+
+func newLeakRegistry(cfg LeakCfg) *leakRegistry {
+	return &leakRegistry{cfg: cfg, items: make(map[interface{}]*leakItem)}
+}
+
+// track records the creation stack of handle.
+// It is valid to call track on a nil *leakRegistry.
+func (r *leakRegistry) track(handle interface{}, kind, name string) {
+	if r == nil {
+		return
+	}
+	trace := make([]byte, 4096)
+	n := runtime.Stack(trace, false)
+	item := &leakItem{Leak: Leak{Kind: kind, Name: name, Opened: time.Now(), Stack: string(trace[:n])}}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.items[handle]; ok && old.timer != nil { // pooled handle reused
+		old.timer.Stop()
+	}
+	if r.cfg.Timeout > 0 {
+		item.timer = time.AfterFunc(r.cfg.Timeout, func() {
+			lgr.Errorf("ora: leak: %v", item.Leak)
+		})
+	}
+	r.items[handle] = item
+}
+
+// untrack stops tracking handle.
+// It is valid to call untrack on a nil *leakRegistry.
+func (r *leakRegistry) untrack(handle interface{}) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if item, ok := r.items[handle]; ok {
+		if item.timer != nil {
+			item.timer.Stop()
+		}
+		delete(r.items, handle)
+	}
+}
+
+// open returns the currently tracked handles ordered by opening time.
+func (r *leakRegistry) open() []Leak {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	leaks := make([]Leak, 0, len(r.items))
+	for _, item := range r.items {
+		n := len(leaks)
+		leaks = append(leaks, item.Leak)
+		for ; n > 0 && leaks[n].Opened.Before(leaks[n-1].Opened); n-- {
+			leaks[n], leaks[n-1] = leaks[n-1], leaks[n]
+		}
+	}
+	return leaks
+}
+
+// reportAll logs every tracked handle as a leak and stops tracking.
+func (r *leakRegistry) reportAll() {
+	if r == nil {
+		return
+	}
+	for _, leak := range r.open() {
+		lgr.Errorf("ora: leak: %v", leak)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for handle, item := range r.items {
+		if item.timer != nil {
+			item.timer.Stop()
+		}
+		delete(r.items, handle)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadCfg configures Ses.LoadCSV and Ses.LoadRows.
+type LoadCfg struct {
+	// Table is the name of the table loaded.
+	Table string
+
+	// Cols are the table columns in the order of the fields of each record.
+	//
+	// The default is empty, which uses the header record of a CSV load.
+	Cols []string
+
+	// Header determines whether the first CSV record is a header which is
+	// not loaded.
+	//
+	// The default is false.
+	Header bool
+
+	// Comma is the CSV field delimiter.
+	//
+	// The default is zero, which uses ','.
+	Comma rune
+
+	// BatchSize is the number of rows inserted by each array INSERT.
+	//
+	// The default is zero, which uses 1000.
+	BatchSize int
+
+	// MaxErrors is the number of rejected rows after which the load stops.
+	// A rejected row is recorded in LoadResult.Errors and the load continues.
+	//
+	// The default is zero, which stops the load on the first rejected row.
+	MaxErrors int
+
+	// Progress, when not nil, is called after each batch with the number of
+	// rows loaded so far.
+	Progress func(rows uint64)
+}
+
+// LoadError describes a row rejected by a load.
+type LoadError struct {
+	// Row is the one-based number of the row within the input, excluding
+	// any header.
+	Row uint64
+	// Err is the error returned by Oracle for the row.
+	Err error
+}
+
+// Error returns a description of the LoadError.
+func (e LoadError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// LoadResult reports the outcome of a load.
+type LoadResult struct {
+	// Rows is the number of rows inserted.
+	Rows uint64
+	// Errors are the rejected rows.
+	Errors []LoadError
+}
+
+// LoadCSV inserts the CSV records read from r into a table with array-bound
+// INSERTs of LoadCfg.BatchSize rows.
+//
+// Empty fields are inserted as NULL. Values are bound as strings and
+// converted by Oracle to the column types; set NLS formats on the Ses to
+// control the conversion of dates and numbers.
+//
+// When a batch fails, its rows are inserted one at a time to find the
+// rejected rows. The load stops with an error once more than LoadCfg.MaxErrors
+// rows are rejected; rows inserted before the error are kept unless the Ses
+// rolls back an open transaction.
+func (ses *Ses) LoadCSV(r io.Reader, cfg LoadCfg) (result LoadResult, err error) {
+	ses.log(_drv.cfg().Log.Ses.Load)
+	cr := csv.NewReader(r)
+	if cfg.Comma != 0 {
+		cr.Comma = cfg.Comma
+	}
+	cr.FieldsPerRecord = len(cfg.Cols)
+	if cfg.Header {
+		header, err := cr.Read()
+		if err != nil {
+			return result, errE(err)
+		}
+		if len(cfg.Cols) == 0 {
+			cfg.Cols = header
+		}
+	}
+	return ses.load(cfg, func() ([]string, error) {
+		return cr.Read()
+	})
+}
+
+// LoadRows inserts the rows received from rows into a table like LoadCSV.
+// The load ends when rows is closed.
+func (ses *Ses) LoadRows(rows <-chan []string, cfg LoadCfg) (result LoadResult, err error) {
+	ses.log(_drv.cfg().Log.Ses.Load)
+	return ses.load(cfg, func() ([]string, error) {
+		row, ok := <-rows
+		if !ok {
+			return nil, io.EOF
+		}
+		return row, nil
+	})
+}
+
+// load inserts the records returned by next until next returns io.EOF.
+func (ses *Ses) load(cfg LoadCfg, next func() ([]string, error)) (result LoadResult, err error) {
+	if cfg.Table == "" || len(cfg.Cols) == 0 {
+		return result, er("LoadCfg requires a Table and Cols.")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1000
+	}
+	placeholders := make([]string, len(cfg.Cols))
+	for n := range placeholders {
+		placeholders[n] = fmt.Sprintf(":%d", n+1)
+	}
+	sql := fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v)",
+		cfg.Table, strings.Join(cfg.Cols, ", "), strings.Join(placeholders, ", "))
+	stmt, err := ses.Prep(sql)
+	if err != nil {
+		return result, errE(err)
+	}
+	defer func() {
+		if err0 := stmt.Close(); err == nil {
+			err = err0
+		}
+	}()
+	l := loader{cfg: cfg, stmt: stmt, result: &result, cols: make([][]String, len(cfg.Cols))}
+	for {
+		record, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, errE(err)
+		}
+		if len(record) != len(cfg.Cols) {
+			return result, errF("Row %d has %d fields for %d columns.", l.read+1, len(record), len(cfg.Cols))
+		}
+		l.read++
+		for n, field := range record {
+			l.cols[n] = append(l.cols[n], String{IsNull: field == "", Value: field})
+		}
+		if len(l.cols[0]) == cfg.BatchSize {
+			if err = l.flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err = l.flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// loader holds the state of a load between batches.
+type loader struct {
+	cfg    LoadCfg
+	stmt   *Stmt
+	result *LoadResult
+	cols   [][]String
+	read   uint64 // records read
+}
+
+// flush inserts the buffered batch, retrying row by row when the batch fails.
+func (l *loader) flush() error {
+	size := len(l.cols[0])
+	if size == 0 {
+		return nil
+	}
+	first := l.read - uint64(size) + 1 // row number of the batch's first row
+	params := make([]interface{}, len(l.cols))
+	for n := range l.cols {
+		params[n] = l.cols[n]
+	}
+	// a failed array INSERT keeps the rows preceding the failing row; undo
+	// them before retrying row by row
+	ses := l.stmt.ses
+	autoCommit := l.stmt.Cfg().IsAutoCommitting && ses.NumTx() == 0
+	if !autoCommit {
+		if _, err := ses.exe("SAVEPOINT ORA_LOAD"); err != nil {
+			return errE(err)
+		}
+	}
+	if _, err := l.stmt.Exe(params...); err != nil {
+		undo := "ROLLBACK" // nothing uncommitted but the failed batch
+		if !autoCommit {
+			undo = "ROLLBACK TO SAVEPOINT ORA_LOAD"
+		}
+		if _, err := ses.exe(undo); err != nil {
+			return errE(err)
+		}
+		for row := 0; row < size; row++ {
+			for n := range l.cols {
+				params[n] = l.cols[n][row : row+1]
+			}
+			if _, err := l.stmt.Exe(params...); err != nil {
+				l.result.Errors = append(l.result.Errors, LoadError{Row: first + uint64(row), Err: err})
+				if len(l.result.Errors) > l.cfg.MaxErrors {
+					return errE(l.result.Errors[len(l.result.Errors)-1])
+				}
+				continue
+			}
+			l.result.Rows++
+		}
+	} else {
+		l.result.Rows += uint64(size)
+	}
+	for n := range l.cols {
+		l.cols[n] = l.cols[n][:0]
+	}
+	if l.cfg.Progress != nil {
+		l.cfg.Progress(l.result.Rows)
+	}
+	return nil
+}
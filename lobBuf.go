@@ -0,0 +1,73 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "sync"
+
+const (
+	// lobChunkSize is the default size of the pieces in which LOBs are read
+	// and written; see EnvCfg.LobChunkSize.
+	lobChunkSize = 16 << 20 // 16Mb
+
+	// maxLobChunkSize is the largest EnvCfg.LobChunkSize and
+	// SesCfg.LobChunkSize.
+	maxLobChunkSize = 1 << 30 // 1Gb
+
+	// minLobBufShift is the log2 of the size of the smallest pooled LOB
+	// buffer; smaller requests get a buffer of the smallest size.
+	minLobBufShift = 16 // 64Kb
+	// maxLobBufShift is the log2 of the size of the largest pooled LOB buffer.
+	maxLobBufShift = 30
+)
+
+// lobBufPools pool the LOB chunk buffers of each power-of-two size from
+// 1<<minLobBufShift to 1<<maxLobBufShift bytes, so that sessions with
+// different chunk sizes share buffers without holding the largest size.
+var lobBufPools [maxLobBufShift - minLobBufShift + 1]sync.Pool
+
+// lobBufTier returns the index in lobBufPools of the buffers of size bytes,
+// or -1 when size is too large to pool.
+func lobBufTier(size int) int {
+	tier := 0
+	for n := 1 << minLobBufShift; n < size; n <<= 1 {
+		tier++
+	}
+	if tier >= len(lobBufPools) {
+		return -1
+	}
+	return tier
+}
+
+// getLobBuf returns a buffer of length size from the pool of its tier.
+// Return it with putLobBuf.
+func getLobBuf(size int) *[]byte {
+	tier := lobBufTier(size)
+	if tier < 0 {
+		b := make([]byte, size)
+		return &b
+	}
+	if v := lobBufPools[tier].Get(); v != nil {
+		b := v.(*[]byte)
+		*b = (*b)[:size]
+		return b
+	}
+	b := make([]byte, size, 1<<uint(minLobBufShift+tier))
+	return &b
+}
+
+// putLobBuf returns a buffer got with getLobBuf to its pool.
+func putLobBuf(b *[]byte) {
+	if tier := lobBufTier(cap(*b)); tier >= 0 && cap(*b) == 1<<uint(minLobBufShift+tier) {
+		lobBufPools[tier].Put(b)
+	}
+}
+
+// lobChunk returns the LOB chunk size of the Ses.
+func (ses *Ses) lobChunk() int {
+	if ses.cfg.LobChunkSize > 0 {
+		return ses.cfg.LobChunkSize
+	}
+	return lobChunkSize
+}
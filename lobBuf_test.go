@@ -0,0 +1,35 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestLobBuf tests getLobBuf and putLobBuf.
+func TestLobBuf(t *testing.T) {
+	for _, tc := range []struct {
+		size, tier, cap int
+	}{
+		{1, 0, 1 << 16},
+		{1 << 16, 0, 1 << 16},
+		{1<<16 + 1, 1, 1 << 17},
+		{lobChunkSize, 8, lobChunkSize},
+		{maxLobChunkSize, maxLobBufShift - minLobBufShift, maxLobChunkSize},
+	} {
+		if tier := lobBufTier(tc.size); tier != tc.tier {
+			t.Errorf("%d: got tier %d, wanted %d", tc.size, tier, tc.tier)
+		}
+		if tc.size > lobChunkSize {
+			continue // don't allocate 1Gb
+		}
+		b := getLobBuf(tc.size)
+		if len(*b) != tc.size || cap(*b) != tc.cap {
+			t.Errorf("%d: got len %d cap %d, wanted cap %d", tc.size, len(*b), cap(*b), tc.cap)
+		}
+		putLobBuf(b)
+	}
+	if tier := lobBufTier(maxLobChunkSize + 1); tier != -1 {
+		t.Errorf("got tier %d for an unpooled size, wanted -1", tier)
+	}
+}
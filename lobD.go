@@ -0,0 +1,131 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"io"
+	"unsafe"
+)
+
+// LobD is a CLOB, NCLOB or BLOB select-list value whose content is read
+// on demand.
+//
+// Specify the OraLobD GoColumnType to obtain LobD values. The length and chunk
+// size are read when the row is fetched, so callers can decide per row
+// whether to download the content with LobD.Reader or LobD.Bytes. The defines
+// of the Rset set OCI_ATTR_LOBPREFETCH_LENGTH, with which OCI returns both
+// values from the fetched locator; an OCI client or server that doesn't
+// prefetch them makes a round trip for each. A LobD which isn't read must be
+// closed with LobD.Close.
+type LobD struct {
+	IsNull bool
+	// Length is the length of the LOB; in bytes for a BLOB and characters for a CLOB.
+	Length uint64
+	// ChunkSize is the optimal size in bytes of a LOB read or write; see
+	// LobD.PieceSize.
+	ChunkSize uint32
+
+	loc *lobLocator
+}
+
+// lobLocator is the locator of a LobD which hasn't been read.
+type lobLocator struct {
+	ses           *Ses
+	ociLobLocator *C.OCILobLocator
+	charsetForm   C.ub1
+	clob          bool
+}
+
+// lobD returns a LobD for the current locator and dissociates this def from
+// the LOB.
+func (def *defLob) lobD() (value LobD, err error) {
+	ses := def.rset.stmt.ses
+	length, err := lobLength(ses, def.ociLobLocator)
+	if err != nil {
+		return value, err
+	}
+	chunkSize, err := lobGetChunkSize(ses, def.ociLobLocator)
+	if err != nil {
+		return value, err
+	}
+	value.Length = uint64(length)
+	value.ChunkSize = chunkSize
+	value.loc = &lobLocator{ses: ses, ociLobLocator: def.ociLobLocator, charsetForm: def.charsetForm, clob: def.sqlt == C.SQLT_CLOB}
+	def.ociLobLocator = nil
+	ses.leaks.track(value.loc, "Lob", def.rset.sysName())
+	return value, nil
+}
+
+// Reader returns an io.ReadCloser reading the content of the LOB.
+//
+// A LobD may be read once. Closing the returned ReadCloser releases the LOB.
+func (l LobD) Reader() (io.ReadCloser, error) {
+	if l.IsNull {
+		return nil, er("LobD is null.")
+	}
+	if l.loc == nil || l.loc.ociLobLocator == nil {
+		return nil, er("LobD is closed or has been read.")
+	}
+	ses, lob := l.loc.ses, l.loc.ociLobLocator
+	ses.leaks.untrack(l.loc)
+	l.loc.ociLobLocator, l.loc.ses = nil, nil
+	length, err := lobOpen(ses, lob, C.OCI_LOB_READONLY)
+	if err != nil {
+		return nil, errE(err)
+	}
+	lr := &lobReader{
+		ses:           ses,
+		ociLobLocator: lob,
+		charsetForm:   l.loc.charsetForm,
+		piece:         C.OCI_FIRST_PIECE,
+		Length:        length,
+		clob:          l.loc.clob,
+	}
+	ses.leaks.track(lr, "Lob", ses.sysName())
+	return lr, nil
+}
+
+// Bytes reads the content of the LOB and releases it.
+func (l LobD) Bytes() ([]byte, error) {
+	if l.IsNull {
+		return nil, nil
+	}
+	r, err := l.Reader()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if l.Length > 0 && l.Length < lobChunkSize {
+		buf.Grow(int(l.Length))
+	}
+	_, err = buf.ReadFrom(r)
+	if closeErr := r.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errE(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Close releases a LobD which hasn't been read.
+//
+// It is valid to call Close on a null or read LobD.
+func (l LobD) Close() error {
+	if l.loc == nil || l.loc.ociLobLocator == nil {
+		return nil
+	}
+	l.loc.ses.leaks.untrack(l.loc)
+	C.OCIDescriptorFree(
+		unsafe.Pointer(l.loc.ociLobLocator), //void     *descp,
+		C.OCI_DTYPE_LOB)                     //ub4      type );
+	l.loc.ociLobLocator, l.loc.ses = nil, nil
+	return nil
+}
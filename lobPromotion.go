@@ -0,0 +1,33 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+
+// promotesToLob reports whether a string or binary value of length bytes is
+// bound as a temporary LOB, as configured by StmtCfg.LobPromotionSize.
+// No locking occurs.
+func (stmt *Stmt) promotesToLob(length int) bool {
+	floor := stmt.ses.srv.maxStringSize()
+	if stmt.stmtType == C.OCI_STMT_BEGIN || stmt.stmtType == C.OCI_STMT_DECLARE {
+		floor = maxExtendedStringSize
+	}
+	return promotesToLob(length, stmt.cfg.LobPromotionSize, floor)
+}
+
+// promotesToLob reports whether a value of length bytes exceeds limit, or
+// floor when limit is smaller. A limit of zero or less never promotes.
+func promotesToLob(length, limit, floor int) bool {
+	if limit <= 0 {
+		return false
+	}
+	if limit < floor {
+		limit = floor
+	}
+	return length > limit
+}
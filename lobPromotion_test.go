@@ -0,0 +1,33 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestPromotesToLob tests promotesToLob.
+func TestPromotesToLob(t *testing.T) {
+	for _, tc := range []struct {
+		length, limit, floor int
+		want                 bool
+	}{
+		{4000, 4000, maxStringSize, false},
+		{4001, 4000, maxStringSize, true},
+		{4001, 4000, maxExtendedStringSize, false},
+		{32768, 4000, maxExtendedStringSize, true},
+		{40000, 40000, maxExtendedStringSize, false},
+		{1 << 20, 0, maxStringSize, false},
+	} {
+		if got := promotesToLob(tc.length, tc.limit, tc.floor); got != tc.want {
+			t.Errorf("%d/%d floor %d: got %v, wanted %v", tc.length, tc.limit, tc.floor, got, tc.want)
+		}
+	}
+}
+
+// TestLobPromotionSize_default tests that promotion is opt-in.
+func TestLobPromotionSize_default(t *testing.T) {
+	if n := NewStmtCfg().LobPromotionSize; n != 0 {
+		t.Errorf("got %v, wanted 0", n)
+	}
+}
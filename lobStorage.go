@@ -0,0 +1,119 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "unsafe"
+
+// LobStorage are the SecureFile storage options of a LOB.
+type LobStorage struct {
+	// SecureFile is true for a SecureFile LOB. The options of a BasicFile
+	// LOB are all false.
+	SecureFile bool
+	// Compressed is true when the LOB is stored with SecureFile compression.
+	Compressed bool
+	// Deduplicated is true when the LOB is stored with SecureFile
+	// deduplication.
+	Deduplicated bool
+	// Encrypted is true when the LOB is stored with SecureFile encryption.
+	Encrypted bool
+}
+
+// errBasicFile is the code of ORA-43856, returned when SecureFile options
+// are got for a BasicFile LOB.
+const errBasicFile = "43856"
+
+// Storage returns the SecureFile storage options of a LobD which hasn't been
+// read, with a round trip to the server.
+func (l LobD) Storage() (storage LobStorage, err error) {
+	if l.IsNull {
+		return storage, er("LobD is null.")
+	}
+	if l.loc == nil || l.loc.ociLobLocator == nil {
+		return storage, er("LobD is closed or has been read.")
+	}
+	ses := l.loc.ses
+	var options C.ub4
+	size := C.ub4(unsafe.Sizeof(options))
+	types := C.ub4(C.OCI_LOB_OPT_COMPRESS | C.OCI_LOB_OPT_ENCRYPT | C.OCI_LOB_OPT_DEDUPLICATE)
+	r := ses.poll(nil, func() C.sword {
+		return C.OCILobGetOptions(
+			ses.ocisvcctx,            //OCISvcCtx          *svchp,
+			ses.ocierr,               //OCIError           *errhp,
+			l.loc.ociLobLocator,      //OCILobLocator      *locp,
+			types,                    //ub4                option_types,
+			unsafe.Pointer(&options), //void               *optionsp,
+			&size,                    //ub4                *optionslenp,
+			C.OCI_DEFAULT)            //ub4                mode );
+	})
+	if r == C.OCI_ERROR {
+		err = ses.ociError()
+		if m := oraCode.FindStringSubmatch(err.Error()); m != nil && m[1] == errBasicFile {
+			return storage, nil
+		}
+		return storage, err
+	}
+	storage.SecureFile = true
+	storage.Compressed = options&C.OCI_LOB_COMPRESS_ON != 0
+	storage.Deduplicated = options&C.OCI_LOB_DEDUPLICATE_ON != 0
+	storage.Encrypted = options&C.OCI_LOB_ENCRYPT_ON != 0
+	return storage, nil
+}
+
+// PieceSize returns the size of the largest read or write piece of at most
+// max bytes which is a multiple of ChunkSize, or ChunkSize when max is
+// smaller. Pieces aligned to the chunk size avoid rewriting partial chunks,
+// which is costly for compressed and deduplicated SecureFiles.
+func (l LobD) PieceSize(max int) int {
+	return alignLobPiece(max, l.ChunkSize)
+}
+
+// alignLobPiece returns the largest multiple of chunkSize not greater than
+// size, chunkSize when size is smaller, or size when chunkSize is zero.
+func alignLobPiece(size int, chunkSize uint32) int {
+	chunk := int(chunkSize)
+	if chunk <= 0 {
+		return size
+	}
+	if size <= chunk {
+		return chunk
+	}
+	return size - size%chunk
+}
+
+// tempLobChunkSize returns the chunk size of lob, a temporary LOB bound by the
+// Stmt. The size is read once per Stmt, since the temporary LOBs of a session
+// share the block size of its temporary tablespace. No locking occurs.
+func (stmt *Stmt) tempLobChunkSize(lob *C.OCILobLocator) (uint32, error) {
+	if stmt.lobChunk == 0 {
+		chunkSize, err := lobGetChunkSize(stmt.ses, lob)
+		if err != nil {
+			return 0, err
+		}
+		stmt.lobChunk = chunkSize
+	}
+	return stmt.lobChunk, nil
+}
+
+// lobGetChunkSize returns the chunk size of the LOB in bytes; the usable
+// data size of a LOB block. The chunk size of a selected LOB is prefetched
+// with its length; see lobLength.
+func lobGetChunkSize(ses *Ses, lob *C.OCILobLocator) (uint32, error) {
+	var chunkSize C.ub4
+	r := ses.poll(nil, func() C.sword {
+		return C.OCILobGetChunkSize(
+			ses.ocisvcctx, //OCISvcCtx          *svchp,
+			ses.ocierr,    //OCIError           *errhp,
+			lob,           //OCILobLocator      *locp,
+			&chunkSize)    //ub4                *chunksizep );
+	})
+	if r == C.OCI_ERROR {
+		return 0, ses.ociError()
+	}
+	return uint32(chunkSize), nil
+}
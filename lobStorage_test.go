@@ -0,0 +1,26 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestAlignLobPiece tests alignLobPiece.
+func TestAlignLobPiece(t *testing.T) {
+	for _, tc := range []struct {
+		size      int
+		chunkSize uint32
+		want      int
+	}{
+		{1 << 20, 0, 1 << 20},
+		{1 << 20, 8132, 1040896},
+		{100, 8132, 8132},
+		{8132, 8132, 8132},
+		{3 * 8192, 8192, 3 * 8192},
+	} {
+		if got := alignLobPiece(tc.size, tc.chunkSize); got != tc.want {
+			t.Errorf("alignLobPiece(%d, %d): got %d, wanted %d", tc.size, tc.chunkSize, got, tc.want)
+		}
+	}
+}
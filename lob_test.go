@@ -0,0 +1,110 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestLobRuneReader tests Lob.RuneReader.
+func TestLobRuneReader(t *testing.T) {
+	rs := Lob{Reader: strings.NewReader("zß水🍺")}.RuneReader()
+	var got []rune
+	for {
+		r, _, err := rs.ReadRune()
+		if err != nil {
+			break
+		}
+		got = append(got, r)
+	}
+	if want := []rune("zß水🍺"); string(got) != string(want) {
+		t.Errorf("got %q, wanted %q", string(got), string(want))
+	}
+	if _, _, err := (Lob{}).RuneReader().ReadRune(); err == nil {
+		t.Error("wanted EOF for a null Lob")
+	}
+	if _, _, ok := (Lob{Reader: strings.NewReader("a")}).ReadAmounts(); ok {
+		t.Error("wanted no amounts for a Reader which isn't of a selected LOB")
+	}
+}
+
+// TestLobSeek tests Lob.ReadAt and Lob.Seek delegating to the Reader.
+func TestLobSeek(t *testing.T) {
+	lob := Lob{Reader: strings.NewReader("abcdef")}
+	if off, err := lob.Seek(-2, io.SeekEnd); err != nil || off != 4 {
+		t.Fatalf("SeekEnd: got %d, %v; wanted 4", off, err)
+	}
+	if b, err := ioutil.ReadAll(lob); err != nil || string(b) != "ef" {
+		t.Errorf("Read after SeekEnd: got %q, %v; wanted \"ef\"", b, err)
+	}
+	if off, err := lob.Seek(-5, io.SeekCurrent); err != nil || off != 1 {
+		t.Fatalf("SeekCurrent: got %d, %v; wanted 1", off, err)
+	}
+	p := make([]byte, 2)
+	if n, err := lob.Read(p); err != nil || string(p[:n]) != "bc" {
+		t.Errorf("Read after SeekCurrent: got %q, %v; wanted \"bc\"", p[:n], err)
+	}
+	if n, err := lob.ReadAt(p, 3); err != nil || string(p[:n]) != "de" {
+		t.Errorf("ReadAt: got %q, %v; wanted \"de\"", p[:n], err)
+	}
+	lob = Lob{Reader: bytes.NewBufferString("abc")}
+	if _, err := lob.Seek(1, io.SeekStart); err == nil {
+		t.Error("Seek: wanted an error for a Reader which isn't an io.Seeker")
+	}
+	if _, err := lob.ReadAt(p, 1); err == nil {
+		t.Error("ReadAt: wanted an error for a Reader which isn't an io.ReaderAt")
+	}
+}
+
+// TestLobReaderSeek tests the offsets of lobReader.Seek, and the rejection of
+// a CLOB by Seek and ReadAt.
+func TestLobReaderSeek(t *testing.T) {
+	lr := &lobReader{} // an empty LOB, which isn't opened
+	for _, tc := range []struct {
+		offset int64
+		whence int
+		want   int64
+	}{
+		{3, io.SeekStart, 3},
+		{-1, io.SeekCurrent, 2},
+		{2, io.SeekCurrent, 4},
+		{0, io.SeekEnd, 0},
+	} {
+		if off, err := lr.Seek(tc.offset, tc.whence); err != nil || off != tc.want {
+			t.Errorf("Seek(%d, %d): got %d, %v; wanted %d", tc.offset, tc.whence, off, err, tc.want)
+		}
+	}
+	if _, err := lr.Seek(-1, io.SeekEnd); err == nil {
+		t.Error("Seek: wanted an error for a negative offset")
+	}
+	if _, err := lr.Seek(0, 3); err == nil {
+		t.Error("Seek: wanted an error for an invalid whence")
+	}
+	p := make([]byte, 4)
+	if n, err := lr.Read(p); n != 0 || err != io.EOF {
+		t.Errorf("Read after Seek: got %d, %v; wanted EOF", n, err)
+	}
+	if _, err := lr.ReadAt(p, -1); err == nil {
+		t.Error("ReadAt: wanted an error for a negative offset")
+	}
+	if n, err := lr.ReadAt(p, 0); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt: got %d, %v; wanted EOF", n, err)
+	}
+
+	clob := &lobReader{clob: true}
+	if off, err := clob.Seek(0, io.SeekStart); err != nil || off != 0 {
+		t.Errorf("CLOB Seek to the current offset: got %d, %v; wanted 0", off, err)
+	}
+	if _, err := clob.Seek(1, io.SeekStart); err == nil {
+		t.Error("CLOB Seek: wanted an error")
+	}
+	if _, err := clob.ReadAt(p, 0); err == nil {
+		t.Error("CLOB ReadAt: wanted an error")
+	}
+}
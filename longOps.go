@@ -0,0 +1,187 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"time"
+)
+
+// LongOp is an in-progress entry of V$SESSION_LONGOPS, recorded by the server
+// for long-running operations such as full scans and sorts, or registered
+// with WithLongOp.
+type LongOp struct {
+	OpName         string
+	Target         string
+	TargetDesc     string
+	Sofar          float64
+	TotalWork      float64
+	Units          string
+	StartTime      time.Time
+	LastUpdateTime time.Time
+	TimeRemaining  time.Duration
+	Elapsed        time.Duration
+	Message        string
+	SqlId          string
+}
+
+// Percent returns the percentage of the work done, or zero when the total
+// work is unknown.
+func (op LongOp) Percent() float64 {
+	if op.TotalWork <= 0 {
+		return 0
+	}
+	return op.Sofar / op.TotalWork * 100
+}
+
+// LongOpCfg describes an entry registered in V$SESSION_LONGOPS by
+// Stmt.ExeContext when set on the context with WithLongOp.
+type LongOpCfg struct {
+	// OpName is the name of the operation.
+	OpName string
+
+	// Target is a description of the object operated on.
+	//
+	// The default is "unknown target".
+	Target string
+
+	// TotalWork is the amount of work of the operation, in Units.
+	//
+	// The default is 1.
+	TotalWork float64
+
+	// Units is the unit of TotalWork.
+	//
+	// The default is "statements".
+	Units string
+}
+
+// NewLongOpCfg creates a LongOpCfg named opName with default values.
+func NewLongOpCfg(opName string) LongOpCfg {
+	c := LongOpCfg{OpName: opName}
+	c.Target = "unknown target"
+	c.TotalWork = 1
+	c.Units = "statements"
+	return c
+}
+
+// longOpKey is the context key of a LongOpCfg set by WithLongOp.
+type longOpKey struct{}
+
+// WithLongOp returns a copy of ctx carrying cfg. Stmt.ExeContext registers
+// cfg in V$SESSION_LONGOPS before executing its statement, and completes the
+// entry when the statement finishes, so that another session polling the
+// executing session with Ses.LongOps can display the operation and its
+// elapsed time.
+func WithLongOp(ctx context.Context, cfg LongOpCfg) context.Context {
+	return context.WithValue(ctx, longOpKey{}, cfg)
+}
+
+// longOpFrom returns the LongOpCfg carried by ctx.
+func longOpFrom(ctx context.Context) (cfg LongOpCfg, ok bool) {
+	if ctx == nil {
+		return cfg, false
+	}
+	cfg, ok = ctx.Value(longOpKey{}).(LongOpCfg)
+	return cfg, ok
+}
+
+// setLongOp registers or updates the V$SESSION_LONGOPS entry of cfg.
+// rindex and slno identify the entry; a rindex of zero registers a new entry.
+func (ses *Ses) setLongOp(cfg LongOpCfg, rindex, slno *int64, sofar float64) error {
+	_, err := ses.exe(`DECLARE
+	r BINARY_INTEGER := :1;
+	s BINARY_INTEGER := :2;
+BEGIN
+	IF r = 0 THEN
+		r := DBMS_APPLICATION_INFO.SET_SESSION_LONGOPS_NOHINT;
+	END IF;
+	DBMS_APPLICATION_INFO.SET_SESSION_LONGOPS(r, s, :3, 0, 0, :4, :5, :6, :7);
+	:8 := r;
+	:9 := s;
+END;`, *rindex, *slno, cfg.OpName, sofar, cfg.TotalWork, cfg.Target, cfg.Units, rindex, slno)
+	return err
+}
+
+// exeLongOp executes the Stmt between the registration and completion of a
+// V$SESSION_LONGOPS entry.
+func (stmt *Stmt) exeLongOp(ctx context.Context, cfg LongOpCfg, params []interface{}) (rowsAffected uint64, err error) {
+	ses := stmt.ses
+	var rindex, slno int64
+	if err = ses.setLongOp(cfg, &rindex, &slno, 0); err != nil {
+		return 0, errE(err)
+	}
+	rowsAffected, _, err = stmt.exe(ctx, params, false)
+	if err0 := ses.setLongOp(cfg, &rindex, &slno, cfg.TotalWork); err == nil && err0 != nil {
+		err = errE(err0)
+	}
+	return rowsAffected, err
+}
+
+// Sid returns the session identifier of the Ses, the SID column of
+// V$SESSION, for polling its long operations from another Ses.
+func (ses *Ses) Sid() (sid int64, err error) {
+	ses.log(_drv.cfg().Log.Ses.Sid)
+	stmt, err := ses.prepSql(`SELECT TO_NUMBER(SYS_CONTEXT('USERENV', 'SID')) FROM DUAL`, I64)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry()
+	if err != nil {
+		return 0, errE(err)
+	}
+	for rset.Next() {
+		sid, _ = rset.Row[0].(int64)
+	}
+	if rset.Err != nil {
+		return 0, errE(rset.Err)
+	}
+	return sid, nil
+}
+
+// LongOps returns the in-progress V$SESSION_LONGOPS entries of the session
+// identified by sid, ordered by start time.
+//
+// A Ses executing a statement is busy until the statement completes, so poll
+// LongOps from another Ses with the Sid of the executing Ses. Querying
+// V$SESSION_LONGOPS requires the SELECT privilege on the view.
+func (ses *Ses) LongOps(sid int64) (ops []LongOp, err error) {
+	ses.log(_drv.cfg().Log.Ses.LongOps)
+	stmt, err := ses.prepSql(`SELECT OPNAME, TARGET, TARGET_DESC, SOFAR, TOTALWORK, UNITS,
+	START_TIME, LAST_UPDATE_TIME, TIME_REMAINING, ELAPSED_SECONDS, MESSAGE, SQL_ID
+FROM V$SESSION_LONGOPS
+WHERE SID = :1 AND SOFAR <> TOTALWORK
+ORDER BY START_TIME`,
+		OraS, OraS, OraS, OraF64, OraF64, OraS, OraT, OraT, OraF64, OraF64, OraS, OraS)
+	if err != nil {
+		return nil, errE(err)
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry(sid)
+	if err != nil {
+		return nil, errE(err)
+	}
+	for rset.Next() {
+		ops = append(ops, LongOp{
+			OpName:         rset.Row[0].(String).Value,
+			Target:         rset.Row[1].(String).Value,
+			TargetDesc:     rset.Row[2].(String).Value,
+			Sofar:          rset.Row[3].(Float64).Value,
+			TotalWork:      rset.Row[4].(Float64).Value,
+			Units:          rset.Row[5].(String).Value,
+			StartTime:      rset.Row[6].(Time).Value,
+			LastUpdateTime: rset.Row[7].(Time).Value,
+			TimeRemaining:  time.Duration(rset.Row[8].(Float64).Value) * time.Second,
+			Elapsed:        time.Duration(rset.Row[9].(Float64).Value) * time.Second,
+			Message:        rset.Row[10].(String).Value,
+			SqlId:          rset.Row[11].(String).Value,
+		})
+	}
+	if rset.Err != nil {
+		return nil, errE(rset.Err)
+	}
+	return ops, nil
+}
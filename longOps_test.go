@@ -0,0 +1,27 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLongOpCfg(t *testing.T) {
+	if _, ok := longOpFrom(context.Background()); ok {
+		t.Errorf("background: wanted no LongOpCfg")
+	}
+	ctx := WithLongOp(context.Background(), NewLongOpCfg("nightly load"))
+	cfg, ok := longOpFrom(ctx)
+	if !ok || cfg.OpName != "nightly load" || cfg.TotalWork != 1 {
+		t.Errorf("got %#v, %t", cfg, ok)
+	}
+	if p := (LongOp{Sofar: 25, TotalWork: 200}).Percent(); p != 12.5 {
+		t.Errorf("Percent: got %v, wanted 12.5", p)
+	}
+	if p := (LongOp{Sofar: 25}).Percent(); p != 0 {
+		t.Errorf("Percent of unknown work: got %v, wanted 0", p)
+	}
+}
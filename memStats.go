@@ -0,0 +1,95 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "reflect"
+
+// MemStats is an approximate accounting of the memory held by a Ses or Stmt,
+// for the investigation of leaks without heap dumps.
+//
+// Binds and Defines count the Go-side buffers of bind parameters and
+// select-list defines: the size of each bind and define and the capacity of
+// its buffers. Memory allocated by OCI on the client isn't counted, as OCI
+// doesn't report it per handle.
+type MemStats struct {
+	Stmts   int   // open statements
+	Rsets   int   // open result sets
+	Binds   int64 // bytes held by bind buffers
+	Defines int64 // bytes held by define buffers of open result sets
+
+	// UGA and PGA are the server session memory in bytes, as reported by
+	// V$MYSTAT; they're set by Ses.MemStats only.
+	UGA int64
+	PGA int64
+}
+
+// add adds the counts of m to ms.
+func (ms *MemStats) add(m MemStats) {
+	ms.Stmts += m.Stmts
+	ms.Rsets += m.Rsets
+	ms.Binds += m.Binds
+	ms.Defines += m.Defines
+}
+
+// MemStats returns the memory held by the Stmt and its open result sets.
+func (stmt *Stmt) MemStats() MemStats {
+	stmt.log(_drv.cfg().Log.Stmt.MemStats)
+	return stmt.memStats()
+}
+
+func (stmt *Stmt) memStats() MemStats {
+	ms := MemStats{Stmts: 1}
+	stmt.mu.Lock()
+	for _, b := range stmt.bnds {
+		ms.Binds += bufferSize(b)
+	}
+	stmt.mu.Unlock()
+	for _, rset := range stmt.openRsets.snapshot() {
+		ms.Rsets++
+		rset.mu.Lock()
+		for _, d := range rset.defs {
+			ms.Defines += bufferSize(d)
+		}
+		rset.mu.Unlock()
+	}
+	return ms
+}
+
+// MemStats returns the memory held by the open statements of the Ses, and the
+// UGA and PGA memory of the server session. The server memory requires
+// SELECT privileges on V$MYSTAT and V$STATNAME; when it can't be queried,
+// the client memory is returned with the error.
+func (ses *Ses) MemStats() (ms MemStats, err error) {
+	ses.log(_drv.cfg().Log.Ses.MemStats)
+	for _, stmt := range ses.openStmts.snapshot() {
+		ms.add(stmt.memStats())
+	}
+	stats, err := ses.stats([]string{"session uga memory", "session pga memory"})
+	if err != nil {
+		return ms, errE(err)
+	}
+	ms.UGA, ms.PGA = stats["session uga memory"], stats["session pga memory"]
+	return ms, nil
+}
+
+// bufferSize returns the approximate bytes held by a bind or define: the size
+// of the struct v points to, and the capacity of each of its slices.
+func bufferSize(v interface{}) (size int64) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return 0
+	}
+	rv = rv.Elem()
+	size = int64(rv.Type().Size())
+	if rv.Kind() != reflect.Struct {
+		return size
+	}
+	for n := 0; n < rv.NumField(); n++ {
+		if f := rv.Field(n); f.Kind() == reflect.Slice {
+			size += int64(f.Cap()) * int64(f.Type().Elem().Size())
+		}
+	}
+	return size
+}
@@ -0,0 +1,30 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// TestBufferSize tests bufferSize.
+func TestBufferSize(t *testing.T) {
+	type buffers struct {
+		buf   []byte
+		lens  []uint16
+		count int
+	}
+	v := &buffers{buf: make([]byte, 10, 100), lens: make([]uint16, 4)}
+	want := int64(unsafe.Sizeof(*v)) + 100 + 8
+	if got := bufferSize(v); got != want {
+		t.Errorf("got %d, wanted %d", got, want)
+	}
+	if got := bufferSize(nil); got != 0 {
+		t.Errorf("nil: got %d, wanted 0", got)
+	}
+	if got := bufferSize((*buffers)(nil)); got != 0 {
+		t.Errorf("nil pointer: got %d, wanted 0", got)
+	}
+}
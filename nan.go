@@ -0,0 +1,37 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "math"
+
+// NaNPolicy determines the handling of NaN and infinite floating-point
+// values fetched from BINARY_DOUBLE and BINARY_FLOAT columns or bound as
+// float64 and float32 values.
+type NaNPolicy int
+
+const (
+	// NaNPass fetches and binds NaN and infinity unchanged. Oracle rejects
+	// them when bound to a NUMBER column.
+	NaNPass NaNPolicy = iota
+	// NaNReject returns an error for NaN and infinity.
+	NaNReject
+	// NaNNull fetches and binds NaN and infinity as NULL.
+	NaNNull
+)
+
+// check applies the policy to value, returning whether value is treated as
+// NULL.
+func (p NaNPolicy) check(value float64) (null bool, err error) {
+	if !math.IsNaN(value) && !math.IsInf(value, 0) {
+		return false, nil
+	}
+	switch p {
+	case NaNReject:
+		return false, errF("Floating-point value %v rejected by NaNPolicy.", value)
+	case NaNNull:
+		return true, nil
+	}
+	return false, nil
+}
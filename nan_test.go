@@ -0,0 +1,33 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNaNPolicy tests NaNPolicy.check.
+func TestNaNPolicy(t *testing.T) {
+	for i, tc := range []struct {
+		policy NaNPolicy
+		value  float64
+		null   bool
+		err    bool
+	}{
+		{policy: NaNReject, value: 1.5},
+		{policy: NaNPass, value: math.NaN()},
+		{policy: NaNPass, value: math.Inf(1)},
+		{policy: NaNReject, value: math.NaN(), err: true},
+		{policy: NaNReject, value: math.Inf(-1), err: true},
+		{policy: NaNNull, value: math.Inf(1), null: true},
+		{policy: NaNNull, value: 0},
+	} {
+		null, err := tc.policy.check(tc.value)
+		if null != tc.null || (err != nil) != tc.err {
+			t.Errorf("%d. got %v, %v; wanted %v, error %v", i, null, err, tc.null, tc.err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// NestedTx is a pseudo-nested transaction of a Tx, emulated with a
+// savepoint: Rollback undoes the changes made since the NestedTx began,
+// and Commit keeps them as part of the enclosing transaction, which may still
+// roll them back. Oracle has no nested transactions.
+//
+// Committing or rolling back a NestedTx erases the savepoints of the
+// NestedTxs begun within it, whose Commit and Rollback then return an error;
+// their changes are kept or undone with those of the enclosing NestedTx.
+type NestedTx struct {
+	tx        *Tx
+	savepoint string
+	done      bool
+}
+
+// nestedSavepoint returns the savepoint name of the nth NestedTx of a Tx.
+func nestedSavepoint(n uint64) string {
+	return fmt.Sprintf("ORA_NESTED_%d", n)
+}
+
+// Nested begins a NestedTx of the Tx with a savepoint.
+func (tx *Tx) Nested() (*NestedTx, error) {
+	if err := tx.checkIsOpen(); err != nil {
+		return nil, errE(err)
+	}
+	tx.log(_drv.cfg().Log.Tx.Nested)
+	savepoint := nestedSavepoint(atomic.AddUint64(&tx.nested, 1))
+	if err := tx.Savepoint(savepoint); err != nil {
+		return nil, err
+	}
+	return &NestedTx{tx: tx, savepoint: savepoint}, nil
+}
+
+// Nested begins a NestedTx within the NestedTx.
+func (n *NestedTx) Nested() (*NestedTx, error) {
+	if n.done {
+		return nil, er("NestedTx is done.")
+	}
+	return n.tx.Nested()
+}
+
+// Commit ends the NestedTx, keeping its changes in the enclosing transaction.
+func (n *NestedTx) Commit() error {
+	if n.done {
+		return er("NestedTx is done.")
+	}
+	if err := n.tx.checkIsOpen(); err != nil {
+		return errE(err)
+	}
+	n.tx.log(_drv.cfg().Log.Tx.Commit)
+	n.done = true
+	return n.tx.ReleaseSavepoint(n.savepoint)
+}
+
+// Rollback ends the NestedTx, undoing the changes made since it began.
+func (n *NestedTx) Rollback() error {
+	if n.done {
+		return er("NestedTx is done.")
+	}
+	if err := n.tx.checkIsOpen(); err != nil {
+		return errE(err)
+	}
+	n.tx.log(_drv.cfg().Log.Tx.Rollback)
+	n.done = true
+	return n.tx.RollbackTo(n.savepoint)
+}
@@ -0,0 +1,240 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NetLevel is the level of a native network encryption or checksumming
+// service negotiated by the client.
+type NetLevel string
+
+const (
+	// NetAccepted turns on the service when the server requests or requires it.
+	NetAccepted NetLevel = "ACCEPTED"
+	// NetRejected turns off the service, failing when the server requires it.
+	NetRejected NetLevel = "REJECTED"
+	// NetRequested turns on the service when the server accepts it.
+	NetRequested NetLevel = "REQUESTED"
+	// NetRequired turns on the service, failing when the server rejects it.
+	NetRequired NetLevel = "REQUIRED"
+)
+
+// Authentication services of NetCfg.AuthServices.
+const (
+	// NetAuthKerberos5 authenticates with Kerberos.
+	NetAuthKerberos5 = "KERBEROS5"
+	// NetAuthRadius authenticates with RADIUS.
+	NetAuthRadius = "RADIUS"
+	// NetAuthTcps authenticates with the client certificate of a TCPS
+	// connection.
+	NetAuthTcps = "TCPS"
+)
+
+// NetCfg configures the native network encryption, data integrity and
+// external authentication of Oracle Net from the driver rather than a
+// hand-edited sqlnet.ora.
+//
+// The settings are written to a sqlnet.ora generated in Dir, which includes
+// the sqlnet.ora and tnsnames.ora of the existing TNS_ADMIN directory, and
+// TNS_ADMIN is set to Dir when the Env is opened. Oracle Net reads its
+// configuration once per process, when the first server is attached, so set
+// EnvCfg.Net on an Env opened before the first Srv or database/sql
+// connection of the process; the first applied NetCfg is used for the life
+// of the process. Once the first Srv is attached, TNS_ADMIN is restored and
+// a Dir created in the temporary directory is removed.
+type NetCfg struct {
+	// Encryption is the level of network encryption, the
+	// SQLNET.ENCRYPTION_CLIENT parameter. An empty Encryption leaves the
+	// parameter unset.
+	//
+	// The default is "".
+	Encryption NetLevel
+
+	// EncryptionTypes are the encryption algorithms offered, such as
+	// "AES256", the SQLNET.ENCRYPTION_TYPES_CLIENT parameter.
+	//
+	// The default is nil.
+	EncryptionTypes []string
+
+	// Checksum is the level of data integrity checking, the
+	// SQLNET.CRYPTO_CHECKSUM_CLIENT parameter. An empty Checksum leaves the
+	// parameter unset.
+	//
+	// The default is "".
+	Checksum NetLevel
+
+	// ChecksumTypes are the checksum algorithms offered, such as "SHA256",
+	// the SQLNET.CRYPTO_CHECKSUM_TYPES_CLIENT parameter.
+	//
+	// The default is nil.
+	ChecksumTypes []string
+
+	// AuthServices are the authentication services of the client, such as
+	// NetAuthKerberos5 and NetAuthRadius, the SQLNET.AUTHENTICATION_SERVICES
+	// parameter. A Kerberos session is opened with external credentials, an
+	// empty SesCfg.Username and Password; a RADIUS session is opened with the
+	// username and password authenticated by the RADIUS server.
+	//
+	// The default is nil.
+	AuthServices []string
+
+	// KerberosCCName is the path of the Kerberos credential cache, the
+	// SQLNET.KERBEROS5_CC_NAME parameter, such as "/tmp/krb5cc_1000".
+	//
+	// The default is "".
+	KerberosCCName string
+
+	// KerberosService is the Kerberos service name of the database server,
+	// the SQLNET.AUTHENTICATION_KERBEROS5_SERVICE parameter, such as "oracle".
+	//
+	// The default is "".
+	KerberosService string
+
+	// KerberosConf is the path of the Kerberos configuration file, the
+	// SQLNET.KERBEROS5_CONF parameter, such as "/etc/krb5.conf".
+	//
+	// The default is "".
+	KerberosConf string
+
+	// Dir is the directory of the generated configuration. An empty Dir uses
+	// a directory created in the temporary directory.
+	//
+	// The default is "".
+	Dir string
+}
+
+// NewNetCfg creates a NetCfg with default values.
+func NewNetCfg() *NetCfg {
+	c := &NetCfg{}
+	return c
+}
+
+// sqlnetOra returns the text of a sqlnet.ora applying cfg, which includes
+// the files of includes.
+func sqlnetOra(cfg NetCfg, includes ...string) string {
+	var buf bytes.Buffer
+	for _, include := range includes {
+		buf.WriteString("IFILE = " + include + "\n")
+	}
+	param := func(name, value string) {
+		if value != "" {
+			buf.WriteString(name + " = " + value + "\n")
+		}
+	}
+	list := func(values []string) string {
+		if len(values) == 0 {
+			return ""
+		}
+		return "(" + strings.Join(values, ", ") + ")"
+	}
+	param("SQLNET.ENCRYPTION_CLIENT", string(cfg.Encryption))
+	param("SQLNET.ENCRYPTION_TYPES_CLIENT", list(cfg.EncryptionTypes))
+	param("SQLNET.CRYPTO_CHECKSUM_CLIENT", string(cfg.Checksum))
+	param("SQLNET.CRYPTO_CHECKSUM_TYPES_CLIENT", list(cfg.ChecksumTypes))
+	param("SQLNET.AUTHENTICATION_SERVICES", list(cfg.AuthServices))
+	param("SQLNET.KERBEROS5_CC_NAME", cfg.KerberosCCName)
+	param("SQLNET.AUTHENTICATION_KERBEROS5_SERVICE", cfg.KerberosService)
+	param("SQLNET.KERBEROS5_CONF", cfg.KerberosConf)
+	return buf.String()
+}
+
+// tnsAdminDir returns the directory of the existing Oracle Net
+// configuration, or an empty string.
+func tnsAdminDir() string {
+	if dir := os.Getenv("TNS_ADMIN"); dir != "" {
+		return dir
+	}
+	if home := os.Getenv("ORACLE_HOME"); home != "" {
+		return filepath.Join(home, "network", "admin")
+	}
+	return ""
+}
+
+// applyNetCfg writes the configuration of cfg and points TNS_ADMIN at it
+// until the first Srv is attached. A NetCfg differing from the one already
+// applied, or applied after Oracle Net read its configuration, returns an
+// error. The caller holds _drv.mu.
+func applyNetCfg(cfg NetCfg) error {
+	_drv.netMu.Lock()
+	defer _drv.netMu.Unlock()
+	params := sqlnetOra(cfg)
+	if _drv.netApplied {
+		if params != _drv.netCfg {
+			return er("NetCfg differs from the NetCfg already applied.")
+		}
+		return nil
+	}
+	if _drv.srvAttached {
+		return er("NetCfg must be applied before the first Srv of the process is opened.")
+	}
+	var includes [2][]string
+	if dir := tnsAdminDir(); dir != "" {
+		for n, name := range []string{"sqlnet.ora", "tnsnames.ora"} {
+			if path := filepath.Join(dir, name); fileExists(path) {
+				includes[n] = []string{path}
+			}
+		}
+	}
+	dir, temp := cfg.Dir, cfg.Dir == ""
+	if temp {
+		var err error
+		if dir, err = ioutil.TempDir("", "ora-net"); err != nil {
+			return errE(err)
+		}
+	}
+	err := ioutil.WriteFile(filepath.Join(dir, "sqlnet.ora"), []byte(sqlnetOra(cfg, includes[0]...)), 0600)
+	if err == nil {
+		var tnsnames string
+		for _, include := range includes[1] {
+			tnsnames += "IFILE = " + include + "\n"
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, "tnsnames.ora"), []byte(tnsnames), 0600)
+	}
+	prev, prevSet := os.LookupEnv("TNS_ADMIN")
+	if err == nil {
+		err = os.Setenv("TNS_ADMIN", dir)
+	}
+	restore := func() {
+		if prevSet {
+			os.Setenv("TNS_ADMIN", prev)
+		} else {
+			os.Unsetenv("TNS_ADMIN")
+		}
+		if temp {
+			os.RemoveAll(dir)
+		}
+	}
+	if err != nil {
+		restore()
+		return errE(err)
+	}
+	_drv.netCfg, _drv.netApplied, _drv.netRestore = params, true, restore
+	return nil
+}
+
+// netAttached records that a Srv was attached: Oracle Net has read its
+// configuration, so TNS_ADMIN is restored and a generated configuration is
+// removed.
+func netAttached() {
+	_drv.netMu.Lock()
+	defer _drv.netMu.Unlock()
+	_drv.srvAttached = true
+	if _drv.netRestore != nil {
+		_drv.netRestore()
+		_drv.netRestore = nil
+	}
+}
+
+// fileExists reports whether path is an existing regular file.
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
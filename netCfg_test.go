@@ -0,0 +1,106 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSqlnetOra tests sqlnetOra.
+func TestSqlnetOra(t *testing.T) {
+	if got := sqlnetOra(*NewNetCfg()); got != "" {
+		t.Errorf("got %q for default NetCfg", got)
+	}
+	cfg := NetCfg{
+		Encryption:      NetRequired,
+		EncryptionTypes: []string{"AES256", "AES192"},
+		Checksum:        NetRequested,
+		ChecksumTypes:   []string{"SHA256"},
+		AuthServices:    []string{NetAuthKerberos5},
+		KerberosCCName:  "/tmp/krb5cc_1000",
+		KerberosService: "oracle",
+	}
+	want := "IFILE = /etc/oracle/sqlnet.ora\n" +
+		"SQLNET.ENCRYPTION_CLIENT = REQUIRED\n" +
+		"SQLNET.ENCRYPTION_TYPES_CLIENT = (AES256, AES192)\n" +
+		"SQLNET.CRYPTO_CHECKSUM_CLIENT = REQUESTED\n" +
+		"SQLNET.CRYPTO_CHECKSUM_TYPES_CLIENT = (SHA256)\n" +
+		"SQLNET.AUTHENTICATION_SERVICES = (KERBEROS5)\n" +
+		"SQLNET.KERBEROS5_CC_NAME = /tmp/krb5cc_1000\n" +
+		"SQLNET.AUTHENTICATION_KERBEROS5_SERVICE = oracle\n"
+	if got := sqlnetOra(cfg, "/etc/oracle/sqlnet.ora"); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+// TestApplyNetCfg tests applyNetCfg and netAttached.
+func TestApplyNetCfg(t *testing.T) {
+	_drv.netMu.Lock()
+	netCfg, netApplied, netRestore, srvAttached := _drv.netCfg, _drv.netApplied, _drv.netRestore, _drv.srvAttached
+	_drv.netCfg, _drv.netApplied, _drv.netRestore, _drv.srvAttached = "", false, nil, false
+	_drv.netMu.Unlock()
+	defer func() {
+		_drv.netMu.Lock()
+		_drv.netCfg, _drv.netApplied, _drv.netRestore, _drv.srvAttached = netCfg, netApplied, netRestore, srvAttached
+		_drv.netMu.Unlock()
+	}()
+	tnsAdmin, err := ioutil.TempDir("", "ora-net-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tnsAdmin)
+	if err = ioutil.WriteFile(filepath.Join(tnsAdmin, "tnsnames.ora"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	prev, prevSet := os.LookupEnv("TNS_ADMIN")
+	defer func() {
+		if prevSet {
+			os.Setenv("TNS_ADMIN", prev)
+		} else {
+			os.Unsetenv("TNS_ADMIN")
+		}
+	}()
+	os.Setenv("TNS_ADMIN", tnsAdmin)
+
+	cfg := NetCfg{Encryption: NetRequired}
+	if err = applyNetCfg(cfg); err != nil {
+		t.Fatal(err)
+	}
+	dir := os.Getenv("TNS_ADMIN")
+	if dir == tnsAdmin {
+		t.Fatal("TNS_ADMIN wasn't set")
+	}
+	tnsnames, err := ioutil.ReadFile(filepath.Join(dir, "tnsnames.ora"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "IFILE = " + filepath.Join(tnsAdmin, "tnsnames.ora") + "\n"; string(tnsnames) != want {
+		t.Errorf("got tnsnames.ora %q, wanted %q", tnsnames, want)
+	}
+	if err = applyNetCfg(NetCfg{Encryption: NetRejected}); err == nil {
+		t.Error("applying a different NetCfg: wanted an error")
+	}
+
+	netAttached()
+	if got := os.Getenv("TNS_ADMIN"); got != tnsAdmin {
+		t.Errorf("got TNS_ADMIN %q after the first Srv, wanted %q", got, tnsAdmin)
+	}
+	if _, err = os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("the generated directory %v wasn't removed", dir)
+	}
+	if err = applyNetCfg(cfg); err != nil {
+		t.Errorf("applying the same NetCfg again: %v", err)
+	}
+
+	_drv.netMu.Lock()
+	_drv.netApplied = false
+	_drv.netMu.Unlock()
+	if err = applyNetCfg(cfg); err == nil {
+		t.Error("applying a NetCfg after the first Srv: wanted an error")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"context"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// minPollInterval is the first wait between polls of a non-blocking OCI call.
+// The wait doubles on each poll up to SrvCfg.PollInterval.
+const minPollInterval = 100 * time.Microsecond
+
+// maxBreakInterval is the longest wait of Ses.breakOnDone for a call to
+// start after its context is done.
+const maxBreakInterval = 10 * time.Millisecond
+
+// setNonBlocking puts the server handle in OCI non-blocking mode.
+//
+// OCI_ATTR_NONBLOCKING_MODE toggles the mode on each set; setNonBlocking sets
+// it only once per Srv. No locking occurs.
+func (srv *Srv) setNonBlocking() error {
+	if srv.nonBlocking {
+		return nil
+	}
+	err := srv.env.setAttr(unsafe.Pointer(srv.ocisrv), C.OCI_HTYPE_SERVER, nil, C.ub4(0), C.OCI_ATTR_NONBLOCKING_MODE)
+	if err != nil {
+		return err
+	}
+	srv.nonBlocking = true
+	return nil
+}
+
+// poll runs call until it returns something other than OCI_STILL_EXECUTING.
+//
+// Between polls the goroutine sleeps, releasing its OS thread to the Go
+// scheduler. When ctx is done, OCIBreak is issued on ocisvcctx and polling
+// continues until Oracle acknowledges the break, typically with ORA-01013.
+// A nil ctx is never done. brk, when not nil, replaces the OCIBreak.
+//
+// In blocking mode call runs once.
+func (srv *Srv) poll(ctx context.Context, ocisvcctx *C.OCISvcCtx, call func() C.sword, brk func()) C.sword {
+	r := call()
+	if r != C.OCI_STILL_EXECUTING {
+		return r
+	}
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+	maxInterval := srv.cfg.PollInterval
+	if maxInterval < minPollInterval {
+		maxInterval = minPollInterval
+	}
+	interval := minPollInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-done:
+			srv.logF(_drv.cfg().Log.Ses.Break, "break: %v", ctx.Err())
+			if brk != nil {
+				brk()
+			} else {
+				C.OCIBreak(unsafe.Pointer(ocisvcctx), srv.env.ocierr)
+			}
+			done = nil // break once
+		case <-timer.C:
+			if interval *= 2; interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+		if r = call(); r != C.OCI_STILL_EXECUTING {
+			return r
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+// sesCalls tracks the calls in flight on a Ses for Ses.Break. Each Stmt of
+// the Ses counts its own calls, so the end of one call doesn't hide another.
+type sesCalls struct {
+	mu     sync.Mutex
+	n      int  // calls in flight
+	broken bool // OCIBreak was issued since the calls began
+}
+
+// poll runs call on the Ses's service context. See Srv.poll.
+//
+// poll counts call in flight so that Ses.Break may interrupt it. When the
+// last call in flight ends after a Break, the Ses is reset before another
+// call can begin, so that a Break racing the end of a call can't interrupt
+// a later call.
+func (ses *Ses) poll(ctx context.Context, call func() C.sword) C.sword {
+	ses.calls.mu.Lock()
+	ses.calls.n++
+	ses.calls.mu.Unlock()
+	r := ses.srv.poll(ctx, ses.ocisvcctx, call, func() { ses.Break() })
+	ses.calls.mu.Lock()
+	defer ses.calls.mu.Unlock()
+	if ses.calls.n--; ses.calls.n == 0 && ses.calls.broken {
+		ses.calls.broken = false
+		if err := ses.reset(); err != nil {
+			ses.logF(_drv.cfg().Log.Ses.Reset, "reset after break: %v", err)
+		}
+	}
+	return r
+}
+
+// calling reports whether a call is in flight on the Ses.
+func (ses *Ses) calling() bool {
+	ses.calls.mu.Lock()
+	defer ses.calls.mu.Unlock()
+	return ses.calls.n > 0
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+
+// oraNumbersFromInts converts the n integers of size bytes at values to
+// numbers, stopping at the first error.
+static sword oraNumbersFromInts(OCIError *errhp, const void *values, uword size, uword signFlag, ub4 n, OCINumber *numbers) {
+	ub4 i;
+	for (i = 0; i < n; i++) {
+		sword r = OCINumberFromInt(errhp, (const char *)values + i*size, size, signFlag, &numbers[i]);
+		if (r == OCI_ERROR) {
+			return r;
+		}
+	}
+	return OCI_SUCCESS;
+}
+
+// oraNumbersFromReals converts the n floats of size bytes at values to
+// numbers, stopping at the first error.
+static sword oraNumbersFromReals(OCIError *errhp, const void *values, uword size, ub4 n, OCINumber *numbers) {
+	ub4 i;
+	for (i = 0; i < n; i++) {
+		sword r = OCINumberFromReal(errhp, (const char *)values + i*size, size, &numbers[i]);
+		if (r == OCI_ERROR) {
+			return r;
+		}
+	}
+	return OCI_SUCCESS;
+}
+*/
+import "C"
+import "unsafe"
+
+// numbersFromInts converts the n integers of size bytes at values to
+// numbers in one cgo call. No locking occurs.
+func (ses *Ses) numbersFromInts(values unsafe.Pointer, size int, signed bool, n int, numbers []C.OCINumber) error {
+	if n == 0 {
+		return nil
+	}
+	signFlag := C.uword(C.OCI_NUMBER_UNSIGNED)
+	if signed {
+		signFlag = C.OCI_NUMBER_SIGNED
+	}
+	if C.oraNumbersFromInts(ses.ocierr, values, C.uword(size), signFlag, C.ub4(n), &numbers[0]) == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	return nil
+}
+
+// numbersFromReals converts the n floats of size bytes at values to numbers
+// in one cgo call. No locking occurs.
+func (ses *Ses) numbersFromReals(values unsafe.Pointer, size int, n int, numbers []C.OCINumber) error {
+	if n == 0 {
+		return nil
+	}
+	if C.oraNumbersFromReals(ses.ocierr, values, C.uword(size), C.ub4(n), &numbers[0]) == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	return nil
+}
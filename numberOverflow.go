@@ -0,0 +1,161 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"math/big"
+	"unsafe"
+)
+
+// NumberOverflow determines the select-list value of a NUMBER which doesn't
+// fit the integer Go type of its column, such as a NUMBER(20) value defined
+// as I64.
+type NumberOverflow int
+
+const (
+	// OverflowError returns an error from Rset.Next.
+	OverflowError NumberOverflow = iota
+	// OverflowSaturate returns the largest or smallest value of the Go type.
+	OverflowSaturate
+	// OverflowString returns the number as a Go string, or as an ora.String
+	// for a nullable Go type such as OraI64.
+	OverflowString
+	// OverflowBigInt returns the number as a *big.Int.
+	OverflowBigInt
+)
+
+// numberTextFmt formats a NUMBER as text, in scientific notation when the
+// decimal notation exceeds 64 characters.
+const numberTextFmt = "TM9"
+
+// numberTextNls fixes the decimal character of numberTextFmt.
+const numberTextNls = "NLS_NUMERIC_CHARACTERS='.,'"
+
+// numberToInt converts number into the integer of size bytes at rsl. When the
+// number doesn't fit, RsetCfg.NumberOverflow determines the outcome: an error,
+// a saturated value at rsl, or a replacement value returned as over.
+func (rset *Rset) numberToInt(number *C.OCINumber, size int, signed, nullable bool, rsl unsafe.Pointer) (over interface{}, err error) {
+	ses := rset.stmt.ses
+	flag := C.uword(C.OCI_NUMBER_UNSIGNED)
+	if signed {
+		flag = C.OCI_NUMBER_SIGNED
+	}
+	r := C.OCINumberToInt(
+		ses.ocierr,    //OCIError              *err,
+		number,        //const OCINumber       *number,
+		C.uword(size), //uword                 rsl_length,
+		flag,          //uword                 rsl_flag,
+		rsl)           //void                  *rsl );
+	if r != C.OCI_ERROR {
+		return nil, nil
+	}
+	ociErr := ses.ociError()
+	var buf [128]C.char
+	bufSize := C.ub4(len(buf))
+	cFmt, cNls := C.CString(numberTextFmt), C.CString(numberTextNls)
+	defer C.free(unsafe.Pointer(cFmt))
+	defer C.free(unsafe.Pointer(cNls))
+	r = C.OCINumberToText(
+		ses.ocierr,                            //OCIError        *err,
+		number,                                //const OCINumber *number,
+		(*C.oratext)(unsafe.Pointer(cFmt)),    //const oratext   *fmt,
+		C.ub4(len(numberTextFmt)),             //ub4             fmt_length,
+		(*C.oratext)(unsafe.Pointer(cNls)),    //const oratext   *nls_params,
+		C.ub4(len(numberTextNls)),             //ub4             nls_p_length,
+		&bufSize,                              //ub4             *buf_size,
+		(*C.oratext)(unsafe.Pointer(&buf[0]))) //oratext         *buf );
+	if r == C.OCI_ERROR {
+		return nil, ociErr
+	}
+	text := C.GoStringN(&buf[0], C.int(bufSize))
+	over, saturated, ok := resolveOverflow(text, size, signed, nullable, rset.cfg.Rset.NumberOverflow)
+	if !ok {
+		return nil, ociErr
+	}
+	if over == nil && saturated == nil {
+		return nil, errF("NUMBER value %v overflows a %d-byte integer.", text, size)
+	}
+	if saturated != nil {
+		storeInt(rsl, size, signed, saturated)
+	}
+	return over, nil
+}
+
+// resolveOverflow applies policy to the NUMBER text which didn't fit an
+// integer of size bytes. When text is not out of range, ok is false. An
+// OverflowError policy returns neither over nor saturated.
+func resolveOverflow(text string, size int, signed, nullable bool, policy NumberOverflow) (over interface{}, saturated *big.Int, ok bool) {
+	f, _, err := big.ParseFloat(text, 10, 512, big.ToZero)
+	if err != nil {
+		return nil, nil, false
+	}
+	n, _ := f.Int(nil) // truncates like OCINumberToInt
+	min, max := intLimits(size, signed)
+	if n.Cmp(min) >= 0 && n.Cmp(max) <= 0 {
+		return nil, nil, false
+	}
+	switch policy {
+	case OverflowSaturate:
+		if n.Sign() < 0 {
+			return nil, min, true
+		}
+		return nil, max, true
+	case OverflowString:
+		if f.IsInt() {
+			text = n.String()
+		}
+		if nullable {
+			return String{Value: text}, nil, true
+		}
+		return text, nil, true
+	case OverflowBigInt:
+		return n, nil, true
+	}
+	return nil, nil, true
+}
+
+// intLimits returns the smallest and largest integers of size bytes.
+func intLimits(size int, signed bool) (min, max *big.Int) {
+	bits := uint(size * 8)
+	if !signed {
+		return new(big.Int), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1))
+	}
+	max = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits-1), big.NewInt(1))
+	return new(big.Int).Neg(new(big.Int).Add(max, big.NewInt(1))), max
+}
+
+// storeInt stores v into the integer of size bytes at rsl.
+func storeInt(rsl unsafe.Pointer, size int, signed bool, v *big.Int) {
+	if signed {
+		i := v.Int64()
+		switch size {
+		case 1:
+			*(*int8)(rsl) = int8(i)
+		case 2:
+			*(*int16)(rsl) = int16(i)
+		case 4:
+			*(*int32)(rsl) = int32(i)
+		default:
+			*(*int64)(rsl) = i
+		}
+		return
+	}
+	u := v.Uint64()
+	switch size {
+	case 1:
+		*(*uint8)(rsl) = uint8(u)
+	case 2:
+		*(*uint16)(rsl) = uint16(u)
+	case 4:
+		*(*uint32)(rsl) = uint32(u)
+	default:
+		*(*uint64)(rsl) = u
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestResolveOverflow tests resolveOverflow.
+func TestResolveOverflow(t *testing.T) {
+	const big20 = "12345678901234567890"
+	for i, tc := range []struct {
+		text      string
+		size      int
+		signed    bool
+		nullable  bool
+		policy    NumberOverflow
+		over      interface{}
+		saturated string
+		ok        bool
+	}{
+		{text: "127", size: 1, signed: true, policy: OverflowSaturate},
+		{text: "128", size: 1, signed: true, policy: OverflowError, ok: true},
+		{text: "128", size: 1, signed: true, policy: OverflowSaturate, saturated: "127", ok: true},
+		{text: "-129", size: 1, signed: true, policy: OverflowSaturate, saturated: "-128", ok: true},
+		{text: "-1", size: 4, policy: OverflowSaturate, saturated: "0", ok: true},
+		{text: big20, size: 8, signed: true, policy: OverflowString, over: big20, ok: true},
+		{text: big20, size: 8, signed: true, nullable: true, policy: OverflowString, over: String{Value: big20}, ok: true},
+		{text: "1.5E+30", size: 8, policy: OverflowString, over: "1500000000000000000000000000000", ok: true},
+		{text: "x", size: 8, policy: OverflowString},
+	} {
+		over, saturated, ok := resolveOverflow(tc.text, tc.size, tc.signed, tc.nullable, tc.policy)
+		if ok != tc.ok {
+			t.Errorf("%d. got ok %v, wanted %v", i, ok, tc.ok)
+			continue
+		}
+		if over != tc.over {
+			t.Errorf("%d. got over %#v, wanted %#v", i, over, tc.over)
+		}
+		if got := ""; saturated != nil {
+			if got = saturated.String(); got != tc.saturated {
+				t.Errorf("%d. got saturated %v, wanted %v", i, got, tc.saturated)
+			}
+		} else if tc.saturated != "" {
+			t.Errorf("%d. got no saturated value, wanted %v", i, tc.saturated)
+		}
+	}
+	over, _, _ := resolveOverflow("18446744073709551616", 8, false, false, OverflowBigInt)
+	if want, _ := new(big.Int).SetString("18446744073709551616", 10); over.(*big.Int).Cmp(want) != 0 {
+		t.Errorf("got %v, wanted %v", over, want)
+	}
+}
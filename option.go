@@ -0,0 +1,269 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Option configures the Env, Srv and Ses opened by OpenSes, or the Env and
+// Pool opened by OpenPool.
+//
+// Logging is configured for the package rather than per session, with the
+// LogDrvCfg of SetCfg.
+type Option func(c *openCfg) error
+
+// openCfg holds the configuration built by Options.
+type openCfg struct {
+	env  EnvCfg
+	srv  SrvCfg
+	ses  SesCfg
+	pool PoolCfg
+	nls  map[string]string
+}
+
+func newOpenCfg(opts []Option) (*openCfg, error) {
+	c := &openCfg{
+		env:  *_drv.cfg().Env,
+		srv:  *NewSrvCfg(),
+		ses:  *NewSesCfg(),
+		pool: *NewPoolCfg(),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.srv.Dblink == "" {
+		return nil, er("An Option setting the dblink, such as WithDblink, is required.")
+	}
+	return c, nil
+}
+
+// WithDblink sets SrvCfg.Dblink, the easy connect string, net service name
+// or connect descriptor of the server. WithDblink is required.
+func WithDblink(dblink string) Option {
+	return func(c *openCfg) error {
+		c.srv.Dblink = dblink
+		return nil
+	}
+}
+
+// WithCredentials sets SesCfg.Username and SesCfg.Password.
+func WithCredentials(username, password string) Option {
+	return func(c *openCfg) error {
+		c.ses.Username, c.ses.Password = username, password
+		return nil
+	}
+}
+
+// WithEnvCfg replaces the EnvCfg, which is the EnvCfg of DrvCfg by default.
+func WithEnvCfg(cfg EnvCfg) Option {
+	return func(c *openCfg) error {
+		c.env = cfg
+		return nil
+	}
+}
+
+// WithSrvCfg replaces the SrvCfg, keeping a dblink already set when
+// cfg.Dblink is empty.
+func WithSrvCfg(cfg SrvCfg) Option {
+	return func(c *openCfg) error {
+		if cfg.Dblink == "" {
+			cfg.Dblink = c.srv.Dblink
+		}
+		c.srv = cfg
+		return nil
+	}
+}
+
+// WithSesCfg replaces the SesCfg, keeping credentials already set when
+// cfg.Username and cfg.Password are empty.
+func WithSesCfg(cfg SesCfg) Option {
+	return func(c *openCfg) error {
+		if cfg.Username == "" && cfg.Password == "" {
+			cfg.Username, cfg.Password = c.ses.Username, c.ses.Password
+		}
+		c.ses = cfg
+		return nil
+	}
+}
+
+// WithPool sets the PoolCfg of OpenPool. OpenSes ignores WithPool.
+func WithPool(cfg PoolCfg) Option {
+	return func(c *openCfg) error {
+		c.pool = cfg
+		return nil
+	}
+}
+
+// WithPoolSize sets PoolCfg.MaxSessions and PoolCfg.MaxSessionsPerKey of
+// OpenPool.
+func WithPoolSize(maxSessions, maxSessionsPerKey int) Option {
+	return func(c *openCfg) error {
+		c.pool.MaxSessions, c.pool.MaxSessionsPerKey = maxSessions, maxSessionsPerKey
+		return nil
+	}
+}
+
+// WithNls sets the NLS session parameter name, such as NLS_DATE_FORMAT, to
+// value with ALTER SESSION once each session is opened. name is validated
+// like the names of Ses.WithParams, and must start with NLS_.
+func WithNls(name, value string) Option {
+	return func(c *openCfg) error {
+		params, names, err := sesParamNames(map[string]string{name: value})
+		if err != nil {
+			return err
+		}
+		if name = names[0]; !strings.HasPrefix(name, "NLS_") {
+			return errF("%v is not an NLS session parameter.", name)
+		}
+		if c.nls == nil {
+			c.nls = make(map[string]string)
+		}
+		c.nls[name] = params[name]
+		return nil
+	}
+}
+
+// WithNonBlocking puts the server connection in non-blocking mode polled
+// every pollInterval, so that the context of Stmt.ExeContext and
+// Stmt.QryContext interrupts a call when it's done; see SrvCfg.NonBlocking.
+// WithNonBlocking sets no timeout itself; a call is bounded by the deadline
+// of its context. A pollInterval of zero keeps the default.
+func WithNonBlocking(pollInterval time.Duration) Option {
+	return func(c *openCfg) error {
+		if pollInterval < 0 {
+			return er("Parameter 'pollInterval' may not be negative.")
+		}
+		c.srv.NonBlocking = true
+		if pollInterval > 0 {
+			c.srv.PollInterval = pollInterval
+		}
+		return nil
+	}
+}
+
+// WithCallTimeout sets SesCfg.CallTimeout, the timeout of each execution of a
+// Stmt of the Ses. A Pool applies it to each session it opens.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(c *openCfg) error {
+		if timeout < 0 {
+			return er("Parameter 'timeout' may not be negative.")
+		}
+		c.ses.CallTimeout = timeout
+		return nil
+	}
+}
+
+// WithResumableTimeout sets SesCfg.ResumableTimeout.
+func WithResumableTimeout(timeout time.Duration) Option {
+	return func(c *openCfg) error {
+		c.ses.ResumableTimeout = timeout
+		return nil
+	}
+}
+
+// OpenSes opens an Env, a Srv and a Ses configured by opts in one call.
+// Closing the Ses closes the Srv and the Env.
+//
+// ctx is checked before each step; a server attach in progress isn't
+// interrupted.
+func OpenSes(ctx context.Context, opts ...Option) (ses *Ses, err error) {
+	log(_drv.cfg().Log.OpenSes)
+	c, err := newOpenCfg(opts)
+	if err != nil {
+		return nil, errE(err)
+	}
+	if err = ctxErr(ctx); err != nil {
+		return nil, err
+	}
+	env, err := OpenEnv(&c.env)
+	if err != nil {
+		return nil, errE(err)
+	}
+	var srv *Srv
+	defer func() {
+		if err == nil {
+			return
+		}
+		if srv != nil {
+			srv.Close()
+		}
+		env.Close()
+	}()
+	if err = ctxErr(ctx); err != nil {
+		return nil, err
+	}
+	if srv, err = env.OpenSrv(&c.srv); err != nil {
+		return nil, err // a *ConnectError is returned as is
+	}
+	if err = ctxErr(ctx); err != nil {
+		return nil, err
+	}
+	if ses, err = srv.OpenSes(&c.ses); err != nil {
+		return nil, errE(err)
+	}
+	if err = c.alterNls(ses); err != nil {
+		return nil, errE(err)
+	}
+	ses.ownsSrv = true
+	return ses, nil
+}
+
+// OpenPool opens an Env and a Pool configured by opts in one call. Closing
+// or draining the Pool closes the Env.
+func OpenPool(ctx context.Context, opts ...Option) (p *Pool, err error) {
+	log(_drv.cfg().Log.OpenPool)
+	c, err := newOpenCfg(opts)
+	if err != nil {
+		return nil, errE(err)
+	}
+	if err = ctxErr(ctx); err != nil {
+		return nil, err
+	}
+	if c.pool.Env != nil { // open the Env of the Pool only once
+		c.env, c.pool.Env = *c.pool.Env, nil
+	}
+	env, err := OpenEnv(&c.env)
+	if err != nil {
+		return nil, errE(err)
+	}
+	if p, err = env.NewPool(&c.srv, &c.ses, &c.pool); err != nil {
+		env.Close()
+		return nil, errE(err)
+	}
+	if len(c.nls) > 0 { // before the state restored by Put is saved
+		onOpen := p.cfg.OnOpen
+		p.cfg.OnOpen = func(ses *Ses, key string) error {
+			if onOpen != nil {
+				if err := onOpen(ses, key); err != nil {
+					return err
+				}
+			}
+			return c.alterNls(ses)
+		}
+	}
+	p.ownsEnv = true
+	return p, nil
+}
+
+// alterNls sets the NLS parameters of the options on ses.
+func (c *openCfg) alterNls(ses *Ses) error {
+	if len(c.nls) == 0 {
+		return nil
+	}
+	return ses.alter(alterNls(c.nls))
+}
+
+// ctxErr returns the error of ctx, or nil when ctx is nil or not done.
+func ctxErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
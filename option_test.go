@@ -0,0 +1,50 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenCfg(t *testing.T) {
+	if _, err := newOpenCfg(nil); err == nil {
+		t.Error("expected an error without a dblink")
+	}
+	sesCfg := NewSesCfg()
+	sesCfg.StmtCacheSize = 10
+	c, err := newOpenCfg([]Option{
+		WithDblink("db:1521/svc"),
+		WithCredentials("scott", "tiger"),
+		WithSesCfg(*sesCfg),
+		WithPoolSize(8, 2),
+		WithNls("nls_date_format", "YYYY-MM-DD"),
+		WithNonBlocking(time.Millisecond),
+		WithCallTimeout(time.Second),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.srv.Dblink != "db:1521/svc" || !c.srv.NonBlocking || c.srv.PollInterval != time.Millisecond {
+		t.Errorf("got srv cfg %+v", c.srv)
+	}
+	if c.ses.Username != "scott" || c.ses.Password != "tiger" || c.ses.StmtCacheSize != 10 || c.ses.CallTimeout != time.Second {
+		t.Errorf("got ses cfg %+v", c.ses)
+	}
+	if c.pool.MaxSessions != 8 || c.pool.MaxSessionsPerKey != 2 {
+		t.Errorf("got pool cfg %+v", c.pool)
+	}
+	if c.nls["NLS_DATE_FORMAT"] != "YYYY-MM-DD" {
+		t.Errorf("got nls %v", c.nls)
+	}
+	for _, name := range []string{"", "NLS_DATE_FORMAT = 'YYYY' NLS_LANGUAGE", "OPTIMIZER_MODE"} {
+		if _, err = newOpenCfg([]Option{WithDblink("db/svc"), WithNls(name, "x")}); err == nil {
+			t.Errorf("%q: expected an error for an invalid NLS parameter name", name)
+		}
+	}
+	if _, err = newOpenCfg([]Option{WithDblink("db/svc"), WithCallTimeout(-time.Second)}); err == nil {
+		t.Error("expected an error for a negative call timeout")
+	}
+}
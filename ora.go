@@ -32,7 +32,7 @@ func init() {
 	_drv = &Drv{}
 	_drv.locations = make(map[string]*time.Location)
 	_drv.openEnvs = newEnvList()
-	_drv.cfg = *NewDrvCfg()
+	_drv.cfgV.Store(NewDrvCfg())
 
 	// init general pools
 	_drv.listPool = newPool(func() interface{} { return list.New() })
@@ -96,10 +96,11 @@ func init() {
 	_drv.bndPools[bndIdxIntervalDSSlice] = newPool(func() interface{} { return &bndIntervalDSSlice{} })
 	_drv.bndPools[bndIdxRset] = newPool(func() interface{} { return &bndRset{} })
 	_drv.bndPools[bndIdxBfile] = newPool(func() interface{} { return &bndBfile{} })
+	_drv.bndPools[bndIdxInList] = newPool(func() interface{} { return &bndInList{} })
 	_drv.bndPools[bndIdxNil] = newPool(func() interface{} { return &bndNil{} })
 
 	// init def pools
-	_drv.defPools = make([]*pool, defIdxRowid+1)
+	_drv.defPools = make([]*pool, defIdxPiece+1)
 	_drv.defPools[defIdxInt64] = newPool(func() interface{} { return &defInt64{} })
 	_drv.defPools[defIdxInt32] = newPool(func() interface{} { return &defInt32{} })
 	_drv.defPools[defIdxInt16] = newPool(func() interface{} { return &defInt16{} })
@@ -120,6 +121,7 @@ func init() {
 	_drv.defPools[defIdxIntervalYM] = newPool(func() interface{} { return &defIntervalYM{} })
 	_drv.defPools[defIdxIntervalDS] = newPool(func() interface{} { return &defIntervalDS{} })
 	_drv.defPools[defIdxRowid] = newPool(func() interface{} { return &defRowid{} })
+	_drv.defPools[defIdxPiece] = newPool(func() interface{} { return &defPiece{} })
 }
 
 func init() {
@@ -130,6 +132,7 @@ func init() {
 	}
 	// database/sql/driver expects binaryFloat to return float64 (not the Rset default of float32)
 	_drv.sqlPkgEnv.cfg.StmtCfg.Rset.binaryFloat = F64
+	_drv.sqlPkgEnv.sqlPkg = true
 	sql.Register(Name, _drv)
 }
 
@@ -138,7 +141,8 @@ func SetDrvCfg(cfg *DrvCfg) {
 	if cfg == nil {
 		return
 	}
-	_drv.cfg = *cfg
+	c := *cfg // copy by value so that the caller may change cfg independently
+	_drv.cfgV.Store(&c)
 	_drv.sqlPkgEnv.cfg = *cfg.Env
 	_drv.sqlPkgEnv.cfg.StmtCfg.Rset.binaryFloat = F64
 }
@@ -157,11 +161,16 @@ func Register(cfg *DrvCfg) {
 func OpenEnv(cfg *EnvCfg) (env *Env, err error) {
 	_drv.mu.Lock()
 	defer _drv.mu.Unlock()
-	log(_drv.cfg.Log.OpenEnv)
+	log(_drv.cfg().Log.OpenEnv)
 	if cfg == nil { // ensure cfg
-		tmp := *_drv.cfg.Env // copy by value to ensure independent cfgs
+		tmp := *_drv.cfg().Env // copy by value to ensure independent cfgs
 		cfg = &tmp
 	}
+	if cfg.Net != nil {
+		if err = applyNetCfg(*cfg.Net); err != nil {
+			return nil, errE(err)
+		}
+	}
 	var csIDAl32UTF8 C.ub2
 	if csIDAl32UTF8 == 0 { // Get the code for AL32UTF8
 		var ocienv *C.OCIEnv
@@ -176,10 +185,15 @@ func OpenEnv(cfg *EnvCfg) (env *Env, err error) {
 	// OCI_DEFAULT  - The default value, which is non-UTF-16 encoding.
 	// OCI_THREADED - Uses threaded environment. Internal data structures not exposed to the user are protected from concurrent accesses by multiple threads.
 	// OCI_OBJECT   - Uses object features such as OCINumber, OCINumberToInt, OCINumberFromInt. These are used in oracle-go type conversions.
+	// OCI_EVENTS   - Receives FAN high availability events; set by EnvCfg.Events.
+	mode := C.ub4(C.OCI_DEFAULT | C.OCI_OBJECT | C.OCI_THREADED)
+	if cfg.Events {
+		mode |= C.OCI_EVENTS
+	}
 	env = _drv.envPool.Get().(*Env) // set *Env
 	r := C.OCIEnvNlsCreate(
-		&env.ocienv, //OCIEnv        **envhpp,
-		C.OCI_DEFAULT|C.OCI_OBJECT|C.OCI_THREADED, //ub4           mode,
+		&env.ocienv,  //OCIEnv        **envhpp,
+		mode,         //ub4           mode,
 		nil,          //void          *ctxp,
 		nil,          //void          *(*malocfp)
 		nil,          //void          *(*ralocfp)
@@ -214,16 +228,29 @@ func NumEnv() int {
 }
 
 // SetCfg applies the specified cfg to the ora database driver and any open Envs.
+//
+// The driver cfg is swapped atomically, so SetCfg may be called at runtime
+// while the driver is in use: each call observes either the old or the new
+// cfg.
 func SetCfg(cfg DrvCfg) {
 	_drv.mu.Lock()
 	defer _drv.mu.Unlock()
-	_drv.cfg = cfg
+	_drv.cfgV.Store(&cfg)
 	_drv.openEnvs.setAllCfg(cfg.Env)
 }
 
 // Cfg returns the ora database driver's cfg.
+//
+// Changes to the returned cfg aren't synchronized with calls in progress; to
+// change the cfg of a driver in use, change a copy returned by CfgCopy and
+// apply it with SetCfg.
 func Cfg() *DrvCfg {
-	_drv.mu.Lock()
-	defer _drv.mu.Unlock()
-	return &_drv.cfg
+	return _drv.cfg()
+}
+
+// CfgCopy returns a copy of the ora database driver's cfg, sharing no maps,
+// slices or nested cfgs with it. Changes to the copy have no effect until it
+// is applied with SetCfg.
+func CfgCopy() *DrvCfg {
+	return _drv.cfg().copy()
 }
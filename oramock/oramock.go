@@ -0,0 +1,384 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+// Package oramock is an in-memory fake of the ora.Sessioner, ora.Stmter and
+// ora.Rsetter interfaces for unit testing code which uses the native API
+// without a database.
+//
+// Expectations are scripted on a Ses in the order the calls are expected;
+// each is matched by a regular expression of the SQL, and consumed by one
+// execution or query:
+//
+//	ses := oramock.New()
+//	ses.Expect(`^SELECT ID, NAME FROM T1`).
+//		WillReturnRows([]string{"ID", "NAME"}, []interface{}{int64(1), "a"})
+//	ses.Expect(`^UPDATE T1`).WillReturnResult(1)
+//	err := codeUnderTest(ses)
+//	...
+//	if err := ses.ExpectationsWereMet(); err != nil {
+//		t.Error(err)
+//	}
+//
+// The parameters of every call are captured and returned by Ses.Calls.
+package oramock // import "gopkg.in/rana/ora.v3/oramock"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+var (
+	_ ora.Sessioner = (*Ses)(nil)
+	_ ora.Stmter    = (*Stmt)(nil)
+	_ ora.Rsetter   = (*Rset)(nil)
+)
+
+// Call is an execution or query received by a Ses.
+type Call struct {
+	SQL    string
+	Params []interface{}
+}
+
+// Expectation is a scripted result of an execution or query.
+type Expectation struct {
+	re           *regexp.Regexp
+	rowsAffected uint64
+	err          error
+	columns      []string
+	rows         [][]interface{}
+	out          map[int]interface{}
+	prepErr      bool
+	met          bool
+}
+
+// WillReturnResult sets the rows affected returned by an execution.
+func (e *Expectation) WillReturnResult(rowsAffected uint64) *Expectation {
+	e.rowsAffected = rowsAffected
+	return e
+}
+
+// WillReturnRows sets the columns and rows of the Rset returned by a query.
+func (e *Expectation) WillReturnRows(columns []string, rows ...[]interface{}) *Expectation {
+	e.columns, e.rows = columns, rows
+	return e
+}
+
+// WillReturnError sets the error returned by the execution or query.
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// WillFailPrep makes the Prep of the statement return the error set by
+// WillReturnError, consuming the Expectation.
+func (e *Expectation) WillFailPrep() *Expectation {
+	e.prepErr = true
+	return e
+}
+
+// WillSetParam sets the value pointed to by the parameter at index n of the
+// call, as an out bind of a PL/SQL block is set. value must be assignable to
+// the pointed to type.
+func (e *Expectation) WillSetParam(n int, value interface{}) *Expectation {
+	if e.out == nil {
+		e.out = make(map[int]interface{})
+	}
+	e.out[n] = value
+	return e
+}
+
+// Ses is a fake ora.Sessioner. A Ses is safe for concurrent use.
+type Ses struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+	closed       bool
+
+	// PingErr is returned by Ping.
+	PingErr error
+}
+
+// New returns an open Ses without expectations.
+func New() *Ses {
+	return &Ses{}
+}
+
+// Expect adds an Expectation of an execution or query whose SQL matches the
+// regular expression sqlRegexp. Expect panics when sqlRegexp doesn't compile.
+func (ses *Ses) Expect(sqlRegexp string) *Expectation {
+	e := &Expectation{re: regexp.MustCompile(sqlRegexp)}
+	ses.mu.Lock()
+	ses.expectations = append(ses.expectations, e)
+	ses.mu.Unlock()
+	return e
+}
+
+// ExpectationsWereMet returns an error naming the first Expectation not yet
+// consumed, and nil when every Expectation was.
+func (ses *Ses) ExpectationsWereMet() error {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	for _, e := range ses.expectations {
+		if !e.met {
+			return fmt.Errorf("oramock: expectation %q was not met", e.re)
+		}
+	}
+	return nil
+}
+
+// Calls returns the executions and queries received, in order.
+func (ses *Ses) Calls() []Call {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	return append([]Call(nil), ses.calls...)
+}
+
+// next consumes the next Expectation when it matches sql.
+func (ses *Ses) next(sql string, params []interface{}) (*Expectation, error) {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	if ses.closed {
+		return nil, fmt.Errorf("oramock: Ses is closed")
+	}
+	ses.calls = append(ses.calls, Call{SQL: sql, Params: append([]interface{}(nil), params...)})
+	for _, e := range ses.expectations {
+		if e.met {
+			continue
+		}
+		if !e.re.MatchString(sql) {
+			return nil, fmt.Errorf("oramock: %q doesn't match the next expectation %q", sql, e.re)
+		}
+		e.met = true
+		return e, nil
+	}
+	return nil, fmt.Errorf("oramock: unexpected %q", sql)
+}
+
+// peek returns the next Expectation, or nil.
+func (ses *Ses) peek() *Expectation {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	for _, e := range ses.expectations {
+		if !e.met {
+			return e
+		}
+	}
+	return nil
+}
+
+// PrepAndExe consumes the next Expectation, which must match sql.
+func (ses *Ses) PrepAndExe(sql string, params ...interface{}) (rowsAffected uint64, err error) {
+	e, err := ses.next(sql, params)
+	if err != nil {
+		return 0, err
+	}
+	return e.exe(params)
+}
+
+// PrepStmter returns a Stmt of sql. The Prep fails when the next Expectation
+// matches sql and was set by WillFailPrep.
+func (ses *Ses) PrepStmter(sql string, gcts ...ora.GoColumnType) (ora.Stmter, error) {
+	if !ses.IsOpen() {
+		return nil, fmt.Errorf("oramock: Ses is closed")
+	}
+	if e := ses.peek(); e != nil && e.prepErr && e.re.MatchString(sql) {
+		ses.next(sql, nil)
+		if e.err == nil {
+			return nil, fmt.Errorf("oramock: Prep of %q failed", sql)
+		}
+		return nil, e.err
+	}
+	return &Stmt{ses: ses, sql: sql, numInput: numInput(sql)}, nil
+}
+
+// Ping returns PingErr.
+func (ses *Ses) Ping() error {
+	if !ses.IsOpen() {
+		return fmt.Errorf("oramock: Ses is closed")
+	}
+	return ses.PingErr
+}
+
+// IsOpen returns true until Close is called.
+func (ses *Ses) IsOpen() bool {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	return !ses.closed
+}
+
+// Close closes the Ses.
+func (ses *Ses) Close() error {
+	ses.mu.Lock()
+	ses.closed = true
+	ses.mu.Unlock()
+	return nil
+}
+
+// exe sets the out params and returns the result of an execution.
+func (e *Expectation) exe(params []interface{}) (uint64, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	for n, value := range e.out {
+		if n >= len(params) {
+			return 0, fmt.Errorf("oramock: no param %d to set", n)
+		}
+		pv := reflect.ValueOf(params[n])
+		if pv.Kind() != reflect.Ptr || pv.IsNil() {
+			return 0, fmt.Errorf("oramock: param %d is %T, not a pointer", n, params[n])
+		}
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(pv.Elem().Type()) {
+			return 0, fmt.Errorf("oramock: %T isn't assignable to param %d of %T", value, n, params[n])
+		}
+		pv.Elem().Set(v)
+	}
+	return e.rowsAffected, nil
+}
+
+// Stmt is a fake ora.Stmter of a Ses.
+type Stmt struct {
+	ses      *Ses
+	sql      string
+	numInput int
+	closed   bool
+}
+
+// Exe consumes the next Expectation of the Ses, which must match the SQL.
+func (stmt *Stmt) Exe(params ...interface{}) (rowsAffected uint64, err error) {
+	return stmt.ExeContext(context.Background(), params...)
+}
+
+// ExeContext is Exe, returning the error of ctx when it's done.
+func (stmt *Stmt) ExeContext(ctx context.Context, params ...interface{}) (rowsAffected uint64, err error) {
+	if err = stmt.check(ctx); err != nil {
+		return 0, err
+	}
+	e, err := stmt.ses.next(stmt.sql, params)
+	if err != nil {
+		return 0, err
+	}
+	return e.exe(params)
+}
+
+// QryRsetter consumes the next Expectation of the Ses, which must match the
+// SQL, returning a Rset of its rows.
+func (stmt *Stmt) QryRsetter(params ...interface{}) (ora.Rsetter, error) {
+	return stmt.QryRsetterContext(context.Background(), params...)
+}
+
+// QryRsetterContext is QryRsetter, returning the error of ctx when it's done.
+func (stmt *Stmt) QryRsetterContext(ctx context.Context, params ...interface{}) (ora.Rsetter, error) {
+	if err := stmt.check(ctx); err != nil {
+		return nil, err
+	}
+	e, err := stmt.ses.next(stmt.sql, params)
+	if err != nil {
+		return nil, err
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return NewRset(e.columns, e.rows...), nil
+}
+
+func (stmt *Stmt) check(ctx context.Context) error {
+	if stmt.closed {
+		return fmt.Errorf("oramock: Stmt is closed")
+	}
+	if ctx != nil {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// NumInput returns the number of distinct placeholders of the SQL.
+func (stmt *Stmt) NumInput() int {
+	return stmt.numInput
+}
+
+// IsOpen returns true until Close is called.
+func (stmt *Stmt) IsOpen() bool {
+	return !stmt.closed
+}
+
+// Close closes the Stmt.
+func (stmt *Stmt) Close() error {
+	stmt.closed = true
+	return nil
+}
+
+// placeholder matches a :name or :n placeholder, skipping string literals.
+var placeholder = regexp.MustCompile(`'[^']*'|:\w+`)
+
+// numInput returns the number of distinct placeholders of sql.
+func numInput(sql string) int {
+	names := make(map[string]bool)
+	for _, m := range placeholder.FindAllString(sql, -1) {
+		if m[0] == ':' {
+			names[m] = true
+		}
+	}
+	return len(names)
+}
+
+// Rset is a fake ora.Rsetter of scripted rows.
+type Rset struct {
+	columns []string
+	rows    [][]interface{}
+	index   int
+	row     []interface{}
+}
+
+// NewRset returns a Rset of columns and rows.
+func NewRset(columns []string, rows ...[]interface{}) *Rset {
+	return &Rset{columns: columns, rows: rows, index: -1}
+}
+
+// Next moves to the next row, returning false when there are no more.
+func (rset *Rset) Next() bool {
+	if rset.index+1 >= len(rset.rows) {
+		rset.row = nil
+		return false
+	}
+	rset.index++
+	rset.row = rset.rows[rset.index]
+	return true
+}
+
+// NextRow moves to the next row and returns it, or nil.
+func (rset *Rset) NextRow() []interface{} {
+	rset.Next()
+	return rset.row
+}
+
+// Values returns the current row.
+func (rset *Rset) Values() []interface{} {
+	return rset.row
+}
+
+// Columns returns the column names.
+func (rset *Rset) Columns() []string {
+	return rset.columns
+}
+
+// Len returns the number of rows retrieved.
+func (rset *Rset) Len() int {
+	return rset.index + 1
+}
+
+// IsOpen returns true until every row is retrieved.
+func (rset *Rset) IsOpen() bool {
+	return rset.index+1 < len(rset.rows) || rset.row != nil
+}
+
+// LastErr returns nil; a scripted query fails with its error instead.
+func (rset *Rset) LastErr() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package oramock
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSesScript(t *testing.T) {
+	ses := New()
+	ses.Expect(`^SELECT ID, NAME FROM T1 WHERE ID > :1$`).
+		WillReturnRows([]string{"ID", "NAME"}, []interface{}{int64(1), "a"}, []interface{}{int64(2), "b"})
+	ses.Expect(`^UPDATE T1`).WillReturnResult(2)
+	ses.Expect(`^BEGIN`).WillSetParam(1, "out")
+
+	stmt, err := ses.PrepStmter("SELECT ID, NAME FROM T1 WHERE ID > :1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stmt.NumInput() != 1 {
+		t.Errorf("NumInput: expected 1, actual %d", stmt.NumInput())
+	}
+	rset, err := stmt.QryRsetter(int64(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []interface{}
+	for rset.Next() {
+		names = append(names, rset.Values()[1])
+	}
+	if !reflect.DeepEqual(names, []interface{}{"a", "b"}) || rset.Len() != 2 || rset.IsOpen() {
+		t.Errorf("rows: actual %v, Len %d, IsOpen %v", names, rset.Len(), rset.IsOpen())
+	}
+
+	if n, err := ses.PrepAndExe("UPDATE T1 SET NAME = :1", "c"); err != nil || n != 2 {
+		t.Errorf("PrepAndExe: expected 2, actual %d, %v", n, err)
+	}
+	var out string
+	if _, err = ses.PrepAndExe("BEGIN p(:1, :2); END;", "in", &out); err != nil || out != "out" {
+		t.Errorf("out param: actual %q, %v", out, err)
+	}
+
+	if err = ses.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	calls := ses.Calls()
+	if len(calls) != 3 || !reflect.DeepEqual(calls[1].Params, []interface{}{"c"}) {
+		t.Errorf("Calls: actual %v", calls)
+	}
+}
+
+func TestSesUnexpected(t *testing.T) {
+	ses := New()
+	want := errors.New("ORA-00001")
+	ses.Expect(`^INSERT`).WillReturnError(want)
+	if _, err := ses.PrepAndExe("DELETE FROM T1"); err == nil {
+		t.Error("mismatched SQL: expected an error")
+	}
+	if err := ses.ExpectationsWereMet(); err == nil {
+		t.Error("ExpectationsWereMet: expected an error")
+	}
+	if _, err := ses.PrepAndExe("INSERT INTO T1 VALUES (1)"); err != want {
+		t.Errorf("expected %v, actual %v", want, err)
+	}
+	if _, err := ses.PrepAndExe("INSERT INTO T1 VALUES (1)"); err == nil {
+		t.Error("no expectation left: expected an error")
+	}
+	ses.Close()
+	if err := ses.Ping(); err == nil {
+		t.Error("Ping of a closed Ses: expected an error")
+	}
+}
+
+func TestNumInput(t *testing.T) {
+	for sql, want := range map[string]int{
+		"SELECT 1 FROM DUAL":                  0,
+		"INSERT INTO T1 VALUES (:a, :b, :a)":  2,
+		"SELECT ':x' FROM DUAL WHERE ID = :1": 1,
+		"BEGIN p(:1, :2); END;":               2,
+	} {
+		if got := numInput(sql); got != want {
+			t.Errorf("%q: expected %d, actual %d", sql, want, got)
+		}
+	}
+}
@@ -127,7 +127,7 @@ type col struct {
 // Ins will insert a struct to a table without returning an identity value.
 //
 // Set ora.Schema to specify an optional table name prefix.
-func Ins(v interface{}, ses *Ses) (err error) {
+func Ins(v interface{}, ses Sessioner) (err error) {
 	_drv.insMu.Lock()
 	defer _drv.insMu.Unlock()
 	defer func() {
@@ -135,7 +135,7 @@ func Ins(v interface{}, ses *Ses) (err error) {
 			err = errR(value)
 		}
 	}()
-	log(_drv.cfg.Log.Ins)
+	log(_drv.cfg().Log.Ins)
 	tbl, err := tblGet(v)
 	if err != nil {
 		return errE(err)
@@ -192,7 +192,7 @@ func Ins(v interface{}, ses *Ses) (err error) {
 			params[last] = fv.Addr().Interface()
 		}
 	}
-	stmt, err := ses.Prep(buf.String())
+	stmt, err := ses.PrepStmter(buf.String())
 	if err != nil {
 		return errE(err)
 	}
@@ -222,7 +222,7 @@ func Ins(v interface{}, ses *Ses) (err error) {
 // tag will remove a field from the UPDATE statement.
 //
 // Set ora.Schema to specify an optional table name prefix.
-func Upd(v interface{}, ses *Ses) (err error) {
+func Upd(v interface{}, ses Sessioner) (err error) {
 	_drv.updMu.Lock()
 	defer _drv.updMu.Unlock()
 	defer func() {
@@ -230,7 +230,7 @@ func Upd(v interface{}, ses *Ses) (err error) {
 			err = errR(value)
 		}
 	}()
-	log(_drv.cfg.Log.Upd)
+	log(_drv.cfg().Log.Upd)
 	tbl, err := tblGet(v)
 	if err != nil {
 		return errE(err)
@@ -252,7 +252,11 @@ func Upd(v interface{}, ses *Ses) (err error) {
 	} else {
 		tblName = tbl.name
 	}
-	err = ses.Upd(tblName, pairs...) // expects last pair is pk
+	sql, params, err := updSql(tblName, pairs) // expects last pair is pk
+	if err != nil {
+		return errE(err)
+	}
+	_, err = ses.PrepAndExe(sql, params...)
 	if err != nil {
 		return errE(err)
 	}
@@ -274,7 +278,7 @@ func Upd(v interface{}, ses *Ses) (err error) {
 // alternative column name may be specified to the field tag `db:"column_name"`.
 //
 // Set ora.Schema to specify an optional table name prefix.
-func Del(v interface{}, ses *Ses) (err error) {
+func Del(v interface{}, ses Sessioner) (err error) {
 	_drv.delMu.Lock()
 	defer _drv.delMu.Unlock()
 	defer func() {
@@ -282,7 +286,7 @@ func Del(v interface{}, ses *Ses) (err error) {
 			err = errR(value)
 		}
 	}()
-	log(_drv.cfg.Log.Del)
+	log(_drv.cfg().Log.Del)
 	tbl, err := tblGet(v)
 	if err != nil {
 		return errE(err)
@@ -342,7 +346,7 @@ func Del(v interface{}, ses *Ses) (err error) {
 // MapOfValFk3, and MapOfValFk4.
 //
 // Set ora.Schema to specify an optional table name prefix.
-func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...interface{}) (result interface{}, err error) {
+func Sel(v interface{}, rt ResType, ses Sessioner, where string, whereParams ...interface{}) (result interface{}, err error) {
 	_drv.selMu.Lock()
 	defer _drv.selMu.Unlock()
 	defer func() {
@@ -350,7 +354,7 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 			err = errR(value)
 		}
 	}()
-	log(_drv.cfg.Log.Sel)
+	log(_drv.cfg().Log.Sel)
 	tbl, err := tblGet(v)
 	if err != nil {
 		return nil, errE(err)
@@ -381,18 +385,18 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 		buf.WriteString(where)
 	}
 	// prep
-	stmt, err := ses.Prep(buf.String(), gcts...)
+	stmt, err := ses.PrepStmter(buf.String(), gcts...)
+	if err != nil {
+		return nil, errE(err)
+	}
 	defer func() {
 		err = stmt.Close()
 		if err != nil {
 			err = errE(err)
 		}
 	}()
-	if err != nil {
-		return nil, errE(err)
-	}
 	// qry
-	rset, err := stmt.Qry(whereParams...)
+	rset, err := stmt.QryRsetter(whereParams...)
 	if err != nil {
 		return nil, errE(err)
 	}
@@ -405,7 +409,7 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 			valRV := ptrRV.Elem()
 			for n, col := range tbl.cols {
 				f := valRV.Field(col.fieldIdx)
-				f.Set(reflect.ValueOf(rset.Row[n]))
+				f.Set(reflect.ValueOf(rset.Values()[n]))
 			}
 			sliceOfPtrRV = reflect.Append(sliceOfPtrRV, ptrRV)
 		}
@@ -417,7 +421,7 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 			valRV := reflect.New(tbl.typ).Elem()
 			for n, col := range tbl.cols {
 				f := valRV.Field(col.fieldIdx)
-				f.Set(reflect.ValueOf(rset.Row[n]))
+				f.Set(reflect.ValueOf(rset.Values()[n]))
 			}
 			sliceOfValRV = reflect.Append(sliceOfValRV, valRV)
 		}
@@ -485,7 +489,7 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 			valRV := ptrRV.Elem()
 			for n, col := range tbl.cols {
 				f := valRV.Field(col.fieldIdx)
-				fv := reflect.ValueOf(rset.Row[n])
+				fv := reflect.ValueOf(rset.Values()[n])
 				f.Set(fv)
 				switch rt {
 				case MapOfPtrPk:
@@ -575,7 +579,7 @@ func Sel(v interface{}, rt ResType, ses *Ses, where string, whereParams ...inter
 			valRV := reflect.New(tbl.typ).Elem()
 			for n, col := range tbl.cols {
 				f := valRV.Field(col.fieldIdx)
-				fv := reflect.ValueOf(rset.Row[n])
+				fv := reflect.ValueOf(rset.Values()[n])
 				f.Set(fv)
 				switch rt {
 				case MapOfValPk:
@@ -621,7 +625,7 @@ func AddTbl(v interface{}, tblName string) (err error) {
 	if err != nil {
 		return errE(err)
 	}
-	logF(_drv.cfg.Log.AddTbl, "%v to %v", typ.Name(), tblName)
+	logF(_drv.cfg().Log.AddTbl, "%v to %v", typ.Name(), tblName)
 	_, err = tblCreate(typ, strings.ToUpper(tblName))
 	if err != nil {
 		return errE(err)
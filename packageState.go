@@ -0,0 +1,50 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "strconv"
+
+// packageStateCodes are the Oracle error codes reporting that the state of a
+// PL/SQL package was discarded, typically after the package was recompiled.
+// The next call of the package in the session succeeds with a new state.
+var packageStateCodes = map[int]bool{
+	4061: true, // existing state of package has been invalidated
+	4068: true, // existing state of packages has been discarded
+}
+
+// isPackageStateDiscarded reports whether the first error of err reports a
+// discarded package state.
+func isPackageStateDiscarded(err error) bool {
+	if err == nil {
+		return false
+	}
+	m := oraCode.FindStringSubmatch(err.Error())
+	if m == nil {
+		return false
+	}
+	code, _ := strconv.Atoi(m[1])
+	return packageStateCodes[code]
+}
+
+// retryPackageState re-executes a statement whose execution r failed with a
+// discarded package state, at most StmtCfg.PackageStateRetries times, and
+// returns the result of the last execution. An array execution isn't
+// re-executed, as some of its rows may have been processed. No locking
+// occurs.
+func (stmt *Stmt) retryPackageState(r C.sword, iterations uint32, execute func() C.sword) C.sword {
+	for n := 0; r == C.OCI_ERROR && n < stmt.cfg.PackageStateRetries && iterations <= 1; n++ {
+		err := stmt.ses.ociError()
+		if !isPackageStateDiscarded(err) {
+			break
+		}
+		stmt.logF(_drv.cfg().Log.Stmt.PackageStateRetry, "re-executing after discarded package state: %v", err)
+		r = execute()
+	}
+	return r
+}
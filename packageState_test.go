@@ -0,0 +1,41 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestIsPackageStateDiscarded tests the detection of discarded package states.
+func TestIsPackageStateDiscarded(t *testing.T) {
+	for _, tc := range []struct {
+		msg  string
+		want bool
+	}{
+		{"ORA-04068: existing state of packages has been discarded\nORA-04061: existing state of package body \"APP.PKG\" has been invalidated\nORA-06508: PL/SQL: could not find program unit being called", true},
+		{"ORA-04061: existing state of package \"APP.PKG\" has been invalidated", true},
+		{"ORA-06550: line 1, column 7:\nORA-04068: existing state of packages has been discarded", false},
+		{"ORA-00942: table or view does not exist", false},
+		{"no code", false},
+	} {
+		if got := isPackageStateDiscarded(errors.New(tc.msg)); got != tc.want {
+			t.Errorf("%q: got %v, wanted %v", tc.msg, got, tc.want)
+		}
+	}
+	if isPackageStateDiscarded(nil) {
+		t.Error("got true for nil")
+	}
+}
+
+// TestPackageStateRetries_default tests that re-execution is opt-in.
+func TestPackageStateRetries_default(t *testing.T) {
+	if n := NewStmtCfg().PackageStateRetries; n != 0 {
+		t.Errorf("got %v retries, wanted 0", n)
+	}
+	if !NewLogStmtCfg().PackageStateRetry {
+		t.Error("got re-executions not logged by default")
+	}
+}
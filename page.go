@@ -0,0 +1,141 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// releaseVersion matches the major version in the banner returned by
+// OCIServerVersion, such as "Oracle Database 12c ... Release 12.1.0.2.0".
+var releaseVersion = regexp.MustCompile(`Release (\d+)\.`)
+
+// majorVersion returns the major version of the database server, which is
+// cached after the first call.
+func (srv *Srv) majorVersion() (int, error) {
+	if major := atomic.LoadInt32(&srv.major); major > 0 {
+		return int(major), nil
+	}
+	ver, err := srv.Version()
+	if err != nil {
+		return 0, err
+	}
+	major := parseMajorVersion(ver)
+	if major == 0 {
+		return 0, errF("Unable to determine the major version of %q.", ver)
+	}
+	atomic.StoreInt32(&srv.major, int32(major))
+	return major, nil
+}
+
+// parseMajorVersion returns the major version of a server version banner,
+// or zero.
+func parseMajorVersion(ver string) int {
+	m := releaseVersion.FindStringSubmatch(ver)
+	if m == nil {
+		return 0
+	}
+	major, _ := strconv.Atoi(m[1])
+	return major
+}
+
+// QryPage queries a page of limit rows of the Stmt, skipping the first offset
+// rows, and returns an *Rset of the page.
+//
+// The query is rewritten for the server version: on 12c and later the
+// OFFSET and FETCH NEXT clauses are appended; on earlier versions the query is
+// wrapped in a ROWNUM filter selecting the described columns, so each page has
+// the columns of the query either way. Order the query for consistent pages.
+//
+// The page is queried with a Stmt internal to QryPage, prepared with the
+// GoColumnTypes and StmtCfg of the Stmt and automatically closed when the *Rset
+// retrieves all rows or returns an error.
+func (stmt *Stmt) QryPage(offset, limit uint64, params ...interface{}) (rset *Rset, err error) {
+	stmt.race.enter("Stmt", stmt, "QryPage")
+	defer stmt.race.leave()
+	stmt.mu.Lock()
+	stmt.log(_drv.cfg().Log.Stmt.QryPage)
+	err = stmt.checkClosed()
+	if err != nil {
+		stmt.mu.Unlock()
+		return nil, errE(err)
+	}
+	if limit == 0 {
+		stmt.mu.Unlock()
+		return nil, er("Parameter 'limit' must be greater than zero.")
+	}
+	ses, sql, gcts, cfg := stmt.ses, stmt.sql, stmt.gcts, stmt.cfg
+	params, err = orderParams(params, stmt.paramOrder)
+	stmt.mu.Unlock()
+	if err != nil {
+		return nil, errE(err)
+	}
+	if !ses.cfg.SqlTagAsAction {
+		sql = strings.TrimPrefix(sql, tagSql("", ses.cfg.SqlTag)) // Prep tags the page again
+	}
+	major, err := ses.srv.majorVersion() // locks the Srv; the Stmt isn't locked
+	if err != nil {
+		return nil, errE(err)
+	}
+	var cols []ColumnInfo
+	if major < 12 {
+		desc, err := stmt.Describe()
+		if err != nil {
+			return nil, errE(err)
+		}
+		cols = desc.Columns
+	}
+	var pageParams []interface{}
+	if major >= 12 {
+		sql = offsetFetchSql(sql)
+		pageParams = []interface{}{int64(offset), int64(limit)}
+	} else {
+		sql = rownumSql(sql, cols)
+		pageParams = []interface{}{int64(offset + limit), int64(offset)}
+	}
+	page, err := ses.prepSql(sql, gcts...)
+	if err != nil {
+		return nil, errE(err)
+	}
+	page.SetCfg(&cfg)
+	rset, err = page.Qry(append(params[:len(params):len(params)], pageParams...)...)
+	if err != nil {
+		page.Close()
+		return nil, errE(err)
+	}
+	rset.autoClose = true
+	return rset, nil
+}
+
+// offsetFetchSql returns sql limited by OFFSET and FETCH NEXT placeholders.
+func offsetFetchSql(sql string) string {
+	return trimSql(sql) + "\nOFFSET :ora_offset ROWS FETCH NEXT :ora_limit ROWS ONLY"
+}
+
+// rownumSql returns sql wrapped by a ROWNUM filter selecting cols, with
+// placeholders for the last row and the offset.
+func rownumSql(sql string, cols []ColumnInfo) string {
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	for n, col := range cols {
+		if n > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(`"` + col.Name + `"`)
+	}
+	buf.WriteString(" FROM (SELECT ora_q.*, ROWNUM ora_rn FROM (\n")
+	buf.WriteString(trimSql(sql))
+	buf.WriteString("\n) ora_q WHERE ROWNUM <= :ora_end) WHERE ora_rn > :ora_offset")
+	return buf.String()
+}
+
+// trimSql returns sql without trailing spaces and semicolons.
+func trimSql(sql string) string {
+	return strings.TrimRight(sql, "; \t\r\n")
+}
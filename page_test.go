@@ -0,0 +1,32 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+func TestParseMajorVersion(t *testing.T) {
+	for ver, want := range map[string]int{
+		"Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production":               11,
+		"Oracle Database 12c Enterprise Edition Release 12.1.0.2.0 - 64bit Production":               12,
+		"Oracle Database 19c Enterprise Edition Release 19.0.0.0.0 - Production\nVersion 19.3.0.0.0": 19,
+		"unknown": 0,
+	} {
+		if got := parseMajorVersion(ver); got != want {
+			t.Errorf("%q: got %d, want %d.", ver, got, want)
+		}
+	}
+}
+
+func TestPageSql(t *testing.T) {
+	sql := "SELECT C1, C2 FROM T1 ORDER BY C1;\n"
+	if got, want := offsetFetchSql(sql), "SELECT C1, C2 FROM T1 ORDER BY C1\nOFFSET :ora_offset ROWS FETCH NEXT :ora_limit ROWS ONLY"; got != want {
+		t.Errorf("offsetFetchSql: got %q, want %q.", got, want)
+	}
+	cols := []ColumnInfo{{Name: "C1"}, {Name: "C2"}}
+	want := "SELECT \"C1\", \"C2\" FROM (SELECT ora_q.*, ROWNUM ora_rn FROM (\nSELECT C1, C2 FROM T1 ORDER BY C1\n) ora_q WHERE ROWNUM <= :ora_end) WHERE ora_rn > :ora_offset"
+	if got := rownumSql(sql, cols); got != want {
+		t.Errorf("rownumSql: got %q, want %q.", got, want)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Paginator pages through the rows of a query by the values of ordered key
+// columns, continuing each page after the keys of the last row of the
+// previous page. Unlike offset paging, keyset paging reads only the rows of
+// each page and isn't disturbed by rows inserted or deleted between pages.
+//
+// The key columns must uniquely identify a row of the query, must not be
+// NULL, and must be of a numeric, character, date or timestamp type.
+type Paginator struct {
+	ses      *Ses
+	sql      string
+	gcts     []GoColumnType
+	order    []int // param order of the query; see ConvertPlaceholders
+	pageSize int
+	keys     []string // described key column names
+	keyIdx   []int    // select-list positions of keys
+	desc     bool
+}
+
+// NewPaginator returns a Paginator of the query of stmt, ordered by keyCols,
+// which pages pageSize rows at a time. keyCols name select-list columns of the
+// query, and are located by describing stmt. When desc is true, the rows are
+// ordered descending.
+//
+// Pages are queried on the Ses of stmt with the GoColumnTypes of stmt.
+func NewPaginator(stmt *Stmt, pageSize int, desc bool, keyCols ...string) (*Paginator, error) {
+	if pageSize <= 0 {
+		return nil, er("Parameter 'pageSize' must be greater than zero.")
+	}
+	if len(keyCols) == 0 {
+		return nil, er("Paginator requires key columns.")
+	}
+	description, err := stmt.Describe()
+	if err != nil {
+		return nil, errE(err)
+	}
+	p := &Paginator{pageSize: pageSize, desc: desc}
+	stmt.mu.Lock()
+	p.ses, p.sql, p.gcts, p.order = stmt.ses, stmt.sql, stmt.gcts, stmt.paramOrder
+	stmt.mu.Unlock()
+	if !p.ses.cfg.SqlTagAsAction {
+		p.sql = strings.TrimPrefix(p.sql, tagSql("", p.ses.cfg.SqlTag)) // Prep tags each page again
+	}
+	for _, key := range keyCols {
+		n := describedColumn(description.Columns, key)
+		if n < 0 {
+			return nil, errF("Key column %v is not in the select-list.", key)
+		}
+		switch description.Columns[n].Type {
+		case "CLOB", "NCLOB", "BLOB", "BFILE", "LONG", "LONG RAW":
+			return nil, errF("Key column %v of type %v can't be compared.", key, description.Columns[n].Type)
+		}
+		p.keys = append(p.keys, description.Columns[n].Name)
+		p.keyIdx = append(p.keyIdx, n)
+	}
+	return p, nil
+}
+
+// describedColumn returns the position of the column named name, or -1.
+// Unquoted names are compared ignoring case.
+func describedColumn(cols []ColumnInfo, name string) int {
+	quoted := len(name) > 1 && name[0] == '"' && name[len(name)-1] == '"'
+	for n, col := range cols {
+		if quoted && col.Name == name[1:len(name)-1] || !quoted && strings.EqualFold(col.Name, name) {
+			return n
+		}
+	}
+	return -1
+}
+
+// Page returns the rows of the page following the page whose cursor is
+// token, and the cursor of the next page. An empty token returns the first
+// page. The returned cursor is empty after the last page.
+//
+// params are bound to the placeholders of the query of the Paginator.
+func (p *Paginator) Page(token string, params ...interface{}) (rows [][]interface{}, next string, err error) {
+	p.ses.log(_drv.cfg().Log.Ses.Page)
+	if params, err = orderParams(params, p.order); err != nil {
+		return nil, "", errE(err)
+	}
+	var keys []interface{}
+	if token != "" {
+		if keys, err = decodeKeys(token); err != nil {
+			return nil, "", errE(err)
+		}
+		if len(keys) != len(p.keys) {
+			return nil, "", errF("Cursor has %d keys; the Paginator has %d.", len(keys), len(p.keys))
+		}
+	}
+	major, err := p.ses.srv.majorVersion()
+	if err != nil {
+		return nil, "", errE(err)
+	}
+	sql, keyParams := keysetSql(p.sql, p.keys, keys, p.desc, major >= 12)
+	stmt, err := p.ses.prepSql(sql, p.gcts...)
+	if err != nil {
+		return nil, "", errE(err)
+	}
+	defer stmt.Close()
+	params = append(params[:len(params):len(params)], keyParams...)
+	rset, err := stmt.Qry(append(params, int64(p.pageSize))...)
+	if err != nil {
+		return nil, "", errE(err)
+	}
+	for rset.Next() {
+		row := make([]interface{}, len(rset.Row))
+		copy(row, rset.Row)
+		rows = append(rows, row)
+	}
+	if rset.Err != nil {
+		return nil, "", errE(rset.Err)
+	}
+	if len(rows) < p.pageSize {
+		return rows, "", nil
+	}
+	last := rows[len(rows)-1]
+	keys = make([]interface{}, len(p.keyIdx))
+	for n, idx := range p.keyIdx {
+		keys[n] = last[idx]
+	}
+	if next, err = encodeKeys(keys); err != nil {
+		return nil, "", errE(err)
+	}
+	return rows, next, nil
+}
+
+// keysetSql returns sql ordered by keys and limited to a page; when values
+// are given, only rows following values are selected. The continuation
+// predicate (k1, k2) > (:1, :2) is expanded, as Oracle doesn't compare rows
+// of values. The returned params bind the predicate; the page size is bound
+// last.
+func keysetSql(sql string, keys []string, values []interface{}, desc, fetchFirst bool) (string, []interface{}) {
+	op, dir := ">", ""
+	if desc {
+		op, dir = "<", " DESC"
+	}
+	var params []interface{}
+	var buf bytes.Buffer
+	buf.WriteString("SELECT * FROM (\n")
+	buf.WriteString(trimSql(sql))
+	buf.WriteString("\n) ora_k")
+	if len(values) > 0 {
+		buf.WriteString(" WHERE ")
+		for n := range keys {
+			if n > 0 {
+				buf.WriteString(" OR ")
+			}
+			buf.WriteString("(")
+			for m := 0; m < n; m++ {
+				fmt.Fprintf(&buf, "\"%v\" = :ora_k%d AND ", keys[m], len(params))
+				params = append(params, values[m])
+			}
+			fmt.Fprintf(&buf, "\"%v\" %v :ora_k%d)", keys[n], op, len(params))
+			params = append(params, values[n])
+		}
+	}
+	buf.WriteString(" ORDER BY ")
+	for n, key := range keys {
+		if n > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "\"%v\"%v", key, dir)
+	}
+	if fetchFirst {
+		buf.WriteString(" FETCH FIRST :ora_limit ROWS ONLY")
+		return buf.String(), params
+	}
+	return "SELECT * FROM (" + buf.String() + ") WHERE ROWNUM <= :ora_limit", params
+}
+
+// keyValue is the encoding of a key value in a cursor: a type tag and a
+// string form of the value.
+type keyValue struct {
+	T string `json:"t"`
+	V string `json:"v"`
+}
+
+// encodeKeys returns an opaque cursor holding the key values of a row.
+func encodeKeys(keys []interface{}) (string, error) {
+	encoded := make([]keyValue, len(keys))
+	for n, key := range keys {
+		v := reflect.ValueOf(key)
+		if v.Kind() == reflect.Struct && v.Type() != reflect.TypeOf(time.Time{}) {
+			// nullable ora types such as Int64 and String
+			if isNull := v.FieldByName("IsNull"); isNull.IsValid() && isNull.Bool() {
+				return "", errF("Key %d is NULL.", n)
+			}
+			if v = v.FieldByName("Value"); !v.IsValid() {
+				return "", errF("Key %d of type %T can't be encoded.", n, key)
+			}
+		}
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			encoded[n] = keyValue{"i", strconv.FormatInt(v.Int(), 10)}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			encoded[n] = keyValue{"u", strconv.FormatUint(v.Uint(), 10)}
+		case reflect.Float32, reflect.Float64:
+			encoded[n] = keyValue{"f", strconv.FormatFloat(v.Float(), 'g', -1, 64)}
+		case reflect.String:
+			encoded[n] = keyValue{"s", v.String()}
+		case reflect.Struct:
+			encoded[n] = keyValue{"t", v.Interface().(time.Time).Format(time.RFC3339Nano)}
+		case reflect.Invalid:
+			return "", errF("Key %d is NULL.", n)
+		default:
+			return "", errF("Key %d of type %T can't be encoded.", n, key)
+		}
+	}
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeKeys returns the key values held by a cursor of encodeKeys.
+func decodeKeys(token string) (keys []interface{}, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errF("Invalid cursor: %v", err)
+	}
+	var encoded []keyValue
+	if err = json.Unmarshal(b, &encoded); err != nil {
+		return nil, errF("Invalid cursor: %v", err)
+	}
+	keys = make([]interface{}, len(encoded))
+	for n, kv := range encoded {
+		switch kv.T {
+		case "i":
+			keys[n], err = strconv.ParseInt(kv.V, 10, 64)
+		case "u":
+			keys[n], err = strconv.ParseUint(kv.V, 10, 64)
+		case "f":
+			keys[n], err = strconv.ParseFloat(kv.V, 64)
+		case "s":
+			keys[n] = kv.V
+		case "t":
+			keys[n], err = time.Parse(time.RFC3339Nano, kv.V)
+		default:
+			err = errF("unknown key type %q", kv.T)
+		}
+		if err != nil {
+			return nil, errF("Invalid cursor key %d: %v", n, err)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeysetSql(t *testing.T) {
+	sql, params := keysetSql("SELECT ID, NAME FROM T1;", []string{"NAME", "ID"}, nil, false, true)
+	if want := "SELECT * FROM (\nSELECT ID, NAME FROM T1\n) ora_k ORDER BY \"NAME\", \"ID\" FETCH FIRST :ora_limit ROWS ONLY"; sql != want {
+		t.Errorf("first page: got %q, want %q.", sql, want)
+	}
+	if len(params) != 0 {
+		t.Errorf("first page: got params %v", params)
+	}
+	sql, params = keysetSql("SELECT ID, NAME FROM T1", []string{"NAME", "ID"}, []interface{}{"b", int64(7)}, true, false)
+	want := "SELECT * FROM (SELECT * FROM (\nSELECT ID, NAME FROM T1\n) ora_k" +
+		" WHERE (\"NAME\" < :ora_k0) OR (\"NAME\" = :ora_k1 AND \"ID\" < :ora_k2)" +
+		" ORDER BY \"NAME\" DESC, \"ID\" DESC) WHERE ROWNUM <= :ora_limit"
+	if sql != want {
+		t.Errorf("next page: got\n%q, want\n%q.", sql, want)
+	}
+	if want := []interface{}{"b", "b", int64(7)}; !reflect.DeepEqual(params, want) {
+		t.Errorf("next page: got params %v, want %v.", params, want)
+	}
+}
+
+func TestKeysCursor(t *testing.T) {
+	now := time.Date(2015, 6, 1, 12, 30, 0, 500, time.UTC)
+	token, err := encodeKeys([]interface{}{int32(-3), uint8(4), 1.5, "a b", now, Int64{Value: 9}, String{Value: "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := decodeKeys(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{int64(-3), uint64(4), 1.5, "a b", now, int64(9), "x"}
+	if len(keys) != len(want) {
+		t.Fatalf("got %d keys, want %d", len(keys), len(want))
+	}
+	for n := range want {
+		if tm, ok := want[n].(time.Time); ok {
+			if !tm.Equal(keys[n].(time.Time)) {
+				t.Errorf("%d. got %v, want %v", n, keys[n], tm)
+			}
+		} else if keys[n] != want[n] {
+			t.Errorf("%d. got %#v, want %#v", n, keys[n], want[n])
+		}
+	}
+	if _, err = encodeKeys([]interface{}{Int64{IsNull: true}}); err == nil {
+		t.Errorf("NULL key: wanted error")
+	}
+	if _, err = encodeKeys([]interface{}{nil}); err == nil {
+		t.Errorf("nil key: wanted error")
+	}
+	if _, err = decodeKeys("not a cursor"); err == nil {
+		t.Errorf("invalid cursor: wanted error")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// sqlCaretWidth is the number of bytes of SQL text shown on each side of a
+// parse error position.
+const sqlCaretWidth = 40
+
+// exeError returns the error of a failed OCIStmtExecute. When Oracle reports
+// the position of a parse error, such as ORA-00904 or ORA-00942, the error is
+// annotated with the SQL text around the position. A lost server connection
+// is reported to the OnTxLost hooks of the Ses, and reconnected when
+// SrvCfg.Reconnect is set. No locking occurs.
+func (stmt *Stmt) exeError() error {
+	err := stmt.ses.ociError()
+	stmt.ses.txLost(err, stmt.sql, false)
+	stmt.ses.srv.lost(err)
+	var offset C.ub2
+	if stmt.attr(unsafe.Pointer(&offset), 2, C.OCI_ATTR_PARSE_ERROR_OFFSET) != nil || offset == 0 {
+		return err
+	}
+	return errors.New(err.Error() + "\n" + redact(sqlCaret(stmt.sql, int(offset))))
+}
+
+// sqlCaret returns the line of sql containing the zero-based byte offset,
+// followed by a line with a caret under the offset.
+func sqlCaret(sql string, offset int) string {
+	if offset > len(sql) {
+		offset = len(sql)
+	}
+	start := strings.LastIndex(sql[:offset], "\n") + 1
+	end := strings.IndexByte(sql[offset:], '\n')
+	if end < 0 {
+		end = len(sql)
+	} else {
+		end += offset
+	}
+	var prefix, suffix string
+	if offset-start > sqlCaretWidth {
+		start, prefix = offset-sqlCaretWidth, "..."
+	}
+	if end-offset > sqlCaretWidth {
+		end, suffix = offset+sqlCaretWidth, "..."
+	}
+	line := strings.TrimRight(sql[start:end], "\r")
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "at position %d:\n%v%v%v\n%v", offset, prefix, line, suffix, strings.Repeat(" ", len(prefix)))
+	for _, c := range []byte(sql[start:offset]) {
+		if c == '\t' {
+			buf.WriteByte('\t') // keep the caret aligned with tab stops
+		} else {
+			buf.WriteByte(' ')
+		}
+	}
+	buf.WriteByte('^')
+	return buf.String()
+}
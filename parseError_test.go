@@ -0,0 +1,28 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSqlCaret tests sqlCaret.
+func TestSqlCaret(t *testing.T) {
+	for _, tc := range []struct {
+		sql    string
+		offset int
+		want   string
+	}{
+		{"SELECT c1 FROM bogus", 15, "at position 15:\nSELECT c1 FROM bogus\n               ^"},
+		{"SELECT c1\nFROM\tbogus\nWHERE 1 = 1", 15, "at position 15:\nFROM\tbogus\n    \t^"},
+		{"SELECT " + strings.Repeat("x", 50) + " FROM t", 58, "at position 58:\n..." +
+			strings.Repeat("x", 39) + " FROM t\n" + strings.Repeat(" ", 43) + "^"},
+	} {
+		if got := sqlCaret(tc.sql, tc.offset); got != tc.want {
+			t.Errorf("%q at %d:\ngot\n%v\nwanted\n%v", tc.sql, tc.offset, got, tc.want)
+		}
+	}
+}
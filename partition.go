@@ -0,0 +1,135 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"regexp"
+	"sync"
+)
+
+// partitionClause matches the partition extension clause of a table reference.
+var partitionClause = regexp.MustCompile(`(?i)\bPARTITION\s*\(\s*("[^"]+"|[A-Za-z0-9_$#]+)\s*\)`)
+
+// PartitionResult is the outcome of executing a statement of Stmt.ExeP
+// against one partition.
+type PartitionResult struct {
+	// Partition is the partition name.
+	Partition string
+	// RowsAffected is the number of rows affected in the partition.
+	RowsAffected uint64
+	// Err is the error of the execution, or nil.
+	Err error
+}
+
+// ExeP executes the sql of the Stmt once per partition, concurrently on up
+// to parallel sessions, and returns a result per partition in the order of
+// partitions.
+//
+// The sql must contain a partition extension clause, such as
+// DELETE FROM T1 PARTITION (P1) WHERE C1 < :1, whose partition name is
+// replaced by each of partitions. params are bound to every execution and are
+// shared between sessions, so they must not be pointers.
+//
+// Each session is opened on a server connection of its own, with the SrvCfg
+// of the Srv and the SesCfg of the Ses of the Stmt, and closed when its
+// partitions are done. The statements of a session are executed with the
+// StmtCfg of the Stmt; the executions are not part of a transaction of the
+// Ses and follow StmtCfg.IsAutoCommitting. A parallel of
+// zero or less uses one session per partition. The returned error is non-nil
+// when any partition failed; the failed partitions have a non-nil
+// PartitionResult.Err.
+func (stmt *Stmt) ExeP(partitions []string, parallel int, params ...interface{}) (results []PartitionResult, err error) {
+	stmt.mu.Lock()
+	stmt.log(_drv.cfg().Log.Stmt.ExeP)
+	err = stmt.checkClosed()
+	if err != nil {
+		stmt.mu.Unlock()
+		return nil, errE(err)
+	}
+	srv, sql, cfg, stmtCfg := stmt.ses.srv, stmt.sql, stmt.ses.cfg, stmt.cfg
+	params, err = orderParams(params, stmt.paramOrder)
+	stmt.mu.Unlock()
+	if err != nil {
+		return nil, errE(err)
+	}
+	srv.mu.Lock()
+	env, srvCfg := srv.env, srv.cfg
+	srv.mu.Unlock()
+	if !cfg.SqlTagAsAction {
+		cfg.SqlTag = "" // sql is already tagged
+	}
+	sqls := make([]string, len(partitions))
+	for n, partition := range partitions {
+		if sqls[n], err = partitionSql(sql, partition); err != nil {
+			return nil, errE(err)
+		}
+	}
+	if parallel <= 0 || parallel > len(partitions) {
+		parallel = len(partitions)
+	}
+	results = make([]PartitionResult, len(partitions))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(parallel)
+	for w := 0; w < parallel; w++ {
+		go func() {
+			defer wg.Done()
+			var ses *Ses
+			srv, err := env.OpenSrv(&srvCfg)
+			if err == nil {
+				defer srv.Close() // closes ses
+				ses, err = srv.OpenSes(&cfg)
+			}
+			for n := range work {
+				results[n].Partition = partitions[n]
+				if err != nil {
+					results[n].Err = err
+					continue
+				}
+				results[n].RowsAffected, results[n].Err = exePartition(ses, sqls[n], &stmtCfg, params)
+			}
+		}()
+	}
+	for n := range partitions {
+		work <- n
+	}
+	close(work)
+	wg.Wait()
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, errF("%d of %d partitions failed.", failed, len(partitions))
+	}
+	return results, nil
+}
+
+// exePartition executes sql on ses with cfg.
+func exePartition(ses *Ses, sql string, cfg *StmtCfg, params []interface{}) (rowsAffected uint64, err error) {
+	stmt, err := ses.prep(sql, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	stmt.SetCfg(cfg)
+	return stmt.Exe(params...)
+}
+
+// partitionSql returns sql with the name of its partition extension clause
+// replaced by partition.
+func partitionSql(sql, partition string) (string, error) {
+	loc := partitionClause.FindStringSubmatchIndex(sql)
+	if loc == nil {
+		return "", er("Sql has no PARTITION (name) clause.")
+	}
+	name, err := schemaName(partition)
+	if err != nil {
+		return "", errF("Invalid partition name %q.", partition)
+	}
+	return sql[:loc[2]] + `"` + name + `"` + sql[loc[3]:], nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+func TestPartitionSql(t *testing.T) {
+	for i, tc := range []struct {
+		sql, partition, want string
+	}{
+		{"DELETE FROM T1 PARTITION (P1) WHERE C1 < :1", "p_2019",
+			`DELETE FROM T1 PARTITION ("P_2019") WHERE C1 < :1`},
+		{`UPDATE T1 partition( "Old" ) SET C1 = 0`, `"New"`,
+			`UPDATE T1 partition( "New" ) SET C1 = 0`},
+	} {
+		got, err := partitionSql(tc.sql, tc.partition)
+		if err != nil {
+			t.Errorf("%d. %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%d. got %q, want %q.", i, got, tc.want)
+		}
+	}
+	if _, err := partitionSql("DELETE FROM T1", "P1"); err == nil {
+		t.Errorf("no clause: wanted error")
+	}
+	if _, err := partitionSql("DELETE FROM T1 PARTITION (P1)", "P1; DROP"); err == nil {
+		t.Errorf("invalid name: wanted error")
+	}
+}
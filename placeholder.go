@@ -0,0 +1,149 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// rewritePlaceholders returns sql with each placeholder replaced by the
+// result of fn. fn receives the zero-based ordinal of the placeholder and its
+// name without the colon.
+//
+// String literals, including q'[...]' literals, quoted identifiers, comments
+// and the PL/SQL assignment operator (:=) are skipped.
+func rewritePlaceholders(sql string, fn func(n int, name string) string) string {
+	var buf bytes.Buffer
+	n := 0
+	for _, tok := range scanSql(sql) {
+		if tok.kind != sqlTokPlaceholder {
+			buf.WriteString(tok.text)
+			continue
+		}
+		buf.WriteString(fn(n, tok.text[1:]))
+		n++
+	}
+	return buf.String()
+}
+
+// isPlaceholderChar reports whether c may appear in an Oracle placeholder name.
+func isPlaceholderChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '$' || c == '#'
+}
+
+// placeholderNames returns the names of the placeholders in sql, in order.
+func placeholderNames(sql string) (names []string) {
+	rewritePlaceholders(sql, func(n int, name string) string {
+		names = append(names, name)
+		return ""
+	})
+	return names
+}
+
+// ConvertPlaceholders returns sql with the '?' placeholders of ODBC and
+// MySQL, or the '$n' placeholders of PostgreSQL, replaced by Oracle ':1'
+// placeholders numbered in order from 1, and the order of the arguments: the
+// placeholder ':k' binds the argument order[k-1]. order is nil when the
+// arguments bind in the order they're passed, as they do for '?' placeholders.
+//
+// Oracle binds placeholders by position, so each '$n' is renumbered in order:
+// 'C1 = $2 AND C2 = $1' becomes 'C1 = :1 AND C2 = :2' with order [1 0], and a
+// repeated '$n' binds the same argument at each position. An error is
+// returned when sql mixes '$n' placeholders with '?' or Oracle placeholders.
+//
+// String literals, including q'[...]' literals, quoted identifiers and
+// comments are skipped, as are '$' within identifiers such as V$SESSION.
+// Oracle placeholders are left unchanged, so converting sql again returns it
+// unchanged. Set DrvCfg.ConvertPlaceholders to have Ses.Prep and database/sql
+// convert statements and order their arguments.
+func ConvertPlaceholders(sql string) (result string, order []int, err error) {
+	var buf bytes.Buffer
+	questions, oracles := 0, 0
+	for _, tok := range scanSql(sql) {
+		switch {
+		case tok.kind == sqlTokPlaceholder:
+			oracles++
+			buf.WriteString(tok.text)
+		case tok.kind == sqlTokWord && tok.text[0] == '$' && len(tok.text) > 1 && isDigits(tok.text[1:]):
+			arg, err := strconv.Atoi(tok.text[1:])
+			if err != nil || arg < 1 || arg > 65535 {
+				return sql, nil, errF("Invalid placeholder %v.", tok.text)
+			}
+			order = append(order, arg-1)
+			buf.WriteByte(':')
+			buf.WriteString(strconv.Itoa(len(order)))
+		case tok.text == "?":
+			questions++
+			buf.WriteByte(':')
+			buf.WriteString(strconv.Itoa(questions))
+		default:
+			buf.WriteString(tok.text)
+		}
+	}
+	if order == nil {
+		return buf.String(), nil, nil
+	}
+	if questions > 0 || oracles > 0 {
+		return sql, nil, er("'$n' placeholders can't be mixed with '?' or Oracle placeholders.")
+	}
+	count := paramCount(order)
+	used := make([]bool, count)
+	for _, arg := range order {
+		used[arg] = true
+	}
+	for arg, ok := range used {
+		if !ok {
+			return sql, nil, errF("Placeholder $%d is missing.", arg+1)
+		}
+	}
+	inOrder := len(order) == count
+	for k, arg := range order {
+		inOrder = inOrder && arg == k
+	}
+	if inOrder {
+		order = nil
+	}
+	return buf.String(), order, nil
+}
+
+// paramCount returns the number of arguments bound in the order of order.
+func paramCount(order []int) (count int) {
+	for _, arg := range order {
+		if arg >= count {
+			count = arg + 1
+		}
+	}
+	return count
+}
+
+// orderParams returns params in the order of the placeholders converted by
+// ConvertPlaceholders. Params beyond those of the converted placeholders,
+// such as the bind of a clause the driver appends, follow in order.
+func orderParams(params []interface{}, order []int) ([]interface{}, error) {
+	if order == nil {
+		return params, nil
+	}
+	count := paramCount(order)
+	if len(params) < count {
+		return nil, errF("The statement has %d parameters; %d were passed.", count, len(params))
+	}
+	ordered := make([]interface{}, 0, len(order)+len(params)-count)
+	for _, arg := range order {
+		ordered = append(ordered, params[arg])
+	}
+	return append(ordered, params[count:]...), nil
+}
+
+// isDigits reports whether s is made of ASCII digits.
+func isDigits(s string) bool {
+	for n := 0; n < len(s); n++ {
+		if s[n] < '0' || s[n] > '9' {
+			return false
+		}
+	}
+	return true
+}
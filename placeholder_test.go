@@ -0,0 +1,104 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestRewritePlaceholders tests rewritePlaceholders.
+func TestRewritePlaceholders(t *testing.T) {
+	for i, tc := range []struct {
+		sql, want string
+		names     []string
+	}{
+		{"SELECT 1 FROM DUAL", "SELECT 1 FROM DUAL", nil},
+		{"INSERT INTO T1 (C1, C2) VALUES (:c1, :2)", "INSERT INTO T1 (C1, C2) VALUES (:p0, :p1)", []string{"c1", "2"}},
+		{"SELECT ':x', \"A:B\" FROM T1 WHERE C1 = :c1", "SELECT ':x', \"A:B\" FROM T1 WHERE C1 = :p0", []string{"c1"}},
+		{"SELECT 'it''s :x' FROM T1 WHERE C1 = :a", "SELECT 'it''s :x' FROM T1 WHERE C1 = :p0", []string{"a"}},
+		{"BEGIN :out := F(:in); END;", "BEGIN :p0 := F(:p1); END;", []string{"out", "in"}},
+		{"BEGIN x := 1; END;", "BEGIN x := 1; END;", nil},
+		{"SELECT 1 -- :c\nFROM T1 /* :d */ WHERE C1 = :e", "SELECT 1 -- :c\nFROM T1 /* :d */ WHERE C1 = :p0", []string{"e"}},
+		{"SELECT q'[it's :x]', Nq'{:y}' FROM T1 WHERE C1 = :c1", "SELECT q'[it's :x]', Nq'{:y}' FROM T1 WHERE C1 = :p0", []string{"c1"}},
+	} {
+		got := rewritePlaceholders(tc.sql, func(n int, name string) string {
+			return ":p" + strconv.Itoa(n)
+		})
+		if got != tc.want {
+			t.Errorf("%d. got %q, want %q.", i, got, tc.want)
+		}
+		if names := placeholderNames(tc.sql); !reflect.DeepEqual(names, tc.names) {
+			t.Errorf("%d. got names %q, want %q.", i, names, tc.names)
+		}
+	}
+}
+
+// TestConvertPlaceholders tests ConvertPlaceholders.
+func TestConvertPlaceholders(t *testing.T) {
+	for i, tc := range []struct {
+		sql, want string
+		order     []int
+	}{
+		{"SELECT 1 FROM DUAL", "SELECT 1 FROM DUAL", nil},
+		{"INSERT INTO T1 (C1, C2) VALUES (?, ?)", "INSERT INTO T1 (C1, C2) VALUES (:1, :2)", nil},
+		{"INSERT INTO T1 (C1, C2) VALUES ($1, $2)", "INSERT INTO T1 (C1, C2) VALUES (:1, :2)", nil},
+		{"SELECT * FROM T1 WHERE C1 = $2 AND C2 = $1", "SELECT * FROM T1 WHERE C1 = :1 AND C2 = :2", []int{1, 0}},
+		{"SELECT * FROM T1 WHERE C1 = $1 OR C2 = $1", "SELECT * FROM T1 WHERE C1 = :1 OR C2 = :2", []int{0, 0}},
+		{"SELECT * FROM T1 WHERE C1 = $1 AND C2 = $2 OR C3 = $1", "SELECT * FROM T1 WHERE C1 = :1 AND C2 = :2 OR C3 = :3", []int{0, 1, 0}},
+		{"SELECT '?', \"$1\", 'it''s ?' FROM T1 WHERE C1 = ?", "SELECT '?', \"$1\", 'it''s ?' FROM T1 WHERE C1 = :1", nil},
+		{"SELECT q'[it's ?]', Q'{$1}' FROM T1 WHERE C1 = ?", "SELECT q'[it's ?]', Q'{$1}' FROM T1 WHERE C1 = :1", nil},
+		{"SELECT nq'<?>', q'!'$1'!' FROM T1 WHERE C1 = $1", "SELECT nq'<?>', q'!'$1'!' FROM T1 WHERE C1 = :1", nil},
+		{"SELECT SID FROM V$SESSION WHERE SID = $1", "SELECT SID FROM V$SESSION WHERE SID = :1", nil},
+		{"SELECT 1 -- ?\nFROM T1 /* $1 */ WHERE C1 = ?", "SELECT 1 -- ?\nFROM T1 /* $1 */ WHERE C1 = :1", nil},
+		{"SELECT seq FROM T1 WHERE C1 = :c1 AND C2 = ?", "SELECT seq FROM T1 WHERE C1 = :c1 AND C2 = :1", nil},
+		{"BEGIN :out := F(?); END;", "BEGIN :out := F(:1); END;", nil},
+	} {
+		got, order, err := ConvertPlaceholders(tc.sql)
+		if err != nil {
+			t.Errorf("%d. %q: %v", i, tc.sql, err)
+			continue
+		}
+		if got != tc.want || !reflect.DeepEqual(order, tc.order) {
+			t.Errorf("%d. got %q %v, want %q %v.", i, got, order, tc.want, tc.order)
+		}
+		if got, order, err := ConvertPlaceholders(tc.want); got != tc.want || order != nil || err != nil {
+			t.Errorf("%d. converted %q again to %q %v (%v).", i, tc.want, got, order, err)
+		}
+	}
+	for i, sql := range []string{
+		"SELECT * FROM T1 WHERE C1 = $1 AND C2 = ?",
+		"SELECT * FROM T1 WHERE C1 = $1 AND C2 = :c2",
+		"SELECT * FROM T1 WHERE C1 = $2",
+		"SELECT * FROM T1 WHERE C1 = $0",
+	} {
+		if got, _, err := ConvertPlaceholders(sql); err == nil {
+			t.Errorf("%d. converted %q to %q, want an error.", i, sql, got)
+		}
+	}
+}
+
+// TestOrderParams tests orderParams.
+func TestOrderParams(t *testing.T) {
+	for i, tc := range []struct {
+		params []interface{}
+		order  []int
+		want   []interface{}
+	}{
+		{[]interface{}{"a", "b"}, nil, []interface{}{"a", "b"}},
+		{[]interface{}{"a", "b"}, []int{1, 0}, []interface{}{"b", "a"}},
+		{[]interface{}{"a"}, []int{0, 0}, []interface{}{"a", "a"}},
+		{[]interface{}{"a", "b", "id"}, []int{1, 0}, []interface{}{"b", "a", "id"}},
+	} {
+		got, err := orderParams(tc.params, tc.order)
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d. got %v (%v), want %v.", i, got, err, tc.want)
+		}
+	}
+	if _, err := orderParams([]interface{}{"a"}, []int{1, 0}); err == nil {
+		t.Errorf("got no error for missing params.")
+	}
+}
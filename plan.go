@@ -0,0 +1,140 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "strings"
+
+// PlanRow is an operation of an execution plan.
+type PlanRow struct {
+	Id          int
+	ParentId    int // -1 for the first operation
+	Depth       int
+	Operation   string // such as TABLE ACCESS
+	Options     string // such as BY INDEX ROWID
+	ObjectOwner string
+	ObjectName  string
+	Cost        int64
+	Cardinality int64
+	Bytes       int64
+}
+
+// Plan is the execution plan of a statement: its operations, and the plan
+// formatted by DBMS_XPLAN.
+type Plan struct {
+	Rows []PlanRow
+	Text string
+}
+
+// planColumns are the columns of PLAN_TABLE and V$SQL_PLAN read into PlanRow.
+const planColumns = "ID, PARENT_ID, DEPTH, OPERATION, OPTIONS, OBJECT_OWNER, OBJECT_NAME, COST, CARDINALITY, BYTES"
+
+// planGcts are the GoColumnTypes of planColumns.
+var planGcts = []GoColumnType{OraI64, OraI64, OraI64, OraS, OraS, OraS, OraS, OraI64, OraI64, OraI64}
+
+// Plan returns the plan the optimizer chooses for the Stmt, with EXPLAIN
+// PLAN. Bind parameters aren't peeked, so the plan may differ from the plan
+// of an execution; see CursorPlan.
+//
+// The plan is written to and removed from the PLAN_TABLE of the session.
+func (stmt *Stmt) Plan() (plan *Plan, err error) {
+	stmt.log(_drv.cfg().Log.Stmt.Plan)
+	stmt.mu.Lock()
+	err = stmt.checkClosed()
+	sql, ses, id := stmt.sql, stmt.ses, stmt.sysName()
+	stmt.mu.Unlock()
+	if err != nil {
+		return nil, errE(err)
+	}
+	if _, err = ses.exe("EXPLAIN PLAN SET STATEMENT_ID = '" + id + "' FOR " + trimSql(sql)); err != nil {
+		return nil, errE(err)
+	}
+	defer ses.exe("DELETE FROM PLAN_TABLE WHERE STATEMENT_ID = :1", id)
+	plan = &Plan{}
+	if plan.Rows, err = planRows(ses, "SELECT "+planColumns+" FROM PLAN_TABLE WHERE STATEMENT_ID = :1 ORDER BY ID", id); err != nil {
+		return nil, errE(err)
+	}
+	if plan.Text, err = planText(ses, "SELECT PLAN_TABLE_OUTPUT FROM TABLE(DBMS_XPLAN.DISPLAY('PLAN_TABLE', :1, 'TYPICAL'))", id); err != nil {
+		return nil, errE(err)
+	}
+	return plan, nil
+}
+
+// CursorPlan returns the plan of the previous execution on the Ses of the
+// Stmt, from V$SQL_PLAN and DBMS_XPLAN.DISPLAY_CURSOR. Call CursorPlan
+// directly after the execution of the Stmt, before another statement is
+// executed on the Ses. CursorPlan requires SELECT privileges on V$SESSION,
+// V$SQL_PLAN and V$SQL.
+func (stmt *Stmt) CursorPlan() (plan *Plan, err error) {
+	stmt.log(_drv.cfg().Log.Stmt.CursorPlan)
+	stmt.mu.Lock()
+	err = stmt.checkClosed()
+	ses := stmt.ses
+	stmt.mu.Unlock()
+	if err != nil {
+		return nil, errE(err)
+	}
+	sqlId, child, err := ses.prevCursor()
+	if err != nil {
+		return nil, errE(err)
+	}
+	plan = &Plan{}
+	if plan.Rows, err = planRows(ses, "SELECT "+planColumns+" FROM V$SQL_PLAN WHERE SQL_ID = :1 AND CHILD_NUMBER = :2 ORDER BY ID", sqlId, child); err != nil {
+		return nil, errE(err)
+	}
+	if plan.Text, err = planText(ses, "SELECT PLAN_TABLE_OUTPUT FROM TABLE(DBMS_XPLAN.DISPLAY_CURSOR(:1, :2, 'TYPICAL'))", sqlId, child); err != nil {
+		return nil, errE(err)
+	}
+	return plan, nil
+}
+
+// planRows queries the operations of a plan.
+func planRows(ses *Ses, sql string, params ...interface{}) (rows []PlanRow, err error) {
+	stmt, err := ses.prepSql(sql, planGcts...)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry(params...)
+	if err != nil {
+		return nil, err
+	}
+	for rset.Next() {
+		row := PlanRow{
+			Id:          int(rset.Row[0].(Int64).Value),
+			ParentId:    -1,
+			Depth:       int(rset.Row[2].(Int64).Value),
+			Operation:   rset.Row[3].(String).Value,
+			Options:     rset.Row[4].(String).Value,
+			ObjectOwner: rset.Row[5].(String).Value,
+			ObjectName:  rset.Row[6].(String).Value,
+			Cost:        rset.Row[7].(Int64).Value,
+			Cardinality: rset.Row[8].(Int64).Value,
+			Bytes:       rset.Row[9].(Int64).Value,
+		}
+		if parent := rset.Row[1].(Int64); !parent.IsNull {
+			row.ParentId = int(parent.Value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, rset.Err
+}
+
+// planText queries the lines of a plan formatted by DBMS_XPLAN.
+func planText(ses *Ses, sql string, params ...interface{}) (string, error) {
+	stmt, err := ses.prepSql(sql, OraS)
+	if err != nil {
+		return "", err
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry(params...)
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	for rset.Next() {
+		lines = append(lines, rset.Row[0].(String).Value)
+	}
+	return strings.Join(lines, "\n"), rset.Err
+}
@@ -0,0 +1,713 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LogPoolCfg represents Pool logging configuration values.
+type LogPoolCfg struct {
+	// Get determines whether the Pool.Get method is logged.
+	//
+	// The default is true.
+	Get bool
+
+	// Put determines whether the Pool.Put method is logged.
+	//
+	// The default is true.
+	Put bool
+
+	// Stats determines whether the Pool.Stats method is logged.
+	//
+	// The default is false.
+	Stats bool
+
+	// Close determines whether the Pool.Close method is logged.
+	//
+	// The default is true.
+	Close bool
+
+	// Drain determines whether the Pool.Drain method is logged.
+	//
+	// The default is true.
+	Drain bool
+
+	// Check determines whether the Pool.Check method is logged.
+	//
+	// The default is true.
+	Check bool
+}
+
+// NewLogPoolCfg creates a LogPoolCfg with default values.
+func NewLogPoolCfg() LogPoolCfg {
+	c := LogPoolCfg{}
+	c.Get = true
+	c.Put = true
+	c.Close = true
+	c.Drain = true
+	c.Check = true
+	return c
+}
+
+// Fairness is the policy by which a Pool serves sessions to waiting callers
+// of different keys.
+type Fairness int
+
+const (
+	// FairFIFO serves waiting callers in the order they began waiting.
+	FairFIFO Fairness = iota
+	// FairRoundRobin serves waiting callers by rotating among keys in key
+	// order, so that a key with many waiting callers is served no more often
+	// than any other waiting key.
+	FairRoundRobin
+)
+
+// PoolCfg configures a Pool.
+type PoolCfg struct {
+	// MaxSessions is the maximum number of sessions open in the Pool across
+	// all keys.
+	//
+	// The default is 16.
+	MaxSessions int
+
+	// MaxSessionsPerKey is the maximum number of sessions open for one key.
+	// Limiting the sessions of a key keeps a busy key from holding every
+	// session of the Pool. Zero allows a key MaxSessions sessions.
+	//
+	// The default is 0.
+	MaxSessionsPerKey int
+
+	// Fairness is the policy by which a released session, or the capacity to
+	// open one, is given to callers waiting for different keys.
+	//
+	// The default is FairFIFO.
+	Fairness Fairness
+
+	// OnOpen is called with each session opened for a key, for example to set
+	// the CURRENT_SCHEMA of a tenant. When OnOpen returns an error, the
+	// session is closed and Get returns the error.
+	//
+	// The default is nil.
+	OnOpen func(ses *Ses, key string) error
+
+	// MaxSessionAge is the age after which a session is closed rather than
+	// reused, so that a long-lived Pool picks up rotated credentials and
+	// relocated pluggable databases. Zero doesn't limit the age of sessions.
+	// A closed session isn't replaced until a Get needs one; see MaxIdle.
+	//
+	// The default is 0.
+	MaxSessionAge time.Duration
+
+	// MaxIdle is the time after which an idle session is closed, before a
+	// firewall drops its idle connection. Zero doesn't limit idle time. The
+	// Pool keeps no minimum of open sessions: an idle Pool may close every
+	// session, and the next Get then opens one.
+	//
+	// The default is 0.
+	MaxIdle time.Duration
+
+	// CheckSql is the query made by Pool.Check, such as SELECT 1 FROM DUAL.
+	// An empty CheckSql checks with OCIPing.
+	//
+	// The default is an empty string.
+	CheckSql string
+
+	// Env, when set, configures an Env opened for the Pool in place of the
+	// Env of NewPool. A Pool with an Env of its own doesn't share the error
+	// handle, the Env mode or the StmtCfg of its Env with other workloads.
+	// The Env is closed with the Pool.
+	//
+	// The client character set of every Env is AL32UTF8, as the package
+	// converts strings as UTF-8.
+	//
+	// The default is nil.
+	Env *EnvCfg
+}
+
+// NewPoolCfg creates a PoolCfg with default values.
+func NewPoolCfg() *PoolCfg {
+	c := &PoolCfg{}
+	c.MaxSessions = 16
+	return c
+}
+
+// PoolKeyStats are the statistics of the sessions of a Pool key.
+type PoolKeyStats struct {
+	Open     int           // sessions open
+	InUse    int           // sessions checked out with Get
+	Idle     int           // sessions open and not checked out
+	Waiting  int           // callers waiting for a session
+	Waits    uint64        // calls to Get which waited for a session
+	WaitTime time.Duration // total time waited by Get
+	Timeouts uint64        // waits ended by the done context of Get
+}
+
+// poolSes is a session opened by a Pool.
+type poolSes struct {
+	ses    *Ses
+	srv    *Srv     // server connection of ses, closed with it
+	state  SesState // state of ses when opened, restored by Put
+	key    string
+	opened time.Time
+	used   time.Time // time last returned with Put
+
+	closeOnce sync.Once
+}
+
+// poolKey is the sub-pool of the sessions of a key.
+type poolKey struct {
+	idle     []*poolSes
+	open     int
+	inUse    int
+	waiting  int
+	waits    uint64
+	waitTime time.Duration
+	timeouts uint64
+}
+
+// poolWaiter is a caller of Get waiting for a session. A waiter is granted
+// either an idle session or the capacity to open one, and then ready is
+// closed.
+type poolWaiter struct {
+	key      string
+	ready    chan struct{}
+	ps       *poolSes
+	reserved bool
+}
+
+// Pool is a pool of sessions partitioned by key, such as a schema or tenant
+// tag. Sessions are reused only for the key they were opened for. The
+// sessions of a key are limited by PoolCfg.MaxSessionsPerKey, and callers
+// waiting for a session are served by PoolCfg.Fairness.
+//
+// Pool is safe for concurrent use.
+type Pool struct {
+	id     uint64
+	env    *Env
+	srvCfg SrvCfg
+	sesCfg SesCfg
+	cfg    PoolCfg
+
+	mu      sync.Mutex
+	keys    map[string]*poolKey
+	out     map[*Ses]*poolSes
+	open    int
+	waiters []*poolWaiter
+	lastKey string
+	closed  bool
+	drained chan struct{} // closed when Drain sees the last session returned
+
+	stopRecycle chan struct{}
+
+	openSes  func(key string) (*poolSes, error)
+	closeSes func(ps *poolSes) error
+	ownsEnv  bool // the Env was opened by OpenPool or for PoolCfg.Env
+}
+
+// NewPool creates a Pool of sessions of the server of srvCfg, opened with
+// sesCfg. Each session is opened on a server connection of its own, so that
+// the sessions of the Pool make calls concurrently.
+func (env *Env) NewPool(srvCfg *SrvCfg, sesCfg *SesCfg, cfg *PoolCfg) (*Pool, error) {
+	if srvCfg == nil {
+		return nil, er("Parameter 'srvCfg' may not be nil.")
+	}
+	if sesCfg == nil {
+		return nil, er("Parameter 'sesCfg' may not be nil.")
+	}
+	if cfg == nil {
+		cfg = NewPoolCfg()
+	}
+	if cfg.MaxSessions <= 0 {
+		return nil, er("PoolCfg.MaxSessions must be greater than zero.")
+	}
+	if cfg.MaxSessionsPerKey < 0 {
+		return nil, er("PoolCfg.MaxSessionsPerKey may not be negative.")
+	}
+	ownsEnv := false
+	if cfg.Env != nil {
+		var err error
+		if env, err = OpenEnv(cfg.Env); err != nil {
+			return nil, errE(err)
+		}
+		ownsEnv = true
+	}
+	p := &Pool{
+		id:      _drv.poolId.nextId(),
+		env:     env,
+		srvCfg:  *srvCfg,
+		sesCfg:  *sesCfg,
+		cfg:     *cfg,
+		keys:    make(map[string]*poolKey),
+		out:     make(map[*Ses]*poolSes),
+		ownsEnv: ownsEnv,
+	}
+	p.openSes = p.dial
+	p.closeSes = closePoolSes
+	if interval := recycleInterval(cfg.MaxSessionAge, cfg.MaxIdle); interval > 0 {
+		p.stopRecycle = make(chan struct{})
+		go p.recycleEvery(interval, p.stopRecycle)
+	}
+	return p, nil
+}
+
+// dial connects the server and opens a session for key on the connection.
+// The state of the session, after PoolCfg.OnOpen, is saved for Put.
+func (p *Pool) dial(key string) (ps *poolSes, err error) {
+	srv, err := p.env.OpenSrv(&p.srvCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			srv.Close() // closes the session
+		}
+	}()
+	ses, err := srv.OpenSes(&p.sesCfg)
+	if err != nil {
+		return nil, err
+	}
+	if p.cfg.OnOpen != nil {
+		if err = p.cfg.OnOpen(ses, key); err != nil {
+			return nil, err
+		}
+	}
+	state, err := ses.SaveState()
+	if err != nil {
+		return nil, err
+	}
+	return &poolSes{ses: ses, srv: srv, state: state}, nil
+}
+
+// closePoolSes closes the server connection of ps, which closes the session
+// when it's open. Only the first call closes the connection.
+func closePoolSes(ps *poolSes) (err error) {
+	ps.closeOnce.Do(func() {
+		err = ps.srv.Close()
+	})
+	return err
+}
+
+// reset returns the session of ps to the state saved when it was opened, for
+// reuse: the commit, rollback and OnTxLost hooks of the session are removed,
+// and the NLS parameters, current schema, application info and isolation
+// level are restored; only the values changed since are set again, see
+// Ses.RestoreState. A session with an open transaction isn't reset.
+func (p *Pool) reset(ps *poolSes) error {
+	ses := ps.ses
+	if ses.NumTx() > 0 {
+		return er("Ses was returned with an open transaction.")
+	}
+	ses.txHooks.clear()
+	return ses.RestoreState(ps.state)
+}
+
+// Get returns a session for key, waiting for one when the Pool or the key is
+// at its limit of sessions. See GetContext.
+func (p *Pool) Get(key string) (*Ses, error) {
+	return p.GetContext(context.Background(), key)
+}
+
+// GetContext returns a session for key. An idle session of key is reused;
+// otherwise a session is opened when the limits of the Pool and of key allow,
+// closing an idle session of another key if the Pool is full. Otherwise
+// GetContext waits until a session is returned with Put or ctx is done, in
+// which case the error of ctx is returned.
+//
+// Return the session with Put. A nil ctx is context.Background().
+func (p *Pool) GetContext(ctx context.Context, key string) (*Ses, error) {
+	p.log(_drv.cfg().Log.Pool.Get, key)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p.recycle()
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, er("Pool is closed.")
+	}
+	k := p.key(key)
+	if n := len(k.idle); n > 0 {
+		ps := k.idle[n-1]
+		k.idle = k.idle[:n-1]
+		k.inUse++
+		p.out[ps.ses] = ps
+		p.mu.Unlock()
+		return ps.ses, nil
+	}
+	if p.keyAllows(k) {
+		var evicted *poolSes
+		if p.open >= p.cfg.MaxSessions {
+			evicted = p.evictIdle()
+		}
+		if p.open < p.cfg.MaxSessions {
+			p.reserve(k)
+			p.mu.Unlock()
+			if evicted != nil {
+				p.closeSes(evicted)
+			}
+			return p.openFor(key, k)
+		}
+	}
+	w := &poolWaiter{key: key, ready: make(chan struct{})}
+	p.waiters = append(p.waiters, w)
+	k.waiting++
+	k.waits++
+	start := time.Now()
+	p.mu.Unlock()
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		p.mu.Lock()
+		if p.removeWaiter(w) {
+			k.waiting--
+			k.timeouts++
+			k.waitTime += time.Since(start)
+			p.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		p.mu.Unlock() // granted as ctx was done
+		<-w.ready
+	}
+	p.mu.Lock()
+	k.waitTime += time.Since(start)
+	ps, reserved := w.ps, w.reserved
+	p.mu.Unlock()
+	if ps != nil {
+		return ps.ses, nil
+	}
+	if reserved {
+		return p.openFor(key, k)
+	}
+	return nil, er("Pool is closed.")
+}
+
+// openFor opens a session for key with capacity reserved in k.
+func (p *Pool) openFor(key string, k *poolKey) (*Ses, error) {
+	ps, err := p.openSes(key)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.release(k)
+		p.grant()
+		return nil, errE(err)
+	}
+	now := time.Now()
+	ps.key, ps.opened, ps.used = key, now, now
+	p.out[ps.ses] = ps
+	return ps.ses, nil
+}
+
+// Put returns a session obtained with Get to the Pool. The session is reset
+// to the state it was opened in, and given to a caller waiting for its key,
+// or, when a caller of another key may be served, closed to make room for a
+// session of that key; otherwise it's kept idle. A session which is closed,
+// has an open transaction or fails to reset is closed and frees its place in
+// the Pool.
+func (p *Pool) Put(ses *Ses) error {
+	p.log(_drv.cfg().Log.Pool.Put)
+	p.mu.Lock()
+	ps, ok := p.out[ses]
+	closed := p.closed
+	p.mu.Unlock()
+	if !ok {
+		return er("Ses was not obtained from the Pool.")
+	}
+	isOpen := ses.IsOpen()
+	if isOpen && !closed {
+		if err := p.reset(ps); err != nil {
+			p.log(_drv.cfg().Log.Pool.Put, "closing the Ses: ", err)
+			isOpen = false
+		}
+	}
+	p.mu.Lock()
+	if ps, ok = p.out[ses]; !ok {
+		p.mu.Unlock()
+		return er("Ses was not obtained from the Pool.")
+	}
+	delete(p.out, ses)
+	if p.drained != nil && len(p.out) == 0 {
+		close(p.drained)
+		p.drained = nil
+	}
+	k := p.keys[ps.key]
+	k.inUse--
+	ps.used = time.Now()
+	var toClose *poolSes
+	if p.closed || !isOpen || expired(ps, ps.used, p.cfg.MaxSessionAge, 0) {
+		p.open--
+		k.open--
+		toClose = ps
+		p.grant()
+	} else if n := p.pickWaiter(ps.key); n >= 0 {
+		w := p.takeWaiter(n)
+		if w.key == ps.key {
+			k.inUse++
+			p.out[ses] = ps
+			w.ps = ps
+		} else {
+			k.open--
+			p.open--
+			p.reserve(p.keys[w.key])
+			w.reserved = true
+			toClose = ps
+		}
+		close(w.ready)
+	} else {
+		k.idle = append(k.idle, ps)
+	}
+	p.mu.Unlock()
+	if toClose != nil {
+		return p.closeSes(toClose)
+	}
+	return nil
+}
+
+// Stats returns the statistics of each key of the Pool.
+func (p *Pool) Stats() map[string]PoolKeyStats {
+	p.log(_drv.cfg().Log.Pool.Stats)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make(map[string]PoolKeyStats, len(p.keys))
+	for key, k := range p.keys {
+		stats[key] = PoolKeyStats{
+			Open:     k.open,
+			InUse:    k.inUse,
+			Idle:     len(k.idle),
+			Waiting:  k.waiting,
+			Waits:    k.waits,
+			WaitTime: k.waitTime,
+			Timeouts: k.timeouts,
+		}
+	}
+	return stats
+}
+
+// Close closes the idle sessions of the Pool and the sessions checked out,
+// with their server connections. Waiting callers of Get return an error.
+func (p *Pool) Close() error {
+	p.log(_drv.cfg().Log.Pool.Close)
+	return p.shutdown(nil)
+}
+
+// Drain shuts down the Pool gracefully. Drain stops handing out sessions,
+// failing waiting and later callers of Get, closes the idle sessions, and
+// waits until the sessions checked out are returned with Put or ctx is done.
+// The sessions still checked out are then closed.
+//
+// When ctx is done before every session is returned, the returned error
+// includes the error of ctx.
+func (p *Pool) Drain(ctx context.Context) error {
+	p.log(_drv.cfg().Log.Pool.Drain)
+	return p.shutdown(ctx)
+}
+
+// shutdown stops the Pool and closes its sessions. When ctx isn't nil,
+// sessions checked out are waited for until ctx is done.
+func (p *Pool) shutdown(ctx context.Context) (err error) {
+	p.mu.Lock()
+	if !p.closed && p.stopRecycle != nil {
+		close(p.stopRecycle)
+	}
+	p.closed = true
+	var idle []*poolSes
+	for _, k := range p.keys {
+		idle = append(idle, k.idle...)
+		p.open -= len(k.idle)
+		k.open -= len(k.idle)
+		k.idle = nil
+	}
+	for _, w := range p.waiters {
+		p.keys[w.key].waiting--
+		close(w.ready)
+	}
+	p.waiters = nil
+	var drained chan struct{}
+	if ctx != nil && len(p.out) > 0 {
+		if p.drained == nil {
+			p.drained = make(chan struct{})
+		}
+		drained = p.drained
+	}
+	p.mu.Unlock()
+	errs := _drv.listPool.Get().(*list.List)
+	defer func() {
+		if value := recover(); value != nil {
+			errs.PushBack(errR(value))
+		}
+		multiErr := newMultiErrL(errs)
+		if multiErr != nil {
+			err = errE(*multiErr)
+		}
+		errs.Init()
+		_drv.listPool.Put(errs)
+	}()
+	for _, ps := range idle {
+		if err0 := p.closeSes(ps); err0 != nil {
+			errs.PushBack(errE(err0))
+		}
+	}
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			errs.PushBack(ctx.Err())
+		}
+	}
+	// close sessions not returned; Put frees their places
+	p.mu.Lock()
+	var out []*poolSes
+	for _, ps := range p.out {
+		out = append(out, ps)
+	}
+	p.mu.Unlock()
+	for _, ps := range out {
+		if err0 := p.closeSes(ps); err0 != nil {
+			errs.PushBack(errE(err0))
+		}
+	}
+	if p.ownsEnv && p.env.IsOpen() {
+		if err0 := p.env.Close(); err0 != nil {
+			errs.PushBack(errE(err0))
+		}
+	}
+	return nil
+}
+
+// key returns the sub-pool of key, creating it. No locking occurs.
+func (p *Pool) key(key string) *poolKey {
+	k, ok := p.keys[key]
+	if !ok {
+		k = &poolKey{}
+		p.keys[key] = k
+	}
+	return k
+}
+
+// keyAllows reports whether k is below PoolCfg.MaxSessionsPerKey. No locking
+// occurs.
+func (p *Pool) keyAllows(k *poolKey) bool {
+	return p.cfg.MaxSessionsPerKey <= 0 || k.open < p.cfg.MaxSessionsPerKey
+}
+
+// reserve counts a session being opened for k. No locking occurs.
+func (p *Pool) reserve(k *poolKey) {
+	p.open++
+	k.open++
+	k.inUse++
+}
+
+// release uncounts a session of k which is in use. No locking occurs.
+func (p *Pool) release(k *poolKey) {
+	p.open--
+	k.open--
+	k.inUse--
+}
+
+// evictIdle removes and returns the least recently used idle session of any
+// key, or nil. No locking occurs.
+func (p *Pool) evictIdle() (evicted *poolSes) {
+	var from *poolKey
+	for _, k := range p.keys {
+		if len(k.idle) > 0 && (evicted == nil || k.idle[0].used.Before(evicted.used)) {
+			evicted, from = k.idle[0], k
+		}
+	}
+	if evicted != nil {
+		from.idle = from.idle[1:]
+		from.open--
+		p.open--
+	}
+	return evicted
+}
+
+// grant gives freed capacity to a waiter which may open a session. No locking
+// occurs.
+func (p *Pool) grant() {
+	if p.closed || p.open >= p.cfg.MaxSessions {
+		return
+	}
+	if n := p.pickWaiter(""); n >= 0 {
+		w := p.takeWaiter(n)
+		p.reserve(p.keys[w.key])
+		w.reserved = true
+		close(w.ready)
+	}
+}
+
+// pickWaiter returns the index of the waiter to serve with a session of key,
+// or with freed capacity when key is empty, or -1. No locking occurs.
+func (p *Pool) pickWaiter(key string) int {
+	return pickWaiter(p.waiters, p.lastKey, p.cfg.Fairness, func(waitKey string) bool {
+		return waitKey == key || p.keyAllows(p.keys[waitKey])
+	})
+}
+
+// takeWaiter removes the waiter at index n and records its key as the last
+// served. No locking occurs.
+func (p *Pool) takeWaiter(n int) *poolWaiter {
+	w := p.waiters[n]
+	p.waiters = append(p.waiters[:n], p.waiters[n+1:]...)
+	p.keys[w.key].waiting--
+	p.lastKey = w.key
+	return w
+}
+
+// removeWaiter removes w if it's still waiting. No locking occurs.
+func (p *Pool) removeWaiter(w *poolWaiter) bool {
+	for n, waiter := range p.waiters {
+		if waiter == w {
+			p.waiters = append(p.waiters[:n], p.waiters[n+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// pickWaiter returns the index of the waiter to serve among the waiters whose
+// key is eligible, or -1. FairFIFO picks the first eligible waiter.
+// FairRoundRobin picks the first waiter of the eligible key following
+// lastKey in key order, wrapping to the least key.
+func pickWaiter(waiters []*poolWaiter, lastKey string, fairness Fairness, eligible func(key string) bool) int {
+	first, next := -1, -1
+	for n, w := range waiters {
+		if !eligible(w.key) {
+			continue
+		}
+		if fairness != FairRoundRobin {
+			return n
+		}
+		if first < 0 || w.key < waiters[first].key {
+			first = n
+		}
+		if w.key > lastKey && (next < 0 || w.key < waiters[next].key) {
+			next = n
+		}
+	}
+	if next >= 0 {
+		return next
+	}
+	return first
+}
+
+// sysName returns a string representing the Pool.
+func (p *Pool) sysName() string {
+	return fmt.Sprintf("P%v", p.id)
+}
+
+// log writes a message with a Pool system name and caller info.
+func (p *Pool) log(enabled bool, v ...interface{}) {
+	if enabled {
+		if len(v) == 0 {
+			lgr.Infof("%v %v", p.sysName(), callInfo(1))
+		} else {
+			lgr.Infof("%v %v %v", p.sysName(), callInfo(1), fmt.Sprint(v...))
+		}
+	}
+}
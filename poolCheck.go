@@ -0,0 +1,99 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"time"
+)
+
+// PoolStatus is the result of Pool.Check.
+type PoolStatus struct {
+	Latency      time.Duration // duration of the round trip to the server
+	Version      string        // server version banner
+	OpenSessions int           // sessions open in the Pool
+	InUse        int           // sessions checked out of the Pool
+	Idle         int           // sessions open and not checked out
+}
+
+// Check validates connectivity to the server of the Pool for readiness
+// probes. Check borrows an idle session, or opens one for the empty key when
+// none is idle, and makes a round trip: OCIPing, or the query of
+// PoolCfg.CheckSql when set. A round trip in flight when ctx is done is
+// interrupted with Ses.Break, and the error of ctx is returned.
+//
+// The session is returned to the Pool, or closed when the round trip failed.
+// A nil ctx is context.Background().
+func (p *Pool) Check(ctx context.Context) (status PoolStatus, err error) {
+	p.log(_drv.cfg().Log.Pool.Check)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ses, err := p.idleSes(ctx)
+	if err != nil {
+		return status, errE(err)
+	}
+	start := time.Now()
+	stop := ses.breakOnDone(ctx)
+	err = p.roundTrip(ses)
+	stop()
+	status.Latency = time.Since(start)
+	if err == nil {
+		status.Version, err = ses.srv.Version()
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	if err != nil {
+		ses.Close() // Put frees its place
+	}
+	p.Put(ses)
+	p.mu.Lock()
+	status.OpenSessions = p.open
+	for _, k := range p.keys {
+		status.InUse += k.inUse
+		status.Idle += len(k.idle)
+	}
+	p.mu.Unlock()
+	return status, err
+}
+
+// roundTrip pings the server with ses, or queries PoolCfg.CheckSql.
+func (p *Pool) roundTrip(ses *Ses) error {
+	if p.cfg.CheckSql == "" {
+		return ses.Ping()
+	}
+	rset, err := ses.PrepAndQry(p.cfg.CheckSql)
+	if err != nil {
+		return err
+	}
+	for rset.Next() {
+	}
+	return rset.Err
+}
+
+// idleSes checks out the most recently used idle session of any key, or gets
+// a session for the empty key when none is idle.
+func (p *Pool) idleSes(ctx context.Context) (*Ses, error) {
+	p.mu.Lock()
+	if !p.closed {
+		var from *poolKey
+		var ps *poolSes
+		for _, k := range p.keys {
+			if n := len(k.idle); n > 0 && (ps == nil || k.idle[n-1].used.After(ps.used)) {
+				ps, from = k.idle[n-1], k
+			}
+		}
+		if ps != nil {
+			from.idle = from.idle[:len(from.idle)-1]
+			from.inUse++
+			p.out[ps.ses] = ps
+			p.mu.Unlock()
+			return ps.ses, nil
+		}
+	}
+	p.mu.Unlock()
+	return p.GetContext(ctx, "")
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "time"
+
+// expired reports whether ps is older than maxAge, or has been idle longer
+// than maxIdle, at now. A zero limit is ignored.
+func expired(ps *poolSes, now time.Time, maxAge, maxIdle time.Duration) bool {
+	return maxAge > 0 && now.Sub(ps.opened) >= maxAge ||
+		maxIdle > 0 && now.Sub(ps.used) >= maxIdle
+}
+
+// recycleInterval returns the interval at which idle sessions are checked
+// for PoolCfg.MaxSessionAge and PoolCfg.MaxIdle: half the lesser positive
+// limit, or zero when neither is set.
+func recycleInterval(maxAge, maxIdle time.Duration) time.Duration {
+	interval := maxAge
+	if maxIdle > 0 && (interval <= 0 || maxIdle < interval) {
+		interval = maxIdle
+	}
+	if interval <= 0 {
+		return 0
+	}
+	return interval / 2
+}
+
+// recycleEvery recycles idle sessions at each interval until stop is closed.
+func (p *Pool) recycleEvery(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.recycle()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// recycle closes idle sessions past PoolCfg.MaxSessionAge or PoolCfg.MaxIdle.
+// The freed places are given to waiting callers, which open replacement
+// sessions. recycle opens no session itself: the Pool has no minimum size,
+// so sessions closed while no caller waits are replaced by later Gets.
+func (p *Pool) recycle() {
+	if p.cfg.MaxSessionAge <= 0 && p.cfg.MaxIdle <= 0 {
+		return
+	}
+	now := time.Now()
+	var stale []*poolSes
+	p.mu.Lock()
+	for _, k := range p.keys {
+		idle := k.idle[:0]
+		for _, ps := range k.idle {
+			if expired(ps, now, p.cfg.MaxSessionAge, p.cfg.MaxIdle) {
+				stale = append(stale, ps)
+				p.open--
+				k.open--
+			} else {
+				idle = append(idle, ps)
+			}
+		}
+		for n := len(idle); n < len(k.idle); n++ {
+			k.idle[n] = nil
+		}
+		k.idle = idle
+	}
+	for range stale {
+		p.grant()
+	}
+	p.mu.Unlock()
+	for _, ps := range stale {
+		p.closeSes(ps)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPickWaiter tests pickWaiter.
+func TestPickWaiter(t *testing.T) {
+	var waiters []*poolWaiter
+	for _, key := range []string{"b", "b", "a", "c", "b"} {
+		waiters = append(waiters, &poolWaiter{key: key})
+	}
+	all := func(string) bool { return true }
+	notB := func(key string) bool { return key != "b" }
+	for _, tc := range []struct {
+		lastKey  string
+		fairness Fairness
+		eligible func(string) bool
+		want     int
+	}{
+		{"", FairFIFO, all, 0},
+		{"", FairFIFO, notB, 2},
+		{"", FairRoundRobin, all, 2},
+		{"a", FairRoundRobin, all, 0},
+		{"b", FairRoundRobin, all, 3},
+		{"c", FairRoundRobin, all, 2},
+		{"a", FairRoundRobin, notB, 3},
+		{"", FairFIFO, func(string) bool { return false }, -1},
+	} {
+		if got := pickWaiter(waiters, tc.lastKey, tc.fairness, tc.eligible); got != tc.want {
+			t.Errorf("%q/%v: got %d, wanted %d", tc.lastKey, tc.fairness, got, tc.want)
+		}
+	}
+}
+
+// TestExpired tests expired and recycleInterval.
+func TestExpired(t *testing.T) {
+	now := time.Now()
+	ps := &poolSes{opened: now.Add(-time.Hour), used: now.Add(-time.Minute)}
+	for _, tc := range []struct {
+		maxAge, maxIdle time.Duration
+		want            bool
+	}{
+		{0, 0, false},
+		{2 * time.Hour, 0, false},
+		{time.Hour, 0, true},
+		{0, 2 * time.Minute, false},
+		{0, 30 * time.Second, true},
+		{2 * time.Hour, 30 * time.Second, true},
+	} {
+		if got := expired(ps, now, tc.maxAge, tc.maxIdle); got != tc.want {
+			t.Errorf("%v/%v: got %v, wanted %v", tc.maxAge, tc.maxIdle, got, tc.want)
+		}
+	}
+	if got := recycleInterval(time.Hour, 10*time.Minute); got != 5*time.Minute {
+		t.Errorf("recycleInterval: got %v, wanted %v", got, 5*time.Minute)
+	}
+	if got := recycleInterval(0, 0); got != 0 {
+		t.Errorf("recycleInterval: got %v, wanted 0", got)
+	}
+}
+
+// TestNewLogPoolCfg tests the defaults of LogPoolCfg.
+func TestNewLogPoolCfg(t *testing.T) {
+	c := NewLogPoolCfg()
+	if !c.Get || !c.Put || !c.Close || !c.Drain || !c.Check {
+		t.Errorf("got %+v, wanted Get, Put, Close, Drain and Check logged", c)
+	}
+	if c.Stats {
+		t.Error("Stats: got true, wanted false")
+	}
+}
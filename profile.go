@@ -0,0 +1,33 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// profile calls fn with the pprof label "ora" set to op, added to the labels
+// of ctx, when DrvCfg.ProfileLabels is set.
+func profile(ctx context.Context, op string, fn func()) {
+	if !_drv.cfg().ProfileLabels {
+		fn()
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	pprof.Do(ctx, pprof.Labels("ora", op), func(context.Context) { fn() })
+}
+
+// pollOp is poll labeled with op for profiling; see DrvCfg.ProfileLabels.
+func (ses *Ses) pollOp(ctx context.Context, op string, call func() C.sword) (r C.sword) {
+	profile(ctx, op, func() { r = ses.poll(ctx, call) })
+	return r
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// raceGuard detects the simultaneous use of a handle by two goroutines when
+// DrvCfg.RaceDetect is enabled.
+//
+// A raceGuard is independent of a handle's mutex; internal locking, such as
+// Stmt eviction, never trips the guard.
+type raceGuard struct {
+	busy int32
+}
+
+// sysNamer is implemented by handles having a system name.
+type sysNamer interface {
+	sysName() string
+}
+
+// enter marks the handle as in use, panicking when another goroutine is
+// already using it. The zero raceGuard is ready to use.
+func (g *raceGuard) enter(kind string, handle sysNamer, method string) {
+	if !_drv.cfg().RaceDetect {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&g.busy, 0, 1) {
+		panic(fmt.Sprintf("ora: %v %v: %v called while another goroutine is using the %v; "+
+			"a %v must not be used by two goroutines simultaneously", kind, handle.sysName(), method, kind, kind))
+	}
+}
+
+// leave marks the handle as no longer in use.
+func (g *raceGuard) leave() {
+	atomic.StoreInt32(&g.busy, 0)
+}
@@ -0,0 +1,54 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type sysNameString string
+
+func (s sysNameString) sysName() string { return string(s) }
+
+// TestRaceGuard tests raceGuard.
+func TestRaceGuard(t *testing.T) {
+	prev := CfgCopy()
+	defer SetCfg(*prev)
+	enter := func(g *raceGuard) (msg string) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = fmt.Sprint(r)
+			}
+		}()
+		g.enter("Stmt", sysNameString("E1S1S1"), "Qry")
+		return ""
+	}
+
+	var g raceGuard
+	if msg := enter(&g); msg != "" {
+		t.Fatalf("RaceDetect off: got panic %q", msg)
+	}
+	if msg := enter(&g); msg != "" {
+		t.Fatalf("RaceDetect off, simultaneous use: got panic %q", msg)
+	}
+	g.leave()
+
+	cfg := CfgCopy()
+	cfg.RaceDetect = true
+	SetCfg(*cfg)
+	if msg := enter(&g); msg != "" {
+		t.Fatalf("RaceDetect on: got panic %q", msg)
+	}
+	msg := enter(&g)
+	if !strings.Contains(msg, "Stmt E1S1S1: Qry called while another goroutine") {
+		t.Errorf("RaceDetect on, simultaneous use: got panic %q", msg)
+	}
+	g.leave()
+	if msg := enter(&g); msg != "" {
+		t.Errorf("RaceDetect on, after leave: got panic %q", msg)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"unsafe"
+)
+
+// rebnd is implemented by bind types whose buffers may be updated in place by
+// Stmt.ReExe while keeping the OCI bind handle.
+type rebnd interface {
+	bnd
+	// rebind copies value into the bound buffer. rebind returns false when
+	// value doesn't have the type or size of the bound value.
+	rebind(value interface{}) (bool, error)
+}
+
+// numberFromInt converts the integer at inum into number.
+func numberFromInt(stmt *Stmt, inum unsafe.Pointer, length C.uword, signFlag C.uword, number *C.OCINumber) error {
+	r := C.OCINumberFromInt(
+		stmt.ses.ocierr, //OCIError            *err,
+		inum,            //const void          *inum,
+		length,          //uword               inum_length,
+		signFlag,        //uword               inum_s_flag,
+		number)          //OCINumber           *number );
+	if r == C.OCI_ERROR {
+		return stmt.ses.ociError()
+	}
+	return nil
+}
+
+// numberFromReal converts the floating-point number at rnum into number.
+func numberFromReal(stmt *Stmt, rnum unsafe.Pointer, length C.uword, number *C.OCINumber) error {
+	r := C.OCINumberFromReal(
+		stmt.ses.ocierr, //OCIError            *err,
+		rnum,            //const void          *rnum,
+		length,          //uword               rnum_length,
+		number)          //OCINumber           *number );
+	if r == C.OCI_ERROR {
+		return stmt.ses.ociError()
+	}
+	return nil
+}
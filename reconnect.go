@@ -0,0 +1,300 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// ReconnectCfg configures the automatic reconnection of a Srv whose server
+// connection is lost.
+type ReconnectCfg struct {
+	// MaxAttempts is the maximum number of attempts to re-attach the server.
+	//
+	// The default is 5.
+	MaxAttempts int
+
+	// InitialDelay is the wait before the second attempt. Each further wait
+	// doubles, up to MaxDelay.
+	//
+	// The default is 100ms.
+	InitialDelay time.Duration
+
+	// MaxDelay is the maximum wait between attempts.
+	//
+	// The default is 10s.
+	MaxDelay time.Duration
+
+	// Reinit is called with each re-opened Ses to restore session state, such
+	// as NLS settings and package state. A Reinit error is reported in a
+	// ReconnectFailed event, and the Ses stays open. Reinit and OnEvent are
+	// called on the goroutine reconnecting the Srv, not on the goroutine of
+	// the failed call.
+	//
+	// The default is nil.
+	Reinit func(ses *Ses) error
+
+	// OnEvent is called with each reconnection event.
+	//
+	// The default is nil.
+	OnEvent func(event ReconnectEvent)
+}
+
+// NewReconnectCfg creates a ReconnectCfg with default values.
+func NewReconnectCfg() *ReconnectCfg {
+	c := &ReconnectCfg{}
+	c.MaxAttempts = 5
+	c.InitialDelay = 100 * time.Millisecond
+	c.MaxDelay = 10 * time.Second
+	return c
+}
+
+// ReconnectEventKind is the kind of a ReconnectEvent.
+type ReconnectEventKind int
+
+const (
+	// ReconnectLost reports that the server connection was lost.
+	ReconnectLost ReconnectEventKind = iota
+	// ReconnectAttempt reports a failed attempt to re-attach the server.
+	ReconnectAttempt
+	// ReconnectDone reports that the server is re-attached and the sessions
+	// re-opened.
+	ReconnectDone
+	// ReconnectFailed reports that the server couldn't be re-attached, or that
+	// a session couldn't be re-opened or re-initialized.
+	ReconnectFailed
+)
+
+// ReconnectEvent reports the progress of the reconnection of a Srv.
+type ReconnectEvent struct {
+	Kind ReconnectEventKind
+	Srv  *Srv
+	// Ses is the Ses which failed to re-open or re-initialize, or nil.
+	Ses *Ses
+	// Attempt is the one-based number of the attempt to re-attach.
+	Attempt int
+	// Err is the error which caused the event, or nil.
+	Err error
+}
+
+// connLostCodes are the Oracle error codes of a lost server connection.
+var connLostCodes = map[int]bool{
+	3113:  true, // end-of-file on communication channel
+	3114:  true, // not connected to ORACLE
+	3135:  true, // connection lost contact
+	12537: true, // TNS:connection closed
+	12547: true, // TNS:lost contact
+	12570: true, // TNS:packet reader failure
+	12571: true, // TNS:packet writer failure
+	28547: true, // connection to server failed, probable Oracle Net admin error
+}
+
+// isConnLost reports whether err reports a lost server connection.
+func isConnLost(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, m := range oraCode.FindAllStringSubmatch(err.Error(), -1) {
+		if code, _ := strconv.Atoi(m[1]); connLostCodes[code] {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the one-based attempt.
+func backoff(attempt int, initial, max time.Duration) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+	d := initial
+	for n := 2; n < attempt && d < max; n++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// lost starts reconnecting the Srv on a goroutine of its own when err
+// reports a lost server connection and SrvCfg.Reconnect is set, so that the
+// caller, which may hold Stmt and Ses locks, doesn't wait for the backoff. The
+// call which returned err isn't retried.
+func (srv *Srv) lost(err error) {
+	if srv == nil || srv.cfg.Reconnect == nil || !isConnLost(err) {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&srv.reconnecting, 0, 1) {
+		return // a Reinit call or another Ses is reconnecting
+	}
+	go func() {
+		defer atomic.StoreInt32(&srv.reconnecting, 0)
+		srv.reconnect(err)
+	}()
+}
+
+func (srv *Srv) event(event ReconnectEvent) {
+	event.Srv = srv
+	srv.logF(_drv.cfg().Log.Srv.Reconnect, "reconnect event %v attempt %v: %v", event.Kind, event.Attempt, event.Err)
+	if fn := srv.cfg.Reconnect.OnEvent; fn != nil {
+		fn(event)
+	}
+}
+
+// reconnect re-attaches the server and re-opens its sessions. Stmts of the
+// sessions are re-prepared on their next use; their open Rsets, whose
+// cursors were lost, return an error from Next. Srv.mu is held only while the
+// server handle is used, not during the backoff, and reconnect gives up when
+// the Srv is closed meanwhile.
+func (srv *Srv) reconnect(cause error) {
+	srv.mu.Lock()
+	if srv.env == nil {
+		srv.mu.Unlock()
+		return
+	}
+	cfg := srv.cfg.Reconnect
+	// the server handle changes when the Srv is closed and reused
+	ocisrv := srv.ocisrv
+	C.OCIServerDetach(srv.ocisrv, srv.env.ocierr, C.OCI_DEFAULT) // the connection is already lost
+	srv.nonBlocking = false
+	srv.mu.Unlock()
+	srv.event(ReconnectEvent{Kind: ReconnectLost, Err: cause})
+	var err error
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		time.Sleep(backoff(attempt, cfg.InitialDelay, cfg.MaxDelay))
+		srv.mu.Lock()
+		if srv.env == nil || srv.ocisrv != ocisrv {
+			srv.mu.Unlock()
+			return
+		}
+		if err = srv.attach(); err == nil && srv.cfg.NonBlocking {
+			if err = srv.setNonBlocking(); err != nil {
+				srv.mu.Unlock()
+				srv.event(ReconnectEvent{Kind: ReconnectFailed, Err: err})
+				return
+			}
+		}
+		srv.mu.Unlock()
+		if err == nil {
+			break
+		}
+		srv.event(ReconnectEvent{Kind: ReconnectAttempt, Attempt: attempt, Err: err})
+	}
+	if err != nil {
+		srv.event(ReconnectEvent{Kind: ReconnectFailed, Attempt: cfg.MaxAttempts, Err: err})
+		return
+	}
+	for _, ses := range srv.openSess.snapshot() {
+		reopened, err := ses.reopen(srv)
+		if err == nil && reopened && cfg.Reinit != nil {
+			err = cfg.Reinit(ses)
+		}
+		if err != nil {
+			srv.event(ReconnectEvent{Kind: ReconnectFailed, Ses: ses, Err: err})
+		}
+	}
+	srv.event(ReconnectEvent{Kind: ReconnectDone})
+}
+
+// attach attaches the server handle to the dblink of the Srv. The caller
+// holds Srv.mu.
+func (srv *Srv) attach() error {
+	cDblink := C.CString(srv.dblink)
+	defer C.free(unsafe.Pointer(cDblink))
+	r := C.OCIServerAttach(
+		srv.ocisrv,                            //OCIServer     *srvhp,
+		srv.env.ocierr,                        //OCIError      *errhp,
+		(*C.OraText)(unsafe.Pointer(cDblink)), //const OraText *dblink,
+		C.sb4(len(srv.dblink)),                //sb4           dblink_len,
+		C.OCI_DEFAULT)                         //ub4           mode);
+	if r == C.OCI_ERROR {
+		return srv.env.ociError()
+	}
+	return nil
+}
+
+// reopen begins the session again on the re-attached server srv, resets the
+// session state and restarts resumable space allocation. reopened is false
+// when the Ses was closed, or reused for another Srv, meanwhile.
+func (ses *Ses) reopen(srv *Srv) (reopened bool, err error) {
+	ses.mu.Lock()
+	if ses.srv != srv || ses.ocises == nil {
+		ses.mu.Unlock()
+		return false, nil
+	}
+	err = ses.rebegin()
+	monitor := ses.resumable
+	ses.resumable = nil
+	timeout := ses.cfg.ResumableTimeout
+	ses.mu.Unlock()
+	monitor.stop() // restarted for the new session by enableResumable
+	if err != nil {
+		return true, err
+	}
+	if timeout > 0 {
+		return true, ses.enableResumable()
+	}
+	return true, nil
+}
+
+// rebegin ends the lost session and begins it again. The caller holds
+// Ses.mu.
+func (ses *Ses) rebegin() error {
+	C.OCISessionEnd(ses.ocisvcctx, ses.ocierr, ses.ocises, C.OCI_DEFAULT) // the session is already lost
+	credentialType := C.ub4(C.OCI_CRED_EXT)
+	if ses.cfg.Username != "" || ses.cfg.Password != "" {
+		credentialType = C.OCI_CRED_RDBMS
+	}
+	r := C.OCISessionBegin(
+		ses.ocisvcctx,  //OCISvcCtx     *svchp,
+		ses.ocierr,     //OCIError      *errhp,
+		ses.ocises,     //OCISession    *usrhp,
+		credentialType, //ub4           credt,
+		C.OCI_DEFAULT)  //ub4           mode );
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	err := ses.setAttr(unsafe.Pointer(ses.ocisvcctx), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(ses.ocises), C.ub4(0), C.OCI_ATTR_SESSION)
+	if err != nil {
+		return err
+	}
+	atomic.AddUint32(&ses.gen, 1)
+	ses.isolationLevel = ""
+	ses.currentSchema = ""
+	ses.params = nil
+	ses.tagMu.Lock()
+	ses.action, ses.ecid = "", ""
+	ses.tagMu.Unlock()
+	return nil
+}
+
+// dropStale releases the handle of a Stmt prepared before its Ses was
+// re-opened, so that the Stmt is re-prepared. No locking occurs.
+func (stmt *Stmt) dropStale() {
+	if stmt.evicted || stmt.gen == atomic.LoadUint32(&stmt.ses.gen) {
+		return
+	}
+	for _, bind := range stmt.bnds {
+		if bind != nil {
+			bind.close()
+		}
+	}
+	stmt.bnds = nil
+	stmt.hasPtrBind = false
+	if stmt.ocistmt != nil {
+		C.OCIStmtRelease(stmt.ocistmt, stmt.ses.ocierr, nil, 0, C.OCI_DEFAULT)
+		stmt.ocistmt = nil
+	}
+	stmt.evicted = true
+}
@@ -0,0 +1,39 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestIsConnLost tests isConnLost.
+func TestIsConnLost(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("ORA-03113: end-of-file on communication channel"), true},
+		{errors.New("ORA-12152: TNS:unable to send break message\nORA-03135: connection lost contact"), true},
+		{errors.New("ORA-00942: table or view does not exist"), false},
+	} {
+		if got := isConnLost(tc.err); got != tc.want {
+			t.Errorf("%v: got %v, wanted %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+// TestBackoff tests backoff.
+func TestBackoff(t *testing.T) {
+	initial, max := 100*time.Millisecond, time.Second
+	for attempt, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond,
+		400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if got := backoff(attempt, initial, max); got != want {
+			t.Errorf("attempt %d: got %v, wanted %v", attempt, got, want)
+		}
+	}
+}
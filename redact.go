@@ -0,0 +1,149 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// redactMask replaces masked secrets and bind values.
+const redactMask = "****"
+
+// secretPatterns match secrets in SQL text and connection strings. The first
+// group of each match is kept, and the remainder is masked.
+var secretPatterns = []*regexp.Regexp{
+	// CREATE USER u IDENTIFIED BY pw, CONNECT TO u IDENTIFIED BY pw of a
+	// database link, and IDENTIFIED BY VALUES 'hash'
+	regexp.MustCompile(`(?i)(\bIDENTIFIED\s+BY\s+(?:VALUES\s+)?)("[^"]*"|'[^']*'|[^\s;,)]+)`),
+	// password=pw and pwd=pw of connection properties
+	regexp.MustCompile(`(?i)(\b(?:password|pwd)\s*=\s*)("[^"]*"|'[^']*'|[^\s;,&]+)`),
+	// username/password@dblink
+	regexp.MustCompile(`([A-Za-z0-9_$#."]+/)([^\s/@]+)(@)`),
+}
+
+// RedactCfg configures the masking of secrets in log messages and in the
+// messages of errors returned by the driver, which may contain SQL text and
+// bind values.
+type RedactCfg struct {
+	// Secrets determines whether passwords are masked: the password of an
+	// IDENTIFIED BY clause, password= and pwd= properties, and the password of
+	// a username/password@dblink connection string.
+	//
+	// The default is true.
+	Secrets bool
+
+	// BindPositions are the one-based positions of bind parameters whose
+	// values are masked.
+	//
+	// The default is nil.
+	BindPositions []int
+
+	// BindNames are the placeholder names, without the colon, of bind
+	// parameters whose values are masked. Names are compared ignoring case.
+	//
+	// The default is nil.
+	BindNames []string
+}
+
+// NewRedactCfg creates a RedactCfg with default values.
+func NewRedactCfg() RedactCfg {
+	c := RedactCfg{}
+	c.Secrets = true
+	return c
+}
+
+// redact returns s with secrets masked when RedactCfg.Secrets is true.
+func redact(s string) string {
+	if !_drv.cfg().Log.Redact.Secrets {
+		return s
+	}
+	return redactSecrets(s)
+}
+
+// redactSecrets returns s with the secrets matched by secretPatterns masked.
+func redactSecrets(s string) string {
+	for _, re := range secretPatterns {
+		s = re.ReplaceAllString(s, "${1}"+redactMask+"${3}")
+	}
+	return s
+}
+
+// masked reports whether the bind parameter at the zero-based position n,
+// bound to the placeholder name, is masked by cfg.
+func (cfg RedactCfg) masked(n int, name string) bool {
+	for _, position := range cfg.BindPositions {
+		if position == n+1 {
+			return true
+		}
+	}
+	for _, bindName := range cfg.BindNames {
+		if name != "" && strings.EqualFold(strings.TrimPrefix(bindName, ":"), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBind returns value, or redactMask when the bind parameter at the
+// zero-based position n is masked. No locking occurs.
+func (stmt *Stmt) redactBind(n int, value interface{}) interface{} {
+	cfg := _drv.cfg().Log.Redact
+	if len(cfg.BindPositions) == 0 && len(cfg.BindNames) == 0 {
+		return value
+	}
+	if cfg.masked(n, bindName(placeholderNames(stmt.sql), n, stmt.stmtType == C.OCI_STMT_BEGIN || stmt.stmtType == C.OCI_STMT_DECLARE)) {
+		return redactMask
+	}
+	return value
+}
+
+// bindName returns the placeholder name of the zero-based bind position n,
+// or an empty string. PL/SQL binds a repeated placeholder name once, so when
+// plsql is true positions count unique names.
+func bindName(names []string, n int, plsql bool) string {
+	if plsql {
+		var unique []string
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if key := strings.ToUpper(name); !seen[key] {
+				seen[key] = true
+				unique = append(unique, name)
+			}
+		}
+		names = unique
+	}
+	if n < 0 || n >= len(names) {
+		return ""
+	}
+	return names[n]
+}
+
+// redactLgr is the Logger of the driver. It writes messages to
+// LogDrvCfg.Logger with secrets masked.
+type redactLgr struct{}
+
+var lgr Logger = redactLgr{}
+
+func (redactLgr) Infof(format string, v ...interface{}) {
+	_drv.cfg().Log.Logger.Infof("%v", redact(fmt.Sprintf(format, v...)))
+}
+
+func (redactLgr) Infoln(v ...interface{}) {
+	_drv.cfg().Log.Logger.Infoln(redact(strings.TrimSuffix(fmt.Sprintln(v...), "\n")))
+}
+
+func (redactLgr) Errorf(format string, v ...interface{}) {
+	_drv.cfg().Log.Logger.Errorf("%v", redact(fmt.Sprintf(format, v...)))
+}
+
+func (redactLgr) Errorln(v ...interface{}) {
+	_drv.cfg().Log.Logger.Errorln(redact(strings.TrimSuffix(fmt.Sprintln(v...), "\n")))
+}
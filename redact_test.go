@@ -0,0 +1,51 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestRedactSecrets tests redactSecrets.
+func TestRedactSecrets(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"CREATE USER scott IDENTIFIED BY tiger", "CREATE USER scott IDENTIFIED BY ****"},
+		{`ALTER USER scott identified by "t i;ger" ACCOUNT UNLOCK`, "ALTER USER scott identified by **** ACCOUNT UNLOCK"},
+		{"ALTER USER scott IDENTIFIED BY VALUES 'S:ABC123';", "ALTER USER scott IDENTIFIED BY VALUES ****;"},
+		{"CREATE DATABASE LINK l CONNECT TO u IDENTIFIED BY pw USING 'db'", "CREATE DATABASE LINK l CONNECT TO u IDENTIFIED BY **** USING 'db'"},
+		{"user=scott password=tiger dblink=orcl", "user=scott password=**** dblink=orcl"},
+		{"open scott/tiger@localhost:1521/orcl", "open scott/****@localhost:1521/orcl"},
+		{"SELECT a / b FROM t", "SELECT a / b FROM t"},
+	} {
+		if got := redactSecrets(tc.in); got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestRedactCfgMasked tests RedactCfg.masked and bindName.
+func TestRedactCfgMasked(t *testing.T) {
+	cfg := RedactCfg{BindPositions: []int{2}, BindNames: []string{":PWD"}}
+	names := []string{"id", "pwd", "id", "name"}
+	for _, tc := range []struct {
+		n     int
+		plsql bool
+		want  bool
+	}{
+		{0, false, false},
+		{1, false, true}, // position 2
+		{2, false, false},
+		{1, true, true},
+		{2, true, false}, // name, as the repeated id binds once
+	} {
+		if got := cfg.masked(tc.n, bindName(names, tc.n, tc.plsql)); got != tc.want {
+			t.Errorf("%d plsql=%v: got %v, wanted %v", tc.n, tc.plsql, got, tc.want)
+		}
+	}
+	if !(RedactCfg{BindNames: []string{"pwd"}}).masked(5, "PWD") {
+		t.Errorf("name not masked")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+)
+
+// namedDrv is a database/sql driver registered by RegisterName, opening
+// connections with its own Env.
+type namedDrv struct {
+	name string
+	env  *Env
+}
+
+// Open opens a connection to an Oracle server with the Env of the driver.
+//
+// Open is a member of the driver.Driver interface.
+func (drv *namedDrv) Open(conStr string) (driver.Conn, error) {
+	log(true, drv.name)
+	con, err := drv.env.OpenCon(conStr)
+	if err != nil {
+		return nil, errE(err)
+	}
+	return con, nil
+}
+
+var (
+	namedDrvsMu sync.Mutex
+	namedDrvs   = make(map[string]*namedDrv)
+)
+
+// RegisterName registers a database/sql driver named name, such as
+// "ora-utf8", whose connections are opened with an Env of its own configured
+// by cfg, so that databases requiring different Env settings are used from
+// one process. If cfg is nil, the EnvCfg of the DrvCfg is applied.
+//
+// The Env of a named driver keeps its cfg when SetCfg or SetDrvCfg changes
+// the EnvCfg of the "ora" driver. RegisterName returns an error when name is already
+// registered; it's safe for concurrent use.
+func RegisterName(name string, cfg *EnvCfg) error {
+	log(_drv.cfg().Log.RegisterName, name)
+	if name == "" {
+		return er("Parameter 'name' may not be empty.")
+	}
+	namedDrvsMu.Lock()
+	defer namedDrvsMu.Unlock()
+	if _, ok := namedDrvs[name]; ok || name == Name {
+		return errF("A driver named %q is already registered.", name)
+	}
+	for _, registered := range sql.Drivers() {
+		if registered == name {
+			return errF("A driver named %q is already registered.", name)
+		}
+	}
+	env, err := OpenEnv(cfg)
+	if err != nil {
+		return errE(err)
+	}
+	// database/sql/driver expects binaryFloat to return float64 (not the Rset default of float32);
+	// the StmtCfg is copied as it may be shared with cfg or the DrvCfg
+	env.mu.Lock()
+	stmtCfg := NewStmtCfg()
+	if env.cfg.StmtCfg != nil {
+		stmtCfg = env.cfg.StmtCfg.copy()
+	}
+	stmtCfg.Rset.binaryFloat = F64
+	env.cfg.StmtCfg = stmtCfg
+	env.sqlPkg = true
+	env.named = true
+	env.mu.Unlock()
+	drv := &namedDrv{name: name, env: env}
+	namedDrvs[name] = drv
+	sql.Register(name, drv)
+	return nil
+}
+
+// NamedEnv returns the Env of the driver registered by RegisterName as name,
+// or nil. The Env stays open for the life of the process; don't close it.
+func NamedEnv(name string) *Env {
+	namedDrvsMu.Lock()
+	defer namedDrvsMu.Unlock()
+	if drv, ok := namedDrvs[name]; ok {
+		return drv.env
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"unsafe"
+)
+
+// ReplayId identifies the executions of a unit of work, such as a request,
+// so that a workload captured for Real Application Testing is correlated
+// with its replay.
+type ReplayId struct {
+	// Action is the session action, reported in V$SESSION and the workload
+	// capture. A non-empty Action takes precedence over a tag set by
+	// WithSqlTag.
+	Action string
+
+	// Ecid is the execution context id, an end-to-end identifier of at most
+	// 64 bytes reported in V$SESSION.ECID and the workload capture.
+	Ecid string
+}
+
+// replayIdKey is the context key of a ReplayId set by WithReplayId.
+type replayIdKey struct{}
+
+// WithReplayId returns a copy of ctx carrying id, which Stmt.ExeContext and
+// Stmt.QryContext set on the session before each execution. Identifiers are
+// sent to the server with the round trip of the execution.
+func WithReplayId(ctx context.Context, id ReplayId) context.Context {
+	return context.WithValue(ctx, replayIdKey{}, id)
+}
+
+// replayIdFrom returns the ReplayId carried by ctx.
+func replayIdFrom(ctx context.Context) (id ReplayId, ok bool) {
+	if ctx == nil {
+		return id, false
+	}
+	id, ok = ctx.Value(replayIdKey{}).(ReplayId)
+	return id, ok
+}
+
+// tagEcid sets the execution context id of the session when it differs from
+// the current one. The caller holds tagMu.
+func (ses *Ses) tagEcid(ecid string) error {
+	if ecid == ses.ecid {
+		return nil
+	}
+	if len(ecid) > 64 {
+		return errF("Execution context id %q exceeds 64 bytes.", ecid)
+	}
+	cEcid := C.CString(ecid)
+	defer C.free(unsafe.Pointer(cEcid))
+	err := ses.setAttr(unsafe.Pointer(ses.ocises), C.OCI_HTYPE_SESSION, unsafe.Pointer(cEcid), C.ub4(len(ecid)), C.OCI_ATTR_ECONTEXT_ID)
+	if err != nil {
+		return err
+	}
+	ses.ecid = ecid
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+
+#ifndef OCI_ATTR_STMT_IS_RESULT_CACHED
+#define OCI_ATTR_STMT_IS_RESULT_CACHED 0
+#endif
+*/
+import "C"
+import "unsafe"
+
+// ResultCacheMode determines whether a query uses the OCI client result cache.
+//
+// The client result cache is enabled on the server with the
+// CLIENT_RESULT_CACHE_SIZE parameter and requires OCI statement caching;
+// set SesCfg.StmtCacheSize to a non-zero value to use it.
+type ResultCacheMode uint8
+
+const (
+	// ResultCacheDefault caches queries having a /*+ RESULT_CACHE */ hint or
+	// reading tables annotated with RESULT_CACHE (MODE FORCE).
+	ResultCacheDefault ResultCacheMode = iota
+	// ResultCacheOn caches a query as if it had a /*+ RESULT_CACHE */ hint.
+	ResultCacheOn
+	// ResultCacheOff never caches a query.
+	ResultCacheOff
+)
+
+// exeMode returns the OCIStmtExecute mode flag of the ResultCacheMode.
+func (m ResultCacheMode) exeMode() C.ub4 {
+	switch m {
+	case ResultCacheOn:
+		return C.OCI_RESULT_CACHE
+	case ResultCacheOff:
+		return C.OCI_NO_RESULT_CACHE
+	}
+	return 0
+}
+
+// IsResultCached reports whether the latest query of the Stmt was answered
+// from the client result cache, without a server round trip, as reported by
+// OCI_ATTR_STMT_IS_RESULT_CACHED. An error is returned when the OCI client
+// the driver is built with doesn't define the attribute.
+func (stmt *Stmt) IsResultCached() (cached bool, err error) {
+	stmt.log(_drv.cfg().Log.Stmt.IsResultCached)
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	if err = stmt.checkClosed(); err != nil {
+		return false, errE(err)
+	}
+	if C.OCI_ATTR_STMT_IS_RESULT_CACHED == 0 {
+		return false, er("The OCI client doesn't report result cache hits.")
+	}
+	if stmt.evicted { // no query since the cursor was released
+		return false, nil
+	}
+	var value C.boolean
+	if err = stmt.attr(unsafe.Pointer(&value), C.ub4(unsafe.Sizeof(value)), C.OCI_ATTR_STMT_IS_RESULT_CACHED); err != nil {
+		return false, errE(err)
+	}
+	return value != 0, nil
+}
+
+// ResultCacheStats returns the client result cache statistics of the Ses,
+// such as "Find Count" (cache hits) and "Create Count Success", keyed by name.
+//
+// Statistics are sent to the server periodically, as set by the
+// CLIENT_RESULT_CACHE_LAG parameter, and are read from
+// CLIENT_RESULT_CACHE_STATS$; the user requires SELECT privileges on it and
+// V$SESSION_CONNECT_INFO.
+func (ses *Ses) ResultCacheStats() (stats map[string]int64, err error) {
+	ses.log(_drv.cfg().Log.Ses.ResultCacheStats)
+	rset, err := ses.qry(`SELECT S.NAME, S.VALUE
+FROM CLIENT_RESULT_CACHE_STATS$ S
+WHERE S.CACHE_ID = (SELECT MAX(I.CLIENT_REGID) FROM V$SESSION_CONNECT_INFO I
+	WHERE I.SID = SYS_CONTEXT('USERENV', 'SID'))`)
+	if err != nil {
+		return nil, errE(err)
+	}
+	stats = make(map[string]int64)
+	for rset.Next() {
+		name, _ := rset.Row[0].(string)
+		switch value := rset.Row[1].(type) {
+		case int64:
+			stats[name] = value
+		case float64:
+			stats[name] = int64(value)
+		}
+	}
+	if rset.Err != nil {
+		return nil, errE(rset.Err)
+	}
+	return stats, nil
+}
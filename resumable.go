@@ -0,0 +1,171 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"time"
+)
+
+// resumablePollInterval is the interval at which a resumable monitor checks
+// a calling Ses for suspended statements.
+const resumablePollInterval = time.Second
+
+// ResumableEvent describes a statement suspended by resumable space
+// allocation, as reported by USER_RESUMABLE.
+type ResumableEvent struct {
+	// Name is the name of the resumable statement.
+	Name string
+	// SqlText is the text of the suspended statement.
+	SqlText string
+	// ErrorNumber is the Oracle error which suspended the statement,
+	// such as 1653 for ORA-01653.
+	ErrorNumber int64
+	// ErrorMsg is the message of the error which suspended the statement.
+	ErrorMsg string
+	// SuspendTime is the time the statement was suspended.
+	SuspendTime time.Time
+	// Timeout is the duration the statement is suspended before failing.
+	Timeout time.Duration
+}
+
+// enableResumable enables resumable space allocation for the Ses, and starts
+// a monitor reporting suspensions when SesCfg.OnResumable is set.
+func (ses *Ses) enableResumable() error {
+	timeout := int64(ses.cfg.ResumableTimeout / time.Second)
+	if timeout < 1 {
+		timeout = 1
+	}
+	err := ses.alter(fmt.Sprintf("ALTER SESSION ENABLE RESUMABLE TIMEOUT %d NAME 'ora %v'", timeout, ses.sysName()))
+	if err != nil {
+		return err
+	}
+	if ses.cfg.OnResumable == nil {
+		return nil
+	}
+	sid, err := ses.Sid()
+	if err != nil {
+		return err
+	}
+	ses.resumable = newResumableMonitor(ses, sid)
+	return nil
+}
+
+// resumableMonitor polls USER_RESUMABLE from a second session while its Ses
+// is calling the server, reporting each suspension once. The second session
+// is opened on a server connection of its own, as the connection of the Ses
+// is busy with the suspended call.
+type resumableMonitor struct {
+	done chan struct{}
+}
+
+func newResumableMonitor(ses *Ses, sid int64) *resumableMonitor {
+	m := &resumableMonitor{done: make(chan struct{})}
+	cfg := ses.cfg
+	cfg.ResumableTimeout = 0
+	cfg.OnResumable = nil
+	ses.srv.mu.Lock()
+	env, srvCfg := ses.srv.env, ses.srv.cfg
+	ses.srv.mu.Unlock()
+	onResumable := ses.cfg.OnResumable
+	go func() {
+		var srv *Srv
+		var monitor *Ses
+		defer func() {
+			if srv != nil && srv.IsOpen() {
+				srv.Close() // closes the monitor session
+			}
+		}()
+		reported := make(map[time.Time]bool)
+		ticker := time.NewTicker(resumablePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+			}
+			if m.stopped() {
+				return
+			}
+			if !ses.calling() {
+				continue
+			}
+			if monitor == nil {
+				var err error
+				if srv, err = env.OpenSrv(&srvCfg); err != nil {
+					lgr.Errorf("ora: resumable monitor: %v", err)
+					return
+				}
+				if monitor, err = srv.OpenSes(&cfg); err != nil {
+					lgr.Errorf("ora: resumable monitor: %v", err)
+					return
+				}
+				if m.stopped() {
+					return
+				}
+			}
+			events, err := suspendedStmts(monitor, sid)
+			if err != nil {
+				lgr.Errorf("ora: resumable monitor: %v", err)
+				continue
+			}
+			for _, event := range events {
+				if !reported[event.SuspendTime] {
+					reported[event.SuspendTime] = true
+					onResumable(event)
+				}
+			}
+		}
+	}()
+	return m
+}
+
+// stop signals the monitor to close its session and return. stop doesn't
+// wait, as the monitor may be connecting to the server. It is valid to call
+// stop on a nil *resumableMonitor.
+func (m *resumableMonitor) stop() {
+	if m == nil {
+		return
+	}
+	close(m.done)
+}
+
+// stopped reports whether stop was called.
+func (m *resumableMonitor) stopped() bool {
+	select {
+	case <-m.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// suspendedStmts returns the suspended statements of the session sid.
+func suspendedStmts(ses *Ses, sid int64) (events []ResumableEvent, err error) {
+	stmt, err := ses.prepSql(`SELECT NAME, SQL_TEXT, ERROR_NUMBER, ERROR_MSG,
+	TO_DATE(SUSPEND_TIME, 'MM/DD/YY HH24:MI:SS'), TIMEOUT
+FROM USER_RESUMABLE
+WHERE SESSION_ID = :1 AND STATUS = 'SUSPENDED'`, OraS, OraS, OraI64, OraS, OraT, OraI64)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry(sid)
+	if err != nil {
+		return nil, err
+	}
+	for rset.Next() {
+		events = append(events, ResumableEvent{
+			Name:        rset.Row[0].(String).Value,
+			SqlText:     rset.Row[1].(String).Value,
+			ErrorNumber: rset.Row[2].(Int64).Value,
+			ErrorMsg:    rset.Row[3].(String).Value,
+			SuspendTime: rset.Row[4].(Time).Value,
+			Timeout:     time.Duration(rset.Row[5].(Int64).Value) * time.Second,
+		})
+	}
+	return events, rset.Err
+}
@@ -0,0 +1,151 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rowScnColumn is the pseudocolumn of the SCN of the latest change of a row.
+const rowScnColumn = "ORA_ROWSCN"
+
+// rowScnPlaceholder is the placeholder of the condition appended by
+// rowScnCondSql.
+const rowScnPlaceholder = ":ora_rowscn"
+
+// ErrRowChanged is returned by Ses.ExeIfUnchanged when no row has the
+// expected ORA_ROWSCN, because the row was changed or deleted since it was
+// fetched.
+var ErrRowChanged = errNew("row changed since it was fetched")
+
+// rowScnSql returns sql with ORA_ROWSCN appended to the select list, when sql
+// is a query of a single table whose rows ORA_ROWSCN identifies; otherwise ok
+// is false. Queries selecting only *, or using DISTINCT, GROUP BY, set
+// operators or joins, are unchanged.
+func rowScnSql(sql string) (result string, ok bool) {
+	words := topLevelWords(sql)
+	if len(words) < 3 || words[0].text != "SELECT" || (words[1].text == "*" && words[2].text == "FROM") {
+		return sql, false
+	}
+	from := -1
+	for n, word := range words[1:] {
+		switch word.text {
+		case "DISTINCT", "UNIQUE", "GROUP", "UNION", "INTERSECT", "MINUS", "EXCEPT", "JOIN", rowScnColumn:
+			return sql, false
+		case ",":
+			if from >= 0 {
+				return sql, false // more than one table
+			}
+		case "FROM":
+			if from < 0 {
+				from = n + 1
+			}
+		case "WHERE", "START", "CONNECT", "ORDER", "OFFSET", "FETCH", "FOR":
+			if from >= 0 {
+				return insertSelectItem(sql, words[from].pos), true
+			}
+		}
+	}
+	if from < 0 {
+		return sql, false
+	}
+	return insertSelectItem(sql, words[from].pos), true
+}
+
+// insertSelectItem returns sql with ORA_ROWSCN inserted before the FROM
+// keyword at index from.
+func insertSelectItem(sql string, from int) string {
+	return strings.TrimRight(sql[:from], " \t") + ", " + rowScnColumn + " " + sql[from:]
+}
+
+// RowScn returns the ORA_ROWSCN of the current row, the SCN of the latest
+// committed change of the row, when the query selects ORA_ROWSCN; see
+// StmtCfg.RowScn. Pass the SCN to Ses.ExeIfUnchanged to change the row only
+// if no other transaction changed it since.
+func (rset *Rset) RowScn() (scn uint64, ok bool) {
+	names := rset.describedNames
+	if names == nil {
+		names = rset.ColumnNames
+	}
+	for n, name := range names {
+		if n < len(rset.Row) && strings.EqualFold(name, rowScnColumn) {
+			return scnValue(rset.Row[n])
+		}
+	}
+	return 0, false
+}
+
+// scnValue returns the SCN of a fetched NUMBER value.
+func scnValue(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return uint64(v), v >= 0
+	case uint64:
+		return v, true
+	case float64:
+		return uint64(v), v >= 0
+	case Int64:
+		return uint64(v.Value), !v.IsNull && v.Value >= 0
+	case Uint64:
+		return v.Value, !v.IsNull
+	case Float64:
+		return uint64(v.Value), !v.IsNull && v.Value >= 0
+	case string:
+		scn, err := strconv.ParseUint(v, 10, 64)
+		return scn, err == nil
+	}
+	return 0, false
+}
+
+// rowScnCondSql returns sql, an UPDATE or DELETE statement, limited to rows
+// whose ORA_ROWSCN is a placeholder: the top-level WHERE condition is
+// parenthesized and extended, or a WHERE clause is appended.
+func rowScnCondSql(sql string) string {
+	sql = trimSql(sql)
+	i := topLevelWhere(sql)
+	if i < 0 {
+		return sql + "\nWHERE " + rowScnColumn + " = " + rowScnPlaceholder
+	}
+	i += len("WHERE")
+	return sql[:i] + " (" + sql[i:] + "\n) AND " + rowScnColumn + " = " + rowScnPlaceholder
+}
+
+// ExeIfUnchanged executes sql, an UPDATE or DELETE statement whose WHERE
+// clause ends the statement, on the rows whose ORA_ROWSCN is still scn, as
+// returned by Rset.RowScn; params are bound to the placeholders of sql,
+// followed by scn. ErrRowChanged is returned when no row is affected. sql is
+// rewritten by DrvCfg.RewriteSql, and its placeholders converted, before the
+// condition is appended.
+//
+// ORA_ROWSCN is tracked per block unless the table is created with
+// ROWDEPENDENCIES, so a change of another row of the same block also makes a
+// row appear changed.
+func (ses *Ses) ExeIfUnchanged(sql string, scn uint64, params ...interface{}) (rowsAffected uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.ExeIfUnchanged, sql)
+	ses.mu.Lock()
+	err = ses.checkClosed()
+	ses.mu.Unlock()
+	if err != nil {
+		return 0, errE(err)
+	}
+	// rewrite sql as Ses.Prep does before appending the condition
+	sql, order, err := _drv.cfg().convertSql(sql)
+	if err != nil {
+		return 0, errE(err)
+	}
+	if params, err = orderParams(params, order); err != nil {
+		return 0, errE(err)
+	}
+	params = append(params[:len(params):len(params)], scn)
+	rowsAffected, err = ses.exe(rowScnCondSql(sql), params...)
+	if err != nil {
+		return rowsAffected, err
+	}
+	if rowsAffected == 0 {
+		return 0, ErrRowChanged
+	}
+	return rowsAffected, nil
+}
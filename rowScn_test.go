@@ -0,0 +1,54 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestRowScnSql tests the ORA_ROWSCN rewrite of single-table queries.
+func TestRowScnSql(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "SELECT A, B FROM T WHERE C = 'x, y'", want: "SELECT A, B, ORA_ROWSCN FROM T WHERE C = 'x, y'"},
+		{sql: "select t.* from t order by a, b", want: "select t.*, ORA_ROWSCN from t order by a, b"},
+		{sql: "SELECT A -- from\nFROM T", want: "SELECT A -- from\n, ORA_ROWSCN FROM T"},
+		{sql: "SELECT (SELECT MAX(X) FROM U) M FROM T", want: "SELECT (SELECT MAX(X) FROM U) M, ORA_ROWSCN FROM T"},
+		{sql: "SELECT * FROM T"},
+		{sql: "SELECT DISTINCT A FROM T"},
+		{sql: "SELECT A, COUNT(*) FROM T GROUP BY A"},
+		{sql: "SELECT A FROM T, U WHERE T.ID = U.ID"},
+		{sql: "SELECT A FROM T JOIN U USING (ID)"},
+		{sql: "SELECT A FROM T UNION SELECT A FROM U"},
+		{sql: "SELECT A, ORA_ROWSCN FROM T"},
+		{sql: "UPDATE T SET A = 1"},
+	}
+	for _, test := range tests {
+		got, ok := rowScnSql(test.sql)
+		if test.want == "" {
+			if ok || got != test.sql {
+				t.Errorf("%q: got %q, wanted no rewrite", test.sql, got)
+			}
+		} else if !ok || got != test.want {
+			t.Errorf("%q: got %q, wanted %q", test.sql, got, test.want)
+		}
+	}
+}
+
+// TestRowScnCondSql tests the ORA_ROWSCN condition of optimistic updates.
+func TestRowScnCondSql(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "UPDATE T SET A = :1 WHERE ID = :2 OR ID = :3", want: "UPDATE T SET A = :1 WHERE ( ID = :2 OR ID = :3\n) AND ORA_ROWSCN = :ora_rowscn"},
+		{sql: "DELETE FROM T;", want: "DELETE FROM T\nWHERE ORA_ROWSCN = :ora_rowscn"},
+	}
+	for _, test := range tests {
+		if got := rowScnCondSql(test.sql); got != test.want {
+			t.Errorf("%q: got %q, wanted %q", test.sql, got, test.want)
+		}
+	}
+}
@@ -6,12 +6,16 @@ package ora
 
 /*
 #include <oci.h>
+#include "version.h"
 */
 import "C"
 import (
 	"container/list"
+	"context"
 	"fmt"
 	"io"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -46,6 +50,16 @@ type LogRsetCfg struct {
 	//
 	// The default is true.
 	OpenDefs bool
+
+	// ForEachBatch determines whether the Rset.ForEachBatch method is logged.
+	//
+	// The default is true.
+	ForEachBatch bool
+
+	// Spool determines whether the Rset.Spool method is logged.
+	//
+	// The default is true.
+	Spool bool
 }
 
 // NewLogTxCfg creates a LogRsetCfg with default values.
@@ -57,6 +71,8 @@ func NewLogRsetCfg() LogRsetCfg {
 	c.Next = false
 	c.Open = true
 	c.OpenDefs = true
+	c.ForEachBatch = true
+	c.Spool = true
 	return c
 }
 
@@ -64,13 +80,24 @@ func NewLogRsetCfg() LogRsetCfg {
 //
 // Opening and closing a Rset is managed internally. Rset doesn't have an Open
 // method or Close method.
+//
+// Calls to Next are serialized. Enable DrvCfg.RaceDetect to panic on the
+// simultaneous use of a Rset instead.
 type Rset struct {
 	id        uint64
+	mu        sync.Mutex
+	race      raceGuard
+	ctx       context.Context // breaks non-blocking fetches; may be nil
 	stmt      *Stmt
+	cfg       StmtCfg // of the call which opened the Rset
 	ocistmt   *C.OCIStmt
 	defs      []def
 	autoClose bool
 	genByPool bool
+	gen       uint32 // Ses.gen when opened
+
+	describedNames []string // column names before RsetCfg.ColumnName
+	strBuf         []byte   // string values of the fetched row; see packStrings
 
 	Row         []interface{}
 	ColumnNames []string
@@ -91,6 +118,16 @@ func (rset *Rset) checkIsOpen() error {
 	return nil
 }
 
+// checkFresh validates that the Ses of the result set wasn't re-opened after
+// a lost connection since the result set was opened: the cursor of a stale
+// result set was lost with the session, and its handle may be released.
+func (rset *Rset) checkFresh() error {
+	if rset.gen != atomic.LoadUint32(&rset.stmt.ses.gen) {
+		return er("Rset is stale: its session was re-opened after a lost connection.")
+	}
+	return nil
+}
+
 // IsOpen returns true when a result set is open; otherwise, false.
 func (rset *Rset) IsOpen() bool {
 	return rset.stmt != nil
@@ -105,7 +142,7 @@ func (rset *Rset) closeWithRemove() (err error) {
 
 // close releases allocated resources.
 func (rset *Rset) close() (err error) {
-	rset.log(_drv.cfg.Log.Rset.Close)
+	rset.log(_drv.cfg().Log.Rset.Close)
 	defer func() {
 		if value := recover(); value != nil {
 			err = errR(value)
@@ -114,9 +151,12 @@ func (rset *Rset) close() (err error) {
 			_drv.rsetPool.Put(rset)
 		}
 	}()
+	rset.mu.Lock()
+	defer rset.mu.Unlock()
 	if err := rset.checkIsOpen(); err != nil {
 		return err
 	}
+	rset.stmt.ses.leaks.untrack(rset)
 	errs := _drv.listPool.Get().(*list.List)
 	if len(rset.defs) > 0 { // close defines
 		for _, def := range rset.defs {
@@ -129,10 +169,14 @@ func (rset *Rset) close() (err error) {
 		}
 	}
 	rset.stmt = nil
+	rset.cfg = StmtCfg{}
 	rset.ocistmt = nil
+	rset.ctx = nil
 	rset.defs = nil
 	rset.Row = nil
 	rset.ColumnNames = nil
+	rset.describedNames = nil
+	rset.strBuf = rset.strBuf[:0]
 	// do not clear error in case of autoClose when error exists
 	// clear error when rset in initialized
 	//rset.Err = nil
@@ -147,31 +191,43 @@ func (rset *Rset) close() (err error) {
 
 // beginRow allocates a handle for each column and fetches one row.
 func (rset *Rset) beginRow() (err error) {
-	rset.log(_drv.cfg.Log.Rset.BeginRow)
+	rset.log(_drv.cfg().Log.Rset.BeginRow)
 	rset.Index++
 	// check is open
 	if rset.ocistmt == nil {
 		return errF("Rset is closed")
 	}
+	if err := rset.checkFresh(); err != nil {
+		return err
+	}
 	// allocate define descriptor handles
 	for _, define := range rset.defs {
 		//glog.Infof("Rset.define: ", define)
-		rset.logF(_drv.cfg.Log.Rset.BeginRow, "%#v", define)
+		rset.logF(_drv.cfg().Log.Rset.BeginRow, "%#v", define)
 		err := define.alloc()
 		if err != nil {
 			return err
 		}
 	}
 	// fetch one row
-	r := C.OCIStmtFetch2(
-		rset.ocistmt,                 //OCIStmt     *stmthp,
-		rset.stmt.ses.srv.env.ocierr, //OCIError    *errhp,
-		C.ub4(1),                     //ub4         nrows,
-		C.OCI_FETCH_NEXT,             //ub2         orientation,
-		C.sb4(0),                     //sb4         fetchOffset,
-		C.OCI_DEFAULT)                //ub4         mode );
+	fetch := func() C.sword {
+		return C.OCIStmtFetch2(
+			rset.ocistmt,         //OCIStmt     *stmthp,
+			rset.stmt.ses.ocierr, //OCIError    *errhp,
+			C.ub4(1),             //ub4         nrows,
+			C.OCI_FETCH_NEXT,     //ub2         orientation,
+			C.sb4(0),             //sb4         fetchOffset,
+			C.OCI_DEFAULT)        //ub4         mode );
+	}
+	r := rset.stmt.ses.pollOp(rset.ctx, "fetch", fetch)
+	if r == C.OCI_NEED_DATA {
+		// piecewise columns; see RsetCfg.MaxRowSize
+		if r, err = rset.fetchPieces(fetch); err != nil {
+			return err
+		}
+	}
 	if r == C.OCI_ERROR {
-		return rset.stmt.ses.srv.env.ociError()
+		return rset.stmt.ses.ociError()
 	} else if r == C.OCI_NO_DATA {
 		// Adjust Index so that Len() returns correct value when all rows read
 		rset.Index--
@@ -183,7 +239,7 @@ func (rset *Rset) beginRow() (err error) {
 
 // endRow deallocates a handle for each column.
 func (rset *Rset) endRow() {
-	rset.log(_drv.cfg.Log.Rset.EndRow)
+	rset.log(_drv.cfg().Log.Rset.EndRow)
 	for _, define := range rset.defs {
 		define.free()
 	}
@@ -197,14 +253,26 @@ func (rset *Rset) endRow() {
 //
 // When Next returns false check Rset.Err for any error that may have occured.
 func (rset *Rset) Next() bool {
-	rset.log(_drv.cfg.Log.Rset.Next)
+	rset.log(_drv.cfg().Log.Rset.Next)
+	rset.race.enter("Rset", rset, "Next")
+	defer rset.race.leave()
+	stmt, ok := rset.next()
+	if !ok && rset.autoClose && stmt != nil {
+		// closing the Stmt closes the Rset; close without holding rset.mu
+		stmt.Close()
+	}
+	return ok
+}
+
+// next loads a row and returns the Rset's Stmt as it was prior to the call.
+func (rset *Rset) next() (stmt *Stmt, ok bool) {
+	rset.mu.Lock()
+	defer rset.mu.Unlock()
+	stmt = rset.stmt
 	if err := rset.checkIsOpen(); err != nil {
 		rset.Err = err
 		rset.Row = nil
-		if rset.autoClose {
-			rset.stmt.Close()
-		}
-		return false
+		return stmt, false
 	}
 	err := rset.beginRow()
 	defer rset.endRow()
@@ -215,25 +283,20 @@ func (rset *Rset) Next() bool {
 		}
 		rset.Err = err
 		rset.Row = nil
-		if rset.autoClose {
-			rset.stmt.Close()
-		}
-		return false
+		return stmt, false
 	}
+	rset.packStrings()
 	// populate column values
 	for n, define := range rset.defs {
 		value, err := define.value()
 		if err != nil {
 			rset.Err = err
 			rset.Row = nil
-			if rset.autoClose {
-				rset.stmt.Close()
-			}
-			return false
+			return stmt, false
 		}
 		rset.Row[n] = value
 	}
-	return true
+	return stmt, true
 }
 
 // NextRow attempts to load a row from the Oracle buffer and return the row.
@@ -258,22 +321,26 @@ func (rset *Rset) putDef(idx int, def def) {
 // Open defines select-list columns.
 func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 	rset.stmt = stmt
+	rset.cfg = stmt.cfg
 	rset.ocistmt = ocistmt
+	rset.gen = atomic.LoadUint32(&stmt.ses.gen)
 	rset.Index = -1
 	rset.Err = nil
-	rset.log(_drv.cfg.Log.Rset.Open) // call log after rset.stmt is set
+	rset.log(_drv.cfg().Log.Rset.Open) // call log after rset.stmt is set
 	// get the implcit select-list describe information; no server round-trip
-	r := C.OCIStmtExecute(
-		rset.stmt.ses.ocisvcctx,      //OCISvcCtx           *svchp,
-		rset.ocistmt,                 //OCIStmt             *stmtp,
-		rset.stmt.ses.srv.env.ocierr, //OCIError            *errhp,
-		C.ub4(1),                     //ub4                 iters,
-		C.ub4(0),                     //ub4                 rowoff,
-		nil,                          //const OCISnapshot   *snap_in,
-		nil,                          //OCISnapshot         *snap_out,
-		C.OCI_DESCRIBE_ONLY)          //ub4                 mode );
+	r := rset.stmt.ses.pollOp(rset.ctx, "describe", func() C.sword {
+		return C.OCIStmtExecute(
+			rset.stmt.ses.ocisvcctx, //OCISvcCtx           *svchp,
+			rset.ocistmt,            //OCIStmt             *stmtp,
+			rset.stmt.ses.ocierr,    //OCIError            *errhp,
+			C.ub4(1),                //ub4                 iters,
+			C.ub4(0),                //ub4                 rowoff,
+			nil,                     //const OCISnapshot   *snap_in,
+			nil,                     //OCISnapshot         *snap_out,
+			C.OCI_DESCRIBE_ONLY)     //ub4                 mode );
+	})
 	if r == C.OCI_ERROR {
-		return rset.stmt.ses.srv.env.ociError()
+		return rset.stmt.ses.ociError()
 	}
 	// get the parameter count
 	var paramCount C.ub4
@@ -288,60 +355,53 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 	//fmt.Printf("rset.open (paramCount %v)\n", paramCount)
 
 	// create parameters for each select-list column
+	// get the describe attributes of every column in one cgo call
+	cols, err := rset.stmt.ses.describeCols(rset.ocistmt, int(paramCount))
+	if err != nil {
+		return err
+	}
 	var gct GoColumnType
 	for n := range rset.defs {
-		// Create oci parameter handle; may be freed by OCIDescriptorFree()
-		// parameter position is 1-based
-		var ocipar *C.OCIParam
-		r := C.OCIParamGet(
-			unsafe.Pointer(rset.ocistmt),               //const void        *hndlp,
-			C.OCI_HTYPE_STMT,                           //ub4               htype,
-			rset.stmt.ses.srv.env.ocierr,               //OCIError          *errhp,
-			(*unsafe.Pointer)(unsafe.Pointer(&ocipar)), //void              **parmdpp,
-			C.ub4(n+1))                                 //ub4               pos );
-		if r == C.OCI_ERROR {
-			return rset.stmt.ses.srv.env.ociError()
-		}
-		// Get column size in bytes
-		var columnSize uint32
-		err = rset.paramAttr(ocipar, unsafe.Pointer(&columnSize), 0, C.OCI_ATTR_DATA_SIZE)
-		if err != nil {
-			return err
-		}
-		// Get oci data type code
-		var ociTypeCode C.ub2
-		err = rset.paramAttr(ocipar, unsafe.Pointer(&ociTypeCode), 0, C.OCI_ATTR_DATA_TYPE)
-		if err != nil {
-			return err
-		}
-		// Get column name
-		var columnName *C.char
-		err := rset.paramAttr(ocipar, unsafe.Pointer(&columnName), 0, C.OCI_ATTR_NAME)
-		if err != nil {
-			return err
-		}
-		rset.ColumnNames[n] = C.GoString(columnName)
+		col := &cols[n]
+		columnSize := col.dataSize
+		ociTypeCode := col.dataType
+		rset.ColumnNames[n] = col.name
 		//fmt.Printf("Rset.open: ociTypeCode (%v)\n", ociTypeCode)
 		//Log.Infof("Rset.open: ociTypeCode=%d name=%s size=%d", ociTypeCode, rset.ColumnNames[n], columnSize)
 		//log(true, "ociTypeCode=", int(ociTypeCode), ", name=", rset.ColumnNames[n], ", size=", columnSize)
-		rset.logF(_drv.cfg.Log.Rset.OpenDefs, "%d. %s/%d", n+1, rset.ColumnNames[n], ociTypeCode)
+		rset.logF(_drv.cfg().Log.Rset.OpenDefs, "%d. %s/%d", n+1, rset.ColumnNames[n], ociTypeCode)
+		if conv, ok := rset.cfg.Rset.boolCol(rset.ColumnNames[n]); ok {
+			switch ociTypeCode {
+			case C.SQLT_NUM, C.SQLT_AFC, C.SQLT_CHR:
+				gct = B
+				if stmt.gcts != nil && n < len(stmt.gcts) && stmt.gcts[n] == OraB {
+					gct = OraB
+				}
+				if ociTypeCode == C.SQLT_NUM {
+					columnSize = numberBoolSize
+				}
+				err = rset.defineBool(n, columnSize, gct, &conv)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
 		switch ociTypeCode {
 		case C.SQLT_NUM:
 			// NUMBER
-			// Get precision
-			var precision C.sb2
-			err = rset.paramAttr(ocipar, unsafe.Pointer(&precision), 0, C.OCI_ATTR_PRECISION)
-			if err != nil {
-				return err
-			}
-			// Get scale (the number of decimal places)
-			var scale C.sb1
-			err = rset.paramAttr(ocipar, unsafe.Pointer(&scale), 0, C.OCI_ATTR_SCALE)
-			if err != nil {
-				return err
+			if stmt.gcts != nil && n < len(stmt.gcts) && (stmt.gcts[n] == B || stmt.gcts[n] == OraB) {
+				// Interpret NUMBER(1) as bool, compared to RsetCfg.TrueRune
+				err = rset.defineBool(n, numberBoolSize, stmt.gcts[n], nil)
+				if err != nil {
+					return err
+				}
+				break
 			}
+			// precision and scale (the number of decimal places)
+			precision, scale := col.precision, col.scale
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.numericColumnType(int(precision), int(scale))
+				gct = rset.cfg.Rset.numericColumnType(int(precision), int(scale))
 			} else {
 				err = checkNumericColumn(stmt.gcts[n], rset.ColumnNames[n])
 				if err != nil {
@@ -349,15 +409,15 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 				}
 				gct = stmt.gcts[n]
 			}
-			rset.logF(_drv.cfg.Log.Rset.OpenDefs, "%d. prec=%d scale=%d => gct=%s", n+1, precision, scale, GctName(gct))
-			err := rset.defineNumeric(n, gct)
+			rset.logF(_drv.cfg().Log.Rset.OpenDefs, "%d. prec=%d scale=%d => gct=%s", n+1, precision, scale, GctName(gct))
+			err := rset.defineNumeric(n, gct, false)
 			if err != nil {
 				return err
 			}
 		case C.SQLT_IBDOUBLE:
 			// BINARY_DOUBLE
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.binaryDouble
+				gct = rset.cfg.Rset.binaryDouble
 			} else {
 				err = checkNumericColumn(stmt.gcts[n], rset.ColumnNames[n])
 				if err != nil {
@@ -365,14 +425,14 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 				}
 				gct = stmt.gcts[n]
 			}
-			err := rset.defineNumeric(n, gct)
+			err := rset.defineNumeric(n, gct, true)
 			if err != nil {
 				return err
 			}
 		case C.SQLT_IBFLOAT:
 			// BINARY_FLOAT
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.binaryFloat
+				gct = rset.cfg.Rset.binaryFloat
 			} else {
 				err = checkNumericColumn(stmt.gcts[n], rset.ColumnNames[n])
 				if err != nil {
@@ -380,7 +440,7 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 				}
 				gct = stmt.gcts[n]
 			}
-			err := rset.defineNumeric(n, gct)
+			err := rset.defineNumeric(n, gct, true)
 			if err != nil {
 				return err
 			}
@@ -389,13 +449,13 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
 				switch ociTypeCode {
 				case C.SQLT_DAT:
-					gct = rset.stmt.cfg.Rset.date
+					gct = rset.cfg.Rset.date
 				case C.SQLT_TIMESTAMP:
-					gct = rset.stmt.cfg.Rset.timestamp
+					gct = rset.cfg.Rset.timestamp
 				case C.SQLT_TIMESTAMP_TZ:
-					gct = rset.stmt.cfg.Rset.timestampTz
+					gct = rset.cfg.Rset.timestampTz
 				case C.SQLT_TIMESTAMP_LTZ:
-					gct = rset.stmt.cfg.Rset.timestampLtz
+					gct = rset.cfg.Rset.timestampLtz
 				}
 			} else {
 				err = checkTimeColumn(stmt.gcts[n])
@@ -410,6 +470,9 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			}
 			def := rset.getDef(defIdxTime).(*defTime)
 			rset.defs[n] = def
+			if ociTypeCode == C.SQLT_DAT || ociTypeCode == C.SQLT_TIMESTAMP {
+				def.location = rset.cfg.Rset.timeLocation(rset.ColumnNames[n])
+			}
 			err = def.define(n+1, isNullable, rset)
 			if err != nil {
 				return err
@@ -417,7 +480,7 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 		case C.SQLT_CHR:
 			// VARCHAR, VARCHAR2, NVARCHAR2
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.varchar
+				gct = rset.cfg.Rset.varchar
 			} else {
 				err = checkStringColumn(stmt.gcts[n])
 				if err != nil {
@@ -425,17 +488,19 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 				}
 				gct = stmt.gcts[n]
 			}
-			err = rset.defineString(n, columnSize, gct)
+			size := rset.charDefineSize(col)
+			err = rset.defineString(n, size, gct)
 			if err != nil {
 				return err
 			}
 		case C.SQLT_AFC:
 			//Log.Infof("rset AFC size=%d gct=%v", columnSize, gct)
 			// CHAR, NCHAR
+			size := rset.charDefineSize(col)
 			// for char(1 char) columns, columnSize is 4 (AL32UTF8 charset)
 			if columnSize == 1 || columnSize == 4 {
 				if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-					gct = rset.stmt.cfg.Rset.char1
+					gct = rset.cfg.Rset.char1
 				} else {
 					err = checkBoolOrStringColumn(stmt.gcts[n])
 					if err != nil {
@@ -456,14 +521,14 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 					if err != nil {
 						return err
 					}
-				case S, OraS:
+				case S, OraS, SBorrow:
 					// Interpret single char as string
-					rset.defineString(n, columnSize, gct)
+					rset.defineString(n, size, gct)
 				}
 			} else {
 				// Interpret as string
 				if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-					gct = rset.stmt.cfg.Rset.char
+					gct = rset.cfg.Rset.char
 				} else {
 					err = checkStringColumn(stmt.gcts[n])
 					if err != nil {
@@ -471,7 +536,7 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 					}
 					gct = stmt.gcts[n]
 				}
-				err = rset.defineString(n, columnSize, gct)
+				err = rset.defineString(n, size, gct)
 				if err != nil {
 					return err
 				}
@@ -479,7 +544,7 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 		case C.SQLT_LNG:
 			// LONG
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.long
+				gct = rset.cfg.Rset.long
 			} else {
 				err = checkStringColumn(stmt.gcts[n])
 				if err != nil {
@@ -489,39 +554,33 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			}
 
 			// longBufferSize: Use a moderate default buffer size; 2GB max buffer may not be feasible on all clients
-			err = rset.defineString(n, stmt.cfg.longBufferSize, gct)
+			err = rset.defineString(n, rset.cfg.longBufferSize, gct)
 			if err != nil {
 				return err
 			}
 		case C.SQLT_CLOB:
 			// CLOB, NCLOB
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.clob
+				gct = rset.cfg.Rset.clob
 			} else {
-				err = checkStringColumn(stmt.gcts[n])
+				err = checkLobColumn(stmt.gcts[n], checkStringColumn)
 				if err != nil {
 					return err
 				}
 				gct = stmt.gcts[n]
 			}
-			// Get character set form
-			var charsetForm C.ub1
-			err = rset.paramAttr(ocipar, unsafe.Pointer(&charsetForm), 0, C.OCI_ATTR_CHARSET_FORM)
-			if err != nil {
-				return err
-			}
 			def := rset.getDef(defIdxLob).(*defLob)
 			rset.defs[n] = def
-			err = def.define(n+1, charsetForm, C.SQLT_CLOB, gct, rset)
+			err = def.define(n+1, col.charsetForm, C.SQLT_CLOB, gct, rset)
 			if err != nil {
 				return err
 			}
 		case C.SQLT_BLOB:
 			// BLOB
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.blob
+				gct = rset.cfg.Rset.blob
 			} else {
-				err = checkBinColumn(stmt.gcts[n])
+				err = checkLobColumn(stmt.gcts[n], checkBinColumn)
 				if err != nil {
 					return err
 				}
@@ -533,12 +592,28 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			if err != nil {
 				return err
 			}
+		case C.SQLT_JSON:
+			// JSON, fetched as its textual form
+			gct = JSON
+			if stmt.gcts != nil && n < len(stmt.gcts) && stmt.gcts[n] != D {
+				err = checkJSONColumn(stmt.gcts[n])
+				if err != nil {
+					return err
+				}
+				gct = stmt.gcts[n]
+			}
+			def := rset.getDef(defIdxLob).(*defLob)
+			rset.defs[n] = def
+			err = def.define(n+1, C.SQLCS_IMPLICIT, C.SQLT_CLOB, gct, rset)
+			if err != nil {
+				return err
+			}
 		case C.SQLT_BIN:
 			// RAW
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.raw
+				gct = rset.cfg.Rset.raw
 			} else {
-				err = checkBinColumn(stmt.gcts[n])
+				err = checkUUIDColumn(stmt.gcts[n], int(columnSize))
 				if err != nil {
 					return err
 				}
@@ -554,11 +629,12 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			if err != nil {
 				return err
 			}
+			def.uuid = gct == OraUUID
 		case C.SQLT_LBI:
 			//log(true, "LONG RAW")
 			// LONG RAW
 			if stmt.gcts == nil || n >= len(stmt.gcts) || stmt.gcts[n] == D {
-				gct = rset.stmt.cfg.Rset.longRaw
+				gct = rset.cfg.Rset.longRaw
 			} else {
 				err = checkBinColumn(stmt.gcts[n])
 				if err != nil {
@@ -572,7 +648,7 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			}
 			def := rset.getDef(defIdxLongRaw).(*defLongRaw)
 			rset.defs[n] = def
-			err = def.define(n+1, rset.stmt.cfg.longRawBufferSize, isNullable, rset)
+			err = def.define(n+1, rset.cfg.longRawBufferSize, isNullable, rset)
 			if err != nil {
 				return err
 			}
@@ -611,22 +687,75 @@ func (rset *Rset) open(stmt *Stmt, ocistmt *C.OCIStmt) error {
 			return errF("unsupported select-list column type (ociTypeCode: %v)", ociTypeCode)
 		}
 	}
-	rset.logF(_drv.cfg.Log.Rset.OpenDefs, "%#v", rset.defs)
+	if err = rset.capRowSize(); err != nil {
+		return err
+	}
+	if err = rset.sizeFetchArray(); err != nil {
+		return err
+	}
+	rset.describedNames = rset.ColumnNames
+	if rename := rset.cfg.Rset.ColumnName; rename != nil {
+		rset.ColumnNames = make([]string, len(rset.describedNames))
+		for n, name := range rset.describedNames {
+			rset.ColumnNames[n] = rename(name)
+		}
+	}
+	if rset.ColumnNames, err = dedupColumns(rset.ColumnNames, rset.cfg.Rset.DupColumns, rset.cfg.Rset.DupColumnsIgnoreCase); err != nil {
+		return err
+	}
+	rset.logF(_drv.cfg().Log.Rset.OpenDefs, "%#v", rset.defs)
 	return nil
 }
 
+// defineBool defines a bool column interpreted with conv, or with
+// RsetCfg.TrueRune when conv is nil.
+func (rset *Rset) defineBool(n int, columnSize uint32, gct GoColumnType, conv *BoolConvention) (err error) {
+	def := rset.getDef(defIdxBool).(*defBool)
+	rset.defs[n] = def
+	def.conv = conv
+	err = def.define(n+1, int(columnSize), gct == OraB, rset)
+	return err
+}
+
+// charDefineSize returns the define buffer size of the character column col.
+// No locking occurs.
+func (rset *Rset) charDefineSize(col *colAttrs) uint32 {
+	if !col.charUsed {
+		return col.dataSize
+	}
+	return charDefineSize(col.dataSize, col.charSize, rset.cfg.Rset.MaxBytesPerChar)
+}
+
+// charDefineSize returns the larger of the byte length columnSize and the
+// character length charSize of a CHAR-semantics column times maxBytesPerChar.
+// The byte length is described in the database character set, which may be
+// narrower than the client character set.
+func charDefineSize(columnSize, charSize uint32, maxBytesPerChar int) uint32 {
+	if maxBytesPerChar <= 0 {
+		return columnSize
+	}
+	if size := charSize * uint32(maxBytesPerChar); size > columnSize {
+		return size
+	}
+	return columnSize
+}
+
 func (rset *Rset) defineString(n int, columnSize uint32, gct GoColumnType) (err error) {
 	isNullable := false
-	if gct == OraS {
+	if gct == OraS || rset.cfg.NullString == NullStringOra {
 		isNullable = true
 	}
 	def := rset.getDef(defIdxString).(*defString)
 	rset.defs[n] = def
+	def.borrow = gct == SBorrow
 	err = def.define(n+1, int(columnSize), isNullable, rset)
 	return err
 }
 
-func (rset *Rset) defineNumeric(n int, gct GoColumnType) (err error) {
+// defineNumeric defines a numeric column. When native is true the column is a
+// BINARY_DOUBLE or BINARY_FLOAT, and float Go types are defined without an
+// OCINumber conversion.
+func (rset *Rset) defineNumeric(n int, gct GoColumnType, native bool) (err error) {
 	switch gct {
 	case I64:
 		def := rset.getDef(defIdxInt64).(*defInt64)
@@ -663,11 +792,11 @@ func (rset *Rset) defineNumeric(n int, gct GoColumnType) (err error) {
 	case F64:
 		def := rset.getDef(defIdxFloat64).(*defFloat64)
 		rset.defs[n] = def
-		err = def.define(n+1, false, rset)
+		err = def.define(n+1, false, native, rset)
 	case F32:
 		def := rset.getDef(defIdxFloat32).(*defFloat32)
 		rset.defs[n] = def
-		err = def.define(n+1, false, rset)
+		err = def.define(n+1, false, native, rset)
 	case OraI64:
 		def := rset.getDef(defIdxInt64).(*defInt64)
 		rset.defs[n] = def
@@ -703,30 +832,15 @@ func (rset *Rset) defineNumeric(n int, gct GoColumnType) (err error) {
 	case OraF64:
 		def := rset.getDef(defIdxFloat64).(*defFloat64)
 		rset.defs[n] = def
-		err = def.define(n+1, true, rset)
+		err = def.define(n+1, true, native, rset)
 	case OraF32:
 		def := rset.getDef(defIdxFloat32).(*defFloat32)
 		rset.defs[n] = def
-		err = def.define(n+1, true, rset)
+		err = def.define(n+1, true, native, rset)
 	}
 	return err
 }
 
-// paramAttr gets an attribute from the parameter handle.
-func (rset *Rset) paramAttr(ocipar *C.OCIParam, attrup unsafe.Pointer, attrSize C.ub4, attrType C.ub4) error {
-	r := C.OCIAttrGet(
-		unsafe.Pointer(ocipar),       //const void     *trgthndlp,
-		C.OCI_DTYPE_PARAM,            //ub4            trghndltyp,
-		attrup,                       //void           *attributep,
-		&attrSize,                    //ub4            *sizep,
-		attrType,                     //ub4            attrtype,
-		rset.stmt.ses.srv.env.ocierr) //OCIError       *errhp );
-	if r == C.OCI_ERROR {
-		return rset.stmt.ses.srv.env.ociError()
-	}
-	return nil
-}
-
 // attr gets an attribute from the statement handle.
 func (rset *Rset) attr(attrup unsafe.Pointer, attrSize C.ub4, attrType C.ub4) error {
 	r := C.OCIAttrGet(
@@ -735,9 +849,9 @@ func (rset *Rset) attr(attrup unsafe.Pointer, attrSize C.ub4, attrType C.ub4) er
 		attrup,                       //void           *attributep,
 		&attrSize,                    //ub4            *sizep,
 		attrType,                     //ub4            attrtype,
-		rset.stmt.ses.srv.env.ocierr) //OCIError       *errhp );
+		rset.stmt.ses.ocierr)         //OCIError       *errhp );
 	if r == C.OCI_ERROR {
-		return rset.stmt.ses.srv.env.ociError()
+		return rset.stmt.ses.ociError()
 	}
 	return nil
 }
@@ -751,9 +865,9 @@ func (rset *Rset) sysName() string {
 func (rset *Rset) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", rset.sysName(), callInfo(1))
+			lgr.Infof("%v %v", rset.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", rset.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", rset.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -762,9 +876,9 @@ func (rset *Rset) log(enabled bool, v ...interface{}) {
 func (rset *Rset) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", rset.sysName(), callInfo(1))
+			lgr.Infof("%v %v", rset.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", rset.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", rset.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
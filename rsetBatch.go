@@ -0,0 +1,82 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"context"
+	"unsafe"
+)
+
+// ForEachBatch calls fn with each batch of up to n of the remaining rows of
+// the Rset. The rows of a batch are copies and may be retained by fn.
+//
+// ForEachBatch tunes prefetching rather than array fetching: it sets the
+// prefetch row count of the Rset to n, so that OCI fetches the rows from the
+// server up to n rows per round trip, within the prefetch memory size of the
+// StmtCfg, while the rows are defined one at a time as by Rset.Next.
+//
+// ForEachBatch stops early when fn returns an error or ctx is done, closing
+// the Rset; a fetch in flight when ctx is done is interrupted with Ses.Break.
+// The error of fn, the error of ctx, or a fetch error is returned.
+func (rset *Rset) ForEachBatch(ctx context.Context, n int, fn func(batch [][]interface{}) error) (err error) {
+	rset.log(_drv.cfg().Log.Rset.ForEachBatch)
+	if n <= 0 {
+		return er("Parameter 'n' must be greater than zero.")
+	}
+	rset.mu.Lock()
+	err = rset.checkIsOpen()
+	if err == nil {
+		err = rset.checkFresh()
+	}
+	if err == nil {
+		rows := C.ub4(n)
+		err = rset.stmt.ses.setAttr(unsafe.Pointer(rset.ocistmt), C.OCI_HTYPE_STMT, unsafe.Pointer(&rows), 4, C.OCI_ATTR_PREFETCH_ROWS)
+		rset.ctx = ctx
+	}
+	stmt := rset.stmt
+	rset.mu.Unlock()
+	if err != nil {
+		return errE(err)
+	}
+	// break a fetch in flight when ctx is done; wait for the watcher so that
+	// a late Break can't interrupt a call following ForEachBatch
+	defer stmt.ses.breakOnDone(ctx)()
+	batch := make([][]interface{}, 0, n)
+	for {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if !rset.Next() {
+			if err = ctx.Err(); err == nil {
+				err = rset.Err
+			}
+			if err == nil && len(batch) > 0 {
+				err = fn(batch)
+			}
+			break
+		}
+		row := make([]interface{}, len(rset.Row))
+		copy(row, rset.Row)
+		batch = append(batch, row)
+		if len(batch) == n {
+			if err = fn(batch); err != nil {
+				break
+			}
+			batch = make([][]interface{}, 0, n)
+		}
+	}
+	if rset.IsOpen() {
+		if rset.autoClose {
+			stmt.Close()
+		} else {
+			rset.closeWithRemove()
+		}
+	}
+	return err
+}
@@ -4,6 +4,8 @@
 
 package ora
 
+import "time"
+
 // RsetCfg affects the association of Oracle select-list columns to
 // Go types.
 type RsetCfg struct {
@@ -29,6 +31,89 @@ type RsetCfg struct {
 	//
 	// The is default is '1'.
 	TrueRune rune
+
+	// NumberOverflow determines the value of a NUMBER which doesn't fit the
+	// integer GoColumnType of its column.
+	//
+	// The default is OverflowError.
+	NumberOverflow NumberOverflow
+
+	// BoolCols are select-list columns, keyed by name, which are fetched as B,
+	// or as OraB when specified to Ses.Prep, with the given BoolConvention.
+	// NUMBER, CHAR and VARCHAR2 columns may be listed. A column value other
+	// than the convention's False and True values is an error.
+	//
+	// The default is nil.
+	BoolCols map[string]BoolConvention
+
+	// TimeLocation is the location of the values of DATE and TIMESTAMP
+	// columns, which hold no time zone: the fetched date and time is
+	// returned in TimeLocation. When TimeLocation is nil, values are returned
+	// in the session time zone reported by OCI, or in time.Local. TIMESTAMP
+	// WITH TIME ZONE and TIMESTAMP WITH LOCAL TIME ZONE values are unaffected.
+	//
+	// The default is nil.
+	TimeLocation *time.Location
+
+	// TimeLocationCols are DATE and TIMESTAMP select-list columns, keyed by
+	// name, whose values are returned in the given location, overriding
+	// TimeLocation.
+	//
+	// The default is nil.
+	TimeLocationCols map[string]*time.Location
+
+	// ColumnName renames the select-list columns exposed by Rset.ColumnNames
+	// and the Columns method of database/sql Rows, for example to convert the
+	// snake_case names of a schema to the CamelCase of Go struct fields; see
+	// SnakeToCamel. Column names configured in the RsetCfg, such as the keys
+	// of BoolCols, are the names before renaming. A nil ColumnName keeps the
+	// described names.
+	//
+	// The default is nil.
+	ColumnName func(name string) string
+
+	// DupColumns determines the handling of duplicate column names, after
+	// renaming with ColumnName.
+	//
+	// The default is DupColumnsKeep.
+	DupColumns DupColumns
+
+	// DupColumnsIgnoreCase determines whether column names differing only in
+	// case, such as the quoted identifiers "id" and "ID", are duplicates for
+	// DupColumns, as for case-insensitive struct mapping.
+	//
+	// The default is false.
+	DupColumnsIgnoreCase bool
+
+	// MaxBytesPerChar is the maximum number of bytes of a character in the
+	// client character set. The define buffers of VARCHAR2, NVARCHAR2, CHAR and
+	// NCHAR columns with character length semantics hold the character length
+	// of the column times MaxBytesPerChar, preventing ORA-01406 truncation of
+	// multi-byte values. Zero sizes define buffers by the described byte length.
+	//
+	// The default is 4, the maximum of AL32UTF8.
+	MaxBytesPerChar int
+
+	// MaxRowSize is the largest sum in bytes of the define buffers of the
+	// character and binary columns of a row. When a query exceeds it, its
+	// largest VARCHAR2, CHAR, LONG, RAW and LONG RAW columns are fetched
+	// piecewise until the row fits, keeping the memory of very wide rows
+	// bounded. A column fetched piecewise is buffered in pieces of an eighth
+	// of its define buffer, from 512 bytes to 64 KiB. Zero disables the cap.
+	//
+	// The default is 0.
+	MaxRowSize int
+
+	// MaxFetchMemory is the memory budget in bytes of the rows prefetched per
+	// round trip. When set, the number of rows prefetched is sized from the
+	// described row width, so that wide rows are prefetched a few at a time
+	// and narrow rows up to 10,000 at a time. A prefetch row count set with
+	// StmtCfg.SetPrefetchRowCount is an upper bound. The sizing applies from
+	// the first fetch following Stmt.Qry. Zero keeps the prefetch settings
+	// of the StmtCfg.
+	//
+	// The default is 0.
+	MaxFetchMemory int
 }
 
 // NewRsetCfg returns a RsetCfg with default values.
@@ -53,6 +138,11 @@ func NewRsetCfg() RsetCfg {
 	c.longRaw = Bin
 
 	c.TrueRune = '1'
+	c.NumberOverflow = OverflowError
+	c.MaxBytesPerChar = 4
+	c.TimeLocation = nil
+	c.DupColumns = DupColumnsKeep
+	c.DupColumnsIgnoreCase = false
 	return c
 }
 
@@ -430,11 +520,11 @@ func (c *RsetCfg) Long() GoColumnType {
 // SetClob sets a GoColumnType associated to an Oracle select-list
 // CLOB column and NCLOB column.
 //
-// Valid values are S and OraS.
+// Valid values are S, OraS and OraLobD.
 //
 // Returns an error if a non-string GoColumnType is specified.
 func (c *RsetCfg) SetClob(gct GoColumnType) (err error) {
-	err = checkStringColumn(gct)
+	err = checkLobColumn(gct, checkStringColumn)
 	if err == nil {
 		c.clob = gct
 	}
@@ -458,11 +548,11 @@ func (c *RsetCfg) Clob() GoColumnType {
 // SetBlob sets a GoColumnType associated to an Oracle select-list
 // BLOB column.
 //
-// Valid values are Bits and OraBits.
+// Valid values are Bits, OraBits and OraLobD.
 //
 // Returns an error if a non-string GoColumnType is specified.
 func (c *RsetCfg) SetBlob(gct GoColumnType) (err error) {
-	err = checkBinColumn(gct)
+	err = checkLobColumn(gct, checkBinColumn)
 	if err == nil {
 		c.blob = gct
 	}
@@ -0,0 +1,69 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCharDefineSize tests charDefineSize.
+func TestCharDefineSize(t *testing.T) {
+	for _, tc := range []struct {
+		columnSize, charSize uint32
+		maxBytesPerChar      int
+		want                 uint32
+	}{
+		{10, 10, 4, 40}, // VARCHAR2(10 CHAR) of a single-byte database
+		{40, 10, 4, 40}, // VARCHAR2(10 CHAR) of an AL32UTF8 database
+		{30, 10, 2, 30}, // narrower client character set
+		{10, 10, 0, 10}, // correction disabled
+		{4000, 1000, 4, 4000},
+	} {
+		if got := charDefineSize(tc.columnSize, tc.charSize, tc.maxBytesPerChar); got != tc.want {
+			t.Errorf("%d/%d*%d: got %d, wanted %d", tc.columnSize, tc.charSize, tc.maxBytesPerChar, got, tc.want)
+		}
+	}
+}
+
+// TestTimeLocation tests RsetCfg.timeLocation and inLocation.
+func TestTimeLocation(t *testing.T) {
+	utc, tokyo := time.UTC, time.FixedZone("JST", 9*60*60)
+	c := RsetCfg{TimeLocation: utc, TimeLocationCols: map[string]*time.Location{"CREATED": tokyo}}
+	for _, tc := range []struct {
+		name string
+		want *time.Location
+	}{
+		{"CREATED", tokyo},
+		{"created", tokyo},
+		{"UPDATED", utc},
+	} {
+		if got := c.timeLocation(tc.name); got != tc.want {
+			t.Errorf("%v: got %v, wanted %v", tc.name, got, tc.want)
+		}
+	}
+	if got := (&RsetCfg{}).timeLocation("CREATED"); got != nil {
+		t.Errorf("unset: got %v, wanted nil", got)
+	}
+	fetched := time.Date(2016, 3, 4, 5, 6, 7, 8, time.Local)
+	got := inLocation(fetched, tokyo)
+	if want := time.Date(2016, 3, 4, 5, 6, 7, 8, tokyo); !got.Equal(want) || got.Location() != tokyo {
+		t.Errorf("inLocation: got %v, wanted %v", got, want)
+	}
+}
+
+// TestRset_checkFresh tests that an Rset opened before its Ses was re-opened
+// is stale.
+func TestRset_checkFresh(t *testing.T) {
+	ses := &Ses{gen: 3}
+	rset := &Rset{stmt: &Stmt{ses: ses}, gen: 3}
+	if err := rset.checkFresh(); err != nil {
+		t.Errorf("got %v for a fresh Rset", err)
+	}
+	ses.gen++ // re-opened by Srv.reconnect
+	if err := rset.checkFresh(); err == nil {
+		t.Error("got no error for a stale Rset")
+	}
+}
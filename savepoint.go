@@ -0,0 +1,112 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Savepointer is implemented by *Tx; a type wrapping a Tx can implement it
+// by delegation so that partial rollbacks work through the wrapper.
+type Savepointer interface {
+	Savepoint(name string) error
+	RollbackTo(name string) error
+	ReleaseSavepoint(name string) error
+}
+
+// savepointName matches a nonquoted Oracle identifier.
+var savepointName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_$#]{0,127}$`)
+
+// checkSavepoint returns the upper-cased name, or an error when name isn't
+// a nonquoted identifier.
+func checkSavepoint(name string) (string, error) {
+	if !savepointName.MatchString(name) {
+		return "", errF("Savepoint name %q isn't a nonquoted identifier of letters, digits, _, $ and #, starting with a letter.", name)
+	}
+	return strings.ToUpper(name), nil
+}
+
+// savepointIndex returns the index of name in the savepoints of the Tx, or
+// -1. The caller holds Tx.mu.
+func (tx *Tx) savepointIndex(name string) int {
+	for n := len(tx.savepoints) - 1; n >= 0; n-- {
+		if tx.savepoints[n] == name {
+			return n
+		}
+	}
+	return -1
+}
+
+// Savepoint marks a savepoint named name, a nonquoted identifier, in the
+// transaction. A savepoint of the same name is replaced.
+func (tx *Tx) Savepoint(name string) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if err := tx.checkIsOpen(); err != nil {
+		return errE(err)
+	}
+	tx.log(_drv.cfg().Log.Tx.Savepoint, name)
+	name, err := checkSavepoint(name)
+	if err != nil {
+		return errE(err)
+	}
+	if _, err = tx.ses.exe("SAVEPOINT " + name); err != nil {
+		return errE(err)
+	}
+	if n := tx.savepointIndex(name); n >= 0 {
+		tx.savepoints = append(tx.savepoints[:n], tx.savepoints[n+1:]...)
+	}
+	tx.savepoints = append(tx.savepoints, name)
+	return nil
+}
+
+// RollbackTo rolls back the changes made since the savepoint name was
+// marked, keeping the savepoint and erasing the savepoints marked after it.
+// The transaction continues.
+func (tx *Tx) RollbackTo(name string) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if err := tx.checkIsOpen(); err != nil {
+		return errE(err)
+	}
+	tx.log(_drv.cfg().Log.Tx.RollbackTo, name)
+	name, err := checkSavepoint(name)
+	if err != nil {
+		return errE(err)
+	}
+	n := tx.savepointIndex(name)
+	if n < 0 {
+		return errF("Savepoint %v isn't marked in the Tx.", name)
+	}
+	if _, err = tx.ses.exe("ROLLBACK TO SAVEPOINT " + name); err != nil {
+		return errE(err)
+	}
+	tx.savepoints = tx.savepoints[:n+1]
+	return nil
+}
+
+// ReleaseSavepoint forgets the savepoint name and the savepoints marked
+// after it, keeping their changes in the transaction. Oracle has no RELEASE
+// SAVEPOINT, so no round trip occurs; the server keeps the savepoints until
+// the transaction ends, but RollbackTo rejects them.
+func (tx *Tx) ReleaseSavepoint(name string) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if err := tx.checkIsOpen(); err != nil {
+		return errE(err)
+	}
+	tx.log(_drv.cfg().Log.Tx.ReleaseSavepoint, name)
+	name, err := checkSavepoint(name)
+	if err != nil {
+		return errE(err)
+	}
+	n := tx.savepointIndex(name)
+	if n < 0 {
+		return errF("Savepoint %v isn't marked in the Tx.", name)
+	}
+	tx.savepoints = tx.savepoints[:n]
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheckSavepoint tests the validation of savepoint names.
+func TestCheckSavepoint(t *testing.T) {
+	for _, name := range []string{"a", "before_insert", "SP$1", "sp#2", strings.Repeat("X", 128)} {
+		got, err := checkSavepoint(name)
+		if err != nil {
+			t.Errorf("%q: %v", name, err)
+		} else if got != strings.ToUpper(name) {
+			t.Errorf("%q: got %q", name, got)
+		}
+	}
+	for _, name := range []string{"", "1sp", "_sp", "sp name", "sp;DROP TABLE T", `"sp"`, strings.Repeat("X", 129)} {
+		if _, err := checkSavepoint(name); err == nil {
+			t.Errorf("%q: wanted an error", name)
+		}
+	}
+}
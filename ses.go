@@ -12,16 +12,113 @@ import "C"
 import (
 	"bytes"
 	"container/list"
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 type SesCfg struct {
+	// Username and Password are the database credentials of the Ses. When
+	// both are empty the Ses is opened with external credentials, such as
+	// those of operating system or Kerberos authentication; see
+	// NetCfg.AuthServices.
 	Username string
 	Password string
 	StmtCfg  *StmtCfg
+
+	// MaxOpenCursors is the number of open Stmts at which Ses.Prep begins
+	// releasing the server cursors of least-recently-used idle Stmts.
+	//
+	// An evicted Stmt remains open and is transparently re-prepared on its
+	// next use. A Stmt with an open Rset is never evicted.
+	//
+	// Set MaxOpenCursors somewhat below the server's OPEN_CURSORS parameter
+	// to avoid ORA-01000 (maximum open cursors exceeded).
+	//
+	// The default is zero, which disables eviction.
+	MaxOpenCursors int
+
+	// StmtCacheSize is the number of statements kept in the OCI statement
+	// cache of the Ses. A closed Stmt whose SQL is prepared again reuses the
+	// cached cursor, and the client result cache requires statement caching.
+	//
+	// Cached statements hold server cursors; keep StmtCacheSize plus
+	// MaxOpenCursors below the server's OPEN_CURSORS parameter.
+	//
+	// The default is zero, which disables statement caching.
+	StmtCacheSize int
+
+	// SqlTag is a tag attributing the statements of the Ses to the
+	// application in server-side diagnostics such as AWR and ASH, for
+	// example "app:svc route".
+	//
+	// Ses.Prep prepends the tag to the SQL text as a comment. Each distinct
+	// tag yields a distinct SQL_ID for the same statement.
+	//
+	// The default is empty, which disables tagging.
+	SqlTag string
+
+	// SqlTagAsAction determines whether SqlTag is reported as the session
+	// action (V$SESSION.ACTION) of each execution instead of being added to
+	// the SQL text, preserving the SQL_ID of each statement. Tags set with
+	// WithSqlTag are always reported as the action.
+	//
+	// The default is false.
+	SqlTagAsAction bool
+
+	// ResumableTimeout enables resumable space allocation for the Ses with
+	// ALTER SESSION ENABLE RESUMABLE. A statement which runs out of space,
+	// for example with ORA-01653 during a bulk load, is suspended for up to
+	// ResumableTimeout, whole seconds, rather than failing, giving an
+	// administrator time to add space.
+	//
+	// The default is zero, which leaves resumable space allocation disabled.
+	ResumableTimeout time.Duration
+
+	// OnResumable is called when a statement of the Ses is suspended while
+	// ResumableTimeout is set. Suspensions are detected by polling
+	// USER_RESUMABLE each second from a second session, opened with this
+	// SesCfg while the Ses is calling the server and closed with the Ses.
+	// OnResumable is called from the polling goroutine.
+	//
+	// The default is nil.
+	OnResumable func(ResumableEvent)
+
+	// LobChunkSize overrides EnvCfg.LobChunkSize for the Ses.
+	//
+	// The maximum is 1,073,741,824 bytes.
+	//
+	// The default is zero, which uses the EnvCfg.LobChunkSize of the Env.
+	LobChunkSize int
+
+	// TempLobWarning is the number of temporary LOBs of the Ses, created for
+	// LOB binds and not yet freed, at each multiple of which a warning is
+	// logged; see Ses.FreeTempLobs.
+	//
+	// The default is zero, which disables the warning.
+	TempLobWarning int
+
+	// PingTimeout is the call timeout of Ses.PingContext, and so of
+	// Con.Ping, which database/sql calls for DB.PingContext: a ping which
+	// the server doesn't answer within PingTimeout is interrupted with
+	// Ses.Break and returns context.DeadlineExceeded.
+	//
+	// The default is zero, which limits a ping by its context only.
+	PingTimeout time.Duration
+
+	// CallTimeout is the timeout of each execution of a Stmt of the Ses, by
+	// Stmt.Exe, Stmt.Qry and their Context variants: an execution which the
+	// server doesn't complete within CallTimeout is interrupted with
+	// Ses.Break, and the Stmt method returns context.DeadlineExceeded.
+	// Rset.Next isn't limited.
+	//
+	// The default is zero, which limits an execution by its context only.
+	CallTimeout time.Duration
 }
 
 // NewSrvCfg creates a SrvCfg with default values.
@@ -78,10 +175,152 @@ type LogSesCfg struct {
 	// The default is true.
 	Ping bool
 
+	// PingContext determines whether the Ses.PingContext method is logged.
+	//
+	// The default is true.
+	PingContext bool
+
 	// Break determines whether the Ses.Break method is logged.
 	//
 	// The default is true.
 	Break bool
+
+	// Reset determines whether the Ses.Reset method is logged.
+	//
+	// The default is true.
+	Reset bool
+
+	// ExeBatch determines whether the Ses.ExeBatch method is logged.
+	//
+	// The default is true.
+	ExeBatch bool
+
+	// QryStream determines whether the Ses.QryStream method is logged.
+	//
+	// The default is true.
+	QryStream bool
+
+	// OpenDirPath determines whether the Ses.OpenDirPath method is logged.
+	//
+	// The default is true.
+	OpenDirPath bool
+
+	// Load determines whether the Ses.LoadCSV and Ses.LoadRows methods are logged.
+	//
+	// The default is true.
+	Load bool
+
+	// ResultCacheStats determines whether the Ses.ResultCacheStats method is logged.
+	//
+	// The default is true.
+	ResultCacheStats bool
+
+	// ExeIfUnchanged determines whether the Ses.ExeIfUnchanged method is logged.
+	//
+	// The default is true.
+	ExeIfUnchanged bool
+
+	// CurrentScn determines whether the Ses.CurrentScn method is logged.
+	//
+	// The default is true.
+	CurrentScn bool
+
+	// WithSnapshot determines whether the Ses.WithSnapshot and
+	// Ses.WithSnapshotAt methods are logged.
+	//
+	// The default is true.
+	WithSnapshot bool
+
+	// WithParams determines whether the Ses.WithParams method is logged.
+	//
+	// The default is true.
+	WithParams bool
+
+	// SaveState determines whether the Ses.SaveState method is logged.
+	//
+	// The default is true.
+	SaveState bool
+
+	// RestoreState determines whether the Ses.RestoreState method is logged.
+	//
+	// The default is true.
+	RestoreState bool
+
+	// SetIsolationLevel determines whether the Ses.SetIsolationLevel method is logged.
+	//
+	// The default is true.
+	SetIsolationLevel bool
+
+	// SetCurrentSchema determines whether the Ses.SetCurrentSchema method is logged.
+	//
+	// The default is true.
+	SetCurrentSchema bool
+
+	// CopyRows determines whether the CopyRows function is logged.
+	//
+	// The default is true.
+	CopyRows bool
+
+	// BulkUpsert determines whether the Ses.BulkUpsert method is logged.
+	//
+	// The default is true.
+	BulkUpsert bool
+
+	// Sid determines whether the Ses.Sid method is logged.
+	//
+	// The default is true.
+	Sid bool
+
+	// LongOps determines whether the Ses.LongOps method is logged.
+	//
+	// The default is true.
+	LongOps bool
+
+	// Page determines whether the Paginator.Page method is logged.
+	//
+	// The default is true.
+	Page bool
+
+	// MemStats determines whether the Ses.MemStats method is logged.
+	//
+	// The default is true.
+	MemStats bool
+
+	// Stats determines whether the Ses.Stats method is logged.
+	//
+	// The default is true.
+	Stats bool
+
+	// StatsDelta determines whether the Ses.StatsDelta method is logged.
+	//
+	// The default is true.
+	StatsDelta bool
+
+	// ExeChunked determines whether the Ses.ExeChunked method is logged.
+	//
+	// The default is true.
+	ExeChunked bool
+
+	// OnCommit determines whether the Ses.OnCommit method is logged.
+	//
+	// The default is true.
+	OnCommit bool
+
+	// OnRollback determines whether the Ses.OnRollback method is logged.
+	//
+	// The default is true.
+	OnRollback bool
+
+	// OnTxLost determines whether the Ses.OnTxLost method, and the calls of
+	// its hooks, are logged.
+	//
+	// The default is true.
+	OnTxLost bool
+
+	// FreeTempLobs determines whether the Ses.FreeTempLobs method is logged.
+	//
+	// The default is true.
+	FreeTempLobs bool
 }
 
 // NewLogSesCfg creates a LogSesCfg with default values.
@@ -96,7 +335,35 @@ func NewLogSesCfg() LogSesCfg {
 	c.Sel = true
 	c.StartTx = true
 	c.Ping = true
+	c.PingContext = true
 	c.Break = true
+	c.Reset = true
+	c.ExeBatch = true
+	c.QryStream = true
+	c.OpenDirPath = true
+	c.Load = true
+	c.ResultCacheStats = true
+	c.ExeIfUnchanged = true
+	c.CurrentScn = true
+	c.WithSnapshot = true
+	c.WithParams = true
+	c.SaveState = true
+	c.RestoreState = true
+	c.SetIsolationLevel = true
+	c.SetCurrentSchema = true
+	c.CopyRows = true
+	c.BulkUpsert = true
+	c.Sid = true
+	c.LongOps = true
+	c.Page = true
+	c.MemStats = true
+	c.Stats = true
+	c.StatsDelta = true
+	c.ExeChunked = true
+	c.OnCommit = true
+	c.OnRollback = true
+	c.OnTxLost = true
+	c.FreeTempLobs = true
 	return c
 }
 
@@ -108,7 +375,27 @@ type Ses struct {
 	srv       *Srv
 	ocisvcctx *C.OCISvcCtx
 	ocises    *C.OCISession
+	ocierr    *C.OCIError // error handle of the session's calls
 	isLocked  bool
+	leaks     *leakRegistry
+	calls     sesCalls
+
+	isolationLevel string
+	currentSchema  string
+	params         map[string]string // parameter values known by WithParams; guarded by mu
+	tagMu          sync.Mutex
+	action         string // action last set by tagAction; guarded by tagMu
+	ecid           string // execution context id last set by tagEcid; guarded by tagMu
+	resumable      *resumableMonitor
+	txHooks        txHooks
+	tempLobs       tempLobs
+	gen            uint32 // incremented by reopen; accessed atomically
+	ownsSrv        bool   // the Srv and Env were opened by OpenSes
+
+	// evictMu serializes evictStmts and the re-prepare of evicted Stmts,
+	// which hold Stmt.mu and so can't lock mu; it's locked after mu.
+	evictMu        sync.Mutex
+	maxOpenCursors int32 // SesCfg.MaxOpenCursors, for Stmts re-preparing; accessed atomically
 
 	openStmts *stmtList
 	openTxs   *txList
@@ -120,9 +407,22 @@ type Ses struct {
 //
 // Calling Close will cause Ses.IsOpen to return false. Once closed, a session
 // cannot be re-opened. Call Srv.OpenSes to open a new session.
+//
+// The Srv and Env of a Ses opened by OpenSes are closed as well.
 func (ses *Ses) Close() (err error) {
-	ses.srv.openSess.remove(ses)
-	return ses.close()
+	srv, ownsSrv := ses.srv, ses.ownsSrv
+	srv.openSess.remove(ses)
+	err = ses.close()
+	if ownsSrv {
+		env := srv.env
+		if err0 := srv.Close(); err == nil {
+			err = err0
+		}
+		if err0 := env.Close(); err == nil {
+			err = err0
+		}
+	}
+	return err
 }
 
 // close ends a session on an Oracle server.
@@ -130,7 +430,7 @@ func (ses *Ses) Close() (err error) {
 func (ses *Ses) close() (err error) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
-	ses.log(_drv.cfg.Log.Ses.Close)
+	ses.log(_drv.cfg().Log.Ses.Close)
 	err = ses.checkClosed()
 	if err != nil {
 		return errE(err)
@@ -142,8 +442,21 @@ func (ses *Ses) close() (err error) {
 		}
 
 		ses.srv = nil
+		ses.leaks = nil
+		ses.isolationLevel = ""
+		ses.currentSchema = ""
+		ses.params = nil
+		ses.action = ""
+		ses.ecid = ""
+		ses.txHooks.clear()
+		ses.tempLobs.removeAll() // freed by the server with the session
+		ses.resumable = nil
+		ses.ownsSrv = false
+		ses.calls.n = 0
+		ses.calls.broken = false
 		ses.ocisvcctx = nil
 		ses.ocises = nil
+		ses.ocierr = nil
 		ses.openStmts.clear()
 		ses.openTxs.clear()
 		_drv.sesPool.Put(ses)
@@ -156,6 +469,11 @@ func (ses *Ses) close() (err error) {
 		_drv.listPool.Put(errs)
 	}()
 
+	ses.resumable.stop()
+
+	// report handles the user did not close before closing them
+	ses.leaks.reportAll()
+
 	// close transactions
 	// close does not rollback or commit any transactions
 	// Expect user to make explicit Commit or Rollback.
@@ -166,31 +484,53 @@ func (ses *Ses) close() (err error) {
 
 	// close session
 	// OCISessionEnd invalidates oci session handle; no need to free session.ocises
-	r := C.OCISessionEnd(
-		ses.ocisvcctx,      //OCISvcCtx       *svchp,
-		ses.srv.env.ocierr, //OCIError        *errhp,
-		ses.ocises,         //OCISession      *usrhp,
-		C.OCI_DEFAULT)      //ub4             mode );
+	r := ses.poll(nil, func() C.sword {
+		return C.OCISessionEnd(
+			ses.ocisvcctx, //OCISvcCtx       *svchp,
+			ses.ocierr,    //OCIError        *errhp,
+			ses.ocises,    //OCISession      *usrhp,
+			C.OCI_DEFAULT) //ub4             mode );
+	})
 	if r == C.OCI_ERROR {
-		errs.PushBack(errE(ses.srv.env.ociError()))
+		errs.PushBack(errE(ses.ociError()))
+	}
+	if err = ses.srv.env.freeOciHandle(unsafe.Pointer(ses.ocierr), C.OCI_HTYPE_ERROR); err != nil {
+		errs.PushBack(errE(err))
 	}
 	return nil
 }
 
 // PrepAndExe prepares and executes a SQL statement returning the number of rows
 // affected and a possible error.
+//
+// InList params are expanded with ExpandIn.
 func (ses *Ses) PrepAndExe(sql string, params ...interface{}) (rowsAffected uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.PrepAndExe)
+	return ses.prepAndExe(ses.Prep, sql, params)
+}
+
+// exe prepares and executes sql, a statement of the driver, as is: neither
+// DrvCfg.RewriteSql, DrvCfg.ConvertPlaceholders nor StmtCfg.RowScn apply.
+func (ses *Ses) exe(sql string, params ...interface{}) (rowsAffected uint64, err error) {
+	return ses.prepAndExe(ses.prepSql, sql, params)
+}
+
+// prepAndExe prepares sql with prep and executes it.
+func (ses *Ses) prepAndExe(prep func(string, ...GoColumnType) (*Stmt, error), sql string, params []interface{}) (rowsAffected uint64, err error) {
 	defer func() {
 		if value := recover(); value != nil {
 			err = errR(value)
 		}
 	}()
-	ses.log(_drv.cfg.Log.Ses.PrepAndExe)
 	err = ses.checkClosed()
 	if err != nil {
 		return 0, errE(err)
 	}
-	stmt, err := ses.Prep(sql)
+	sql, params, err = ExpandIn(sql, params...)
+	if err != nil {
+		return 0, errE(err)
+	}
+	stmt, err := prep(sql)
 	defer func() {
 		if stmt != nil {
 			err0 := stmt.Close()
@@ -217,12 +557,27 @@ func (ses *Ses) PrepAndExe(sql string, params ...interface{}) (rowsAffected uint
 // The *Stmt internal to this method is automatically closed when the *Rset
 // retrieves all rows or returns an error.
 func (ses *Ses) PrepAndQry(sql string, params ...interface{}) (rset *Rset, err error) {
-	ses.log(_drv.cfg.Log.Ses.PrepAndQry)
+	ses.log(_drv.cfg().Log.Ses.PrepAndQry)
+	return ses.prepAndQry(ses.Prep, sql, params)
+}
+
+// qry prepares and queries sql, a query of the driver, as is: neither
+// DrvCfg.RewriteSql, DrvCfg.ConvertPlaceholders nor StmtCfg.RowScn apply.
+func (ses *Ses) qry(sql string, params ...interface{}) (rset *Rset, err error) {
+	return ses.prepAndQry(ses.prepSql, sql, params)
+}
+
+// prepAndQry prepares sql with prep and queries it.
+func (ses *Ses) prepAndQry(prep func(string, ...GoColumnType) (*Stmt, error), sql string, params []interface{}) (rset *Rset, err error) {
 	err = ses.checkClosed()
 	if err != nil {
 		return nil, errE(err)
 	}
-	stmt, err := ses.Prep(sql)
+	sql, params, err = ExpandIn(sql, params...)
+	if err != nil {
+		return nil, errE(err)
+	}
+	stmt, err := prep(sql)
 	if err != nil {
 		defer stmt.Close()
 		return nil, errE(err)
@@ -237,7 +592,36 @@ func (ses *Ses) PrepAndQry(sql string, params ...interface{}) (rset *Rset, err e
 }
 
 // Prep prepares a sql statement returning a *Stmt and possible error.
+//
+// sql is rewritten by DrvCfg.RewriteSql first, when set, and its
+// placeholders are converted when DrvCfg.ConvertPlaceholders is set.
 func (ses *Ses) Prep(sql string, gcts ...GoColumnType) (stmt *Stmt, err error) {
+	ses.log(_drv.cfg().Log.Ses.Prep, sql)
+	sql, order, err := _drv.cfg().convertSql(sql)
+	if err != nil {
+		return nil, errE(err)
+	}
+	ses.mu.Lock()
+	rowScn := ses.cfg.StmtCfg != nil && ses.cfg.StmtCfg.RowScn
+	ses.mu.Unlock()
+	if rowScn {
+		sql, _ = rowScnSql(sql)
+	}
+	stmt, err = ses.prep(sql, gcts)
+	if err != nil {
+		return stmt, err
+	}
+	stmt.paramOrder = order
+	return stmt, nil
+}
+
+// prepSql prepares sql, a statement of the driver, as is; see Ses.exe.
+func (ses *Ses) prepSql(sql string, gcts ...GoColumnType) (*Stmt, error) {
+	return ses.prep(sql, gcts)
+}
+
+// prep prepares sql as is.
+func (ses *Ses) prep(sql string, gcts []GoColumnType) (stmt *Stmt, err error) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
 	defer func() {
@@ -245,36 +629,28 @@ func (ses *Ses) Prep(sql string, gcts ...GoColumnType) (stmt *Stmt, err error) {
 			err = errR(value)
 		}
 	}()
-	ses.log(_drv.cfg.Log.Ses.Prep, sql)
 	err = ses.checkClosed()
 	if err != nil {
 		return nil, errE(err)
 	}
-	// allocate statement handle
-	upOciStmt, err := ses.srv.env.allocOciHandle(C.OCI_HTYPE_STMT)
+	if !ses.cfg.SqlTagAsAction {
+		sql = tagSql(sql, ses.cfg.SqlTag)
+	}
+	// refreshed for a SesCfg changed through Ses.Cfg
+	atomic.StoreInt32(&ses.maxOpenCursors, int32(ses.cfg.MaxOpenCursors))
+	ses.evictMu.Lock()
+	ses.evictStmts(nil)
+	ocistmt, err := ses.prepOciStmt(sql)
+	ses.evictMu.Unlock()
 	if err != nil {
 		return nil, errE(err)
 	}
-	ocistmt := (*C.OCIStmt)(upOciStmt)
-	cSql := C.CString(sql) // prepare sql text with statement handle
-	defer C.free(unsafe.Pointer(cSql))
-	r := C.OCIStmtPrepare2(
-		ses.ocisvcctx,                      // OCISvcCtx     *svchp,
-		&ocistmt,                           // OCIStmt       *stmtp,
-		ses.srv.env.ocierr,                 // OCIError      *errhp,
-		(*C.OraText)(unsafe.Pointer(cSql)), // const OraText *stmt,
-		C.ub4(len(sql)),                    // ub4           stmt_len,
-		nil,                                // const OraText *key,
-		C.ub4(0),                           // ub4           keylen,
-		C.OCI_NTV_SYNTAX,                   // ub4           language,
-		C.OCI_DEFAULT)                      // ub4           mode );
-	if r == C.OCI_ERROR {
-		return nil, errE(ses.srv.env.ociError())
-	}
 	// set stmt struct
 	stmt = _drv.stmtPool.Get().(*Stmt)
 	stmt.ses = ses
-	stmt.ocistmt = (*C.OCIStmt)(ocistmt)
+	stmt.ocistmt = ocistmt
+	stmt.gen = atomic.LoadUint32(&ses.gen)
+	stmt.lastUsed = time.Now().UnixNano()
 	stmtCfg := ses.cfg.StmtCfg
 	if stmtCfg == nil {
 		stmtCfg = NewStmtCfg()
@@ -283,6 +659,9 @@ func (ses *Ses) Prep(sql string, gcts ...GoColumnType) (stmt *Stmt, err error) {
 		stmtCfg.stringPtrBufferSize = 1000
 	}
 	stmt.cfg = *stmtCfg
+	if stmt.cfg.stringPtrBufferSize == maxStringSize && ses.srv.maxStringSize() == maxExtendedStringSize {
+		stmt.cfg.stringPtrBufferSize = maxExtendedStringSize
+	}
 	stmt.sql = sql
 	stmt.gcts = gcts
 	if stmt.id == 0 {
@@ -293,10 +672,82 @@ func (ses *Ses) Prep(sql string, gcts ...GoColumnType) (stmt *Stmt, err error) {
 		return nil, errE(err)
 	}
 	ses.openStmts.add(stmt)
+	ses.leaks.track(stmt, "Stmt", stmt.sysName())
 
 	return stmt, nil
 }
 
+// prepOciStmt allocates a statement handle and prepares sql. No locking occurs.
+func (ses *Ses) prepOciStmt(sql string) (*C.OCIStmt, error) {
+	// allocate statement handle
+	upOciStmt, err := ses.srv.env.allocOciHandle(C.OCI_HTYPE_STMT)
+	if err != nil {
+		return nil, err
+	}
+	ocistmt := (*C.OCIStmt)(upOciStmt)
+	cSql := C.CString(sql) // prepare sql text with statement handle
+	defer C.free(unsafe.Pointer(cSql))
+	r := C.OCIStmtPrepare2(
+		ses.ocisvcctx,                      // OCISvcCtx     *svchp,
+		&ocistmt,                           // OCIStmt       *stmtp,
+		ses.ocierr,                         // OCIError      *errhp,
+		(*C.OraText)(unsafe.Pointer(cSql)), // const OraText *stmt,
+		C.ub4(len(sql)),                    // ub4           stmt_len,
+		nil,                                // const OraText *key,
+		C.ub4(0),                           // ub4           keylen,
+		C.OCI_NTV_SYNTAX,                   // ub4           language,
+		C.OCI_DEFAULT)                      // ub4           mode );
+	if r == C.OCI_ERROR {
+		return nil, ses.ociError()
+	}
+	return ocistmt, nil
+}
+
+// evictStmts releases the server cursors of least-recently-used idle Stmts
+// until fewer than SesCfg.MaxOpenCursors Stmts hold a cursor. The keep Stmt
+// is never evicted. The caller holds Ses.evictMu; Ses.mu isn't required.
+func (ses *Ses) evictStmts(keep *Stmt) {
+	maxOpenCursors := int(atomic.LoadInt32(&ses.maxOpenCursors))
+	if maxOpenCursors <= 0 {
+		return
+	}
+	stmts := ses.openStmts.snapshot()
+	prepared := 0
+	for _, stmt := range stmts {
+		if !stmt.evicted {
+			prepared++
+		}
+	}
+	if prepared < maxOpenCursors {
+		return
+	}
+	// least-recently-used first
+	sort.Sort(stmtsByLastUsed(stmts))
+	for _, stmt := range stmts {
+		if prepared < maxOpenCursors {
+			return
+		}
+		if stmt == keep {
+			continue
+		}
+		evicted, err := stmt.evict()
+		if err != nil {
+			ses.logF(_drv.cfg().Log.Ses.Prep, "evict %v: %v", stmt.sysName(), err)
+		}
+		if evicted {
+			prepared--
+		}
+	}
+}
+
+type stmtsByLastUsed []*Stmt
+
+func (s stmtsByLastUsed) Len() int      { return len(s) }
+func (s stmtsByLastUsed) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s stmtsByLastUsed) Less(i, j int) bool {
+	return atomic.LoadInt64(&s[i].lastUsed) < atomic.LoadInt64(&s[j].lastUsed)
+}
+
 // Ins composes, prepares and executes a sql INSERT statement returning a
 // possible error.
 //
@@ -308,7 +759,7 @@ func (ses *Ses) Prep(sql string, gcts ...GoColumnType) (stmt *Stmt, err error) {
 // to the variadic parameter 'columnPairs' is expected to be a pointer capable
 // of receiving the identity value.
 func (ses *Ses) Ins(tbl string, columnPairs ...interface{}) (err error) {
-	ses.log(_drv.cfg.Log.Ses.Ins)
+	ses.log(_drv.cfg().Log.Ses.Ins)
 	err = ses.checkClosed()
 	if err != nil {
 		return errE(err)
@@ -373,22 +824,45 @@ func (ses *Ses) Ins(tbl string, columnPairs ...interface{}) (err error) {
 //
 // Upd offers convenience when specifying a long list of sql columns.
 func (ses *Ses) Upd(tbl string, columnPairs ...interface{}) (err error) {
-	ses.log(_drv.cfg.Log.Ses.Upd)
+	ses.log(_drv.cfg().Log.Ses.Upd)
 	err = ses.checkClosed()
 	if err != nil {
 		return errE(err)
 	}
+	sql, params, err := updSql(tbl, columnPairs)
+	if err != nil {
+		return err
+	}
+	stmt, err := ses.Prep(sql) // prep
+	defer func() {
+		err = stmt.Close()
+		if err != nil {
+			err = errE(err)
+		}
+	}()
+	if err != nil {
+		return errE(err)
+	}
+	_, err = stmt.Exe(params...) // exe
+	if err != nil {
+		return errE(err)
+	}
+	return nil
+}
+
+// updSql returns the UPDATE statement and params of Ses.Upd.
+func updSql(tbl string, columnPairs []interface{}) (sql string, params []interface{}, err error) {
 	if tbl == "" {
-		return errF("tbl is empty.")
+		return "", nil, errF("tbl is empty.")
 	}
 	if len(columnPairs) < 2 {
-		return errF("Parameter 'columnPairs' expects at least 2 column name-value pairs.")
+		return "", nil, errF("Parameter 'columnPairs' expects at least 2 column name-value pairs.")
 	}
 	if len(columnPairs)%2 != 0 {
-		return errF("Variadic parameter 'columnPairs' received an odd number of elements. Parameter 'columnPairs' expects an even number of elements.")
+		return "", nil, errF("Variadic parameter 'columnPairs' received an odd number of elements. Parameter 'columnPairs' expects an even number of elements.")
 	}
 	// build UPDATE statement, params slice
-	params := make([]interface{}, len(columnPairs)/2)
+	params = make([]interface{}, len(columnPairs)/2)
 	buf := new(bytes.Buffer)
 	buf.WriteString("UPDATE ")
 	buf.WriteString(tbl)
@@ -398,7 +872,7 @@ func (ses *Ses) Upd(tbl string, columnPairs ...interface{}) (err error) {
 		n := p * 2
 		columnName, ok := columnPairs[n].(string)
 		if !ok {
-			return errF("Variadic parameter 'columnPairs' expected an element at index %v to be of type string", n)
+			return "", nil, errF("Variadic parameter 'columnPairs' expected an element at index %v to be of type string", n)
 		}
 		if p == len(params)-1 {
 			lastColName = columnName
@@ -414,21 +888,7 @@ func (ses *Ses) Upd(tbl string, columnPairs ...interface{}) (err error) {
 	buf.WriteString(" WHERE ")
 	buf.WriteString(lastColName)
 	buf.WriteString(" = :WHERE_VAL")
-	stmt, err := ses.Prep(buf.String()) // prep
-	defer func() {
-		err = stmt.Close()
-		if err != nil {
-			err = errE(err)
-		}
-	}()
-	if err != nil {
-		return errE(err)
-	}
-	_, err = stmt.Exe(params...) // exe
-	if err != nil {
-		return errE(err)
-	}
-	return nil
+	return buf.String(), params, nil
 }
 
 // Sel composes, prepares and queries a sql SELECT statement returning an *ora.Rset
@@ -441,7 +901,7 @@ func (ses *Ses) Upd(tbl string, columnPairs ...interface{}) (err error) {
 // name-GoColumnType pairs. The FROM clause may have additional SQL clauses
 // such as WHERE, HAVING, etc.
 func (ses *Ses) Sel(sqlFrom string, columnPairs ...interface{}) (rset *Rset, err error) {
-	ses.log(_drv.cfg.Log.Ses.Sel)
+	ses.log(_drv.cfg().Log.Ses.Sel)
 	err = ses.checkClosed()
 	if err != nil {
 		return nil, errE(err)
@@ -495,10 +955,22 @@ func (ses *Ses) Sel(sqlFrom string, columnPairs ...interface{}) (rset *Rset, err
 }
 
 // StartTx starts an Oracle transaction returning a *Tx and possible error.
-func (ses *Ses) StartTx() (tx *Tx, err error) {
+//
+// opts set the isolation level and read-only mode of the transaction with
+// the flags of OCITransStart.
+func (ses *Ses) StartTx(opts ...TxOption) (tx *Tx, err error) {
+	ses.log(_drv.cfg().Log.Ses.StartTx)
+	flag, err := newTxCfg(opts).flag()
+	if err != nil {
+		return nil, errE(err)
+	}
+	return ses.startTx(flag)
+}
+
+// startTx starts an Oracle transaction of the mode flag.
+func (ses *Ses) startTx(flag txFlag) (tx *Tx, err error) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
-	ses.log(_drv.cfg.Log.Ses.StartTx)
 	err = ses.checkClosed()
 	if err != nil {
 		return nil, errE(err)
@@ -508,13 +980,24 @@ func (ses *Ses) StartTx() (tx *Tx, err error) {
 	// before it is automatically terminated by the system.
 	// TODO: add timeout config value
 	var timeout C.uword = C.uword(60)
-	r := C.OCITransStart(
-		ses.ocisvcctx,      //OCISvcCtx    *svchp,
-		ses.srv.env.ocierr, //OCIError     *errhp,
-		timeout,            //uword        timeout,
-		C.OCI_TRANS_NEW)    //ub4          flags );
+	flags := C.ub4(C.OCI_TRANS_NEW)
+	switch flag {
+	case txFlagReadWrite:
+		flags |= C.OCI_TRANS_READWRITE
+	case txFlagSerializable:
+		flags |= C.OCI_TRANS_SERIALIZABLE
+	case txFlagReadOnly:
+		flags |= C.OCI_TRANS_READONLY
+	}
+	r := ses.poll(nil, func() C.sword {
+		return C.OCITransStart(
+			ses.ocisvcctx, //OCISvcCtx    *svchp,
+			ses.ocierr,    //OCIError     *errhp,
+			timeout,       //uword        timeout,
+			flags)         //ub4          flags );
+	})
 	if r == C.OCI_ERROR {
-		return nil, errE(ses.srv.env.ociError())
+		return nil, errE(ses.ociError())
 	}
 	tx = _drv.txPool.Get().(*Tx) // set *Tx
 	tx.ses = ses
@@ -522,45 +1005,240 @@ func (ses *Ses) StartTx() (tx *Tx, err error) {
 		tx.id = _drv.txId.nextId()
 	}
 	ses.openTxs.add(tx)
+	ses.leaks.track(tx, "Tx", tx.sysName())
 
 	return tx, nil
 }
 
 // Ping returns nil when an Oracle server is contacted; otherwise, an error.
 func (ses *Ses) Ping() (err error) {
+	ses.log(_drv.cfg().Log.Ses.Ping)
+	return ses.ping(nil)
+}
+
+// PingContext makes an OCIPing round trip like Ping. When ctx is done before
+// the server responds, the round trip is interrupted with Ses.Break and the
+// error of ctx is returned; in non-blocking mode the poll of the call
+// observes ctx, and in blocking mode a goroutine watches it. The round trip
+// is also limited to SesCfg.PingTimeout when set.
+func (ses *Ses) PingContext(ctx context.Context) (err error) {
+	ses.log(_drv.cfg().Log.Ses.PingContext)
+	if err = ctxErr(ctx); err != nil {
+		return err
+	}
+	ses.mu.Lock()
+	timeout := ses.cfg.PingTimeout
+	ses.mu.Unlock()
+	if timeout > 0 {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if ctx == nil || ctx.Done() == nil || ses.srv.nonBlocking {
+		err = ses.ping(ctx)
+	} else {
+		stop := ses.breakOnDone(ctx)
+		err = ses.ping(ctx)
+		stop()
+	}
+	if err != nil {
+		if cerr := ctxErr(ctx); cerr != nil {
+			return cerr
+		}
+	}
+	return err
+}
+
+// callTimeout returns ctx limited to SesCfg.CallTimeout for an execution,
+// and the func ending the limit, which returns context.DeadlineExceeded when
+// the execution timed out. In blocking mode a goroutine breaks the execution
+// once the timeout expires; see Ses.breakOnDone.
+func (ses *Ses) callTimeout(ctx context.Context) (context.Context, func() error) {
+	timeout := ses.cfg.CallTimeout
+	if timeout <= 0 {
+		return ctx, func() error { return nil }
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	stop := func() {}
+	if !ses.srv.nonBlocking {
+		stop = ses.breakOnDone(callCtx)
+	}
+	return callCtx, func() error {
+		stop()
+		defer cancel()
+		if callCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			return context.DeadlineExceeded
+		}
+		return nil
+	}
+}
+
+// ping makes an OCIPing round trip polled with ctx.
+func (ses *Ses) ping(ctx context.Context) (err error) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
-	ses.log(_drv.cfg.Log.Ses.Ping)
 	err = ses.checkClosed()
 	if err != nil {
 		return errE(err)
 	}
-	r := C.OCIPing(
-		ses.ocisvcctx,      //OCISvcCtx     *svchp,
-		ses.srv.env.ocierr, //OCIError      *errhp,
-		C.OCI_DEFAULT)      //ub4           mode );
+	r := ses.poll(ctx, func() C.sword {
+		return C.OCIPing(
+			ses.ocisvcctx, //OCISvcCtx     *svchp,
+			ses.ocierr,    //OCIError      *errhp,
+			C.OCI_DEFAULT) //ub4           mode );
+	})
 	if r == C.OCI_ERROR {
-		return errE(ses.srv.env.ociError())
+		return errE(ses.ociError())
 	}
 	return nil
 }
 
 // Break stops the currently running OCI function.
+//
+// Break is safe to call from another goroutine while a Stmt.Exe, Stmt.Qry,
+// Rset.Next or other server round trip is in flight on the Ses. The
+// interrupted call returns an error, typically ORA-01013. Break is a no-op
+// when no call is in flight. When several calls are in flight on the Ses,
+// such as those of different Stmts, Break interrupts the call the server is
+// running.
+//
+// The Ses is reset once the calls in flight return, before another call
+// begins, so a Break issued as the call ends never interrupts a later call.
 func (ses *Ses) Break() (err error) {
+	if !ses.calling() {
+		ses.mu.Lock()
+		err = ses.checkClosed()
+		ses.mu.Unlock()
+		if err != nil {
+			return errE(err)
+		}
+	}
+	if _, err = ses.breakInFlight(); err != nil {
+		return errE(err)
+	}
+	return nil
+}
+
+// breakInFlight issues OCIBreak when a call is in flight on the Ses, and
+// reports whether the calls in flight are broken.
+//
+// OCIBreak is issued while holding the lock of the calls, so the calls can't
+// end, and another begin, before the Ses is reset; see Ses.poll.
+func (ses *Ses) breakInFlight() (broke bool, err error) {
+	ses.calls.mu.Lock()
+	defer ses.calls.mu.Unlock()
+	if ses.calls.n == 0 {
+		return false, nil
+	}
+	if ses.calls.broken {
+		return true, nil
+	}
+	ses.calls.broken = true
+	// the Ses can't close while a call is in flight; Ses.close waits on the
+	// Stmt lock held by the call
+	ses.log(_drv.cfg().Log.Ses.Break)
+	r := C.OCIBreak(unsafe.Pointer(ses.ocisvcctx), ses.ocierr)
+	if r == C.OCI_ERROR {
+		return true, ses.ociError()
+	}
+	return true, nil
+}
+
+// breakOnDone breaks the call in flight on the Ses once ctx is done, until
+// the returned stop is called. A call which starts after ctx is done is
+// broken as well, so a Break can't be lost while the call is being made.
+// stop waits for the watching goroutine, so that a late Break can't
+// interrupt a call following stop.
+func (ses *Ses) breakOnDone(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		interval := minPollInterval
+		for {
+			if broke, _ := ses.breakInFlight(); broke {
+				return
+			}
+			select {
+			case <-done:
+				return
+			case <-time.After(interval):
+			}
+			if interval *= 2; interval > maxBreakInterval {
+				interval = maxBreakInterval
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// Reset resets the OCI protocol of the Ses after a Break, discarding the
+// remainder of the interrupted call.
+//
+// Reset is only needed when a Break was issued by a means other than
+// Ses.Break, such as from a signal handler in C code. Reset returns an error
+// when a call is in flight on the Ses.
+func (ses *Ses) Reset() (err error) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
-	ses.log(_drv.cfg.Log.Ses.Break)
+	ses.log(_drv.cfg().Log.Ses.Reset)
 	err = ses.checkClosed()
 	if err != nil {
 		return errE(err)
 	}
-	r := C.OCIBreak(unsafe.Pointer(ses.ocisvcctx), ses.srv.env.ocierr)
+	if ses.calling() {
+		return er("Ses has a call in flight.")
+	}
+	return errE(ses.reset())
+}
+
+// reset issues OCIReset. No locking occurs.
+func (ses *Ses) reset() error {
+	r := C.OCIReset(unsafe.Pointer(ses.ocisvcctx), ses.ocierr)
 	if r == C.OCI_ERROR {
-		return errE(ses.srv.env.ociError())
+		return ses.ociError()
 	}
 	return nil
 }
 
+// breakCall issues OCIBreak and OCIReset to abort a LOB read or write left
+// unfinished, whether or not a call is in flight. Unlike Break, breakCall is
+// for the driver's own use on the goroutine of the unfinished operation. No
+// locking occurs.
+func (ses *Ses) breakCall() error {
+	ses.log(_drv.cfg().Log.Ses.Break)
+	r := C.OCIBreak(unsafe.Pointer(ses.ocisvcctx), ses.ocierr)
+	if r == C.OCI_ERROR {
+		return ses.ociError()
+	}
+	return ses.reset()
+}
+
+// Leaks returns the Stmt, Rset, Tx and Lob handles which are currently open
+// on the Ses, with their creation stacks.
+//
+// Leaks returns nil unless DrvCfg.Leak.Enabled was set when the Ses was opened.
+func (ses *Ses) Leaks() []Leak {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	return ses.leaks.open()
+}
+
 // NumStmt returns the number of open Oracle statements.
 func (ses *Ses) NumStmt() int {
 	ses.mu.Lock()
@@ -582,6 +1260,7 @@ func (ses *Ses) SetCfg(cfg SesCfg) {
 	ses.mu.Lock()
 	defer ses.mu.Unlock()
 	ses.cfg = cfg
+	atomic.StoreInt32(&ses.maxOpenCursors, int32(cfg.MaxOpenCursors))
 }
 
 // Cfg returns the Ses's cfg.
@@ -591,6 +1270,15 @@ func (ses *Ses) Cfg() *SesCfg {
 	return &ses.cfg
 }
 
+// CfgCopy returns a copy of the Ses's cfg, sharing no maps or nested cfgs
+// with it. Changes to the copy have no effect until it is applied with
+// Ses.SetCfg.
+func (ses *Ses) CfgCopy() *SesCfg {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	return ses.cfg.copy()
+}
+
 // IsOpen returns true when a session is open; otherwise, false.
 //
 // Calling Close will cause Ses.IsOpen to return false. Once closed, a session
@@ -601,6 +1289,28 @@ func (ses *Ses) IsOpen() bool {
 	return ses.checkClosed() == nil
 }
 
+// ociError returns the error recorded in the error handle of the session.
+func (ses *Ses) ociError() error {
+	return ociErrorGet(ses.ocierr)
+}
+
+// setAttr sets an attribute value on a handle or descriptor used by the
+// session, recording any error in the error handle of the session. No locking
+// occurs.
+func (ses *Ses) setAttr(target unsafe.Pointer, targetType C.ub4, attribute unsafe.Pointer, attributeSize C.ub4, attributeType C.ub4) error {
+	r := C.OCIAttrSet(
+		target,        //void        *trgthndlp,
+		targetType,    //ub4         trghndltyp,
+		attribute,     //void        *attributep,
+		attributeSize, //ub4         size,
+		attributeType, //ub4         attrtype,
+		ses.ocierr)    //OCIError    *errhp );
+	if r == C.OCI_ERROR {
+		return errE(ses.ociError())
+	}
+	return nil
+}
+
 // checkClosed returns an error if Ses is closed. No locking occurs.
 func (ses *Ses) checkClosed() error {
 	if ses == nil || ses.ocises == nil {
@@ -621,9 +1331,9 @@ func (ses *Ses) sysName() string {
 func (ses *Ses) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", ses.sysName(), callInfo(1))
+			lgr.Infof("%v %v", ses.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", ses.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", ses.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -632,9 +1342,9 @@ func (ses *Ses) log(enabled bool, v ...interface{}) {
 func (ses *Ses) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", ses.sysName(), callInfo(1))
+			lgr.Infof("%v %v", ses.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", ses.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", ses.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
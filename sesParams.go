@@ -0,0 +1,152 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"sort"
+	"strings"
+)
+
+// sesParamNames returns the parameters keyed by upper-cased name, and the
+// names sorted, or an error when a name isn't an identifier.
+func sesParamNames(params map[string]string) (map[string]string, []string, error) {
+	upper := make(map[string]string, len(params))
+	names := make([]string, 0, len(params))
+	for name, value := range params {
+		if name == "" {
+			return nil, nil, er("A session parameter name is empty.")
+		}
+		for n := 0; n < len(name); n++ {
+			if !isPlaceholderChar(name[n]) {
+				return nil, nil, errF("Invalid session parameter name %q.", name)
+			}
+		}
+		name = strings.ToUpper(name)
+		upper[name] = value
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return upper, names, nil
+}
+
+// sameSesParam reports whether two values of a parameter are equal; the
+// values of NLS parameters, such as date formats, are case sensitive.
+func sameSesParam(name, a, b string) bool {
+	if strings.HasPrefix(name, "NLS_") {
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
+
+// sesParams returns the current values of the named parameters, from the
+// values known by WithParams or queried from NLS_SESSION_PARAMETERS and
+// V$PARAMETER.
+func (ses *Ses) sesParams(names []string) (values map[string]string, err error) {
+	values = make(map[string]string, len(names))
+	var nls, other []string
+	ses.mu.Lock()
+	for _, name := range names {
+		if value, ok := ses.params[name]; ok {
+			values[name] = value
+		} else if strings.HasPrefix(name, "NLS_") {
+			nls = append(nls, name)
+		} else {
+			other = append(other, name)
+		}
+	}
+	ses.mu.Unlock()
+	for _, query := range []struct {
+		sql   string
+		names []string
+	}{
+		{sql: "SELECT PARAMETER, VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER IN (:1)", names: nls},
+		{sql: "SELECT UPPER(NAME), VALUE FROM V$PARAMETER WHERE UPPER(NAME) IN (:1)", names: other},
+	} {
+		if len(query.names) == 0 {
+			continue
+		}
+		rset, err := ses.qry(query.sql, In(query.names))
+		if err != nil {
+			return nil, errE(err)
+		}
+		for rset.Next() {
+			name, _ := rset.Row[0].(string)
+			value, _ := rset.Row[1].(string)
+			values[name] = value
+		}
+		if rset.Err != nil {
+			return nil, errE(rset.Err)
+		}
+	}
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			return nil, errF("Session parameter %v has no value to restore.", name)
+		}
+	}
+	return values, nil
+}
+
+// alterSesParams sets the parameters with ALTER SESSION, and records their
+// values.
+func (ses *Ses) alterSesParams(params map[string]string) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := ses.alter(alterSession(params)); err != nil {
+		return err
+	}
+	ses.mu.Lock()
+	if ses.params == nil {
+		ses.params = make(map[string]string, len(params))
+	}
+	for name, value := range params {
+		ses.params[name] = value
+	}
+	ses.mu.Unlock()
+	return nil
+}
+
+// WithParams sets the session parameters params, keyed by name, such as
+// OPTIMIZER_MODE or NLS_DATE_FORMAT, with ALTER SESSION, calls fn, and
+// restores the previous values of the parameters when fn returns or panics.
+// The error of fn is returned.
+//
+// The values of the parameters are read from NLS_SESSION_PARAMETERS, and
+// V$PARAMETER for parameters other than NLS parameters, which requires the
+// SELECT privilege on V$PARAMETER. The values are cached so that parameters
+// already set to the value aren't set again, and later calls don't query
+// them. The cache is cleared by ALTER SESSION statements executed on the Ses,
+// by Ses.RestoreState and when the Ses is closed.
+func (ses *Ses) WithParams(params map[string]string, fn func() error) (err error) {
+	ses.log(_drv.cfg().Log.Ses.WithParams)
+	if err = ses.checkClosed(); err != nil {
+		return errE(err)
+	}
+	params, names, err := sesParamNames(params)
+	if err != nil {
+		return errE(err)
+	}
+	prev, err := ses.sesParams(names)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]string, len(names))
+	restore := make(map[string]string, len(names))
+	for _, name := range names {
+		if !sameSesParam(name, params[name], prev[name]) {
+			set[name] = params[name]
+			restore[name] = prev[name]
+		}
+	}
+	if err = ses.alterSesParams(set); err != nil {
+		return errE(err)
+	}
+	defer func() {
+		if err0 := ses.alterSesParams(restore); err == nil && err0 != nil {
+			err = errE(err0)
+		}
+	}()
+	return fn()
+}
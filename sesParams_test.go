@@ -0,0 +1,38 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestAlterSession tests the ALTER SESSION statements of Ses.WithParams.
+func TestAlterSession(t *testing.T) {
+	params, names, err := sesParamNames(map[string]string{
+		"optimizer_mode":           "FIRST_ROWS_10",
+		"OPTIMIZER_INDEX_COST_ADJ": "50",
+		"nls_date_format":          "YYYY-MM-DD",
+		"NLS_SORT":                 "BINARY",
+		"plsql_ccflags":            "debug:TRUE",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 5 || names[0] != "NLS_DATE_FORMAT" || names[4] != "PLSQL_CCFLAGS" {
+		t.Errorf("got names %v", names)
+	}
+	got := alterSession(params)
+	want := "ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD' NLS_SORT = 'BINARY'" +
+		" OPTIMIZER_INDEX_COST_ADJ = 50 OPTIMIZER_MODE = FIRST_ROWS_10 PLSQL_CCFLAGS = 'debug:TRUE'"
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	for _, name := range []string{"", "optimizer_mode = ALL_ROWS --"} {
+		if _, _, err = sesParamNames(map[string]string{name: "x"}); err == nil {
+			t.Errorf("%q: wanted an error", name)
+		}
+	}
+	if !sameSesParam("OPTIMIZER_MODE", "all_rows", "ALL_ROWS") || sameSesParam("NLS_DATE_FORMAT", "dd", "DD") {
+		t.Error("wrong comparison of parameter values")
+	}
+}
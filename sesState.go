@@ -0,0 +1,329 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SesState is a snapshot of the state of a Ses taken by Ses.SaveState.
+type SesState struct {
+	// Nls are the NLS session parameters keyed by name, such as NLS_DATE_FORMAT.
+	Nls map[string]string
+	// CurrentSchema is the schema used to resolve unqualified names.
+	CurrentSchema string
+	// Module, Action and ClientInfo are the DBMS_APPLICATION_INFO values.
+	Module     string
+	Action     string
+	ClientInfo string
+	// IsolationLevel is the isolation level set with Ses.SetIsolationLevel,
+	// or empty when not set.
+	IsolationLevel string
+}
+
+// SaveState returns a snapshot of the NLS parameters, current schema,
+// application info and isolation level of the Ses.
+func (ses *Ses) SaveState() (state SesState, err error) {
+	ses.log(_drv.cfg().Log.Ses.SaveState)
+	if state, err = ses.currentState(); err != nil {
+		return state, errE(err)
+	}
+	ses.mu.Lock()
+	state.IsolationLevel = ses.isolationLevel
+	ses.mu.Unlock()
+	return state, nil
+}
+
+// currentState queries the NLS parameters, current schema and application
+// info of the Ses in one round trip.
+func (ses *Ses) currentState() (state SesState, err error) {
+	rset, err := ses.qry(`SELECT PARAMETER, VALUE FROM NLS_SESSION_PARAMETERS
+UNION ALL SELECT 'CURRENT_SCHEMA', SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL
+UNION ALL SELECT 'MODULE', SYS_CONTEXT('USERENV', 'MODULE') FROM DUAL
+UNION ALL SELECT 'ACTION', SYS_CONTEXT('USERENV', 'ACTION') FROM DUAL
+UNION ALL SELECT 'CLIENT_INFO', SYS_CONTEXT('USERENV', 'CLIENT_INFO') FROM DUAL`)
+	if err != nil {
+		return state, err
+	}
+	state.Nls = make(map[string]string)
+	for rset.Next() {
+		name, _ := rset.Row[0].(string)
+		value, _ := rset.Row[1].(string)
+		switch name {
+		case "CURRENT_SCHEMA":
+			state.CurrentSchema = value
+		case "MODULE":
+			state.Module = value
+		case "ACTION":
+			state.Action = value
+		case "CLIENT_INFO":
+			state.ClientInfo = value
+		default:
+			state.Nls[name] = value
+		}
+	}
+	return state, rset.Err
+}
+
+// RestoreState returns the Ses to a state captured by Ses.SaveState.
+//
+// The current values are queried in one round trip, and only the values
+// differing from state are set again, so that restoring an unchanged Ses
+// executes no other statement. The isolation level, which can't be queried,
+// is compared with the level last set on the Ses.
+//
+// NLS parameters absent from state keep their current values; an empty
+// IsolationLevel resets the isolation level to READ COMMITTED.
+func (ses *Ses) RestoreState(state SesState) (err error) {
+	ses.log(_drv.cfg().Log.Ses.RestoreState)
+	current, err := ses.currentState()
+	if err != nil {
+		return errE(err)
+	}
+	nls := make(map[string]string)
+	for name, value := range state.Nls {
+		if current.Nls[name] != value {
+			nls[name] = value
+		}
+	}
+	if len(nls) > 0 {
+		if err = ses.alter(alterNls(nls)); err != nil {
+			return errE(err)
+		}
+		ses.mu.Lock()
+		for name, value := range nls {
+			if _, ok := ses.params[name]; ok { // values known by WithParams
+				ses.params[name] = value
+			}
+		}
+		ses.mu.Unlock()
+	}
+	if state.CurrentSchema != "" && state.CurrentSchema != current.CurrentSchema {
+		if err = ses.alter(`ALTER SESSION SET CURRENT_SCHEMA = "` + state.CurrentSchema + `"`); err != nil {
+			return errE(err)
+		}
+	}
+	if state.CurrentSchema != "" {
+		ses.mu.Lock()
+		ses.currentSchema = state.CurrentSchema
+		ses.mu.Unlock()
+	}
+	if state.Module != current.Module || state.Action != current.Action || state.ClientInfo != current.ClientInfo {
+		_, err = ses.exe(`BEGIN
+	DBMS_APPLICATION_INFO.SET_MODULE(:1, :2);
+	DBMS_APPLICATION_INFO.SET_CLIENT_INFO(:3);
+END;`, String{Value: state.Module, IsNull: state.Module == ""},
+			String{Value: state.Action, IsNull: state.Action == ""},
+			String{Value: state.ClientInfo, IsNull: state.ClientInfo == ""})
+		if err != nil {
+			return errE(err)
+		}
+	}
+	ses.tagMu.Lock()
+	ses.action = state.Action
+	ses.tagMu.Unlock()
+	level := state.IsolationLevel
+	if level == "" {
+		level = "READ COMMITTED"
+	}
+	ses.mu.Lock()
+	currentLevel := ses.isolationLevel
+	ses.mu.Unlock()
+	if currentLevel == "" {
+		currentLevel = "READ COMMITTED" // the default of a session
+	}
+	if level == currentLevel {
+		return nil
+	}
+	return ses.SetIsolationLevel(level)
+}
+
+// SetIsolationLevel sets the isolation level of transactions started on the
+// Ses to "READ COMMITTED" or "SERIALIZABLE".
+func (ses *Ses) SetIsolationLevel(level string) (err error) {
+	ses.log(_drv.cfg().Log.Ses.SetIsolationLevel)
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if level != "READ COMMITTED" && level != "SERIALIZABLE" {
+		return errF("Unsupported isolation level %q.", level)
+	}
+	if err = ses.alter("ALTER SESSION SET ISOLATION_LEVEL = " + level); err != nil {
+		return errE(err)
+	}
+	ses.mu.Lock()
+	ses.isolationLevel = level
+	ses.mu.Unlock()
+	return nil
+}
+
+// SetCurrentSchema sets the schema used to resolve unqualified names in SQL
+// statements of the Ses.
+//
+// An unquoted name is converted to upper case; a double quoted name is used
+// as is. An empty name switches back to the schema of the session user.
+//
+// The schema is cached so that setting the current schema again is free. The
+// cache is cleared by ALTER SESSION statements executed on the Ses, and when
+// the Ses is closed. Pool.Put restores the current schema a pooled Ses was
+// opened with.
+func (ses *Ses) SetCurrentSchema(name string) (err error) {
+	ses.log(_drv.cfg().Log.Ses.SetCurrentSchema)
+	schema, err := schemaName(name)
+	if err != nil {
+		return errE(err)
+	}
+	if schema == "" {
+		rset, err := ses.qry("SELECT SYS_CONTEXT('USERENV', 'SESSION_USER') FROM DUAL")
+		if err != nil {
+			return errE(err)
+		}
+		for rset.Next() {
+			schema, _ = rset.Row[0].(string)
+		}
+		if rset.Err != nil {
+			return errE(rset.Err)
+		}
+	}
+	ses.mu.Lock()
+	cached := ses.currentSchema == schema
+	ses.mu.Unlock()
+	if cached {
+		return nil
+	}
+	if err = ses.alter(`ALTER SESSION SET CURRENT_SCHEMA = "` + schema + `"`); err != nil {
+		return errE(err)
+	}
+	ses.mu.Lock()
+	ses.currentSchema = schema
+	ses.mu.Unlock()
+	return nil
+}
+
+// CurrentSchema returns the schema last set with Ses.SetCurrentSchema, or an
+// empty string when the current schema hasn't been set.
+func (ses *Ses) CurrentSchema() string {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	return ses.currentSchema
+}
+
+// alter executes sql, an ALTER SESSION statement of the driver, leaving the
+// session state cached by the Ses to the caller: sesAltered isn't called, so
+// that setting one value doesn't clear the others.
+func (ses *Ses) alter(sql string) error {
+	prep := func(sql string, gcts ...GoColumnType) (*Stmt, error) {
+		stmt, err := ses.prepSql(sql, gcts...)
+		if stmt != nil {
+			stmt.ownAlter = true
+		}
+		return stmt, err
+	}
+	_, err := ses.prepAndExe(prep, sql, nil)
+	return err
+}
+
+// sesAltered updates the session state cached by the Ses after sql, an
+// executed ALTER SESSION statement, so that a statement run with Stmt.Exe
+// doesn't leave a stale value: the cached current schema and the parameter
+// values known by WithParams are cleared, and a new isolation level is
+// recorded. The ALTER SESSION statements of the driver, run with Ses.alter,
+// update the cache themselves.
+func (ses *Ses) sesAltered(sql string) {
+	words := topLevelWords(sql)
+	if len(words) < 3 || words[0].text != "ALTER" || words[1].text != "SESSION" {
+		return
+	}
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	ses.currentSchema = ""
+	ses.params = nil
+	if level, ok := alteredIsolationLevel(words); ok {
+		ses.isolationLevel = level
+	}
+}
+
+// alteredIsolationLevel returns the isolation level set by the words of an
+// ALTER SESSION statement.
+func alteredIsolationLevel(words []sqlWord) (level string, ok bool) {
+	for n := 0; n+1 < len(words); n++ {
+		if words[n].text != "ISOLATION_LEVEL" {
+			continue
+		}
+		switch words[n+1].text {
+		case "SERIALIZABLE":
+			return "SERIALIZABLE", true
+		case "READ":
+			return "READ COMMITTED", true
+		}
+	}
+	return "", false
+}
+
+// schemaName returns the stored form of a schema name.
+func schemaName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) > 1 && name[0] == '"' && name[len(name)-1] == '"' {
+		quoted := name[1 : len(name)-1]
+		if quoted == "" || strings.IndexByte(quoted, '"') >= 0 {
+			return "", errF("Invalid schema name %v.", name)
+		}
+		return quoted, nil
+	}
+	for n := 0; n < len(name); n++ {
+		if !isPlaceholderChar(name[n]) {
+			return "", errF("Invalid schema name %q.", name)
+		}
+	}
+	return strings.ToUpper(name), nil
+}
+
+// alterNls returns an ALTER SESSION statement setting the NLS parameters.
+func alterNls(nls map[string]string) string {
+	return alterSession(nls)
+}
+
+// alterSession returns an ALTER SESSION statement setting the parameters,
+// keyed by upper-cased name; see sesParamValue.
+//
+// NLS_LANGUAGE and NLS_TERRITORY are set first as they reset the defaults of
+// other parameters.
+func alterSession(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if name != "NLS_LANGUAGE" && name != "NLS_TERRITORY" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range []string{"NLS_TERRITORY", "NLS_LANGUAGE"} {
+		if _, ok := params[name]; ok {
+			names = append([]string{name}, names...)
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString("ALTER SESSION SET")
+	for _, name := range names {
+		fmt.Fprintf(&buf, " %v = %v", name, sesParamValue(name, params[name]))
+	}
+	return buf.String()
+}
+
+// sesParamValue returns the value of an ALTER SESSION parameter: a quoted
+// literal for NLS parameters and values other than a number or identifier,
+// such as 0.5, ALL_ROWS or TRUE.
+func sesParamValue(name, value string) string {
+	if !strings.HasPrefix(name, "NLS_") && value != "" {
+		word := true
+		for n := 0; n < len(value) && word; n++ {
+			word = isPlaceholderChar(value[n]) || value[n] == '.'
+		}
+		if word {
+			return value
+		}
+	}
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
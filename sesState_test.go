@@ -0,0 +1,63 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestAlterNls tests alterNls.
+func TestAlterNls(t *testing.T) {
+	got := alterNls(map[string]string{
+		"NLS_DATE_FORMAT": "DD-MON-RR",
+		"NLS_TERRITORY":   "AMERICA",
+		"NLS_CURRENCY":    "$'",
+		"NLS_LANGUAGE":    "AMERICAN",
+	})
+	want := "ALTER SESSION SET NLS_LANGUAGE = 'AMERICAN' NLS_TERRITORY = 'AMERICA'" +
+		" NLS_CURRENCY = '$''' NLS_DATE_FORMAT = 'DD-MON-RR'"
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+// TestSchemaName tests schemaName.
+func TestSchemaName(t *testing.T) {
+	for _, tc := range []struct {
+		name, want string
+		err        bool
+	}{
+		{name: "hr", want: "HR"},
+		{name: " App_1$ ", want: "APP_1$"},
+		{name: `"MixedCase"`, want: "MixedCase"},
+		{name: "", want: ""},
+		{name: "hr; DROP", err: true},
+		{name: `"a"b"`, err: true},
+	} {
+		got, err := schemaName(tc.name)
+		if (err != nil) != tc.err {
+			t.Errorf("%q: got error %v", tc.name, err)
+		} else if got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestAlteredIsolationLevel tests alteredIsolationLevel.
+func TestAlteredIsolationLevel(t *testing.T) {
+	for _, tc := range []struct {
+		sql, want string
+		ok        bool
+	}{
+		{sql: "ALTER SESSION SET ISOLATION_LEVEL = SERIALIZABLE", want: "SERIALIZABLE", ok: true},
+		{sql: "alter session set isolation_level=read committed", want: "READ COMMITTED", ok: true},
+		{sql: "ALTER SESSION SET CURRENT_SCHEMA = HR ISOLATION_LEVEL = SERIALIZABLE", want: "SERIALIZABLE", ok: true},
+		{sql: "ALTER SESSION SET CURRENT_SCHEMA = HR"},
+		{sql: "ALTER SESSION SET NLS_DATE_FORMAT = 'ISOLATION_LEVEL SERIALIZABLE'"},
+	} {
+		got, ok := alteredIsolationLevel(topLevelWords(tc.sql))
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%q: got %q %v, wanted %q %v", tc.sql, got, ok, tc.want, tc.ok)
+		}
+	}
+}
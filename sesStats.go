@@ -0,0 +1,99 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SesStatNames are the session statistics returned by Ses.Stats when no
+// names are given.
+var SesStatNames = []string{
+	"parse count (total)",
+	"parse count (hard)",
+	"execute count",
+	"user calls",
+	"SQL*Net roundtrips to/from client",
+	"bytes sent via SQL*Net to client",
+	"bytes received via SQL*Net from client",
+	"sorts (memory)",
+	"sorts (disk)",
+	"session logical reads",
+	"session uga memory",
+	"session pga memory",
+}
+
+// SesStats are session statistics keyed by name, as in V$STATNAME.
+type SesStats map[string]int64
+
+// Delta returns the change of each statistic of s since before.
+func (s SesStats) Delta(before SesStats) SesStats {
+	delta := make(SesStats, len(s))
+	for name, value := range s {
+		delta[name] = value - before[name]
+	}
+	return delta
+}
+
+// Stats returns a snapshot of the statistics of the server session named by
+// names, or of SesStatNames when no names are given. Stats requires SELECT
+// privileges on V$MYSTAT and V$STATNAME.
+//
+// The query of Stats itself counts a parse, an execute and a round trip or
+// more; compare snapshots with SesStats.Delta, or use StatsDelta.
+func (ses *Ses) Stats(names ...string) (stats SesStats, err error) {
+	ses.log(_drv.cfg().Log.Ses.Stats)
+	return ses.stats(names)
+}
+
+// StatsDelta returns the change of the statistics named by names, or of
+// SesStatNames when no names are given, over a call to fn. The error of fn is
+// returned with the statistics.
+func (ses *Ses) StatsDelta(fn func() error, names ...string) (delta SesStats, err error) {
+	ses.log(_drv.cfg().Log.Ses.StatsDelta)
+	before, err := ses.stats(names)
+	if err != nil {
+		return nil, errE(err)
+	}
+	fnErr := fn()
+	after, err := ses.stats(names)
+	if err != nil {
+		return nil, errE(err)
+	}
+	return after.Delta(before), fnErr
+}
+
+func (ses *Ses) stats(names []string) (stats SesStats, err error) {
+	if len(names) == 0 {
+		names = SesStatNames
+	}
+	placeholders := make([]string, len(names))
+	params := make([]interface{}, len(names))
+	for n, name := range names {
+		placeholders[n] = fmt.Sprintf(":%d", n+1)
+		params[n] = name
+	}
+	rset, err := ses.qry(`SELECT N.NAME, S.VALUE
+FROM V$MYSTAT S JOIN V$STATNAME N ON N.STATISTIC# = S.STATISTIC#
+WHERE N.NAME IN (`+strings.Join(placeholders, ", ")+`)`, params...)
+	if err != nil {
+		return nil, errE(err)
+	}
+	stats = make(SesStats, len(names))
+	for rset.Next() {
+		name, _ := rset.Row[0].(string)
+		switch value := rset.Row[1].(type) {
+		case int64:
+			stats[name] = value
+		case float64:
+			stats[name] = int64(value)
+		}
+	}
+	if rset.Err != nil {
+		return nil, errE(rset.Err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,19 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestSesStatsDelta tests SesStats.Delta.
+func TestSesStatsDelta(t *testing.T) {
+	before := SesStats{"execute count": 10, "user calls": 4}
+	after := SesStats{"execute count": 13, "user calls": 4, "sorts (memory)": 2}
+	delta := after.Delta(before)
+	for name, want := range map[string]int64{"execute count": 3, "user calls": 0, "sorts (memory)": 2} {
+		if got := delta[name]; got != want {
+			t.Errorf("%v: got %d, wanted %d", name, got, want)
+		}
+	}
+}
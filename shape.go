@@ -0,0 +1,107 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "strings"
+
+// ExpectColumns describes the select-list of the Stmt without executing it
+// and returns an error when it doesn't have the expected shape.
+//
+// Each expected value is a column name, compared case-insensitively, or a
+// GoColumnType which must be valid for the column's Oracle type; D matches
+// any column. The number of expected values must equal the number of
+// columns. The GoColumnTypes specified to Ses.Prep are validated as well.
+//
+// Call ExpectColumns after Ses.Prep to detect schema drift before the first
+// Stmt.Qry.
+func (stmt *Stmt) ExpectColumns(expected ...interface{}) (err error) {
+	stmt.race.enter("Stmt", stmt, "ExpectColumns")
+	defer stmt.race.leave()
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.log(_drv.cfg().Log.Stmt.ExpectColumns)
+	err = stmt.checkClosed()
+	if err != nil {
+		return errE(err)
+	}
+	err = stmt.prepare()
+	if err != nil {
+		return errE(err)
+	}
+	desc, err := stmt.describe()
+	if err != nil {
+		return errE(err)
+	}
+	err = expectColumns(desc.Columns, stmt.gcts, expected)
+	if err != nil {
+		return errE(err)
+	}
+	return nil
+}
+
+// expectColumns compares described columns to the expected names and
+// GoColumnTypes, and to the GoColumnTypes gcts specified to Ses.Prep.
+func expectColumns(cols []ColumnInfo, gcts []GoColumnType, expected []interface{}) error {
+	if len(cols) != len(expected) {
+		return errF("Expected %d columns, described %d.", len(expected), len(cols))
+	}
+	for n, col := range cols {
+		switch e := expected[n].(type) {
+		case string:
+			if !strings.EqualFold(e, col.Name) {
+				return errF("Column %d: expected name %v, described %v.", n+1, e, col.Name)
+			}
+		case GoColumnType:
+			if err := checkColumnGct(col, e); err != nil {
+				return errF("Column %d (%v): %v", n+1, col.Name, err)
+			}
+		default:
+			return errF("Column %d: expected value %T is neither a name nor a GoColumnType.", n+1, e)
+		}
+		if n < len(gcts) {
+			if err := checkColumnGct(col, gcts[n]); err != nil {
+				return errF("Column %d (%v): %v", n+1, col.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkColumnGct returns nil when gct may define the described column;
+// otherwise, an error. It applies the checks of Rset.open.
+func checkColumnGct(col ColumnInfo, gct GoColumnType) error {
+	if gct == D {
+		return nil
+	}
+	switch col.Type {
+	case "NUMBER":
+		if gct == B || gct == OraB {
+			return nil
+		}
+		return checkNumericColumn(gct, col.Name)
+	case "BINARY_FLOAT", "BINARY_DOUBLE":
+		return checkNumericColumn(gct, col.Name)
+	case "DATE", "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+		return checkTimeColumn(gct)
+	case "VARCHAR2", "LONG":
+		return checkStringColumn(gct)
+	case "CHAR":
+		if col.Size == 1 || col.Size == 4 { // CHAR(1 CHAR) is 4 bytes in AL32UTF8
+			return checkBoolOrStringColumn(gct)
+		}
+		return checkStringColumn(gct)
+	case "CLOB":
+		return checkLobColumn(gct, checkStringColumn)
+	case "BLOB":
+		return checkLobColumn(gct, checkBinColumn)
+	case "JSON":
+		return checkJSONColumn(gct)
+	case "RAW":
+		return checkUUIDColumn(gct, col.Size)
+	case "LONG RAW":
+		return checkBinColumn(gct)
+	}
+	return nil // the GoColumnType of other columns is ignored
+}
@@ -0,0 +1,35 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestExpectColumns tests expectColumns.
+func TestExpectColumns(t *testing.T) {
+	cols := []ColumnInfo{
+		{Name: "ID", Type: "NUMBER", Size: 22, Precision: 10},
+		{Name: "NAME", Type: "VARCHAR2", Size: 40},
+		{Name: "FLAG", Type: "CHAR", Size: 1},
+	}
+	for _, tc := range []struct {
+		gcts     []GoColumnType
+		expected []interface{}
+		ok       bool
+	}{
+		{expected: []interface{}{"id", "Name", "FLAG"}, ok: true},
+		{expected: []interface{}{I64, OraS, B}, ok: true},
+		{expected: []interface{}{D, "name", OraS}, ok: true},
+		{expected: []interface{}{"ID", "NAME"}},
+		{expected: []interface{}{"ID", "TITLE", "FLAG"}},
+		{expected: []interface{}{T, S, B}},
+		{expected: []interface{}{"ID", "NAME", 3}},
+		{gcts: []GoColumnType{I64, I64}, expected: []interface{}{"ID", "NAME", "FLAG"}},
+	} {
+		err := expectColumns(cols, tc.gcts, tc.expected)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v %v: got error %v", tc.gcts, tc.expected, err)
+		}
+	}
+}
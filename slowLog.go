@@ -0,0 +1,115 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"fmt"
+	"time"
+)
+
+// logSlow logs an execution which took at least StmtCfg.SlowThreshold, with
+// the SQL_ID, child cursor number and captured binds of the statement when
+// StmtCfg.SlowBindCapture is true. Captured bind values are masked as
+// configured by LogDrvCfg.Redact. No locking occurs.
+//
+// exeErr is the error of the execution, read from the error handle before
+// logSlow is called; a failed execution is logged without the diagnostic
+// queries.
+func (stmt *Stmt) logSlow(elapsed time.Duration, exeErr error) {
+	if !isSlow(stmt.cfg.SlowThreshold, elapsed) {
+		return
+	}
+	var sqlId, note string
+	var child int64
+	var binds [][]interface{}
+	switch {
+	case exeErr != nil:
+		note = fmt.Sprintf("failed: %v", exeErr)
+	case stmt.cfg.SlowBindCapture:
+		var err error
+		sqlId, child, err = stmt.ses.prevCursor()
+		if err == nil {
+			binds, err = stmt.ses.diagRows("SELECT POSITION, NAME, DATATYPE_STRING, VALUE_STRING FROM V$SQL_BIND_CAPTURE WHERE SQL_ID = :1 AND CHILD_NUMBER = :2 ORDER BY POSITION", sqlId, child)
+		}
+		if err != nil {
+			note = fmt.Sprintf("no bind capture: %v", err)
+		}
+	}
+	lgr.Infof("%v %v: %v", stmt.sysName(), slowMsg(elapsed, sqlId, child, binds, note, stmt.redactBind), stmt.sql)
+}
+
+// isSlow reports whether an execution taking elapsed is logged as slow with
+// threshold; a threshold of zero or less logs none.
+func isSlow(threshold, elapsed time.Duration) bool {
+	return threshold > 0 && elapsed >= threshold
+}
+
+// slowMsg formats a slow execution message with the SQL_ID and child cursor
+// number when sqlId isn't empty, the binds masked by redact, and a note.
+func slowMsg(elapsed time.Duration, sqlId string, child int64, binds [][]interface{}, note string, redact func(n int, value interface{}) interface{}) string {
+	msg := fmt.Sprintf("slow execution %v", elapsed)
+	if sqlId != "" {
+		msg += fmt.Sprintf(" sql_id=%v child=%d", sqlId, child)
+	}
+	for _, bind := range binds {
+		position := diagInt(bind[0])
+		msg += fmt.Sprintf(" bind %d %v %v=%v", position, bind[1], bind[2], redact(int(position)-1, bind[3]))
+	}
+	if note != "" {
+		msg += " (" + note + ")"
+	}
+	return msg
+}
+
+// prevCursor returns the SQL_ID and child cursor number of the statement
+// executed last on the Ses, from V$SESSION.
+func (ses *Ses) prevCursor() (sqlId string, child int64, err error) {
+	rows, err := ses.diagRows("SELECT PREV_SQL_ID, PREV_CHILD_NUMBER FROM V$SESSION WHERE SID = SYS_CONTEXT('USERENV', 'SID')")
+	if err != nil {
+		return "", 0, err
+	}
+	if len(rows) > 0 {
+		sqlId, _ = rows[0][0].(string)
+		child = diagInt(rows[0][1])
+	}
+	if sqlId == "" {
+		return "", 0, er("The previous execution of the Ses wasn't found.")
+	}
+	return sqlId, child, nil
+}
+
+// diagRows returns the rows of a diagnostic query. The query itself isn't
+// logged as slow.
+func (ses *Ses) diagRows(sql string, params ...interface{}) (rows [][]interface{}, err error) {
+	stmt, err := ses.prepSql(sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	cfg := stmt.Cfg()
+	cfg.SlowThreshold = 0
+	stmt.SetCfg(cfg)
+	rset, err := stmt.Qry(params...)
+	if err != nil {
+		return nil, err
+	}
+	for rset.Next() {
+		row := make([]interface{}, len(rset.Row))
+		copy(row, rset.Row)
+		rows = append(rows, row)
+	}
+	return rows, rset.Err
+}
+
+// diagInt returns a number of a diagnostic query as an int64.
+func diagInt(v interface{}) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
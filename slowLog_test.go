@@ -0,0 +1,57 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIsSlow tests isSlow.
+func TestIsSlow(t *testing.T) {
+	for i, tc := range []struct {
+		threshold, elapsed time.Duration
+		want               bool
+	}{
+		{0, time.Hour, false},
+		{-time.Second, time.Hour, false},
+		{time.Second, time.Second - 1, false},
+		{time.Second, time.Second, true},
+		{time.Second, time.Minute, true},
+	} {
+		if got := isSlow(tc.threshold, tc.elapsed); got != tc.want {
+			t.Errorf("%d. got %v, want %v.", i, got, tc.want)
+		}
+	}
+}
+
+// TestSlowMsg tests slowMsg.
+func TestSlowMsg(t *testing.T) {
+	redact := func(n int, value interface{}) interface{} {
+		if n == 1 {
+			return redactMask
+		}
+		return value
+	}
+	binds := [][]interface{}{
+		{int64(1), ":1", "NUMBER", "42"},
+		{float64(2), ":PWD", "VARCHAR2(32)", "secret"},
+	}
+	for i, tc := range []struct {
+		sqlId string
+		binds [][]interface{}
+		note  string
+		want  string
+	}{
+		{"", nil, "", "slow execution 2s"},
+		{"", nil, "failed: ORA-01013", "slow execution 2s (failed: ORA-01013)"},
+		{"abc", nil, "", "slow execution 2s sql_id=abc child=3"},
+		{"abc", binds, "", "slow execution 2s sql_id=abc child=3 bind 1 :1 NUMBER=42 bind 2 :PWD VARCHAR2(32)=" + redactMask},
+	} {
+		if got := slowMsg(2*time.Second, tc.sqlId, 3, tc.binds, tc.note, redact); got != tc.want {
+			t.Errorf("%d. got %q, want %q.", i, got, tc.want)
+		}
+	}
+}
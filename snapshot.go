@@ -0,0 +1,52 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// WithSnapshot calls fn in a read-only transaction of the Ses, so that the
+// queries of fn, such as of an export, see the data committed before fn was
+// called. fn may not change data. The transaction ends when fn returns or
+// panics, and the error of fn is returned.
+//
+// WithSnapshot fails when the Ses has a transaction with changes.
+func (ses *Ses) WithSnapshot(fn func(ses *Ses) error) (err error) {
+	ses.log(_drv.cfg().Log.Ses.WithSnapshot)
+	tx, err := ses.StartTx(TxReadOnly())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// ending a read-only transaction changes nothing
+		if err0 := tx.Rollback(); err == nil && err0 != nil {
+			err = errE(err0)
+		}
+	}()
+	return fn(ses)
+}
+
+// WithSnapshotAt calls fn with the Ses in flashback mode at scn, such as
+// returned by Ses.CurrentScn, so that the queries of fn see the data committed
+// when scn was current; queries of other sessions given the same scn see the
+// same data. fn may not change data, nor use WithAsOfScn or WithAsOfTime.
+// Flashback mode ends when fn returns or panics, and the error of fn is
+// returned.
+//
+// WithSnapshotAt requires the EXECUTE privilege on DBMS_FLASHBACK, and fails
+// when the Ses has a transaction with changes.
+func (ses *Ses) WithSnapshotAt(scn uint64, fn func(ses *Ses) error) (err error) {
+	ses.log(_drv.cfg().Log.Ses.WithSnapshot, scn)
+	if scn == 0 {
+		return er("The SCN of WithSnapshotAt must be positive.")
+	}
+	disable, err := ses.enableFlashback(flashbackPoint{scn: scn})
+	if err != nil {
+		return errE(err)
+	}
+	defer func() {
+		if err0 := disable(); err == nil && err0 != nil {
+			err = errE(err0)
+		}
+	}()
+	return fn(ses)
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+func init() {
+	// the types of fetched values, as gob encodes interface values by
+	// registered type
+	for _, value := range []interface{}{
+		Int64{}, Int32{}, Int16{}, Int8{}, Uint64{}, Uint32{}, Uint16{}, Uint8{},
+		Float64{}, Float32{}, Time{}, String{}, Bool{}, Raw{}, Bfile{},
+		IntervalYM{}, IntervalDS{}, UUID{}, time.Time{}, json.RawMessage{},
+		map[string]interface{}{}, []interface{}{},
+	} {
+		gob.Register(value)
+	}
+}
+
+// Spool holds the rows of a result set spooled to a temporary file, for
+// iterating result sets too large to materialize in memory more than once
+// or after closing the Rset and its Ses.
+//
+// Spool is not safe for concurrent use.
+type Spool struct {
+	// ColumnNames are the select-list column names of the spooled Rset.
+	ColumnNames []string
+	// Row is the current row, loaded by Next.
+	Row []interface{}
+	// Err is the error of the last Next returning false, or nil.
+	Err error
+
+	file  *os.File
+	rdr   *bufio.Reader
+	dec   *gob.Decoder
+	len   int
+	index int
+}
+
+// Spool fetches the remaining rows of the Rset to a temporary file created in
+// dir, or in os.TempDir when dir is empty, and returns a Spool iterating the
+// rows from the file. The Rset has no rows left when Spool returns.
+//
+// LOB, BFILE data and nested Rset columns can't be spooled; select LOBs as
+// string or []byte GoColumnTypes. Close the Spool to remove the file.
+func (rset *Rset) Spool(dir string) (spool *Spool, err error) {
+	rset.log(_drv.cfg().Log.Rset.Spool)
+	file, err := ioutil.TempFile(dir, "ora-spool-")
+	if err != nil {
+		return nil, errE(err)
+	}
+	spool = &Spool{file: file, ColumnNames: append([]string(nil), rset.ColumnNames...)}
+	defer func() {
+		if err != nil {
+			spool.Close()
+			spool = nil
+		}
+	}()
+	w := bufio.NewWriter(file)
+	enc := gob.NewEncoder(w)
+	for rset.Next() {
+		if err = enc.Encode(rset.Row); err != nil {
+			return nil, errF("Unable to spool row %d: %v", rset.Index+1, err)
+		}
+		spool.len++
+	}
+	if rset.Err != nil {
+		return nil, errE(rset.Err)
+	}
+	if err = w.Flush(); err != nil {
+		return nil, errE(err)
+	}
+	if err = spool.Rewind(); err != nil {
+		return nil, err
+	}
+	return spool, nil
+}
+
+// Len returns the number of spooled rows.
+func (spool *Spool) Len() int {
+	return spool.len
+}
+
+// Index returns the zero-based position of the current row, or -1 before the
+// first call to Next.
+func (spool *Spool) Index() int {
+	return spool.index
+}
+
+// Next loads the next spooled row into Row, returning false after the last
+// row or on an error, which is set to Err.
+func (spool *Spool) Next() bool {
+	if spool.dec == nil || spool.index+1 >= spool.len {
+		spool.Row = nil
+		return false
+	}
+	var row []interface{}
+	if err := spool.dec.Decode(&row); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		spool.Err = errE(err)
+		spool.Row = nil
+		return false
+	}
+	spool.index++
+	spool.Row = row
+	return true
+}
+
+// Rewind positions the Spool before the first row, for iterating the rows
+// again.
+func (spool *Spool) Rewind() error {
+	if spool.file == nil {
+		return er("Spool is closed.")
+	}
+	if _, err := spool.file.Seek(0, io.SeekStart); err != nil {
+		return errE(err)
+	}
+	if spool.rdr == nil {
+		spool.rdr = bufio.NewReader(spool.file)
+	} else {
+		spool.rdr.Reset(spool.file)
+	}
+	spool.dec = gob.NewDecoder(spool.rdr)
+	spool.index = -1
+	spool.Row = nil
+	spool.Err = nil
+	return nil
+}
+
+// Close closes and removes the spool file.
+// It is valid to call Close more than once.
+func (spool *Spool) Close() error {
+	if spool.file == nil {
+		return nil
+	}
+	name := spool.file.Name()
+	err := spool.file.Close()
+	if err0 := os.Remove(name); err == nil {
+		err = err0
+	}
+	spool.file, spool.rdr, spool.dec, spool.Row = nil, nil, nil, nil
+	if err != nil {
+		return errE(err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSpool tests iterating, rewinding and closing a Spool.
+func TestSpool(t *testing.T) {
+	file, err := ioutil.TempFile("", "ora-spool-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]interface{}{
+		{int64(1), "a", time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC), []byte{1}},
+		{int64(2), String{IsNull: true}, Time{IsNull: true}, nil},
+		{Int64{Value: 3}, String{Value: "c"}, Float64{Value: 1.5}, UUID{1, 2}},
+	}
+	enc := gob.NewEncoder(file)
+	for _, row := range rows {
+		if err = enc.Encode(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	spool := &Spool{file: file, len: len(rows)}
+	for pass := 0; pass < 2; pass++ {
+		if err = spool.Rewind(); err != nil {
+			t.Fatal(err)
+		}
+		var got [][]interface{}
+		for spool.Next() {
+			got = append(got, spool.Row)
+		}
+		if spool.Err != nil {
+			t.Fatal(spool.Err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("pass %d: got %#v, wanted %#v", pass, got, rows)
+		}
+	}
+	name := file.Name()
+	if err = spool.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("spool file not removed: %v", err)
+	}
+	if err = spool.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
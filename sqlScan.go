@@ -0,0 +1,113 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// sqlTokenKind is the kind of a token returned by scanSql.
+type sqlTokenKind int
+
+const (
+	// sqlTokOther is a byte of no other kind, such as white space, a
+	// parenthesis, a comma or a '?'.
+	sqlTokOther sqlTokenKind = iota
+	// sqlTokQuoted is a string literal, including q'[...]' literals, a quoted
+	// identifier or a comment: text which holds no SQL to rewrite.
+	sqlTokQuoted
+	// sqlTokPlaceholder is an Oracle placeholder such as :1 or :name.
+	sqlTokPlaceholder
+	// sqlTokWord is an identifier, keyword or number, which may contain '$'
+	// and '#'.
+	sqlTokWord
+)
+
+// sqlToken is a token of a SQL statement and its index.
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	pos  int
+}
+
+// scanSql returns the tokens of sql. The tokens cover sql: joining their text
+// returns sql. Unterminated literals and comments end at the end of sql.
+//
+// Every func rewriting or inspecting the text of a statement scans it with
+// scanSql, so that they agree on what is quoted.
+func scanSql(sql string) (tokens []sqlToken) {
+	for i := 0; i < len(sql); {
+		kind, end := sqlTokOther, i+1
+		c := sql[i]
+		switch {
+		case (c == 'q' || c == 'Q') && i+2 < len(sql) && sql[i+1] == '\'':
+			kind, end = sqlTokQuoted, qLiteralEnd(sql, i+2)
+		case (c == 'n' || c == 'N') && i+3 < len(sql) && (sql[i+1] == 'q' || sql[i+1] == 'Q') && sql[i+2] == '\'':
+			kind, end = sqlTokQuoted, qLiteralEnd(sql, i+3)
+		case c == '\'' || c == '"': // literal or quoted identifier; '' and "" escape
+			kind, end = sqlTokQuoted, quotedEnd(sql, i)
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-': // line comment
+			kind, end = sqlTokQuoted, i+2
+			for end < len(sql) && sql[end] != '\n' {
+				end++
+			}
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*': // block comment
+			kind, end = sqlTokQuoted, i+2
+			for end+1 < len(sql) && !(sql[end] == '*' && sql[end+1] == '/') {
+				end++
+			}
+			if end += 2; end > len(sql) {
+				end = len(sql)
+			}
+		case c == ':' && i+1 < len(sql) && isPlaceholderChar(sql[i+1]):
+			kind = sqlTokPlaceholder
+			for end < len(sql) && isPlaceholderChar(sql[end]) {
+				end++
+			}
+		case isPlaceholderChar(c):
+			kind = sqlTokWord
+			for end < len(sql) && isPlaceholderChar(sql[end]) {
+				end++
+			}
+		}
+		tokens = append(tokens, sqlToken{kind: kind, text: sql[i:end], pos: i})
+		i = end
+	}
+	return tokens
+}
+
+// quotedEnd returns the index after the literal or quoted identifier of sql
+// whose opening quote is at i.
+func quotedEnd(sql string, i int) int {
+	quote := sql[i]
+	for end := i + 1; end < len(sql); end++ {
+		if sql[end] == quote {
+			if end+1 < len(sql) && sql[end+1] == quote {
+				end++
+				continue
+			}
+			return end + 1
+		}
+	}
+	return len(sql)
+}
+
+// qLiteralEnd returns the index after the q'...' literal of sql whose
+// opening delimiter is at i.
+func qLiteralEnd(sql string, i int) int {
+	closing := sql[i]
+	switch closing {
+	case '[':
+		closing = ']'
+	case '(':
+		closing = ')'
+	case '{':
+		closing = '}'
+	case '<':
+		closing = '>'
+	}
+	for end := i + 1; end+1 < len(sql); end++ {
+		if sql[end] == closing && sql[end+1] == '\'' {
+			return end + 2
+		}
+	}
+	return len(sql)
+}
@@ -0,0 +1,47 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestScanSql tests scanSql.
+func TestScanSql(t *testing.T) {
+	for i, tc := range []struct {
+		sql  string
+		want []string // the text of the tokens other than sqlTokOther
+	}{
+		{"SELECT 1 FROM DUAL", []string{"SELECT", "1", "FROM", "DUAL"}},
+		{"C1 = :c1 AND V$X = 'it''s' || \"A\"\"B\"", []string{"C1", ":c1", "AND", "V$X", "'it''s'", "\"A\"\"B\""}},
+		{"q'[it's]' || Q'{}}' || nq'<a>' || N'b' || qty", []string{"q'[it's]'", "Q'{}}'", "nq'<a>'", "N", "'b'", "qty"}},
+		{"q'!a'b!' x", []string{"q'!a'b!'", "x"}},
+		{"x := 1 -- c\n/* d */ y", []string{"x", "1", "-- c", "/* d */", "y"}},
+		{"'open", []string{"'open"}},
+		{"/* open", []string{"/* open"}},
+		{"q'[open", []string{"q'[open"}},
+	} {
+		tokens := scanSql(tc.sql)
+		var got []string
+		var all []string
+		for _, tok := range tokens {
+			if tc.sql[tok.pos:tok.pos+len(tok.text)] != tok.text {
+				t.Errorf("%d. token %q isn't at %d.", i, tok.text, tok.pos)
+			}
+			all = append(all, tok.text)
+			if tok.kind != sqlTokOther {
+				got = append(got, tok.text)
+			}
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d. got %q, want %q.", i, got, tc.want)
+		}
+		if joined := strings.Join(all, ""); joined != tc.sql {
+			t.Errorf("%d. tokens join to %q, want %q.", i, joined, tc.sql)
+		}
+	}
+}
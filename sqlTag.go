@@ -0,0 +1,78 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"strings"
+	"unsafe"
+)
+
+// sqlTagKey is the context key of a tag set by WithSqlTag.
+type sqlTagKey struct{}
+
+// WithSqlTag returns a copy of ctx carrying tag, which Stmt.ExeContext and
+// Stmt.QryContext report as the session action in place of SesCfg.SqlTag.
+//
+// Context tags never change the SQL text, so each statement keeps its SQL_ID
+// whatever the tag; see SesCfg.SqlTagAsAction.
+func WithSqlTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, sqlTagKey{}, tag)
+}
+
+// sqlTagFrom returns the tag carried by ctx, or an empty string.
+func sqlTagFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tag, _ := ctx.Value(sqlTagKey{}).(string)
+	return tag
+}
+
+// tagSql returns sql preceded by a comment holding tag.
+func tagSql(sql, tag string) string {
+	if tag == "" {
+		return sql
+	}
+	return "/* " + strings.Replace(tag, "*/", "* /", -1) + " */ " + sql
+}
+
+// tagAction sets the session action to the ReplayId action or tag of ctx, or
+// to SesCfg.SqlTag in action mode, when it differs from the current action,
+// and the execution context id to the ReplayId of ctx. Identifiers are sent to
+// the server with the next round trip. No locking of the Ses occurs.
+func (ses *Ses) tagAction(ctx context.Context) error {
+	id, replay := replayIdFrom(ctx)
+	tag := id.Action
+	if tag == "" {
+		tag = sqlTagFrom(ctx)
+	}
+	if tag == "" && ses.cfg.SqlTagAsAction {
+		tag = ses.cfg.SqlTag
+	}
+	ses.tagMu.Lock()
+	defer ses.tagMu.Unlock()
+	if replay {
+		if err := ses.tagEcid(id.Ecid); err != nil {
+			return err
+		}
+	}
+	if tag == ses.action {
+		return nil
+	}
+	cTag := C.CString(tag)
+	defer C.free(unsafe.Pointer(cTag))
+	err := ses.setAttr(unsafe.Pointer(ses.ocises), C.OCI_HTYPE_SESSION, unsafe.Pointer(cTag), C.ub4(len(tag)), C.OCI_ATTR_ACTION)
+	if err != nil {
+		return err
+	}
+	ses.action = tag
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"testing"
+)
+
+// TestTagSql tests tagSql and WithSqlTag.
+func TestTagSql(t *testing.T) {
+	if got := tagSql("SELECT 1 FROM DUAL", ""); got != "SELECT 1 FROM DUAL" {
+		t.Errorf("got %q for empty tag", got)
+	}
+	want := "/* app:svc * /x */ SELECT 1 FROM DUAL"
+	if got := tagSql("SELECT 1 FROM DUAL", "app:svc */x"); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if got := sqlTagFrom(WithSqlTag(context.Background(), "route")); got != "route" {
+		t.Errorf("got %q, wanted %q", got, "route")
+	}
+	if got := sqlTagFrom(nil); got != "" {
+		t.Errorf("got %q for nil context", got)
+	}
+	id := ReplayId{Action: "checkout", Ecid: "req-42"}
+	if got, ok := replayIdFrom(WithReplayId(context.Background(), id)); !ok || got != id {
+		t.Errorf("got %+v, wanted %+v", got, id)
+	}
+	if _, ok := replayIdFrom(context.Background()); ok {
+		t.Errorf("got a ReplayId for a context without one")
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"container/list"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -24,12 +26,60 @@ type SrvCfg struct {
 
 	// StmtCfg configures new Stmts.
 	StmtCfg *StmtCfg
+
+	// NonBlocking determines whether the server handle is put in OCI
+	// non-blocking mode when the first Ses is opened.
+	//
+	// In non-blocking mode a long-running OCI call returns control to the
+	// driver, which polls the call while letting the goroutine yield its OS
+	// thread. A call started with a context, such as Stmt.ExeContext, is
+	// broken when the context is done.
+	//
+	// The default is false.
+	NonBlocking bool
+
+	// PollInterval is the maximum wait between polls of a non-blocking OCI
+	// call. The wait starts small and doubles up to PollInterval.
+	//
+	// The default is 10ms.
+	PollInterval time.Duration
+
+	// Dial establishes the connections of the server, such as through a SOCKS
+	// proxy or an SSH tunnel to a bastion. When Dial is set, the listener
+	// address of Dblink is resolved with ResolveDblink, and OCI connects to a
+	// loopback listener forwarding each connection through Dial. Dblink must
+	// be an easy connect string or a connect descriptor.
+	//
+	// The default is nil.
+	Dial DialFunc
+
+	// TLS configures an encrypted TCPS connection. When TLS is set, Dblink is
+	// an easy connect string, such as "tcps://db.example.com:2484/orcl", or a
+	// connect descriptor with a TCPS address, and the wallet, server
+	// certificate and cipher settings of TLS are added to the descriptor.
+	//
+	// The default is nil.
+	TLS *TLSCfg
+
+	// Reconnect enables the automatic reconnection of the Srv. When an
+	// execution fails because the server connection is lost, such as with
+	// ORA-03113, the server is re-attached with exponential backoff and its
+	// sessions are re-opened in the background; Stmts are re-prepared on their
+	// next use. The failed call returns its error at once and isn't retried,
+	// calls made before the sessions are re-opened fail too, and open
+	// transactions and Rsets are lost: Rset.Next of an Rset opened before
+	// returns false with an error.
+	//
+	// The default is nil.
+	Reconnect *ReconnectCfg
 }
 
 // NewSrvCfg creates a SrvCfg with default values.
 func NewSrvCfg() *SrvCfg {
 	c := &SrvCfg{}
 	c.StmtCfg = NewStmtCfg()
+	c.NonBlocking = false
+	c.PollInterval = 10 * time.Millisecond
 	return c
 }
 
@@ -49,6 +99,18 @@ type LogSrvCfg struct {
 	//
 	// The default is true.
 	Version bool
+
+	// ExtendedStrings determines whether the Srv.ExtendedStrings method is
+	// logged.
+	//
+	// The default is true.
+	ExtendedStrings bool
+
+	// Reconnect determines whether the reconnection events of a Srv are
+	// logged.
+	//
+	// The default is true.
+	Reconnect bool
 }
 
 // NewLogSrvCfg creates a LogSrvCfg with default values.
@@ -57,17 +119,26 @@ func NewLogSrvCfg() LogSrvCfg {
 	c.Close = true
 	c.OpenSes = true
 	c.Version = true
+	c.ExtendedStrings = true
+	c.Reconnect = true
 	return c
 }
 
 // Srv represents an Oracle server.
 type Srv struct {
-	id       uint64
-	cfg      SrvCfg
-	mu       sync.Mutex
-	env      *Env
-	ocisrv   *C.OCIServer
-	dbIsUTF8 bool
+	id        uint64
+	cfg       SrvCfg
+	mu        sync.Mutex
+	env       *Env
+	ocisrv    *C.OCIServer
+	dbIsUTF8  bool
+	major     int32 // server major version cached by majorVersion; accessed atomically
+	maxString int32 // largest VARCHAR2 cached by detectMaxStringSize; accessed atomically
+
+	nonBlocking  bool
+	tunnel       *tunnel
+	dblink       string // dblink attached, after SrvCfg.TLS and SrvCfg.Dial
+	reconnecting int32  // 1 while lost reconnects; accessed atomically
 
 	openSess *sesList
 }
@@ -88,7 +159,7 @@ func (srv *Srv) Close() (err error) {
 func (srv *Srv) close() (err error) {
 	srv.mu.Lock()
 	defer srv.mu.Unlock()
-	srv.log(_drv.cfg.Log.Srv.Close)
+	srv.log(_drv.cfg().Log.Srv.Close)
 	err = srv.checkClosed()
 	if err != nil {
 		return errE(err)
@@ -101,6 +172,16 @@ func (srv *Srv) close() (err error) {
 		srv.openSess.clear()
 		srv.env = nil
 		srv.ocisrv = nil
+		srv.nonBlocking = false
+		srv.dblink = ""
+		if srv.tunnel != nil {
+			if err := srv.tunnel.close(); err != nil {
+				errs.PushBack(errE(err))
+			}
+			srv.tunnel = nil
+		}
+		atomic.StoreInt32(&srv.major, 0)
+		atomic.StoreInt32(&srv.maxString, 0)
 		_drv.srvPool.Put(srv)
 
 		multiErr := newMultiErrL(errs)
@@ -116,10 +197,12 @@ func (srv *Srv) close() (err error) {
 	// detach server
 	// OCIServerDetach invalidates oci server handle; no need to free server.ocisvr
 	// OCIServerDetach invalidates oci service context handle; no need to free server.ocisvcctx
-	r := C.OCIServerDetach(
-		srv.ocisrv,     //OCIServer   *srvhp,
-		srv.env.ocierr, //OCIError    *errhp,
-		C.OCI_DEFAULT)  //ub4         mode );
+	r := srv.poll(nil, nil, func() C.sword {
+		return C.OCIServerDetach(
+			srv.ocisrv,     //OCIServer   *srvhp,
+			srv.env.ocierr, //OCIError    *errhp,
+			C.OCI_DEFAULT)  //ub4         mode );
+	}, nil)
 	if r == C.OCI_ERROR {
 		errs.PushBack(errE(srv.env.ociError()))
 	}
@@ -128,9 +211,29 @@ func (srv *Srv) close() (err error) {
 
 // OpenSes opens an Oracle session returning a *Ses and possible error.
 func (srv *Srv) OpenSes(cfg *SesCfg) (ses *Ses, err error) {
+	if ses, err = srv.openSes(cfg); err != nil {
+		return nil, err
+	}
+	// the queries run after Srv.mu is released; a lost connection locks it
+	// to reconnect the Srv
+	if cfg.ResumableTimeout > 0 {
+		if err = ses.enableResumable(); err != nil {
+			srv.openSess.remove(ses)
+			ses.close()
+			return nil, errE(err)
+		}
+	}
+	if atomic.LoadInt32(&srv.maxString) == 0 {
+		ses.detectMaxStringSize()
+	}
+	return ses, nil
+}
+
+// openSes begins the session of OpenSes.
+func (srv *Srv) openSes(cfg *SesCfg) (ses *Ses, err error) {
 	srv.mu.Lock()
 	defer srv.mu.Unlock()
-	srv.log(_drv.cfg.Log.Srv.OpenSes)
+	srv.log(_drv.cfg().Log.Srv.OpenSes)
 	err = srv.checkClosed()
 	if err != nil {
 		return nil, errE(err)
@@ -138,8 +241,41 @@ func (srv *Srv) OpenSes(cfg *SesCfg) (ses *Ses, err error) {
 	if cfg == nil {
 		return nil, er("Parameter 'cfg' may not be nil.")
 	}
+	lobChunk := cfg.LobChunkSize
+	if lobChunk <= 0 {
+		lobChunk = srv.env.cfg.LobChunkSize
+	}
+	if lobChunk <= 0 {
+		lobChunk = lobChunkSize
+	} else if lobChunk > maxLobChunkSize {
+		return nil, errF("LOB chunk size %d is greater than the maximum of %d.", lobChunk, maxLobChunkSize)
+	}
+	// allocate an error handle of the session, so that concurrent sessions
+	// don't share error state
+	ocierr, err := srv.env.allocOciHandle(C.OCI_HTYPE_ERROR)
+	if err != nil {
+		return nil, errE(err)
+	}
+	var ocises, ocisvcctx unsafe.Pointer
+	begun, owned := false, false
+	defer func() {
+		if owned { // freed by Ses.close
+			return
+		}
+		if begun {
+			C.OCISessionEnd((*C.OCISvcCtx)(ocisvcctx), (*C.OCIError)(ocierr), (*C.OCISession)(ocises), C.OCI_DEFAULT)
+		}
+		for _, h := range []struct {
+			handle     unsafe.Pointer
+			handleType C.ub4
+		}{{ocisvcctx, C.OCI_HTYPE_SVCCTX}, {ocises, C.OCI_HTYPE_SESSION}, {ocierr, C.OCI_HTYPE_ERROR}} {
+			if h.handle != nil {
+				srv.env.freeOciHandle(h.handle, h.handleType)
+			}
+		}
+	}()
 	// allocate session handle
-	ocises, err := srv.env.allocOciHandle(C.OCI_HTYPE_SESSION)
+	ocises, err = srv.env.allocOciHandle(C.OCI_HTYPE_SESSION)
 	if err != nil {
 		return nil, errE(err)
 	}
@@ -162,7 +298,7 @@ func (srv *Srv) OpenSes(cfg *SesCfg) (ses *Ses, err error) {
 		}
 	}
 	// allocate service context handle
-	ocisvcctx, err := srv.env.allocOciHandle(C.OCI_HTYPE_SVCCTX)
+	ocisvcctx, err = srv.env.allocOciHandle(C.OCI_HTYPE_SVCCTX)
 	if err != nil {
 		return nil, errE(err)
 	}
@@ -184,42 +320,57 @@ func (srv *Srv) OpenSes(cfg *SesCfg) (ses *Ses, err error) {
 	}
 	// http://docs.oracle.com/cd/B28359_01/appdev.111/b28395/oci07lob.htm#CHDDHFAB
 	// Set LOB prefetch size to chunk size
-	lobPrefetchSize := C.ub4(lobChunkSize)
+	lobPrefetchSize := C.ub4(lobChunk)
 	err = srv.env.setAttr(ocises, C.OCI_HTYPE_SESSION, unsafe.Pointer(&lobPrefetchSize), C.ub4(0), C.OCI_ATTR_DEFAULT_LOBPREFETCH_SIZE)
 	if err != nil {
 		return nil, errE(err)
 	}
 	// begin session
-	r := C.OCISessionBegin(
-		(*C.OCISvcCtx)(ocisvcctx), //OCISvcCtx     *svchp,
-		srv.env.ocierr,            //OCIError      *errhp,
-		(*C.OCISession)(ocises),   //OCISession    *usrhp,
-		credentialType,            //ub4           credt,
-		C.OCI_DEFAULT)             //ub4           mode );
+	r := srv.poll(nil, (*C.OCISvcCtx)(ocisvcctx), func() C.sword {
+		return C.OCISessionBegin(
+			(*C.OCISvcCtx)(ocisvcctx), //OCISvcCtx     *svchp,
+			(*C.OCIError)(ocierr),     //OCIError      *errhp,
+			(*C.OCISession)(ocises),   //OCISession    *usrhp,
+			credentialType,            //ub4           credt,
+			C.OCI_DEFAULT)             //ub4           mode );
+	}, nil)
 	if r == C.OCI_ERROR {
-		return nil, errE(srv.env.ociError())
+		return nil, errE(ociErrorGet((*C.OCIError)(ocierr)))
 	}
+	begun = true
 	// set session handle on service context handle
 	err = srv.env.setAttr(unsafe.Pointer(ocisvcctx), C.OCI_HTYPE_SVCCTX, ocises, C.ub4(0), C.OCI_ATTR_SESSION)
 	if err != nil {
 		return nil, errE(err)
 	}
-	// set stmt cache size to zero
+	// set stmt cache size; zero disables caching
 	// https://docs.oracle.com/database/121/LNOCI/oci09adv.htm#LNOCI16655
-	stmtCacheSize := C.ub4(0)
+	stmtCacheSize := C.ub4(cfg.StmtCacheSize)
 	err = srv.env.setAttr(unsafe.Pointer(ocisvcctx), C.OCI_HTYPE_SVCCTX, unsafe.Pointer(&stmtCacheSize), C.ub4(0), C.OCI_ATTR_STMTCACHESIZE)
 	if err != nil {
 		return nil, errE(err)
 	}
+	if srv.cfg.NonBlocking {
+		if err = srv.setNonBlocking(); err != nil {
+			return nil, errE(err)
+		}
+	}
 
 	ses = _drv.sesPool.Get().(*Ses) // set *Ses
 	ses.srv = srv
 	ses.ocisvcctx = (*C.OCISvcCtx)(ocisvcctx)
 	ses.ocises = (*C.OCISession)(ocises)
+	ses.ocierr = (*C.OCIError)(ocierr)
+	owned = true
 	if ses.id == 0 {
 		ses.id = _drv.sesId.nextId()
 	}
 	ses.cfg = *cfg
+	ses.cfg.LobChunkSize = lobChunk
+	atomic.StoreInt32(&ses.maxOpenCursors, int32(cfg.MaxOpenCursors))
+	if _drv.cfg().Leak.Enabled {
+		ses.leaks = newLeakRegistry(_drv.cfg().Leak)
+	}
 	if ses.cfg.StmtCfg == nil && ses.srv.cfg.StmtCfg != nil {
 		ses.cfg.StmtCfg = &(*ses.srv.cfg.StmtCfg) // copy by value so that user may change independently
 	}
@@ -234,7 +385,7 @@ func (srv *Srv) OpenSes(cfg *SesCfg) (ses *Ses, err error) {
 func (srv *Srv) Version() (ver string, err error) {
 	srv.mu.Lock()
 	defer srv.mu.Unlock()
-	srv.log(_drv.cfg.Log.Srv.Version)
+	srv.log(_drv.cfg().Log.Srv.Version)
 	err = srv.checkClosed()
 	if err != nil {
 		return "", errE(err)
@@ -275,6 +426,15 @@ func (srv *Srv) Cfg() *SrvCfg {
 	return &srv.cfg
 }
 
+// CfgCopy returns a copy of the Srv's cfg, sharing no maps or nested cfgs
+// with it. Changes to the copy have no effect until it is applied with
+// Srv.SetCfg.
+func (srv *Srv) CfgCopy() *SrvCfg {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.cfg.copy()
+}
+
 // IsOpen returns true when the server is open; otherwise, false.
 //
 // Calling Close will cause Srv.IsOpen to return false. Once closed, a server cannot
@@ -305,9 +465,9 @@ func (srv *Srv) sysName() string {
 func (srv *Srv) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", srv.sysName(), callInfo(1))
+			lgr.Infof("%v %v", srv.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", srv.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", srv.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -316,9 +476,9 @@ func (srv *Srv) log(enabled bool, v ...interface{}) {
 func (srv *Srv) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", srv.sysName(), callInfo(1))
+			lgr.Infof("%v %v", srv.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", srv.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", srv.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
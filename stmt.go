@@ -13,10 +13,13 @@ import "C"
 import (
 	"bytes"
 	"container/list"
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 )
@@ -42,6 +45,71 @@ type LogStmtCfg struct {
 	//
 	// The default is true.
 	Bind bool
+
+	// BindTrace determines whether the binds of each execution are logged
+	// before the execution: the position and placeholder name, Go type, OCI
+	// bind type, length and null indicator of each bind.
+	//
+	// The default is false.
+	BindTrace bool
+
+	// BindValues determines whether BindTrace logs bind values. Values are
+	// masked as configured by LogDrvCfg.Redact.
+	//
+	// The default is false.
+	BindValues bool
+
+	// Describe determines whether the Stmt.Describe method is logged.
+	//
+	// The default is true.
+	Describe bool
+
+	// BindNames determines whether the Stmt.BindNames method is logged.
+	//
+	// The default is true.
+	BindNames bool
+
+	// ExpectColumns determines whether the Stmt.ExpectColumns method is logged.
+	//
+	// The default is true.
+	ExpectColumns bool
+
+	// ExeP determines whether the Stmt.ExeP method is logged.
+	//
+	// The default is true.
+	ExeP bool
+
+	// QryPage determines whether the Stmt.QryPage method is logged.
+	//
+	// The default is true.
+	QryPage bool
+
+	// MemStats determines whether the Stmt.MemStats method is logged.
+	//
+	// The default is true.
+	MemStats bool
+
+	// Plan determines whether the Stmt.Plan method is logged.
+	//
+	// The default is true.
+	Plan bool
+
+	// CursorPlan determines whether the Stmt.CursorPlan method is logged.
+	//
+	// The default is true.
+	CursorPlan bool
+
+	// IsResultCached determines whether the Stmt.IsResultCached method is logged.
+	//
+	// The default is true.
+	IsResultCached bool
+
+	// PackageStateRetry determines whether the re-execution of a statement
+	// after a discarded package state is logged; see
+	// StmtCfg.PackageStateRetries.
+	//
+	// The default is true.
+	PackageStateRetry bool
 }
 
 // NewLogStmtCfg creates a LogStmtCfg with default values.
@@ -51,21 +119,42 @@ func NewLogStmtCfg() LogStmtCfg {
 	c.Exe = true
 	c.Qry = true
 	c.Bind = true
+	c.Describe = true
+	c.BindNames = true
+	c.ExpectColumns = true
+	c.ExeP = true
+	c.QryPage = true
+	c.MemStats = true
+	c.Plan = true
+	c.CursorPlan = true
+	c.IsResultCached = true
+	c.PackageStateRetry = true
 	return c
 }
 
 // Stmt represents an Oracle statement.
+//
+// Stmt methods are safe for concurrent use; calls on a Stmt are serialized.
+// Enable DrvCfg.RaceDetect to panic on the simultaneous use of a Stmt instead.
 type Stmt struct {
 	id         uint64
 	cfg        StmtCfg
 	mu         sync.Mutex
+	race       raceGuard
 	ses        *Ses
 	ocistmt    *C.OCIStmt
 	stmtType   C.ub4
 	sql        string
 	gcts       []GoColumnType
+	paramOrder []int // param of each placeholder converted by ConvertPlaceholders
 	bnds       []bnd
 	hasPtrBind bool
+	arena      bndArena
+	evicted    bool   // server cursor released by Ses.evictStmts
+	gen        uint32 // Ses.gen when prepared
+	lastUsed   int64  // UnixNano of the last Prep, Exe or Qry; accessed atomically
+	lobChunk   uint32 // chunk size of the temporary LOBs bound; see tempLobChunkSize
+	ownAlter   bool   // ALTER SESSION of the driver, whose caller updates the cached session state; see Ses.alter
 
 	openRsets *rsetList
 }
@@ -87,7 +176,7 @@ func (stmt *Stmt) Close() (err error) {
 func (stmt *Stmt) close() (err error) {
 	stmt.mu.Lock()
 	defer stmt.mu.Unlock()
-	stmt.log(_drv.cfg.Log.Stmt.Close)
+	stmt.log(_drv.cfg().Log.Stmt.Close)
 	err = stmt.checkClosed()
 	if err != nil {
 		return errE(err)
@@ -100,24 +189,24 @@ func (stmt *Stmt) close() (err error) {
 		// free ocistmt to release cursor on server
 		// OCIStmtRelease must be called with OCIStmtPrepare2
 		// See https://docs.oracle.com/database/121/LNOCI/oci09adv.htm#LNOCI16655
-		r := C.OCIStmtRelease(
-			stmt.ocistmt,            // OCIStmt        *stmthp
-			stmt.ses.srv.env.ocierr, // OCIError       *errhp,
-			nil,           // const OraText  *key
-			C.ub4(0),      // ub4 keylen
-			C.OCI_DEFAULT, // ub4 mode
-		)
-		if r == C.OCI_ERROR {
-			errs.PushBack(errE(stmt.ses.srv.env.ociError()))
+		if err := stmt.release(); err != nil {
+			errs.PushBack(errE(err))
 		}
+		stmt.ses.leaks.untrack(stmt)
 
 		stmt.ses = nil
 		stmt.ocistmt = nil
+		stmt.evicted = false
+		stmt.lastUsed = 0
+		stmt.arena.release()
 		stmt.stmtType = C.ub4(0)
 		stmt.sql = ""
 		stmt.gcts = nil
+		stmt.paramOrder = nil
 		stmt.bnds = nil
 		stmt.hasPtrBind = false
+		stmt.lobChunk = 0
+		stmt.ownAlter = false
 		stmt.openRsets.clear()
 		_drv.stmtPool.Put(stmt)
 
@@ -143,15 +232,116 @@ func (stmt *Stmt) close() (err error) {
 	return nil
 }
 
+// release frees the oci statement handle, releasing the cursor on the server.
+// No locking occurs.
+func (stmt *Stmt) release() error {
+	if stmt.ocistmt == nil { // evicted
+		return nil
+	}
+	// OCIStmtRelease must be called with OCIStmtPrepare2
+	// See https://docs.oracle.com/database/121/LNOCI/oci09adv.htm#LNOCI16655
+	r := C.OCIStmtRelease(
+		stmt.ocistmt,    // OCIStmt        *stmthp
+		stmt.ses.ocierr, // OCIError       *errhp,
+		nil,             // const OraText  *key
+		C.ub4(0),        // ub4 keylen
+		C.OCI_DEFAULT,   // ub4 mode
+	)
+	stmt.ocistmt = nil
+	if r == C.OCI_ERROR {
+		return stmt.ses.ociError()
+	}
+	return nil
+}
+
+// evict releases the server cursor of an idle Stmt, keeping the Stmt open.
+// The Stmt is re-prepared on its next use.
+//
+// evict returns false when the Stmt is in use by another goroutine or has an
+// open Rset.
+func (stmt *Stmt) evict() (evicted bool, err error) {
+	if !stmt.mu.TryLock() {
+		return false, nil
+	}
+	defer stmt.mu.Unlock()
+	if stmt.ocistmt == nil || stmt.openRsets.len() > 0 {
+		return false, nil
+	}
+	stmt.log(_drv.cfg().Log.Stmt.Close, "evict")
+	for _, bind := range stmt.bnds {
+		if bind != nil {
+			bind.close()
+		}
+	}
+	stmt.bnds = nil
+	stmt.hasPtrBind = false
+	stmt.evicted = true
+	return true, stmt.release()
+}
+
+// prepare re-prepares an evicted Stmt. No locking of the Stmt occurs.
+//
+// The caller holds Stmt.mu, so Ses.mu isn't locked: Ses.close locks Ses.mu
+// before closing each Stmt. Ses.evictMu serializes the eviction instead.
+func (stmt *Stmt) prepare() error {
+	atomic.StoreInt64(&stmt.lastUsed, time.Now().UnixNano())
+	stmt.dropStale()
+	if !stmt.evicted {
+		return nil
+	}
+	stmt.ses.evictMu.Lock()
+	defer stmt.ses.evictMu.Unlock()
+	stmt.ses.evictStmts(stmt)
+	ocistmt, err := stmt.ses.prepOciStmt(stmt.sql)
+	if err != nil {
+		return err
+	}
+	stmt.ocistmt = ocistmt
+	stmt.gen = atomic.LoadUint32(&stmt.ses.gen)
+	stmt.evicted = false
+	return nil
+}
+
 // Exe executes a SQL statement on an Oracle server returning the number of
 // rows affected and a possible error.
 func (stmt *Stmt) Exe(params ...interface{}) (rowsAffected uint64, err error) {
-	rowsAffected, _, err = stmt.exe(params)
+	rowsAffected, _, err = stmt.exe(context.Background(), params, false)
+	return rowsAffected, err
+}
+
+// ReExe executes the Stmt again with new values, reusing the bind handles of
+// the previous Exe or ReExe.
+//
+// When each value has the Go type of the value previously bound at its
+// position, and each string value has the length of the previous string, the
+// bind buffers are updated in place without calling OCIBindByPos. Otherwise
+// ReExe binds params like Exe. Numeric and string values are updated in
+// place; other types are always re-bound.
+func (stmt *Stmt) ReExe(params ...interface{}) (rowsAffected uint64, err error) {
+	rowsAffected, _, err = stmt.exe(context.Background(), params, true)
+	return rowsAffected, err
+}
+
+// ExeContext executes a SQL statement like Exe. When the Srv is in
+// non-blocking mode and ctx is done before the statement completes, the
+// statement is broken and an error is returned.
+//
+// When ctx carries a LongOpCfg set by WithLongOp, the statement is registered
+// in V$SESSION_LONGOPS while it executes.
+func (stmt *Stmt) ExeContext(ctx context.Context, params ...interface{}) (rowsAffected uint64, err error) {
+	if cfg, ok := longOpFrom(ctx); ok {
+		return stmt.exeLongOp(ctx, cfg, params)
+	}
+	rowsAffected, _, err = stmt.exe(ctx, params, false)
 	return rowsAffected, err
 }
 
 // exe executes a SQL statement on an Oracle server returning rowsAffected, lastInsertId and error.
-func (stmt *Stmt) exe(params []interface{}) (rowsAffected uint64, lastInsertId int64, err error) {
+//
+// When reuse is true, the existing binds are updated in place if possible.
+func (stmt *Stmt) exe(ctx context.Context, params []interface{}, reuse bool) (rowsAffected uint64, lastInsertId int64, err error) {
+	stmt.race.enter("Stmt", stmt, "Exe")
+	defer stmt.race.leave()
 	stmt.mu.Lock()
 	defer stmt.mu.Unlock()
 	defer func() {
@@ -159,13 +349,29 @@ func (stmt *Stmt) exe(params []interface{}) (rowsAffected uint64, lastInsertId i
 			err = errR(value)
 		}
 	}()
-	stmt.log(_drv.cfg.Log.Stmt.Exe)
+	stmt.log(_drv.cfg().Log.Stmt.Exe)
 	err = stmt.checkClosed()
 	if err != nil {
 		return 0, 0, errE(err)
 	}
+	params, err = orderParams(params, stmt.paramOrder)
+	if err != nil {
+		return 0, 0, errE(err)
+	}
+	restore, err := stmt.overrideCfg(ctx)
+	if err != nil {
+		return 0, 0, errE(err)
+	}
+	defer restore()
+	if !stmt.cfg.asOf.isZero() {
+		return 0, 0, er("WithAsOfScn and WithAsOfTime apply to Stmt.QryContext only.")
+	}
+	err = stmt.prepare()
+	if err != nil {
+		return 0, 0, errE(err)
+	}
 	// for case of inserting and returning identity for database/sql package
-	if _drv.sqlPkgEnv == stmt.ses.srv.env && stmt.stmtType == C.OCI_STMT_INSERT {
+	if stmt.ses.srv.env.sqlPkg && stmt.stmtType == C.OCI_STMT_INSERT {
 		lastIndex := strings.LastIndex(stmt.sql, ")")
 		sqlEnd := stmt.sql[lastIndex+1 : len(stmt.sql)]
 		sqlEnd = strings.ToUpper(sqlEnd)
@@ -174,11 +380,25 @@ func (stmt *Stmt) exe(params []interface{}) (rowsAffected uint64, lastInsertId i
 			params[len(params)-1] = &lastInsertId
 		}
 	}
-	iterations, err := stmt.bind(params) // bind parameters
+	iterations, rebound := uint32(1), false
+	if reuse {
+		rebound, err = stmt.rebind(params)
+		if err != nil {
+			return 0, 0, errE(err)
+		}
+	}
+	if !rebound {
+		profile(ctx, "bind", func() { iterations, err = stmt.bind(params) }) // bind parameters
+		if err != nil {
+			return 0, 0, errE(err)
+		}
+	}
+	stmt.traceBinds(params)
+	err = stmt.setPrefetchSize() // set prefetch size
 	if err != nil {
 		return 0, 0, errE(err)
 	}
-	err = stmt.setPrefetchSize() // set prefetch size
+	err = stmt.ses.tagAction(ctx)
 	if err != nil {
 		return 0, 0, errE(err)
 	}
@@ -188,28 +408,55 @@ func (stmt *Stmt) exe(params []interface{}) (rowsAffected uint64, lastInsertId i
 	} else {
 		mode = C.OCI_DEFAULT
 	}
+	if stmt.cfg.BatchErrors {
+		mode |= C.OCI_BATCH_ERRORS
+	}
 	// Execute statement on Oracle server
-	r := C.OCIStmtExecute(
-		stmt.ses.ocisvcctx,  //OCISvcCtx           *svchp,
-		stmt.ocistmt,            //OCIStmt             *stmtp,
-		stmt.ses.srv.env.ocierr, //OCIError            *errhp,
-		C.ub4(iterations),       //ub4                 iters,
-		C.ub4(0),                //ub4                 rowoff,
-		nil,                     //const OCISnapshot   *snap_in,
-		nil,                     //OCISnapshot         *snap_out,
-		mode)                    //ub4                 mode );
+	start := time.Now()
+	callCtx, endCall := stmt.ses.callTimeout(ctx)
+	execute := func() C.sword {
+		return stmt.ses.pollOp(callCtx, "exe", func() C.sword {
+			return C.OCIStmtExecute(
+				stmt.ses.ocisvcctx, //OCISvcCtx           *svchp,
+				stmt.ocistmt,       //OCIStmt             *stmtp,
+				stmt.ses.ocierr,    //OCIError            *errhp,
+				C.ub4(iterations),  //ub4                 iters,
+				C.ub4(0),           //ub4                 rowoff,
+				nil,                //const OCISnapshot   *snap_in,
+				nil,                //OCISnapshot         *snap_out,
+				mode)               //ub4                 mode );
+		})
+	}
+	r := stmt.retryPackageState(execute(), iterations, execute)
+	timedOut := endCall()
+	elapsed := time.Since(start)
 	if r == C.OCI_ERROR {
-		return 0, 0, errE(stmt.ses.srv.env.ociError())
+		if err = stmt.exeError(); timedOut != nil {
+			err = timedOut
+		}
+		stmt.logSlow(elapsed, err)
+		if timedOut != nil {
+			return 0, 0, timedOut // as is, like Ses.PingContext
+		}
+		return 0, 0, errE(err)
 	}
+	stmt.logSlow(elapsed, nil)
 	var ub8RowsAffected C.ub8 // Get rowsAffected based on statement type
 	switch stmt.stmtType {
-	case C.OCI_STMT_SELECT, C.OCI_STMT_UPDATE, C.OCI_STMT_DELETE, C.OCI_STMT_INSERT:
+	case C.OCI_STMT_SELECT, C.OCI_STMT_UPDATE, C.OCI_STMT_DELETE, C.OCI_STMT_INSERT, C.OCI_STMT_MERGE:
 		err := stmt.attr(unsafe.Pointer(&ub8RowsAffected), 8, C.OCI_ATTR_UB8_ROW_COUNT)
 		if err != nil {
 			return 0, 0, errE(err)
 		}
 		rowsAffected = uint64(ub8RowsAffected)
-	case C.OCI_STMT_CREATE, C.OCI_STMT_DROP, C.OCI_STMT_ALTER, C.OCI_STMT_BEGIN:
+	case C.OCI_STMT_ALTER:
+		if !stmt.ownAlter {
+			stmt.ses.sesAltered(stmt.sql)
+		}
+	case C.OCI_STMT_CREATE, C.OCI_STMT_DROP, C.OCI_STMT_BEGIN:
+	}
+	if mode&C.OCI_COMMIT_ON_SUCCESS == 0 && stmt.stmtType != C.OCI_STMT_SELECT {
+		stmt.ses.openTxs.exeDone(stmt.sql)
 	}
 	if stmt.hasPtrBind { // Set any bind pointers
 		err = stmt.setBindPtrs()
@@ -217,16 +464,30 @@ func (stmt *Stmt) exe(params []interface{}) (rowsAffected uint64, lastInsertId i
 			return rowsAffected, lastInsertId, errE(err)
 		}
 	}
+	if stmt.cfg.BatchErrors {
+		if err = stmt.batchErrors(); err != nil {
+			return rowsAffected, lastInsertId, err
+		}
+	}
 	return rowsAffected, lastInsertId, nil
 }
 
 // Qry runs a SQL query on an Oracle server returning a *Rset and possible error.
 func (stmt *Stmt) Qry(params ...interface{}) (*Rset, error) {
-	return stmt.qry(params)
+	return stmt.qry(context.Background(), params)
+}
+
+// QryContext runs a SQL query like Qry. When the Srv is in non-blocking mode
+// and ctx is done before the query or a later Rset.Next completes, the call is
+// broken and an error is returned.
+func (stmt *Stmt) QryContext(ctx context.Context, params ...interface{}) (*Rset, error) {
+	return stmt.qry(ctx, params)
 }
 
 // qry runs a SQL query on an Oracle server returning a *Rset and possible error.
-func (stmt *Stmt) qry(params []interface{}) (rset *Rset, err error) {
+func (stmt *Stmt) qry(ctx context.Context, params []interface{}) (rset *Rset, err error) {
+	stmt.race.enter("Stmt", stmt, "Qry")
+	defer stmt.race.leave()
 	stmt.mu.Lock()
 	defer stmt.mu.Unlock()
 	defer func() {
@@ -234,31 +495,77 @@ func (stmt *Stmt) qry(params []interface{}) (rset *Rset, err error) {
 			err = errR(value)
 		}
 	}()
-	stmt.log(_drv.cfg.Log.Stmt.Qry)
+	stmt.log(_drv.cfg().Log.Stmt.Qry)
 	err = stmt.checkClosed()
 	if err != nil {
 		return nil, errE(err)
 	}
-	_, err = stmt.bind(params) // bind parameters
+	params, err = orderParams(params, stmt.paramOrder)
+	if err != nil {
+		return nil, errE(err)
+	}
+	restore, err := stmt.overrideCfg(ctx)
+	if err != nil {
+		return nil, errE(err)
+	}
+	defer restore()
+	err = stmt.prepare()
 	if err != nil {
 		return nil, errE(err)
 	}
+	profile(ctx, "bind", func() { _, err = stmt.bind(params) }) // bind parameters
+	if err != nil {
+		return nil, errE(err)
+	}
+	stmt.traceBinds(params)
 	err = stmt.setPrefetchSize() // set prefetch size
 	if err != nil {
 		return nil, errE(err)
 	}
+	err = stmt.ses.tagAction(ctx)
+	if err != nil {
+		return nil, errE(err)
+	}
+	disable, err := stmt.ses.enableFlashback(stmt.cfg.asOf)
+	if err != nil {
+		return nil, errE(err)
+	}
+	mode := C.OCI_DEFAULT | stmt.cfg.ResultCache.exeMode()
 	// Query statement on Oracle server
-	r := C.OCIStmtExecute(
-		stmt.ses.ocisvcctx,  //OCISvcCtx           *svchp,
-		stmt.ocistmt,            //OCIStmt             *stmtp,
-		stmt.ses.srv.env.ocierr, //OCIError            *errhp,
-		C.ub4(0),                //ub4                 iters,
-		C.ub4(0),                //ub4                 rowoff,
-		nil,                     //const OCISnapshot   *snap_in,
-		nil,                     //OCISnapshot         *snap_out,
-		C.OCI_DEFAULT)           //ub4                 mode );
+	start := time.Now()
+	callCtx, endCall := stmt.ses.callTimeout(ctx)
+	execute := func() C.sword {
+		return stmt.ses.pollOp(callCtx, "qry", func() C.sword {
+			return C.OCIStmtExecute(
+				stmt.ses.ocisvcctx, //OCISvcCtx           *svchp,
+				stmt.ocistmt,       //OCIStmt             *stmtp,
+				stmt.ses.ocierr,    //OCIError            *errhp,
+				C.ub4(0),           //ub4                 iters,
+				C.ub4(0),           //ub4                 rowoff,
+				nil,                //const OCISnapshot   *snap_in,
+				nil,                //OCISnapshot         *snap_out,
+				mode)               //ub4                 mode );
+		})
+	}
+	r := stmt.retryPackageState(execute(), 0, execute)
+	timedOut := endCall()
+	elapsed := time.Since(start)
 	if r == C.OCI_ERROR {
-		return nil, errE(stmt.ses.srv.env.ociError())
+		if err = stmt.exeError(); timedOut != nil {
+			err = timedOut
+		}
+	}
+	stmt.logSlow(elapsed, err)
+	if disable != nil { // after exeError, which reads the error handle
+		if err0 := disable(); err == nil {
+			err = err0
+		}
+	}
+	if err != nil {
+		if err == timedOut {
+			return nil, timedOut // as is, like Ses.PingContext
+		}
+		return nil, errE(err)
 	}
 	if stmt.hasPtrBind { // set any bind pointers
 		err = stmt.setBindPtrs()
@@ -271,12 +578,14 @@ func (stmt *Stmt) qry(params []interface{}) (rset *Rset, err error) {
 	if rset.id == 0 {
 		rset.id = _drv.rsetId.nextId()
 	}
+	rset.ctx = ctx
 	err = rset.open(stmt, stmt.ocistmt)
 	if err != nil {
 		rset.close()
 		return nil, errE(err)
 	}
 	stmt.openRsets.add(rset)
+	stmt.ses.leaks.track(rset, "Rset", rset.sysName())
 
 	return rset, nil
 }
@@ -302,6 +611,25 @@ func (stmt *Stmt) putBnd(idx int, bnd bnd) {
 	_drv.bndPools[idx].Put(bnd)
 }
 
+// rebind updates the buffers of the existing binds with params, returning
+// false when any param can't be updated in place. No locking occurs.
+func (stmt *Stmt) rebind(params []interface{}) (rebound bool, err error) {
+	if len(params) == 0 || len(params) != len(stmt.bnds) || stmt.hasPtrBind {
+		return false, nil
+	}
+	for n, param := range params {
+		rb, ok := stmt.bnds[n].(rebnd)
+		if !ok {
+			return false, nil
+		}
+		if rebound, err = rb.rebind(param); !rebound || err != nil {
+			return false, err
+		}
+	}
+	stmt.logF(_drv.cfg().Log.Stmt.Bind, "Rebound %d", len(params))
+	return true, nil
+}
+
 // bind associates Go variables to SQL string placeholders by the
 // position of the variable and the position of the placeholder.
 //
@@ -314,7 +642,8 @@ func (stmt *Stmt) putBnd(idx int, bnd bnd) {
 //
 // No locking occurs.
 func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
-	stmt.logF(_drv.cfg.Log.Stmt.Bind, "Params %d", len(params))
+	stmt.logF(_drv.cfg().Log.Stmt.Bind, "Params %d", len(params))
+	stmt.arena.reset()
 	iterations = 1
 	// Create binds for each parameter; bind position is 1-based
 	if params != nil && len(params) > 0 {
@@ -647,6 +976,13 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 						return iterations, err
 					}
 					iterations = uint32(len(value))
+				} else if value != nil && stmt.promotesToLob(len(value)) {
+					bnd := stmt.getBnd(bndIdxLob).(*bndLob)
+					stmt.bnds[n] = bnd
+					err = bnd.bindReader(bytes.NewReader(value), n+1, stmt.cfg.lobBufferSize, stmt)
+					if err != nil {
+						return iterations, err
+					}
 				} else {
 					switch bnd := stmt.getBnd(bndIdxBin).(type) {
 					case *bndBin:
@@ -809,12 +1145,34 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 				}
 				iterations = uint32(len(value))
 			case string:
-				bnd := stmt.getBnd(bndIdxString).(*bndString)
-				stmt.bnds[n] = bnd
-				err = bnd.bind(value, n+1, stmt)
+				value = stmt.cfg.EmptyString.bindValue(value)
+				if stmt.promotesToLob(len(value)) {
+					bnd := stmt.getBnd(bndIdxLob).(*bndLob)
+					stmt.bnds[n] = bnd
+					err = bnd.bindClob(value, n+1, stmt.cfg.lobBufferSize, stmt)
+				} else {
+					bnd := stmt.getBnd(bndIdxString).(*bndString)
+					stmt.bnds[n] = bnd
+					err = bnd.bind(value, n+1, stmt)
+				}
+				if err != nil {
+					return iterations, err
+				}
+			case json.RawMessage, JSONValue, *JSONValue:
+				text, null, err := jsonBindText(value)
 				if err != nil {
 					return iterations, err
 				}
+				if null {
+					stmt.setNilBind(n, C.SQLT_CHR)
+				} else {
+					bnd := stmt.getBnd(bndIdxString).(*bndString)
+					stmt.bnds[n] = bnd
+					err = bnd.bind(text, n+1, stmt)
+					if err != nil {
+						return iterations, err
+					}
+				}
 			case *string:
 				bnd := stmt.getBnd(bndIdxStringPtr).(*bndStringPtr)
 				stmt.bnds[n] = bnd
@@ -826,10 +1184,17 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 			case String:
 				if value.IsNull {
 					stmt.setNilBind(n, C.SQLT_CHR)
+				} else if stmt.promotesToLob(len(value.Value)) {
+					bnd := stmt.getBnd(bndIdxLob).(*bndLob)
+					stmt.bnds[n] = bnd
+					err = bnd.bindClob(value.Value, n+1, stmt.cfg.lobBufferSize, stmt)
+					if err != nil {
+						return iterations, err
+					}
 				} else {
 					bnd := stmt.getBnd(bndIdxString).(*bndString)
 					stmt.bnds[n] = bnd
-					err = bnd.bind(value.Value, n+1, stmt)
+					err = bnd.bind(stmt.cfg.EmptyString.bindValue(value.Value), n+1, stmt)
 					if err != nil {
 						return iterations, err
 					}
@@ -837,7 +1202,7 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 			case []string:
 				bnd := stmt.getBnd(bndIdxStringSlice).(*bndStringSlice)
 				stmt.bnds[n] = bnd
-				err = bnd.bind(value, nil, n+1, stmt)
+				err = bnd.bind(stmt.cfg.EmptyString.bindValues(value), nil, n+1, stmt)
 				if err != nil {
 					return iterations, err
 				}
@@ -895,6 +1260,13 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 			case Raw:
 				if value.IsNull {
 					stmt.setNilBind(n, C.SQLT_BIN)
+				} else if stmt.promotesToLob(len(value.Value)) {
+					bnd := stmt.getBnd(bndIdxLob).(*bndLob)
+					stmt.bnds[n] = bnd
+					err = bnd.bindReader(bytes.NewReader(value.Value), n+1, stmt.cfg.lobBufferSize, stmt)
+					if err != nil {
+						return iterations, err
+					}
 				} else {
 					bnd := stmt.getBnd(bndIdxBin).(*bndBin)
 					stmt.bnds[n] = bnd
@@ -903,6 +1275,24 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 						return iterations, err
 					}
 				}
+			case UUID:
+				bnd := stmt.getBnd(bndIdxBin).(*bndBin)
+				stmt.bnds[n] = bnd
+				err = bnd.bindUUID(value, n+1, stmt)
+				if err != nil {
+					return iterations, err
+				}
+			case *UUID:
+				if value == nil {
+					stmt.setNilBind(n, C.SQLT_BIN)
+				} else {
+					bnd := stmt.getBnd(bndIdxBin).(*bndBin)
+					stmt.bnds[n] = bnd
+					err = bnd.bindUUID(*value, n+1, stmt)
+					if err != nil {
+						return iterations, err
+					}
+				}
 			case Lob:
 				if value.Reader == nil {
 					stmt.setNilBind(n, C.SQLT_BLOB)
@@ -992,6 +1382,27 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 					return iterations, err
 				}
 				iterations = uint32(len(value))
+			case IntervalDSTable:
+				if stmt.stmtType != C.OCI_STMT_BEGIN && stmt.stmtType != C.OCI_STMT_DECLARE {
+					return iterations, errF("An IntervalDSTable bind parameter requires a PL/SQL block (position %d).", n+1)
+				}
+				bnd := stmt.getBnd(bndIdxIntervalDSSlice).(*bndIntervalDSSlice)
+				stmt.bnds[n] = bnd
+				err = bnd.bindPlsql(value, nil, n+1, stmt)
+				if err != nil {
+					return iterations, err
+				}
+			case *IntervalDSTable:
+				if stmt.stmtType != C.OCI_STMT_BEGIN && stmt.stmtType != C.OCI_STMT_DECLARE {
+					return iterations, errF("An *IntervalDSTable bind parameter requires a PL/SQL block (position %d).", n+1)
+				}
+				bnd := stmt.getBnd(bndIdxIntervalDSSlice).(*bndIntervalDSSlice)
+				stmt.bnds[n] = bnd
+				err = bnd.bindPlsql(*value, value, n+1, stmt)
+				if err != nil {
+					return iterations, err
+				}
+				stmt.hasPtrBind = true
 			case Bfile:
 				if value.IsNull {
 					err = stmt.setNilBind(n, C.SQLT_FILE)
@@ -1003,6 +1414,15 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 						return iterations, err
 					}
 				}
+			case InList:
+				return iterations, errF("Invalid bind parameter (InList). Expand an InList with ExpandIn before Stmt.Exe or Stmt.Qry.")
+			case inListColl:
+				bnd := stmt.getBnd(bndIdxInList).(*bndInList)
+				stmt.bnds[n] = bnd
+				err = bnd.bind(value, n+1, stmt)
+				if err != nil {
+					return iterations, err
+				}
 			case *Rset:
 				bnd := stmt.getBnd(bndIdxRset).(*bndRset)
 				stmt.bnds[n] = bnd
@@ -1014,14 +1434,21 @@ func (stmt *Stmt) bind(params []interface{}) (iterations uint32, err error) {
 			default:
 				if params[n] == nil {
 					err = stmt.setNilBind(n, C.SQLT_CHR)
+				} else if u, ok := asUUID(params[n]); ok {
+					bnd := stmt.getBnd(bndIdxBin).(*bndBin)
+					stmt.bnds[n] = bnd
+					err = bnd.bindUUID(u, n+1, stmt)
+					if err != nil {
+						return iterations, err
+					}
 				} else {
 					t := reflect.TypeOf(params[n])
 					if t.Kind() == reflect.Slice {
 						if t.Elem().Kind() == reflect.Interface {
-							return iterations, errF("Invalid bind parameter. ([]interface{}) (%v).", params[n])
+							return iterations, errF("Invalid bind parameter. ([]interface{}) (%v).", stmt.redactBind(n, params[n]))
 						}
 					}
-					return iterations, errF("Invalid bind parameter (%v) (%T:%v).", t.Name(), params[n], params[n])
+					return iterations, errF("Invalid bind parameter (%v) (%T:%v).", t.Name(), params[n], stmt.redactBind(n, params[n]))
 				}
 			}
 		}
@@ -1041,11 +1468,17 @@ func (stmt *Stmt) NumRset() int {
 func (stmt *Stmt) NumInput() int {
 	stmt.mu.Lock()
 	defer stmt.mu.Unlock()
+	if err := stmt.prepare(); err != nil {
+		return 0
+	}
 	var bindCount uint32
 	err := stmt.attr(unsafe.Pointer(&bindCount), 4, C.OCI_ATTR_BIND_COUNT)
 	if err != nil {
 		return 0
 	}
+	if stmt.paramOrder != nil { // a repeated $n binds one param
+		return int(bindCount) - len(stmt.paramOrder) + paramCount(stmt.paramOrder)
+	}
 	return int(bindCount)
 }
 
@@ -1084,6 +1517,15 @@ func (stmt *Stmt) Cfg() *StmtCfg {
 	return &stmt.cfg
 }
 
+// CfgCopy returns a copy of the Stmt's cfg, sharing no maps or nested cfgs
+// with it. Changes to the copy have no effect until it is applied with
+// Stmt.SetCfg.
+func (stmt *Stmt) CfgCopy() *StmtCfg {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	return stmt.cfg.copy()
+}
+
 // IsOpen returns true when a statement is open; otherwise, false.
 //
 // Calling Close will cause Stmt.IsOpen to return false. Once closed, a statement
@@ -1091,12 +1533,12 @@ func (stmt *Stmt) Cfg() *StmtCfg {
 func (stmt *Stmt) IsOpen() bool {
 	stmt.mu.Lock()
 	defer stmt.mu.Unlock()
-	return stmt.ocistmt != nil
+	return stmt.ocistmt != nil || stmt.evicted
 }
 
 // checkClosed returns an error if Stmt is closed. No locking occurs.
 func (stmt *Stmt) checkClosed() error {
-	if stmt.ocistmt == nil {
+	if stmt.ocistmt == nil && !stmt.evicted {
 		return er("Stmt is closed.")
 	}
 	return nil
@@ -1111,9 +1553,9 @@ func (stmt *Stmt) sysName() string {
 func (stmt *Stmt) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", stmt.sysName(), callInfo(1))
+			lgr.Infof("%v %v", stmt.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", stmt.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", stmt.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -1122,9 +1564,9 @@ func (stmt *Stmt) log(enabled bool, v ...interface{}) {
 func (stmt *Stmt) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", stmt.sysName(), callInfo(1))
+			lgr.Infof("%v %v", stmt.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", stmt.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", stmt.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
@@ -1155,9 +1597,9 @@ func (stmt *Stmt) attr(attrup unsafe.Pointer, attrSize C.ub4, attrType C.ub4) er
 		attrup,                       //void           *attributep,
 		&attrSize,                    //ub4            *sizep,
 		attrType,                     //ub4            attrtype,
-		stmt.ses.srv.env.ocierr)      //OCIError       *errhp );
+		stmt.ses.ocierr)              //OCIError       *errhp );
 	if r == C.OCI_ERROR {
-		return stmt.ses.srv.env.ociError()
+		return stmt.ses.ociError()
 	}
 	return nil
 }
@@ -1170,9 +1612,9 @@ func (stmt *Stmt) setAttr(attrup unsafe.Pointer, attrSize C.ub4, attrType C.ub4)
 		attrup,                       //void        *attributep,
 		attrSize,                     //ub4         size,
 		attrType,                     //ub4         attrtype,
-		stmt.ses.srv.env.ocierr)      //OCIError    *errhp );
+		stmt.ses.ocierr)              //OCIError    *errhp );
 	if r == C.OCI_ERROR {
-		return errE(stmt.ses.srv.env.ociError())
+		return errE(stmt.ses.ociError())
 	}
 
 	return nil
@@ -4,6 +4,8 @@
 
 package ora
 
+import "time"
+
 // StmtCfg affects various aspects of a SQL statement.
 //
 // Assign values to StmtCfg prior to calling Stmt.Exe
@@ -16,6 +18,7 @@ type StmtCfg struct {
 	lobBufferSize       int
 	stringPtrBufferSize int
 	byteSlice           GoColumnType
+	asOf                flashbackPoint // set by WithAsOfScn and WithAsOfTime
 
 	// IsAutoCommitting determines whether DML statements are automatically
 	// committed.
@@ -37,6 +40,124 @@ type StmtCfg struct {
 	// The is default is '1'.
 	TrueRune rune
 
+	// ResultCache determines whether queries use the client result cache.
+	//
+	// The default is ResultCacheDefault.
+	ResultCache ResultCacheMode
+
+	// NativeFloats determines whether float64 and float32 values are bound
+	// as BINARY_DOUBLE and BINARY_FLOAT instead of being converted to
+	// OCINumbers. NaN and infinite values are always bound natively, as an
+	// OCINumber can't represent them.
+	//
+	// BINARY_DOUBLE and BINARY_FLOAT select-list columns are always defined
+	// natively for F64, F32, OraF64 and OraF32.
+	//
+	// The default is false.
+	NativeFloats bool
+
+	// NaN determines the handling of NaN and infinite values fetched from
+	// BINARY_DOUBLE and BINARY_FLOAT columns or bound as float64 and float32
+	// values.
+	//
+	// The default is NaNPass.
+	NaN NaNPolicy
+
+	// BatchErrors determines whether an array DML statement processes every
+	// row, rather than stopping at the first failed row. Stmt.Exe returns a
+	// *BatchError describing the failed rows along with the number of rows
+	// affected.
+	//
+	// The default is false.
+	BatchErrors bool
+
+	// LobPromotionSize is the length in bytes above which string, String,
+	// []byte and Raw values are bound as temporary CLOBs and BLOBs, avoiding
+	// ORA-01461 and ORA-01489 for values exceeding the VARCHAR2 and RAW bind
+	// limits. PL/SQL blocks, and statements of a database with extended data
+	// types (see Srv.ExtendedStrings), promote values longer than 32,767
+	// bytes when LobPromotionSize is smaller. Zero disables promotion.
+	//
+	// The default is 0.
+	LobPromotionSize int
+
+	// SlowThreshold is the duration of an execution at or above which the
+	// execution is logged as slow, with its SQL text. Zero disables the
+	// logging of slow executions.
+	//
+	// The default is 0.
+	SlowThreshold time.Duration
+
+	// SlowBindCapture determines whether a slow execution is logged with the
+	// SQL_ID and child cursor number of the statement, and the binds captured
+	// in V$SQL_BIND_CAPTURE, requiring SELECT privileges on V$SESSION and
+	// V$SQL_BIND_CAPTURE. Binds are captured by the server at most every 15
+	// minutes, and so may belong to an earlier execution.
+	//
+	// The default is false.
+	SlowBindCapture bool
+
+	// EmptyString determines how empty string, String, []string and []String
+	// values are bound. Oracle treats '' as NULL, so EmptyStringSpace binds a
+	// single space to store a non-NULL value.
+	//
+	// The default is EmptyStringNull.
+	EmptyString EmptyStringPolicy
+
+	// NullString determines how NULL is fetched from a character column
+	// defined as S. NullStringOra fetches the column as a String, so that NULL
+	// is distinguished from a stored value.
+	//
+	// The default is NullStringEmpty.
+	NullString NullStringPolicy
+
+	// TimePrecision is the number of fractional second digits, from 0 to 9,
+	// of bound time.Time, *time.Time, Time, []time.Time and []Time values;
+	// further digits are reduced by TimeRounding. With the default, every
+	// digit is bound, and Oracle rounds the value stored in a column of lower
+	// precision. Set TimePrecision to the precision of the column, such as 6
+	// for a TIMESTAMP(6), to make truncation possible. Fetched TIMESTAMP
+	// values keep every digit of the column, including through database/sql.
+	//
+	// The default is 9.
+	TimePrecision int
+
+	// TimeRounding determines how bound time values are reduced to
+	// TimePrecision digits.
+	//
+	// The default is TimeRound.
+	TimeRounding TimeRoundingPolicy
+
+	// InsertIdColumn is the column returned as the LastInsertId of a
+	// single-row INSERT ... VALUES statement executed through database/sql,
+	// such as "ID". The statement is rewritten with RETURNING InsertIdColumn
+	// INTO a hidden bind, so every table inserted into through database/sql
+	// must have the column, of an integer type. Statements with a RETURNING
+	// clause are unchanged. An empty InsertIdColumn disables the rewrite.
+	//
+	// The default is "".
+	InsertIdColumn string
+
+	// PackageStateRetries is the number of times a statement failing with
+	// ORA-04068 or ORA-04061, reporting that the state of a PL/SQL package
+	// was discarded, such as after a deployment recompiled the package, is
+	// re-executed. The failed execution is undone by the server, and the
+	// package gets a new state, so a re-execution usually succeeds. Array
+	// executions aren't re-executed. Zero disables re-execution.
+	//
+	// The default is 0.
+	PackageStateRetries int
+
+	// RowScn determines whether Ses.Prep appends ORA_ROWSCN to the select
+	// list of queries of a single table, as the last column of each row, for
+	// Rset.RowScn and Ses.ExeIfUnchanged. Queries selecting only *, or using
+	// DISTINCT, GROUP BY, set operators or joins, are unchanged. Statements
+	// prepared through database/sql, and the driver's own queries, are
+	// unchanged.
+	//
+	// The default is false.
+	RowScn bool
+
 	// Rset represents configuration options for an Rset struct.
 	Rset RsetCfg
 }
@@ -54,6 +175,19 @@ func NewStmtCfg() *StmtCfg {
 	c.IsAutoCommitting = true
 	c.FalseRune = '0'
 	c.TrueRune = '1'
+	c.ResultCache = ResultCacheDefault
+	c.NativeFloats = false
+	c.NaN = NaNPass
+	c.BatchErrors = false
+	c.LobPromotionSize = 0
+	c.SlowThreshold = 0
+	c.SlowBindCapture = false
+	c.EmptyString = EmptyStringNull
+	c.NullString = NullStringEmpty
+	c.TimePrecision = maxTimePrecision
+	c.TimeRounding = TimeRound
+	c.PackageStateRetries = 0
+	c.RowScn = false
 	c.Rset = NewRsetCfg()
 	return c
 }
@@ -193,7 +327,8 @@ func (c *StmtCfg) SetStringPtrBufferSize(size int) error {
 // For a *string parameter binding, you may wish to increase the size of
 // StringPtrBufferSize depending on the Oracle column type. For VARCHAR2,
 // NVARCHAR2, and RAW oracle columns the Oracle MAX_STRING_SIZE is usually 4000
-// but may be set up to 32767.
+// but may be set up to 32767. The default of 4000 is raised to 32767 for a
+// database with extended data types (see Srv.ExtendedStrings).
 func (c *StmtCfg) StringPtrBufferSize() int {
 	return c.stringPtrBufferSize
 }
@@ -0,0 +1,112 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// RowStream delivers the rows of a query on a channel.
+//
+// RowStream suits queries over pipelined table functions and other long-running
+// producers: the query is fetched by a separate goroutine which blocks until
+// the consumer receives each row, so a slow consumer holds back the producer.
+type RowStream struct {
+	// C receives each row. C is closed when all rows are delivered, an error
+	// occurs or the stream is closed.
+	C <-chan []interface{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+	closed int32 // set by Close; accessed atomically
+	err    error
+}
+
+// QryStream runs a SQL query in a new goroutine, delivering rows on the
+// returned RowStream's channel.
+//
+// Each row delivered on RowStream.C is a new slice. When ctx is done, or
+// RowStream.Close is called, while a fetch is in flight, the fetch is
+// interrupted with Ses.Break.
+//
+// The Ses must not be used for other calls until the stream finishes.
+//
+// A consumer which stops receiving before RowStream.C is closed must call
+// RowStream.Close: the goroutine otherwise blocks forever on sending the next
+// row, leaking the goroutine and its open Stmt. A consumer which receives
+// every row needn't call Close.
+func (ses *Ses) QryStream(ctx context.Context, sql string, params ...interface{}) *RowStream {
+	ses.log(_drv.cfg().Log.Ses.QryStream)
+	ctx, cancel := context.WithCancel(ctx)
+	c := make(chan []interface{})
+	s := &RowStream{C: c, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		defer close(c)
+		defer cancel() // release ctx without waiting for Close
+		s.err = ses.stream(ctx, c, sql, params)
+	}()
+	return s
+}
+
+// stream queries sql and sends each row on c until ctx is done.
+func (ses *Ses) stream(ctx context.Context, c chan<- []interface{}, sql string, params []interface{}) (err error) {
+	defer ses.breakOnDone(ctx)() // break a blocking fetch
+	stmt, err := ses.Prep(sql)
+	if err != nil {
+		return errE(err)
+	}
+	defer func() {
+		if err0 := stmt.Close(); err == nil {
+			err = err0
+		}
+	}()
+	rset, err := stmt.QryContext(ctx, params...)
+	if err != nil {
+		return errE(err)
+	}
+	for rset.Next() {
+		row := make([]interface{}, len(rset.Row))
+		copy(row, rset.Row)
+		select {
+		case c <- row:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if rset.Err != nil {
+		if ctx.Err() != nil { // interrupted by Break
+			return ctx.Err()
+		}
+		return errE(rset.Err)
+	}
+	return nil
+}
+
+// Err returns the error, if any, which ended the stream. Err is valid once
+// RowStream.C is closed.
+//
+// Err returns nil when the stream was ended by RowStream.Close.
+func (s *RowStream) Err() error {
+	<-s.done
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return nil
+	}
+	return s.err
+}
+
+// Close stops the stream, interrupting any fetch in flight, and waits for the
+// query's goroutine to finish. Rows not yet received are discarded.
+func (s *RowStream) Close() error {
+	s.once.Do(func() {
+		atomic.StoreInt32(&s.closed, 1)
+		s.cancel()
+	})
+	<-s.done
+	return nil
+}
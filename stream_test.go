@@ -0,0 +1,34 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestRowStreamClose tests that Err returns nil once the stream is closed,
+// with Close and Err called concurrently.
+func TestRowStreamClose(t *testing.T) {
+	done := make(chan struct{})
+	s := &RowStream{done: done, err: errors.New("canceled")}
+	s.cancel = func() { close(done) }
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.Close()
+	}()
+	var err error
+	go func() {
+		defer wg.Done()
+		err = s.Err()
+	}()
+	wg.Wait()
+	if err != nil {
+		t.Errorf("got %v, wanted nil", err)
+	}
+}
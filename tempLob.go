@@ -0,0 +1,120 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import "sync"
+
+// tempLobs are the temporary LOBs created by a Ses which haven't been freed.
+// Temporary LOBs live in the temporary tablespace of the server until they're
+// freed or the session ends, so a leak grows the tablespace of a long-lived
+// session.
+type tempLobs struct {
+	mu   sync.Mutex
+	lobs map[*C.OCILobLocator]struct{}
+}
+
+// add registers lob and returns the number of temporary LOBs.
+func (t *tempLobs) add(lob *C.OCILobLocator) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lobs == nil {
+		t.lobs = make(map[*C.OCILobLocator]struct{})
+	}
+	t.lobs[lob] = struct{}{}
+	return len(t.lobs)
+}
+
+// remove unregisters lob, returning false when it isn't registered.
+func (t *tempLobs) remove(lob *C.OCILobLocator) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.lobs[lob]; !ok {
+		return false
+	}
+	delete(t.lobs, lob)
+	return true
+}
+
+// removeAll unregisters and returns the temporary LOBs.
+func (t *tempLobs) removeAll() []*C.OCILobLocator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lobs := make([]*C.OCILobLocator, 0, len(t.lobs))
+	for lob := range t.lobs {
+		lobs = append(lobs, lob)
+	}
+	t.lobs = nil
+	return lobs
+}
+
+func (t *tempLobs) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.lobs)
+}
+
+// addTempLob registers a temporary LOB of the Ses, logging a warning each
+// time the number of temporary LOBs reaches a multiple of
+// SesCfg.TempLobWarning.
+func (ses *Ses) addTempLob(lob *C.OCILobLocator) {
+	n := ses.tempLobs.add(lob)
+	if warn := ses.cfg.TempLobWarning; warn > 0 && n%warn == 0 {
+		lgr.Errorf("%v has %d temporary LOBs which haven't been freed; see Ses.FreeTempLobs", ses.sysName(), n)
+	}
+}
+
+// freeTempLob frees lob when it's a temporary LOB of the Ses which hasn't
+// been freed. The locator descriptor isn't freed.
+func (ses *Ses) freeTempLob(lob *C.OCILobLocator) {
+	if lob == nil || !ses.tempLobs.remove(lob) {
+		return
+	}
+	ses.poll(nil, func() C.sword {
+		return C.OCILobFreeTemporary(
+			ses.ocisvcctx, //OCISvcCtx          *svchp,
+			ses.ocierr,    //OCIError           *errhp,
+			lob)           //OCILobLocator      *locp,
+	})
+}
+
+// FreeTempLobs frees the temporary LOBs created by the Ses for LOB binds
+// which haven't been freed, and returns their number.
+//
+// The temporary LOBs of binds are freed when their Stmt is closed; call
+// FreeTempLobs after using Stmts which are kept open, such as those of a
+// statement cache, to release temporary tablespace. A Stmt whose temporary
+// LOBs are freed must be executed again, with new binds, before its LOB
+// parameters are read.
+func (ses *Ses) FreeTempLobs() (n int, err error) {
+	ses.mu.Lock()
+	defer ses.mu.Unlock()
+	ses.log(_drv.cfg().Log.Ses.FreeTempLobs)
+	if err = ses.checkClosed(); err != nil {
+		return 0, errE(err)
+	}
+	for _, lob := range ses.tempLobs.removeAll() {
+		r := ses.poll(nil, func() C.sword {
+			return C.OCILobFreeTemporary(
+				ses.ocisvcctx, //OCISvcCtx          *svchp,
+				ses.ocierr,    //OCIError           *errhp,
+				lob)           //OCILobLocator      *locp,
+		})
+		if r == C.OCI_ERROR && err == nil {
+			err = errE(ses.ociError())
+		}
+		n++
+	}
+	return n, err
+}
+
+// NumTempLob returns the number of temporary LOBs created by the Ses for LOB
+// binds which haven't been freed.
+func (ses *Ses) NumTempLob() int {
+	return ses.tempLobs.len()
+}
@@ -0,0 +1,101 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package testsupport
+
+import (
+	"fmt"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+// Names of the fixture objects created by CreateFixtures.
+const (
+	// PeopleTable has a row of each of the scalar types used by the
+	// examples: ID NUMBER(10), NAME VARCHAR2(40), BORN DATE, SALARY
+	// NUMBER(12,2), ACTIVE CHAR(1), and NOTES CLOB.
+	PeopleTable = "ORA_FIX_PEOPLE"
+	// AddressType is an object type of STREET and CITY VARCHAR2(40)
+	// attributes.
+	AddressType = "ORA_FIX_ADDRESS"
+	// AddressesType is a nested table type of AddressType.
+	AddressesType = "ORA_FIX_ADDRESSES"
+	// Package has the procedure GREET(P_NAME IN VARCHAR2, P_OUT OUT
+	// VARCHAR2), the function ADD_ONE(P_N NUMBER) RETURN NUMBER, and the
+	// procedure PEOPLE(P_CUR OUT SYS_REFCURSOR) opening a cursor over
+	// PeopleTable.
+	Package = "ORA_FIX_PKG"
+)
+
+// People are the rows of PeopleTable inserted by CreateFixtures.
+var People = []struct {
+	ID     int64
+	Name   string
+	Salary float64
+	Active bool
+}{
+	{1, "Ada", 1234.5, true},
+	{2, "Brian", 987.25, false},
+	{3, "Chen", 4500, true},
+}
+
+// CreateFixtures drops and creates the fixture objects, and inserts People.
+func CreateFixtures(ses *ora.Ses) error {
+	DropFixtures(ses)
+	stmts := []string{
+		"CREATE TABLE " + PeopleTable + " (ID NUMBER(10) PRIMARY KEY, NAME VARCHAR2(40), " +
+			"BORN DATE, SALARY NUMBER(12,2), ACTIVE CHAR(1), NOTES CLOB)",
+		"CREATE OR REPLACE TYPE " + AddressType + " AS OBJECT (STREET VARCHAR2(40), CITY VARCHAR2(40))",
+		"CREATE OR REPLACE TYPE " + AddressesType + " AS TABLE OF " + AddressType,
+		"CREATE OR REPLACE PACKAGE " + Package + " AS\n" +
+			"  PROCEDURE greet(p_name IN VARCHAR2, p_out OUT VARCHAR2);\n" +
+			"  FUNCTION add_one(p_n NUMBER) RETURN NUMBER;\n" +
+			"  PROCEDURE people(p_cur OUT SYS_REFCURSOR);\n" +
+			"END;",
+		"CREATE OR REPLACE PACKAGE BODY " + Package + " AS\n" +
+			"  PROCEDURE greet(p_name IN VARCHAR2, p_out OUT VARCHAR2) IS\n" +
+			"  BEGIN\n" +
+			"    p_out := 'Hello, ' || p_name;\n" +
+			"  END;\n" +
+			"  FUNCTION add_one(p_n NUMBER) RETURN NUMBER IS\n" +
+			"  BEGIN\n" +
+			"    RETURN p_n + 1;\n" +
+			"  END;\n" +
+			"  PROCEDURE people(p_cur OUT SYS_REFCURSOR) IS\n" +
+			"  BEGIN\n" +
+			"    OPEN p_cur FOR SELECT ID, NAME, SALARY FROM " + PeopleTable + " ORDER BY ID;\n" +
+			"  END;\n" +
+			"END;",
+	}
+	for _, sql := range stmts {
+		if _, err := ses.PrepAndExe(sql); err != nil {
+			return fmt.Errorf("%s: %v", sql, err)
+		}
+	}
+	for _, p := range People {
+		active := "N"
+		if p.Active {
+			active = "Y"
+		}
+		if _, err := ses.PrepAndExe("INSERT INTO "+PeopleTable+
+			" (ID, NAME, BORN, SALARY, ACTIVE) VALUES (:1, :2, SYSDATE - :3, :4, :5)",
+			p.ID, p.Name, p.ID*1000, p.Salary, active); err != nil {
+			return err
+		}
+	}
+	_, err := ses.PrepAndExe("COMMIT")
+	return err
+}
+
+// DropFixtures drops the fixture objects, ignoring objects which don't exist.
+func DropFixtures(ses *ora.Ses) {
+	for _, sql := range []string{
+		"DROP PACKAGE " + Package,
+		"DROP TYPE " + AddressesType + " FORCE",
+		"DROP TYPE " + AddressType + " FORCE",
+		"DROP TABLE " + PeopleTable + " PURGE",
+	} {
+		ses.PrepAndExe(sql)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package testsupport
+
+import (
+	"testing"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+func TestCreateFixtures(t *testing.T) {
+	conn := open(t)
+	defer conn.Close()
+	ses := conn.Ses
+	// creating the fixtures twice drops the objects of the first time
+	for n := 0; n < 2; n++ {
+		if err := CreateFixtures(ses); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer DropFixtures(ses)
+
+	stmt, err := ses.Prep("SELECT ID, NAME, SALARY, ACTIVE FROM "+PeopleTable+" ORDER BY ID", ora.I64, ora.S, ora.F64, ora.S)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for ; rset.Next(); n++ {
+		p := People[n]
+		active := map[bool]string{true: "Y", false: "N"}[p.Active]
+		if rset.Row[0] != p.ID || rset.Row[1] != p.Name || rset.Row[2] != p.Salary || rset.Row[3] != active {
+			t.Errorf("%d. expected %+v, actual %v", n, p, rset.Row)
+		}
+	}
+	if rset.Err != nil {
+		t.Fatal(rset.Err)
+	}
+	if n != len(People) {
+		t.Fatalf("expected %d people, actual %d", len(People), n)
+	}
+
+	var greeting string
+	if _, err = ses.PrepAndExe("BEGIN "+Package+".greet(:1, :2); END;", "Ada", &greeting); err != nil {
+		t.Fatal(err)
+	}
+	if greeting != "Hello, Ada" {
+		t.Errorf("greet: expected(%q), actual(%q)", "Hello, Ada", greeting)
+	}
+}
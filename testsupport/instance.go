@@ -0,0 +1,213 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+// Package testsupport connects tests to an Oracle server, creates the
+// fixture schema of the examples, and asserts round-trip values.
+//
+// Start connects to the server of the environment variables of the package
+// tests, GO_ORA_DRV_TEST_DB, GO_ORA_DRV_TEST_USERNAME and
+// GO_ORA_DRV_TEST_PASSWORD, or, when GO_ORA_DRV_TEST_DB isn't set, runs an
+// Oracle XE container with docker:
+//
+//	func TestMain(m *testing.M) {
+//		inst, err := testsupport.Start(context.Background(), testsupport.NewCfg())
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		code := m.Run()
+//		inst.Stop()
+//		os.Exit(code)
+//	}
+package testsupport // import "gopkg.in/rana/ora.v3/testsupport"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+// Cfg configures Start.
+type Cfg struct {
+	// Image is the docker image of the Oracle XE container.
+	//
+	// The default is "gvenzl/oracle-xe:21-slim".
+	Image string
+
+	// Service is the service name of the container's database.
+	//
+	// The default is "XEPDB1".
+	Service string
+
+	// Password is the password of the container's SYSTEM user, also the
+	// password of the test user.
+	//
+	// The default is "oracle".
+	Password string
+
+	// Username is the test user created in the container.
+	//
+	// The default is "ora_test".
+	Username string
+
+	// StartTimeout is the maximum wait for the container's database to
+	// accept connections.
+	//
+	// The default is 5m.
+	StartTimeout time.Duration
+}
+
+// NewCfg creates a Cfg with default values.
+func NewCfg() Cfg {
+	c := Cfg{}
+	c.Image = "gvenzl/oracle-xe:21-slim"
+	c.Service = "XEPDB1"
+	c.Password = "oracle"
+	c.Username = "ora_test"
+	c.StartTimeout = 5 * time.Minute
+	return c
+}
+
+// Instance is an Oracle server used by tests.
+type Instance struct {
+	Dblink   string
+	Username string
+	Password string
+
+	container string // docker container id, or empty
+}
+
+// Start returns the Instance of the GO_ORA_DRV_TEST_* environment variables,
+// or runs an Oracle XE container and waits until it accepts connections.
+func Start(ctx context.Context, cfg Cfg) (*Instance, error) {
+	if dblink := os.Getenv("GO_ORA_DRV_TEST_DB"); dblink != "" {
+		return &Instance{
+			Dblink:   dblink,
+			Username: os.Getenv("GO_ORA_DRV_TEST_USERNAME"),
+			Password: os.Getenv("GO_ORA_DRV_TEST_PASSWORD"),
+		}, nil
+	}
+	out, err := docker(ctx, "run", "-d", "-P",
+		"-e", "ORACLE_PASSWORD="+cfg.Password,
+		"-e", "APP_USER="+cfg.Username,
+		"-e", "APP_USER_PASSWORD="+cfg.Password,
+		cfg.Image)
+	if err != nil {
+		return nil, err
+	}
+	inst := &Instance{Username: cfg.Username, Password: cfg.Password, container: out}
+	port, err := docker(ctx, "port", inst.container, "1521/tcp")
+	if err != nil {
+		inst.Stop()
+		return nil, err
+	}
+	// "0.0.0.0:49153", possibly followed by an IPv6 mapping
+	port = strings.Fields(port)[0]
+	port = port[strings.LastIndex(port, ":")+1:]
+	inst.Dblink = "localhost:" + port + "/" + cfg.Service
+	if err = inst.wait(ctx, cfg.StartTimeout); err != nil {
+		inst.Stop()
+		return nil, err
+	}
+	return inst, nil
+}
+
+// wait waits until a session of the Instance can be opened.
+func (inst *Instance) wait(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		conn, err := inst.Open()
+		if err == nil {
+			return conn.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v is not ready: %v", inst.Dblink, err)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// Stop removes the container started by Start; an Instance of the
+// environment variables is left alone.
+// Stop removes the container run by Start. Stop does nothing for an Instance
+// of the GO_ORA_DRV_TEST_* environment variables.
+func (inst *Instance) Stop() error {
+	if inst.container == "" {
+		return nil
+	}
+	_, err := docker(context.Background(), "rm", "-f", "-v", inst.container)
+	inst.container = ""
+	return err
+}
+
+// SrvCfg returns a SrvCfg of the Instance.
+func (inst *Instance) SrvCfg() *ora.SrvCfg {
+	c := ora.NewSrvCfg()
+	c.Dblink = inst.Dblink
+	return c
+}
+
+// SesCfg returns a SesCfg of the test user.
+func (inst *Instance) SesCfg() *ora.SesCfg {
+	c := ora.NewSesCfg()
+	c.Username, c.Password = inst.Username, inst.Password
+	return c
+}
+
+// ConStr returns the connection string of the Instance for sql.Open.
+func (inst *Instance) ConStr() string {
+	return inst.Username + "/" + inst.Password + "@" + inst.Dblink
+}
+
+// Conn is an open connection to an Instance.
+type Conn struct {
+	Env *ora.Env
+	Srv *ora.Srv
+	Ses *ora.Ses
+}
+
+// Open opens an Env, a Srv and a Ses of the test user.
+func (inst *Instance) Open() (*Conn, error) {
+	env, err := ora.OpenEnv(nil)
+	if err != nil {
+		return nil, err
+	}
+	srv, err := env.OpenSrv(inst.SrvCfg())
+	if err != nil {
+		env.Close()
+		return nil, err
+	}
+	ses, err := srv.OpenSes(inst.SesCfg())
+	if err != nil {
+		srv.Close()
+		env.Close()
+		return nil, err
+	}
+	return &Conn{Env: env, Srv: srv, Ses: ses}, nil
+}
+
+// Close closes the Ses, Srv and Env of c.
+func (c *Conn) Close() error {
+	c.Ses.Close()
+	c.Srv.Close()
+	return c.Env.Close()
+}
+
+// docker runs the docker command with args, returning its trimmed output.
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %v: %v: %s", args[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package testsupport
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+// roundTripID numbers the tables of RoundTrip.
+var roundTripID int64
+
+// RoundTrip inserts value into a new table with a single column of
+// columnType, such as "NUMBER(10)" or "VARCHAR2(40 CHAR)", selects it back
+// with gcts, and fails tb unless the selected value equals value. The table
+// is dropped before RoundTrip returns. The selected value is returned.
+//
+// Values are compared with their Equals method when they have one, such as
+// ora.Int64 and ora.String, with time.Time.Equal, or with reflect.DeepEqual.
+func RoundTrip(tb testing.TB, ses *ora.Ses, columnType string, value interface{}, gcts ...ora.GoColumnType) interface{} {
+	tb.Helper()
+	table := fmt.Sprintf("ORA_RT_%d", atomic.AddInt64(&roundTripID, 1))
+	if _, err := ses.PrepAndExe("CREATE TABLE " + table + " (C1 " + columnType + ")"); err != nil {
+		tb.Fatalf("create %v column: %v", columnType, err)
+	}
+	defer ses.PrepAndExe("DROP TABLE " + table + " PURGE")
+	if _, err := ses.PrepAndExe("INSERT INTO "+table+" (C1) VALUES (:1)", value); err != nil {
+		tb.Fatalf("insert %v into %v column: %v", value, columnType, err)
+	}
+	stmt, err := ses.Prep("SELECT C1 FROM "+table, gcts...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer stmt.Close()
+	rset, err := stmt.Qry()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if !rset.Next() {
+		tb.Fatalf("select %v column: no row: %v", columnType, rset.Err)
+	}
+	actual := rset.Row[0]
+	if !Equal(value, actual) {
+		tb.Errorf("%v round trip: expected %v (%T), actual %v (%T)", columnType, value, value, actual, actual)
+	}
+	return actual
+}
+
+// Equal reports whether expected and actual are equal as RoundTrip compares
+// them. A pointer expected is compared by the value it points to.
+func Equal(expected, actual interface{}) bool {
+	if rv := reflect.ValueOf(expected); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		expected = rv.Elem().Interface()
+	}
+	if e, ok := expected.(time.Time); ok {
+		a, ok := actual.(time.Time)
+		return ok && e.Equal(a)
+	}
+	if m := reflect.ValueOf(expected).MethodByName("Equals"); m.IsValid() &&
+		m.Type().NumIn() == 1 && m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Bool {
+		av := reflect.ValueOf(actual)
+		if !av.IsValid() || !av.Type().AssignableTo(m.Type().In(0)) {
+			return false
+		}
+		return m.Call([]reflect.Value{av})[0].Bool()
+	}
+	return reflect.DeepEqual(expected, actual)
+}
@@ -0,0 +1,65 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package testsupport
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+// TestEqual tests Equal.
+func TestEqual(t *testing.T) {
+	now := time.Now()
+	n := int64(3)
+	for i, tc := range []struct {
+		expected, actual interface{}
+		want             bool
+	}{
+		{int64(1), int64(1), true},
+		{int64(1), int32(1), false},
+		{&n, int64(3), true},
+		{now, now.In(time.UTC), true},
+		{now, now.Add(time.Nanosecond), false},
+		{ora.Int64{Value: 2}, ora.Int64{Value: 2}, true},
+		{ora.Int64{Value: 2}, ora.Int64{IsNull: true}, false},
+		{ora.String{Value: "a"}, "a", false},
+		{ora.String{IsNull: true}, nil, false},
+		{[]byte("ab"), []byte("ab"), true},
+	} {
+		if got := Equal(tc.expected, tc.actual); got != tc.want {
+			t.Errorf("%d. Equal(%v, %v): got %v, want %v.", i, tc.expected, tc.actual, got, tc.want)
+		}
+	}
+}
+
+// open connects to the server of GO_ORA_DRV_TEST_DB, skipping the test when
+// it isn't set rather than running a container.
+func open(t *testing.T) *Conn {
+	if os.Getenv("GO_ORA_DRV_TEST_DB") == "" {
+		t.Skip("GO_ORA_DRV_TEST_DB isn't set")
+	}
+	inst, err := Start(context.Background(), NewCfg())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := inst.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func TestRoundTrip(t *testing.T) {
+	conn := open(t)
+	defer conn.Close()
+	RoundTrip(t, conn.Ses, "NUMBER(10)", int64(42), ora.I64)
+	RoundTrip(t, conn.Ses, "VARCHAR2(40 CHAR)", "zß水", ora.S)
+	RoundTrip(t, conn.Ses, "VARCHAR2(40 CHAR)", ora.String{IsNull: true}, ora.OraS)
+	RoundTrip(t, conn.Ses, "BINARY_DOUBLE", 1.5, ora.F64)
+}
@@ -0,0 +1,46 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "time"
+
+// maxTimePrecision is the number of fractional second digits of a
+// TIMESTAMP(9), and of a time.Time.
+const maxTimePrecision = 9
+
+// TimeRoundingPolicy determines how the fractional seconds of a bound
+// time.Time are reduced to StmtCfg.TimePrecision digits.
+type TimeRoundingPolicy int
+
+const (
+	// TimeRound rounds half up, as Oracle does when a value is stored in a
+	// column of lower precision. Rounding may carry into the next second.
+	TimeRound TimeRoundingPolicy = iota
+	// TimeTruncate discards the digits beyond the precision.
+	TimeTruncate
+)
+
+// bindValue returns t with precision fractional second digits.
+func (p TimeRoundingPolicy) bindValue(t time.Time, precision int) time.Time {
+	if precision >= maxTimePrecision {
+		return t
+	}
+	if precision < 0 {
+		precision = 0
+	}
+	unit := time.Second
+	for n := 0; n < precision; n++ {
+		unit /= 10
+	}
+	if p == TimeTruncate {
+		return t.Truncate(unit)
+	}
+	return t.Round(unit)
+}
+
+// bindTime returns the value bound for t.
+func (c *StmtCfg) bindTime(t time.Time) time.Time {
+	return c.TimeRounding.bindValue(t, c.TimePrecision)
+}
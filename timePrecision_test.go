@@ -0,0 +1,42 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTimeRoundingPolicy tests TimeRoundingPolicy.bindValue.
+func TestTimeRoundingPolicy(t *testing.T) {
+	base := time.Date(2016, 2, 29, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		policy    TimeRoundingPolicy
+		precision int
+		nsec      int
+		want      time.Time
+	}{
+		{policy: TimeRound, precision: 9, nsec: 123456789, want: base.Add(123456789)},
+		{policy: TimeTruncate, precision: 9, nsec: 123456789, want: base.Add(123456789)},
+		{policy: TimeRound, precision: 6, nsec: 123456789, want: base.Add(123457000)},
+		{policy: TimeTruncate, precision: 6, nsec: 123456789, want: base.Add(123456000)},
+		{policy: TimeRound, precision: 3, nsec: 999500000, want: base.Add(time.Second)},
+		{policy: TimeTruncate, precision: 3, nsec: 999500000, want: base.Add(999000000)},
+		{policy: TimeRound, precision: 0, nsec: 500000000, want: base.Add(time.Second)},
+		{policy: TimeTruncate, precision: -1, nsec: 500000000, want: base},
+	}
+	for _, test := range tests {
+		got := test.policy.bindValue(base.Add(time.Duration(test.nsec)), test.precision)
+		if !got.Equal(test.want) {
+			t.Errorf("policy %v, precision %v: got %v, wanted %v", test.policy, test.precision, got, test.want)
+		}
+	}
+	// rounding and truncation are independent of the location
+	loc := time.FixedZone("", 5*3600+1800)
+	in := time.Date(2016, 1, 1, 10, 0, 0, 123456789, loc)
+	if got := TimeTruncate.bindValue(in, 3); got.Nanosecond() != 123000000 || got.Location() != loc {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"net"
+	"strings"
+)
+
+// TLSCfg configures an encrypted TCPS connection without a hand-maintained
+// sqlnet.ora. The settings are passed to Oracle Net in the SECURITY section
+// of a connect descriptor generated from SrvCfg.Dblink.
+type TLSCfg struct {
+	// WalletLocation is the directory of the Oracle wallet holding the
+	// trusted certificates, and the client certificate of mutual TLS. An
+	// empty WalletLocation uses the wallet configured for the client.
+	//
+	// The default is "".
+	WalletLocation string
+
+	// ServerDNMatch determines whether the distinguished name of the server
+	// certificate is checked: against ServerDN when set, otherwise against
+	// the host name of the descriptor.
+	//
+	// The default is true.
+	ServerDNMatch bool
+
+	// ServerDN is the expected distinguished name of the server certificate,
+	// such as "CN=db.example.com,O=Example". Set ServerDN along with
+	// SrvCfg.Dial, as the host name of the descriptor is then a loopback
+	// address.
+	//
+	// The default is "".
+	ServerDN string
+
+	// CipherSuites are the names of the cipher suites offered, such as
+	// "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384". Nil offers the suites of the
+	// client configuration.
+	//
+	// The default is nil.
+	CipherSuites []string
+
+	// Version is the TLS version negotiated, such as "1.2". An empty Version
+	// uses the version of the client configuration.
+	//
+	// The default is "".
+	Version string
+}
+
+// NewTLSCfg creates a TLSCfg with default values.
+func NewTLSCfg() *TLSCfg {
+	c := &TLSCfg{}
+	c.ServerDNMatch = true
+	return c
+}
+
+// tcpsPort is the default port of a TCPS listener.
+const tcpsPort = "2484"
+
+// tlsDescriptor returns a TCPS connect descriptor for dblink configured by
+// cfg. dblink is an easy connect string, optionally prefixed by tcps://, or a
+// connect descriptor without a SECURITY section whose protocol is TCPS.
+func tlsDescriptor(dblink string, cfg TLSCfg) (string, error) {
+	dblink = strings.TrimSpace(dblink)
+	security := cfg.security()
+	if strings.HasPrefix(dblink, "(") {
+		upper := strings.ToUpper(strings.Replace(dblink, " ", "", -1))
+		if strings.Contains(upper, "(SECURITY=") {
+			return "", errF("Connect descriptor %q has a SECURITY section; remove it or SrvCfg.TLS.", dblink)
+		}
+		if !strings.Contains(upper, "(PROTOCOL=TCPS)") {
+			return "", errF("Connect descriptor %q has no TCPS address.", dblink)
+		}
+		end := strings.LastIndex(dblink, ")")
+		return dblink[:end] + security + dblink[end:], nil
+	}
+	rest := dblink
+	for _, scheme := range []string{"tcps://", "//"} {
+		if len(rest) >= len(scheme) && strings.EqualFold(rest[:len(scheme)], scheme) {
+			rest = rest[len(scheme):]
+			break
+		}
+	}
+	n := strings.IndexByte(rest, '/')
+	if n <= 0 {
+		return "", errF("Dblink %q isn't an easy connect string of the form host[:port]/service.", dblink)
+	}
+	hostPort, service := rest[:n], rest[n+1:]
+	if n = strings.IndexAny(service, ":/"); n >= 0 {
+		service = service[:n]
+	}
+	host, port := hostPort, tcpsPort
+	if strings.HasPrefix(hostPort, "[") || strings.Count(hostPort, ":") == 1 {
+		var err error
+		if host, port, err = net.SplitHostPort(hostPort); err != nil {
+			return "", errE(err)
+		}
+	}
+	return "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=" + host + ")(PORT=" + port + "))" +
+		"(CONNECT_DATA=(SERVICE_NAME=" + service + "))" + security + ")", nil
+}
+
+// security returns the SECURITY section of a connect descriptor.
+func (cfg TLSCfg) security() string {
+	var buf bytes.Buffer
+	buf.WriteString("(SECURITY=")
+	if cfg.ServerDNMatch {
+		buf.WriteString("(SSL_SERVER_DN_MATCH=YES)")
+	} else {
+		buf.WriteString("(SSL_SERVER_DN_MATCH=NO)")
+	}
+	if cfg.ServerDN != "" {
+		buf.WriteString(`(SSL_SERVER_CERT_DN="` + cfg.ServerDN + `")`)
+	}
+	if cfg.WalletLocation != "" {
+		buf.WriteString(`(MY_WALLET_DIRECTORY="` + cfg.WalletLocation + `")`)
+	}
+	if len(cfg.CipherSuites) > 0 {
+		buf.WriteString("(SSL_CIPHER_SUITES=(" + strings.Join(cfg.CipherSuites, ",") + "))")
+	}
+	if cfg.Version != "" {
+		buf.WriteString("(SSL_VERSION=" + cfg.Version + ")")
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+// TestTlsDescriptor tests tlsDescriptor.
+func TestTlsDescriptor(t *testing.T) {
+	cfg := *NewTLSCfg()
+	cfg.WalletLocation = "/etc/wallet"
+	cfg.ServerDN = "CN=db,O=Example"
+	cfg.CipherSuites = []string{"TLS_A", "TLS_B"}
+	security := `(SECURITY=(SSL_SERVER_DN_MATCH=YES)(SSL_SERVER_CERT_DN="CN=db,O=Example")` +
+		`(MY_WALLET_DIRECTORY="/etc/wallet")(SSL_CIPHER_SUITES=(TLS_A,TLS_B)))`
+	for _, tc := range []struct {
+		dblink, want string
+	}{
+		{"tcps://db:2485/orcl",
+			"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=db)(PORT=2485))(CONNECT_DATA=(SERVICE_NAME=orcl))" + security + ")"},
+		{"db/orcl:dedicated",
+			"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=db)(PORT=2484))(CONNECT_DATA=(SERVICE_NAME=orcl))" + security + ")"},
+		{"(DESCRIPTION=(ADDRESS=(PROTOCOL = tcps)(HOST=db)(PORT=2484))(CONNECT_DATA=(SERVICE_NAME=orcl)))",
+			"(DESCRIPTION=(ADDRESS=(PROTOCOL = tcps)(HOST=db)(PORT=2484))(CONNECT_DATA=(SERVICE_NAME=orcl))" + security + ")"},
+	} {
+		got, err := tlsDescriptor(tc.dblink, cfg)
+		if err != nil {
+			t.Errorf("%q: %v", tc.dblink, err)
+		} else if got != tc.want {
+			t.Errorf("%q: got %q, wanted %q", tc.dblink, got, tc.want)
+		}
+	}
+	for _, dblink := range []string{
+		"orcl",
+		"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db)(PORT=1521)))",
+		"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=db)(PORT=2484))(SECURITY=(SSL_SERVER_DN_MATCH=YES)))",
+	} {
+		if _, err := tlsDescriptor(dblink, cfg); err == nil {
+			t.Errorf("%q: wanted an error", dblink)
+		}
+	}
+}
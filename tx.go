@@ -25,6 +25,37 @@ type LogTxCfg struct {
 	//
 	// The default is true.
 	Rollback bool
+
+	// OnCommit determines whether the Tx.OnCommit method is logged.
+	//
+	// The default is true.
+	OnCommit bool
+
+	// OnRollback determines whether the Tx.OnRollback method is logged.
+	//
+	// The default is true.
+	OnRollback bool
+
+	// Nested determines whether the Tx.Nested method is logged.
+	//
+	// The default is true.
+	Nested bool
+
+	// Savepoint determines whether the Tx.Savepoint method is logged.
+	//
+	// The default is true.
+	Savepoint bool
+
+	// RollbackTo determines whether the Tx.RollbackTo method is logged.
+	//
+	// The default is true.
+	RollbackTo bool
+
+	// ReleaseSavepoint determines whether the Tx.ReleaseSavepoint method is
+	// logged.
+	//
+	// The default is true.
+	ReleaseSavepoint bool
 }
 
 // NewLogTxCfg creates a LogTxCfg with default values.
@@ -32,6 +63,12 @@ func NewLogTxCfg() LogTxCfg {
 	c := LogTxCfg{}
 	c.Commit = true
 	c.Rollback = true
+	c.OnCommit = true
+	c.OnRollback = true
+	c.Nested = true
+	c.Savepoint = true
+	c.RollbackTo = true
+	c.ReleaseSavepoint = true
 	return c
 }
 
@@ -39,9 +76,15 @@ func NewLogTxCfg() LogTxCfg {
 //
 // Implements the driver.Tx interface.
 type Tx struct {
-	id  uint64
-	ses *Ses
-	mu  sync.Mutex
+	id    uint64
+	ses   *Ses
+	mu    sync.Mutex
+	hooks txHooks
+
+	nested     uint64   // number of NestedTxs begun; see Tx.Nested
+	savepoints []string // marked by Tx.Savepoint, in order
+	pending    int      // statements executed; see TxLostEvent
+	lastSql    string   // of the last statement executed
 }
 
 // checkIsOpen validates that the session is open.
@@ -62,7 +105,12 @@ func (tx *Tx) closeWithRemove() (err error) {
 // close releases allocated resources.
 func (tx *Tx) close() (err error) {
 	if tx.ses != nil {
+		tx.ses.leaks.untrack(tx)
 		tx.ses = nil
+		tx.hooks.clear()
+		tx.nested = 0
+		tx.savepoints = tx.savepoints[:0]
+		tx.pending, tx.lastSql = 0, ""
 		_drv.txPool.Put(tx)
 	}
 	return nil
@@ -77,18 +125,28 @@ func (tx *Tx) Commit() (err error) {
 	if tx == nil {
 		return nil
 	}
-	tx.log(_drv.cfg.Log.Tx.Commit)
+	tx.log(_drv.cfg().Log.Tx.Commit)
 	if err = tx.checkIsOpen(); err != nil {
 		return err
 	}
 	defer tx.closeWithRemove()
-	r := C.OCITransCommit(
-		tx.ses.ocisvcctx,  //OCISvcCtx    *svchp,
-		tx.ses.srv.env.ocierr, //OCIError     *errhp,
-		C.OCI_DEFAULT)         //ub4          flags );
+	hooks := tx.txEndHooks(true)
+	var ltxid []byte
+	if len(hooks) > 0 {
+		ltxid = tx.ses.ltxid()
+	}
+	r := tx.ses.poll(nil, func() C.sword {
+		return C.OCITransCommit(
+			tx.ses.ocisvcctx, //OCISvcCtx    *svchp,
+			tx.ses.ocierr,    //OCIError     *errhp,
+			C.OCI_DEFAULT)    //ub4          flags );
+	})
 	if r == C.OCI_ERROR {
-		return tx.ses.srv.env.ociError()
+		err = tx.ses.ociError()
+		tx.ses.txLost(err, "", true)
+		return err
 	}
+	callHooks(hooks, ltxid)
 	return nil
 }
 
@@ -101,7 +159,7 @@ func (tx *Tx) Rollback() (err error) {
 	if tx == nil {
 		return nil
 	}
-	tx.log(_drv.cfg.Log.Tx.Rollback)
+	tx.log(_drv.cfg().Log.Tx.Rollback)
 	if err = tx.checkIsOpen(); err != nil {
 		return err
 	}
@@ -109,13 +167,23 @@ func (tx *Tx) Rollback() (err error) {
 		return nil
 	}
 	defer tx.closeWithRemove()
-	r := C.OCITransRollback(
-		tx.ses.ocisvcctx,  //OCISvcCtx    *svchp,
-		tx.ses.srv.env.ocierr, //OCIError     *errhp,
-		C.OCI_DEFAULT)         //ub4          flags );
+	hooks := tx.txEndHooks(false)
+	var ltxid []byte
+	if len(hooks) > 0 {
+		ltxid = tx.ses.ltxid()
+	}
+	r := tx.ses.poll(nil, func() C.sword {
+		return C.OCITransRollback(
+			tx.ses.ocisvcctx, //OCISvcCtx    *svchp,
+			tx.ses.ocierr,    //OCIError     *errhp,
+			C.OCI_DEFAULT)    //ub4          flags );
+	})
 	if r == C.OCI_ERROR {
-		return tx.ses.srv.env.ociError()
+		err = tx.ses.ociError()
+		tx.ses.txLost(err, "", false)
+		return err
 	}
+	callHooks(hooks, ltxid)
 	return nil
 }
 
@@ -131,9 +199,9 @@ func (tx *Tx) sysName() string {
 func (tx *Tx) log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", tx.sysName(), callInfo(1))
+			lgr.Infof("%v %v", tx.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", tx.sysName(), callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v %v", tx.sysName(), callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -142,9 +210,9 @@ func (tx *Tx) log(enabled bool, v ...interface{}) {
 func (tx *Tx) logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v %v", tx.sysName(), callInfo(1))
+			lgr.Infof("%v %v", tx.sysName(), callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v %v", tx.sysName(), callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v %v", tx.sysName(), callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+/*
+#include <oci.h>
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// TxHook is called after a transaction is committed or rolled back with the
+// logical transaction id (LTXID) of the transaction, or nil when the server
+// doesn't support Transaction Guard.
+type TxHook func(ltxid []byte)
+
+// txHooks are the commit and rollback hooks of a Ses or Tx.
+type txHooks struct {
+	mu       sync.Mutex
+	commit   []TxHook
+	rollback []TxHook
+	lost     []TxLostHook // of a Ses; see Ses.OnTxLost
+}
+
+func (h *txHooks) add(commit bool, fn TxHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if commit {
+		h.commit = append(h.commit, fn)
+	} else {
+		h.rollback = append(h.rollback, fn)
+	}
+}
+
+// hooks returns a copy of the commit or rollback hooks.
+func (h *txHooks) hooks(commit bool) []TxHook {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if commit {
+		return append([]TxHook(nil), h.commit...)
+	}
+	return append([]TxHook(nil), h.rollback...)
+}
+
+func (h *txHooks) clear() {
+	h.mu.Lock()
+	h.commit, h.rollback, h.lost = nil, nil, nil
+	h.mu.Unlock()
+}
+
+// OnCommit registers fn to be called after each successful Tx.Commit of a
+// transaction of the Ses. Hooks are called in registration order, after the
+// hooks of the Tx, and are removed when the Ses is closed.
+func (ses *Ses) OnCommit(fn TxHook) {
+	ses.log(_drv.cfg().Log.Ses.OnCommit)
+	ses.txHooks.add(true, fn)
+}
+
+// OnRollback registers fn to be called after each successful Tx.Rollback of
+// a transaction of the Ses. Hooks are called in registration order, after the
+// hooks of the Tx, and are removed when the Ses is closed.
+func (ses *Ses) OnRollback(fn TxHook) {
+	ses.log(_drv.cfg().Log.Ses.OnRollback)
+	ses.txHooks.add(false, fn)
+}
+
+// OnCommit registers fn to be called after the transaction is committed.
+// fn must not call the Tx.
+func (tx *Tx) OnCommit(fn TxHook) {
+	tx.log(_drv.cfg().Log.Tx.OnCommit)
+	tx.hooks.add(true, fn)
+}
+
+// OnRollback registers fn to be called after the transaction is rolled back.
+// fn must not call the Tx.
+func (tx *Tx) OnRollback(fn TxHook) {
+	tx.log(_drv.cfg().Log.Tx.OnRollback)
+	tx.hooks.add(false, fn)
+}
+
+// txEndHooks returns the hooks of the Tx followed by the hooks of its Ses.
+func (tx *Tx) txEndHooks(commit bool) []TxHook {
+	return append(tx.hooks.hooks(commit), tx.ses.txHooks.hooks(commit)...)
+}
+
+// callHooks calls hooks with ltxid.
+func callHooks(hooks []TxHook, ltxid []byte) {
+	for _, fn := range hooks {
+		fn(ltxid)
+	}
+}
+
+// ltxid returns a copy of the logical transaction id of the session, or nil
+// when it's unavailable. No locking occurs.
+func (ses *Ses) ltxid() []byte {
+	var value *C.ub1
+	var size C.ub4
+	r := C.OCIAttrGet(
+		unsafe.Pointer(ses.ocises), //const void     *trgthndlp,
+		C.OCI_HTYPE_SESSION,        //ub4            trghndltyp,
+		unsafe.Pointer(&value),     //void           *attributep,
+		&size,                      //ub4            *sizep,
+		C.OCI_ATTR_LTXID,           //ub4            attrtype,
+		ses.ocierr)                 //OCIError       *errhp );
+	if r == C.OCI_ERROR || value == nil || size == 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(value), C.int(size))
+}
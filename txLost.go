@@ -0,0 +1,86 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+// TxLostEvent reports a transaction of a Ses whose server connection was
+// lost before the transaction ended. The server rolls back the changes of
+// the transaction, unless the lost call was a commit it completed.
+type TxLostEvent struct {
+	Ses *Ses
+	Tx  *Tx
+	// PendingStmts is the number of statements other than queries executed
+	// successfully in the transaction, whose changes are lost.
+	PendingStmts int
+	// LastSql is the SQL text of the failed statement, or of the last
+	// statement executed in the transaction when Tx.Commit or Tx.Rollback
+	// failed.
+	LastSql string
+	// Commit reports whether the failed call was Tx.Commit, whose outcome is
+	// then unknown.
+	Commit bool
+	// Err is the error of the failed call.
+	Err error
+}
+
+// TxLostHook is called with a TxLostEvent.
+type TxLostHook func(event TxLostEvent)
+
+// OnTxLost registers fn to be called when the server connection of the Ses
+// is lost during a transaction, before the Srv is reconnected; see
+// SrvCfg.Reconnect. fn is called on the goroutine of the failed call, once
+// for each open Tx, and must not call the Ses or the Tx. Hooks are called
+// in registration order, and are removed when the Ses is closed.
+func (ses *Ses) OnTxLost(fn TxLostHook) {
+	ses.log(_drv.cfg().Log.Ses.OnTxLost)
+	ses.txHooks.mu.Lock()
+	ses.txHooks.lost = append(ses.txHooks.lost, fn)
+	ses.txHooks.mu.Unlock()
+}
+
+// exeDone records the execution of a statement other than a query in the
+// open transactions.
+func (l *txList) exeDone(sql string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, tx := range l.items {
+		tx.pending++
+		tx.lastSql = sql
+	}
+}
+
+// lostEvents returns the TxLostEvents of the open transactions. An empty sql
+// is replaced by the last statement executed in each transaction.
+func (l *txList) lostEvents(sql string, commit bool, err error) []TxLostEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := make([]TxLostEvent, 0, len(l.items))
+	for _, tx := range l.items {
+		event := TxLostEvent{Tx: tx, PendingStmts: tx.pending, LastSql: sql, Commit: commit, Err: err}
+		if event.LastSql == "" {
+			event.LastSql = tx.lastSql
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// txLost calls the OnTxLost hooks when err reports a lost server connection
+// during a transaction. sql is the SQL text of the failed statement, or ""
+// for a failed commit or rollback.
+func (ses *Ses) txLost(err error, sql string, commit bool) {
+	if !isConnLost(err) || ses.openTxs.len() == 0 {
+		return
+	}
+	ses.txHooks.mu.Lock()
+	hooks := append([]TxLostHook(nil), ses.txHooks.lost...)
+	ses.txHooks.mu.Unlock()
+	for _, event := range ses.openTxs.lostEvents(sql, commit, err) {
+		event.Ses = ses
+		ses.logF(_drv.cfg().Log.Ses.OnTxLost, "transaction lost with %v pending statements: %v", event.PendingStmts, err)
+		for _, fn := range hooks {
+			fn(event)
+		}
+	}
+}
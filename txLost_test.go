@@ -0,0 +1,35 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestTxLostEvents tests the statements recorded for TxLostEvents.
+func TestTxLostEvents(t *testing.T) {
+	l := newTxList()
+	tx := &Tx{}
+	l.add(tx)
+	l.exeDone("INSERT INTO T1 VALUES (1)")
+	l.exeDone("UPDATE T1 SET C1 = 2")
+	err := errors.New("ORA-03113: end-of-file on communication channel")
+	events := l.lostEvents("", true, err)
+	if len(events) != 1 {
+		t.Fatalf("got %d events, wanted 1", len(events))
+	}
+	want := TxLostEvent{Tx: tx, PendingStmts: 2, LastSql: "UPDATE T1 SET C1 = 2", Commit: true, Err: err}
+	if events[0] != want {
+		t.Errorf("got %+v, wanted %+v", events[0], want)
+	}
+	if events = l.lostEvents("DELETE FROM T1", false, err); events[0].LastSql != "DELETE FROM T1" {
+		t.Errorf("got %q, wanted the failed statement", events[0].LastSql)
+	}
+	l.remove(tx)
+	if events = l.lostEvents("", false, err); len(events) != 0 {
+		t.Errorf("got %d events without a Tx", len(events))
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// TxIsolation is the isolation level of a transaction started by Ses.StartTx.
+type TxIsolation int
+
+const (
+	// TxDefault leaves the isolation level of the session, READ COMMITTED
+	// unless changed with ALTER SESSION SET ISOLATION_LEVEL.
+	TxDefault TxIsolation = iota
+	// TxReadCommitted starts a read-write transaction, with the
+	// OCI_TRANS_READWRITE flag, of the session's isolation level: READ
+	// COMMITTED, in which each query sees the data committed before it
+	// began, unless changed with ALTER SESSION SET ISOLATION_LEVEL.
+	TxReadCommitted
+	// TxSerializable starts a SERIALIZABLE transaction, with the
+	// OCI_TRANS_SERIALIZABLE flag: each query sees the
+	// data committed before the transaction began, and changing a row
+	// changed by a transaction committed since fails with ORA-08177.
+	TxSerializable
+)
+
+// txCfg is the configuration built by TxOptions.
+type txCfg struct {
+	isolation TxIsolation
+	readOnly  bool
+}
+
+// TxOption configures a transaction started by Ses.StartTx.
+type TxOption func(cfg *txCfg)
+
+// TxIsolationLevel returns a TxOption setting the isolation level.
+func TxIsolationLevel(level TxIsolation) TxOption {
+	return func(cfg *txCfg) { cfg.isolation = level }
+}
+
+// TxReadOnly returns a TxOption starting a read-only transaction, with the
+// OCI_TRANS_READONLY flag, whose
+// queries see the data committed before the transaction began, and which may
+// not change data. A read-only transaction has no isolation level.
+func TxReadOnly() TxOption {
+	return func(cfg *txCfg) { cfg.readOnly = true }
+}
+
+// newTxCfg returns the configuration built by opts.
+func newTxCfg(opts []TxOption) txCfg {
+	var cfg txCfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// txFlag is the mode of a transaction passed to OCITransStart with
+// OCI_TRANS_NEW.
+type txFlag int
+
+const (
+	txFlagDefault      txFlag = iota // no flag
+	txFlagReadWrite                  // OCI_TRANS_READWRITE
+	txFlagSerializable               // OCI_TRANS_SERIALIZABLE
+	txFlagReadOnly                   // OCI_TRANS_READONLY
+)
+
+// flag returns the txFlag starting a transaction configured by cfg.
+func (cfg txCfg) flag() (txFlag, error) {
+	if cfg.readOnly {
+		if cfg.isolation == TxReadCommitted {
+			return txFlagDefault, er("A read-only transaction can't have the READ COMMITTED isolation level.")
+		}
+		return txFlagReadOnly, nil
+	}
+	switch cfg.isolation {
+	case TxDefault:
+		return txFlagDefault, nil
+	case TxReadCommitted:
+		return txFlagReadWrite, nil
+	case TxSerializable:
+		return txFlagSerializable, nil
+	}
+	return txFlagDefault, errF("Unknown TxIsolation %v.", cfg.isolation)
+}
+
+// driverTxOptions returns the TxOptions of a database/sql transaction.
+// sql.LevelSnapshot is Oracle's SERIALIZABLE, which is snapshot isolation;
+// other levels Oracle doesn't provide are rejected.
+func driverTxOptions(opts driver.TxOptions) ([]TxOption, error) {
+	var txOpts []TxOption
+	switch sql.IsolationLevel(opts.Isolation) {
+	case sql.LevelDefault:
+	case sql.LevelReadCommitted:
+		txOpts = append(txOpts, TxIsolationLevel(TxReadCommitted))
+	case sql.LevelSerializable, sql.LevelSnapshot:
+		txOpts = append(txOpts, TxIsolationLevel(TxSerializable))
+	default:
+		return nil, errF("Isolation level %v isn't supported by Oracle; use sql.LevelDefault, sql.LevelReadCommitted, sql.LevelSerializable or sql.LevelSnapshot.", sql.IsolationLevel(opts.Isolation))
+	}
+	if opts.ReadOnly {
+		txOpts = append(txOpts, TxReadOnly())
+	}
+	return txOpts, nil
+}
+
+// BeginTx starts a transaction with the isolation level and read-only mode
+// of opts; see Ses.StartTx.
+//
+// BeginTx is a member of the driver.ConnBeginTx interface.
+func (con *Con) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	con.log(_drv.cfg().Log.Con.BeginTx)
+	if err := con.checkIsOpen(); err != nil {
+		return nil, err
+	}
+	if err := ctxErr(ctx); err != nil {
+		return nil, err
+	}
+	txOpts, err := driverTxOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := con.ses.StartTx(txOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
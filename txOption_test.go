@@ -0,0 +1,52 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// TestTxOptions tests the OCITransStart flags of TxOptions and of
+// database/sql TxOptions.
+func TestTxOptions(t *testing.T) {
+	tests := []struct {
+		opts driver.TxOptions
+		want txFlag
+		err  bool
+	}{
+		{opts: driver.TxOptions{}, want: txFlagDefault},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelReadCommitted)}, want: txFlagReadWrite},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSerializable)}, want: txFlagSerializable},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSnapshot)}, want: txFlagSerializable},
+		{opts: driver.TxOptions{ReadOnly: true}, want: txFlagReadOnly},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSerializable), ReadOnly: true}, want: txFlagReadOnly},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelReadCommitted), ReadOnly: true}, err: true},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelRepeatableRead)}, err: true},
+		{opts: driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelReadUncommitted)}, err: true},
+	}
+	for _, test := range tests {
+		txOpts, err := driverTxOptions(test.opts)
+		var got txFlag
+		if err == nil {
+			got, err = newTxCfg(txOpts).flag()
+		}
+		if test.err {
+			if err == nil {
+				t.Errorf("%+v: wanted an error, got %v", test.opts, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", test.opts, err)
+		} else if got != test.want {
+			t.Errorf("%+v: got %v, wanted %v", test.opts, got, test.want)
+		}
+	}
+	if got := nestedSavepoint(3); got != "ORA_NESTED_3" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -11,6 +11,7 @@ package ora
 */
 import "C"
 import (
+	"bufio"
 	"bytes"
 	"container/list"
 	"encoding/json"
@@ -509,6 +510,65 @@ func (this Lob) Close() error {
 	return nil
 }
 
+// Length returns the length of a selected LOB; in bytes for a BLOB and
+// characters for a CLOB. The length is prefetched with the row, so Length
+// doesn't make a round trip to the server. ok is false when the Lob is null
+// or its Reader isn't of a selected LOB.
+func (this Lob) Length() (length uint64, ok bool) {
+	if lr, ok := this.Reader.(interface {
+		lobLength() uint64
+	}); ok {
+		return lr.lobLength(), true
+	}
+	return 0, false
+}
+
+// ReadAmounts returns the number of bytes and, for a CLOB or NCLOB, of
+// characters read so far from a selected LOB. ok is false when the Lob is
+// null or its Reader isn't of a selected LOB.
+func (this Lob) ReadAmounts() (nBytes, nChars uint64, ok bool) {
+	if lr, ok := this.Reader.(interface {
+		readAmounts() (uint64, uint64)
+	}); ok {
+		nBytes, nChars = lr.readAmounts()
+		return nBytes, nChars, true
+	}
+	return 0, 0, false
+}
+
+// RuneReader returns an io.RuneScanner reading the characters of a CLOB or
+// NCLOB, so that substrings of multi-byte content are taken at character
+// boundaries. The RuneScanner buffers the Reader of the Lob, which must not
+// be read otherwise afterwards. A null Lob reads no runes.
+func (this Lob) RuneReader() io.RuneScanner {
+	if rs, ok := this.Reader.(io.RuneScanner); ok {
+		return rs
+	}
+	if this.Reader == nil {
+		return bufio.NewReader(bytes.NewReader(nil))
+	}
+	return bufio.NewReader(this.Reader)
+}
+
+// ReadAt reads len(p) bytes of a selected BLOB starting at off, without
+// downloading the rest of the LOB; see io.ReaderAt. With Seek, a Lob is an
+// io.ReadSeeker which may be served with http.ServeContent.
+func (this Lob) ReadAt(p []byte, off int64) (n int, err error) {
+	if ra, ok := this.Reader.(io.ReaderAt); ok {
+		return ra.ReadAt(p, off)
+	}
+	return 0, er("Lob.Reader isn't an io.ReaderAt.")
+}
+
+// Seek sets the offset of the next Read of a selected BLOB; see io.Seeker.
+// Seek before reading: a Lob which is partly read by streaming can't seek.
+func (this Lob) Seek(offset int64, whence int) (int64, error) {
+	if s, ok := this.Reader.(io.Seeker); ok {
+		return s.Seek(offset, whence)
+	}
+	return 0, er("Lob.Reader isn't an io.Seeker.")
+}
+
 // Equals returns true when the receiver and specified Lob are both null,
 // or when they both not null and share the same Reader.
 func (this Lob) Equals(other Lob) bool {
@@ -636,6 +696,13 @@ func (this IntervalDS) ShiftTime(t time.Time) time.Time {
 	return time.Date(year, month, day+int(this.Day), hour+int(this.Hour), min+int(this.Minute), sec+int(this.Second), t.Nanosecond()+int(this.Nanosecond), t.Location())
 }
 
+// IntervalDSTable is bound as a PL/SQL associative array, a TABLE OF INTERVAL
+// DAY TO SECOND INDEX BY PLS_INTEGER, to a placeholder of a PL/SQL block. A
+// *IntervalDSTable is bound IN OUT: up to its capacity of elements are
+// returned to it after execution. A []IntervalDS is bound as an array of
+// iterations, also in a PL/SQL block.
+type IntervalDSTable []IntervalDS
+
 // MultiErr holds multiple errors in a single string.
 type MultiErr struct {
 	str string
@@ -780,7 +847,9 @@ func (l *envList) setAllCfg(cfg *EnvCfg) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	for n := 0; n < len(l.items); n++ {
-		l.items[n].SetCfg(cfg)
+		if !l.items[n].named {
+			l.items[n].SetCfg(cfg)
+		}
 	}
 }
 
@@ -970,6 +1039,15 @@ func (l *sesList) closeAll(errs *list.List) {
 	l.items = l.items[:0] // clear all Sess from sesList
 }
 
+// snapshot returns a copy of the Sess in the sesList.
+func (l *sesList) snapshot() []*Ses {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	items := make([]*Ses, len(l.items))
+	copy(items, l.items)
+	return items
+}
+
 func (l *sesList) clear() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -1094,6 +1172,15 @@ func (l *stmtList) closeAll(errs *list.List) {
 	l.items = l.items[:0] // clear all Stmts from stmtList
 }
 
+// snapshot returns a copy of the Stmts in the stmtList.
+func (l *stmtList) snapshot() []*Stmt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	items := make([]*Stmt, len(l.items))
+	copy(items, l.items)
+	return items
+}
+
 func (l *stmtList) clear() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -1156,6 +1243,15 @@ func (l *rsetList) closeAll(errs *list.List) {
 	l.items = l.items[:0] // clear all Rsets from rsetList
 }
 
+// snapshot returns a copy of the Rsets in the rsetList.
+func (l *rsetList) snapshot() []*Rset {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	items := make([]*Rset, len(l.items))
+	copy(items, l.items)
+	return items
+}
+
 func (l *rsetList) clear() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -0,0 +1,163 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// upsertBatchSize is the number of rows bound per MERGE execution.
+const upsertBatchSize = 1000
+
+// BulkUpsert inserts or updates rows of table with an array-bound MERGE
+// statement, and returns the number of rows merged.
+//
+// cols names the columns of each row, and keyCols names the subset of cols
+// identifying an existing row. Rows matching on keyCols have their other
+// columns updated; other rows are inserted. Each column is bound as an array
+// of the Go type of its values; a nil value is bound as NULL only in a column
+// of []byte values, so use nullable ora types such as ora.Int64 for other
+// columns holding NULLs.
+//
+// Every row is processed, in batches of 1000. When rows fail, the error is a
+// *BatchError whose RowError.Row values index rows; the other rows are merged.
+func (ses *Ses) BulkUpsert(table string, keyCols, cols []string, rows [][]interface{}) (rowsAffected uint64, err error) {
+	ses.log(_drv.cfg().Log.Ses.BulkUpsert)
+	err = ses.checkClosed()
+	if err != nil {
+		return 0, errE(err)
+	}
+	sql, err := mergeSql(table, keyCols, cols)
+	if err != nil {
+		return 0, errE(err)
+	}
+	for n, row := range rows {
+		if len(row) != len(cols) {
+			return 0, errF("Row %d has %d values and %d columns.", n, len(row), len(cols))
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	stmt, err := ses.Prep(sql)
+	if err != nil {
+		return 0, errE(err)
+	}
+	defer func() {
+		if err0 := stmt.Close(); err == nil {
+			err = err0
+		}
+	}()
+	cfg := stmt.Cfg()
+	cfg.BatchErrors = true
+	stmt.SetCfg(cfg)
+	var batchErr BatchError
+	for start := 0; start < len(rows); start += upsertBatchSize {
+		end := start + upsertBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		params, err := upsertParams(cols, rows[start:end])
+		if err != nil {
+			return rowsAffected, err
+		}
+		n, err := stmt.Exe(params...)
+		rowsAffected += n
+		if be, ok := err.(*BatchError); ok {
+			for _, re := range be.Errors {
+				re.Row += start
+				batchErr.Errors = append(batchErr.Errors, re)
+			}
+		} else if err != nil {
+			return rowsAffected, errE(err)
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return rowsAffected, &batchErr
+	}
+	return rowsAffected, nil
+}
+
+// mergeSql returns a MERGE statement upserting a row of cols into table,
+// matched on keyCols.
+func mergeSql(table string, keyCols, cols []string) (string, error) {
+	if len(keyCols) == 0 {
+		return "", er("BulkUpsert requires key columns.")
+	}
+	isKey := make(map[string]bool, len(keyCols))
+	for _, key := range keyCols {
+		isKey[strings.ToUpper(key)] = true
+	}
+	for _, col := range cols {
+		delete(isKey, strings.ToUpper(col))
+	}
+	for key := range isKey {
+		return "", errF("Key column %v is not one of the columns.", key)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "MERGE INTO %v t USING (SELECT ", table)
+	for n, col := range cols {
+		if n > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, ":%d %v", n+1, col)
+	}
+	buf.WriteString(" FROM DUAL) s ON (")
+	for n, key := range keyCols {
+		if n > 0 {
+			buf.WriteString(" AND ")
+		}
+		fmt.Fprintf(&buf, "t.%v = s.%v", key, key)
+	}
+	buf.WriteString(")")
+	var sets []string
+	for _, col := range cols {
+		key := false
+		for _, k := range keyCols {
+			key = key || strings.EqualFold(k, col)
+		}
+		if !key {
+			sets = append(sets, fmt.Sprintf("t.%v = s.%v", col, col))
+		}
+	}
+	if len(sets) > 0 {
+		fmt.Fprintf(&buf, " WHEN MATCHED THEN UPDATE SET %v", strings.Join(sets, ", "))
+	}
+	values := make([]string, len(cols))
+	for n, col := range cols {
+		values[n] = "s." + col
+	}
+	fmt.Fprintf(&buf, " WHEN NOT MATCHED THEN INSERT (%v) VALUES (%v)", strings.Join(cols, ", "), strings.Join(values, ", "))
+	return buf.String(), nil
+}
+
+// upsertParams returns the column arrays of rows.
+func upsertParams(cols []string, rows [][]interface{}) ([]interface{}, error) {
+	params := make([]interface{}, len(cols))
+	values := make([]interface{}, len(rows))
+	for n := range cols {
+		var typ reflect.Type
+		hasNil := false
+		for m, row := range rows {
+			values[m] = row[n]
+			if row[n] == nil {
+				hasNil = true
+			} else if typ == nil {
+				typ = reflect.TypeOf(row[n])
+			}
+		}
+		if hasNil && typ != nil && typ != reflect.TypeOf([]byte(nil)) {
+			return nil, errF("Column %v has nil and %v values; use a nullable ora type.", cols[n], typ)
+		}
+		var err error
+		if params[n], err = copySlice(values); err != nil {
+			return nil, errF("Column %v: %v", cols[n], err)
+		}
+	}
+	return params, nil
+}
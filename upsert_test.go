@@ -0,0 +1,37 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+func TestMergeSql(t *testing.T) {
+	for i, tc := range []struct {
+		keyCols, cols []string
+		want          string
+	}{
+		{[]string{"ID"}, []string{"ID", "NAME", "QTY"},
+			"MERGE INTO T1 t USING (SELECT :1 ID, :2 NAME, :3 QTY FROM DUAL) s ON (t.ID = s.ID)" +
+				" WHEN MATCHED THEN UPDATE SET t.NAME = s.NAME, t.QTY = s.QTY" +
+				" WHEN NOT MATCHED THEN INSERT (ID, NAME, QTY) VALUES (s.ID, s.NAME, s.QTY)"},
+		{[]string{"a", "b"}, []string{"A", "B"},
+			"MERGE INTO T1 t USING (SELECT :1 A, :2 B FROM DUAL) s ON (t.a = s.a AND t.b = s.b)" +
+				" WHEN NOT MATCHED THEN INSERT (A, B) VALUES (s.A, s.B)"},
+	} {
+		got, err := mergeSql("T1", tc.keyCols, tc.cols)
+		if err != nil {
+			t.Errorf("%d. %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%d. got\n%q, want\n%q.", i, got, tc.want)
+		}
+	}
+	if _, err := mergeSql("T1", nil, []string{"ID"}); err == nil {
+		t.Errorf("no keys: wanted error")
+	}
+	if _, err := mergeSql("T1", []string{"ID"}, []string{"NAME"}); err == nil {
+		t.Errorf("key not in cols: wanted error")
+	}
+}
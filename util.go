@@ -32,19 +32,19 @@ func checkTimeColumn(gct GoColumnType) error {
 // checkStringColumn returns nil when the column type is string; otherwise, an error.
 func checkStringColumn(gct GoColumnType) error {
 	switch gct {
-	case S, OraS:
+	case S, OraS, SBorrow:
 		return nil
 	}
-	return errF("Invalid go column type (%v) specified for string-based sql column. Expected go column type S or OraS.", GctName(gct))
+	return errF("Invalid go column type (%v) specified for string-based sql column. Expected go column type S, OraS or SBorrow.", GctName(gct))
 }
 
 // checkBoolOrStringColumn returns nil when the column type is bool; otherwise, an error.
 func checkBoolOrStringColumn(gct GoColumnType) error {
 	switch gct {
-	case B, OraB, S, OraS:
+	case B, OraB, S, OraS, SBorrow:
 		return nil
 	}
-	return errF("Invalid go column type (%v) specified. Expected go column type B, OraB, S, or OraS.", GctName(gct))
+	return errF("Invalid go column type (%v) specified. Expected go column type B, OraB, S, OraS, or SBorrow.", GctName(gct))
 }
 
 // checkBinOrU8Column returns nil when the column type is Bin or U8; otherwise, an error.
@@ -65,6 +65,30 @@ func checkBinColumn(gct GoColumnType) error {
 	return errF("Invalid go column type (%v) specified. Expected go column type Bits or OraBits.", GctName(gct))
 }
 
+// checkUUIDColumn returns nil when the column type is OraUUID and the RAW
+// column is 16 bytes; otherwise, checkBinColumn is applied.
+func checkUUIDColumn(gct GoColumnType, columnSize int) error {
+	if gct != OraUUID {
+		return checkBinColumn(gct)
+	}
+	if columnSize != len(UUID{}) {
+		return errF("Invalid go column type (OraUUID) specified for a RAW(%v) column. Expected RAW(16).", columnSize)
+	}
+	return nil
+}
+
+// checkLobColumn returns nil when the column type is OraLobD, JSON or JSONAny;
+// otherwise, check is applied.
+func checkLobColumn(gct GoColumnType, check func(GoColumnType) error) error {
+	if gct == OraLobD || checkJSONColumn(gct) == nil {
+		return nil
+	}
+	if gct == SBorrow {
+		return errF("Invalid go column type (%v) specified for LOB sql column.", GctName(gct))
+	}
+	return check(gct)
+}
+
 func GctName(gct GoColumnType) string {
 	switch gct {
 	case D:
@@ -125,6 +149,16 @@ func GctName(gct GoColumnType) string {
 		return "Bin"
 	case OraBin:
 		return "OraBin"
+	case OraLobD:
+		return "OraLobD"
+	case OraUUID:
+		return "OraUUID"
+	case JSON:
+		return "JSON"
+	case JSONAny:
+		return "JSONAny"
+	case SBorrow:
+		return "SBorrow"
 	}
 	return ""
 }
@@ -176,9 +210,9 @@ func errInfo(depth int) string {
 func log(enabled bool, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v", callInfo(1))
+			lgr.Infof("%v", callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v", callInfo(1), fmt.Sprint(v...))
+			lgr.Infof("%v %v", callInfo(1), fmt.Sprint(v...))
 		}
 	}
 }
@@ -187,24 +221,24 @@ func log(enabled bool, v ...interface{}) {
 func logF(enabled bool, format string, v ...interface{}) {
 	if enabled {
 		if len(v) == 0 {
-			_drv.cfg.Log.Logger.Infof("%v", callInfo(1))
+			lgr.Infof("%v", callInfo(1))
 		} else {
-			_drv.cfg.Log.Logger.Infof("%v %v", callInfo(1), fmt.Sprintf(format, v...))
+			lgr.Infof("%v %v", callInfo(1), fmt.Sprintf(format, v...))
 		}
 	}
 }
 
 // err creates an error with caller info.
 func er(v ...interface{}) (err error) {
-	err = errors.New(fmt.Sprintf("%v %v", errInfo(1), fmt.Sprint(v...)))
-	_drv.cfg.Log.Logger.Errorln(err)
+	err = errors.New(redact(fmt.Sprintf("%v %v", errInfo(1), fmt.Sprint(v...))))
+	lgr.Errorln(err)
 	return err
 }
 
 // errF creates a formatted error with caller info.
 func errF(format string, v ...interface{}) (err error) {
-	err = errors.New(fmt.Sprintf("%v %v", errInfo(1), fmt.Sprintf(format, v...)))
-	_drv.cfg.Log.Logger.Errorln(err)
+	err = errors.New(redact(fmt.Sprintf("%v %v", errInfo(1), fmt.Sprintf(format, v...))))
+	lgr.Errorln(err)
 	return err
 }
 
@@ -212,15 +246,15 @@ func errF(format string, v ...interface{}) (err error) {
 func errR(v ...interface{}) (err error) {
 	trace := make([]byte, 4096)
 	n := runtime.Stack(trace, false)
-	err = errors.New(fmt.Sprintf("%v recovered: %v\n%s",
-		errInfo(1), fmt.Sprint(v...), trace[:n]))
-	_drv.cfg.Log.Logger.Errorln(err)
+	err = errors.New(redact(fmt.Sprintf("%v recovered: %v\n%s",
+		errInfo(1), fmt.Sprint(v...), trace[:n])))
+	lgr.Errorln(err)
 	return err
 }
 
 // errE wraps an error with caller info.
 func errE(e error) (err error) {
-	err = errors.New(fmt.Sprintf("%v %v", errInfo(1), e.Error()))
-	_drv.cfg.Log.Logger.Errorln(err)
+	err = errors.New(redact(fmt.Sprintf("%v %v", errInfo(1), e.Error())))
+	lgr.Errorln(err)
 	return err
 }
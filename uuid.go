@@ -0,0 +1,126 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
+	"reflect"
+)
+
+// UUID represents a 16-byte universally unique identifier stored in a
+// RAW(16) Oracle column.
+//
+// UUID binds as RAW(16) and is defined from a RAW(16) column with the OraUUID
+// GoColumnType. UUID has the same underlying type as github.com/google/uuid.UUID,
+// so the two convert directly; any [16]byte array type may also be bound.
+type UUID [16]byte
+
+var _ = (encoding.TextMarshaler)(UUID{})
+var _ = (encoding.TextUnmarshaler)((*UUID)(nil))
+var _ = (encoding.BinaryMarshaler)(UUID{})
+var _ = (encoding.BinaryUnmarshaler)((*UUID)(nil))
+var _ = (driver.Valuer)(UUID{})
+var _ = (sql.Scanner)((*UUID)(nil))
+
+var uuidType = reflect.TypeOf(UUID{})
+
+// ParseUUID parses a UUID from its canonical 36 character form
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, or from 32 hexadecimal digits.
+func ParseUUID(s string) (u UUID, err error) {
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, errF("Invalid UUID (%v).", s)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return u, errF("Invalid UUID length (%v). Expected 36 or 32 characters.", s)
+	}
+	if _, err = hex.Decode(u[:], []byte(s)); err != nil {
+		return UUID{}, errF("Invalid UUID (%v): %v", s, err)
+	}
+	return u, nil
+}
+
+// String returns the canonical form of the UUID,
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// IsZero returns true when every byte of the UUID is zero.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+func (u *UUID) UnmarshalText(p []byte) error {
+	v, err := ParseUUID(string(p))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u[:], nil
+}
+func (u *UUID) UnmarshalBinary(p []byte) error {
+	if len(p) != len(u) {
+		return errF("Invalid UUID length (%v). Expected 16 bytes.", len(p))
+	}
+	copy(u[:], p)
+	return nil
+}
+
+// Value returns the UUID as a 16 byte slice for database/sql.
+func (u UUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+// Scan assigns a UUID from a 16 byte slice or a string for database/sql.
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		if len(v) == len(u) {
+			copy(u[:], v)
+			return nil
+		}
+		return u.UnmarshalText(v)
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case nil:
+		*u = UUID{}
+		return nil
+	}
+	return errF("Unable to scan %T into a UUID.", src)
+}
+
+// asUUID returns value as a UUID when value is a [16]byte array of any type,
+// such as github.com/google/uuid.UUID.
+func asUUID(value interface{}) (UUID, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Array || !v.Type().ConvertibleTo(uuidType) {
+		return UUID{}, false
+	}
+	return v.Convert(uuidType).Interface().(UUID), true
+}
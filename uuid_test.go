@@ -0,0 +1,53 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora
+
+import "testing"
+
+func TestUUID(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	u, err := ParseUUID(canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u[0] != 0x6b || u[15] != 0xc8 {
+		t.Errorf("parsed %x", u[:])
+	}
+	if got := u.String(); got != canonical {
+		t.Errorf("String: got %q, wanted %q", got, canonical)
+	}
+	v, err := ParseUUID("6BA7B8109DAD11D180B400C04FD430C8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != u {
+		t.Errorf("hex form: got %v, wanted %v", v, u)
+	}
+	for _, s := range []string{"", "6ba7b810-9dad-11d1-80b4-00c04fd430c", "6ba7b810+9dad-11d1-80b4-00c04fd430c8", "zba7b810-9dad-11d1-80b4-00c04fd430c8"} {
+		if _, err := ParseUUID(s); err == nil {
+			t.Errorf("%q: wanted error", s)
+		}
+	}
+
+	type googleUUID [16]byte
+	w, ok := asUUID(googleUUID(u))
+	if !ok || w != u {
+		t.Errorf("asUUID: got %v, %t", w, ok)
+	}
+	if _, ok := asUUID([15]byte{}); ok {
+		t.Errorf("asUUID([15]byte): wanted false")
+	}
+	if _, ok := asUUID(u[:]); ok {
+		t.Errorf("asUUID([]byte): wanted false")
+	}
+
+	var x UUID
+	if err := x.Scan(u[:]); err != nil || x != u {
+		t.Errorf("Scan([]byte): got %v, %v", x, err)
+	}
+	if err := x.Scan(canonical); err != nil || x != u {
+		t.Errorf("Scan(string): got %v, %v", x, err)
+	}
+}
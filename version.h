@@ -39,6 +39,14 @@
 	#define OCI_ATTR_UB8_ROW_COUNT		OCI_ATTR_ROW_COUNT
 #endif
 
+#ifndef OCI_STMT_MERGE
+	#define OCI_STMT_MERGE				16
+#endif
+
+#ifndef SQLT_JSON
+	#define SQLT_JSON					119
+#endif
+
 #if ORACLE_VERSION_HEX >= ORACLE_VERSION(10,1)
 	#define LOB_LENGTH_TYPE				oraub8
 	#define OCILOBGETLENGTH				OCILobGetLength2
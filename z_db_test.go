@@ -5,8 +5,11 @@
 package ora_test
 
 import (
+	"database/sql"
 	"fmt"
 	"testing"
+
+	"gopkg.in/rana/ora.v3"
 )
 
 func Test_open_cursors_db(t *testing.T) {
@@ -303,3 +306,41 @@ func Test_blob_bytes_db(t *testing.T) {
 func Test_blobNull_bytes_db(t *testing.T) {
 	testBindDefineDB(gen_bytes(9), t, blobNull)
 }
+
+func TestRegisterName_db(t *testing.T) {
+	envCfg := ora.NewEnvCfg()
+	envCfg.LobChunkSize = 1 << 20
+	err := ora.RegisterName("ora-registered", envCfg)
+	testErr(err, t)
+	if err = ora.RegisterName("ora-registered", nil); err == nil {
+		t.Fatal("registering a name twice: expected an error")
+	}
+	// the cfg passed is unchanged
+	if gct := envCfg.StmtCfg.Rset.BinaryFloat(); gct != ora.F32 {
+		t.Fatalf("BinaryFloat of the cfg passed: expected(%v), actual(%v)", ora.F32, gct)
+	}
+
+	// the named Env keeps its cfg when the DrvCfg changes
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	drvCfg := ora.CfgCopy()
+	drvCfg.Env.LobChunkSize = 1 << 10
+	ora.SetCfg(*drvCfg)
+	env := ora.NamedEnv("ora-registered")
+	if env == nil {
+		t.Fatal("NamedEnv: expected an Env")
+	}
+	if size := env.Cfg().LobChunkSize; size != 1<<20 {
+		t.Fatalf("LobChunkSize of the named Env: expected(%v), actual(%v)", 1<<20, size)
+	}
+
+	db, err := sql.Open("ora-registered", testConStr)
+	testErr(err, t)
+	defer db.Close()
+	var f float64
+	err = db.QueryRow("SELECT CAST(1.5 AS BINARY_FLOAT) FROM DUAL").Scan(&f)
+	testErr(err, t)
+	if f != 1.5 {
+		t.Fatalf("expected(%v), actual(%v)", 1.5, f)
+	}
+}
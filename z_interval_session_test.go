@@ -137,3 +137,135 @@ func TestBindDefine_OraIntervalDS_ShiftTime_session(t *testing.T) {
 		t.Fatalf("expected(%v), actual(%v)", expected, actual)
 	}
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// IntervalDSTable
+////////////////////////////////////////////////////////////////////////////////
+func TestBindPlsql_OraIntervalDSTable_session(t *testing.T) {
+	_, err := testSes.PrepAndExe(`CREATE OR REPLACE PACKAGE ora_test_intervals AS
+  TYPE interval_tab IS TABLE OF INTERVAL DAY TO SECOND INDEX BY PLS_INTEGER;
+  FUNCTION total_days(a interval_tab) RETURN PLS_INTEGER;
+  PROCEDURE double_append(a IN OUT interval_tab);
+END;`)
+	testErr(err, t)
+	defer testSes.PrepAndExe("DROP PACKAGE ora_test_intervals")
+	_, err = testSes.PrepAndExe(`CREATE OR REPLACE PACKAGE BODY ora_test_intervals AS
+  FUNCTION total_days(a interval_tab) RETURN PLS_INTEGER IS
+    n PLS_INTEGER := 0;
+  BEGIN
+    FOR i IN 1..a.COUNT LOOP n := n + EXTRACT(DAY FROM a(i)); END LOOP;
+    RETURN n;
+  END;
+  PROCEDURE double_append(a IN OUT interval_tab) IS
+  BEGIN
+    FOR i IN 1..a.COUNT LOOP a(i) := a(i) * 2; END LOOP;
+    a(a.COUNT + 1) := INTERVAL '1' DAY;
+  END;
+END;`)
+	testErr(err, t)
+
+	// IN
+	var total int64
+	_, err = testSes.PrepAndExe("BEGIN :1 := ora_test_intervals.total_days(:2); END;",
+		&total, ora.IntervalDSTable{{Day: 1}, {Day: 2}, {Day: 3}})
+	testErr(err, t)
+	if total != 6 {
+		t.Fatalf("total days: expected(%v), actual(%v)", 6, total)
+	}
+
+	// IN OUT
+	table := make(ora.IntervalDSTable, 2, 3)
+	table[0] = ora.IntervalDS{Day: 1, Hour: 1}
+	table[1] = ora.IntervalDS{Minute: 30}
+	_, err = testSes.PrepAndExe("BEGIN ora_test_intervals.double_append(:1); END;", &table)
+	testErr(err, t)
+	expected := ora.IntervalDSTable{{Day: 2, Hour: 2}, {Hour: 1}, {Day: 1}}
+	if len(table) != len(expected) {
+		t.Fatalf("elements: expected(%v), actual(%v)", len(expected), len(table))
+	}
+	for n := range expected {
+		if !expected[n].Equals(table[n]) {
+			t.Fatalf("%d. expected(%v), actual(%v)", n, expected[n], table[n])
+		}
+	}
+
+	// outside of PL/SQL
+	if _, err = testSes.PrepAndQry("SELECT 1 FROM DUAL WHERE :1 IS NOT NULL", ora.IntervalDSTable{{Day: 1}}); err == nil {
+		t.Fatal("binding an IntervalDSTable to a query: expected an error")
+	}
+}
+
+func TestEnv_IntervalDS(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	a := ora.IntervalDS{Day: 1, Hour: 23, Minute: 30}
+	b := ora.IntervalDS{Hour: 2, Second: 15, Nanosecond: 500}
+	sum, err := env.AddIntervalDS(a, b)
+	testErr(err, t)
+	if expected := (ora.IntervalDS{Day: 2, Hour: 1, Minute: 30, Second: 15, Nanosecond: 500}); !sum.Equals(expected) {
+		t.Errorf("Add: expected(%v), actual(%v)", expected, sum)
+	}
+	diff, err := env.SubIntervalDS(b, a)
+	testErr(err, t)
+	if expected := (ora.IntervalDS{Day: -1, Hour: -21, Minute: -29, Second: -44, Nanosecond: -999999500}); !diff.Equals(expected) {
+		t.Errorf("Sub: expected(%v), actual(%v)", expected, diff)
+	}
+	if sum, err = env.AddIntervalDS(a, ora.IntervalDS{IsNull: true}); err != nil || !sum.IsNull {
+		t.Errorf("Add of null: expected null, actual %v, %v", sum, err)
+	}
+	for _, tc := range []struct {
+		a, b     ora.IntervalDS
+		expected int
+	}{
+		{a, b, 1},
+		{b, a, -1},
+		{a, ora.IntervalDS{Day: 1, Hour: 23, Minute: 30}, 0},
+	} {
+		cmp, err := env.CompareIntervalDS(tc.a, tc.b)
+		testErr(err, t)
+		if cmp != tc.expected {
+			t.Errorf("Compare(%v, %v): expected(%v), actual(%v)", tc.a, tc.b, tc.expected, cmp)
+		}
+	}
+	if _, err = env.CompareIntervalDS(a, ora.IntervalDS{IsNull: true}); err == nil {
+		t.Error("Compare of null: expected an error")
+	}
+
+	start := time.Date(2016, 3, 31, 22, 0, 0, 0, time.FixedZone("", 2*3600))
+	shifted, err := env.ShiftTimeDS(start, a)
+	testErr(err, t)
+	if expected := time.Date(2016, 4, 2, 21, 30, 0, 0, time.FixedZone("", 2*3600)); !shifted.Equal(expected) {
+		t.Errorf("ShiftTimeDS: expected(%v), actual(%v)", expected, shifted)
+	}
+}
+
+func TestEnv_IntervalYM(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	a, b := ora.IntervalYM{Year: 1, Month: 11}, ora.IntervalYM{Month: 2}
+	sum, err := env.AddIntervalYM(a, b)
+	testErr(err, t)
+	if expected := (ora.IntervalYM{Year: 2, Month: 1}); !sum.Equals(expected) {
+		t.Errorf("Add: expected(%v), actual(%v)", expected, sum)
+	}
+	diff, err := env.SubIntervalYM(b, a)
+	testErr(err, t)
+	if expected := (ora.IntervalYM{Year: -1, Month: -9}); !diff.Equals(expected) {
+		t.Errorf("Sub: expected(%v), actual(%v)", expected, diff)
+	}
+	if cmp, err := env.CompareIntervalYM(a, b); err != nil || cmp != 1 {
+		t.Errorf("Compare: expected 1, actual %v, %v", cmp, err)
+	}
+
+	shifted, err := env.ShiftTimeYM(time.Date(2016, 1, 15, 0, 0, 0, 0, time.UTC), b)
+	testErr(err, t)
+	if expected := time.Date(2016, 3, 15, 0, 0, 0, 0, time.UTC); !shifted.Equal(expected) {
+		t.Errorf("ShiftTimeYM: expected(%v), actual(%v)", expected, shifted)
+	}
+	// February 31 doesn't exist
+	if _, err = env.ShiftTimeYM(time.Date(2016, 1, 31, 0, 0, 0, 0, time.UTC), ora.IntervalYM{Month: 1}); err == nil {
+		t.Error("ShiftTimeYM: expected ORA-01839")
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+func TestLob_Seek_blob_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT TO_BLOB(HEXTORAW('00010203040506070809')) FROM DUAL", ora.OraBin)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	if !rset.Next() {
+		t.Fatalf("expected a row, actual %v", rset.Err)
+	}
+	lob := rset.Row[0].(ora.Lob)
+	defer lob.Close()
+
+	p := make([]byte, 3)
+	if n, err := lob.ReadAt(p, 2); err != nil || string(p[:n]) != "\x02\x03\x04" {
+		t.Errorf("ReadAt: expected [2 3 4], actual %v, %v", p[:n], err)
+	}
+	if n, err := lob.ReadAt(p, 8); err != io.EOF || string(p[:n]) != "\x08\x09" {
+		t.Errorf("ReadAt the end: expected [8 9] and EOF, actual %v, %v", p[:n], err)
+	}
+	if off, err := lob.Seek(-3, io.SeekEnd); err != nil || off != 7 {
+		t.Fatalf("SeekEnd: expected 7, actual %d, %v", off, err)
+	}
+	if n, err := lob.Read(p[:1]); err != nil || p[0] != 7 {
+		t.Errorf("Read after SeekEnd: expected [7], actual %v, %v", p[:n], err)
+	}
+	if off, err := lob.Seek(-6, io.SeekCurrent); err != nil || off != 2 {
+		t.Fatalf("SeekCurrent: expected 2, actual %d, %v", off, err)
+	}
+	if n, err := lob.Read(p); err != nil || string(p[:n]) != "\x02\x03\x04" {
+		t.Errorf("Read after SeekCurrent: expected [2 3 4], actual %v, %v", p[:n], err)
+	}
+	b, err := ioutil.ReadAll(lob)
+	testErr(err, t)
+	if string(b) != "\x05\x06\x07\x08\x09" {
+		t.Errorf("Read the rest: expected [5 6 7 8 9], actual %v", b)
+	}
+}
+
+func TestLob_Seek_clob_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT TO_CLOB('abcdef') FROM DUAL", ora.OraS)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	if !rset.Next() {
+		t.Fatalf("expected a row, actual %v", rset.Err)
+	}
+	lob, ok := rset.Row[0].(ora.Lob)
+	if !ok {
+		t.Fatalf("expected an ora.Lob, actual %T", rset.Row[0])
+	}
+	defer lob.Close()
+	if _, err := lob.Seek(1, io.SeekStart); err == nil {
+		t.Error("Seek: expected an error for a CLOB")
+	}
+	if _, err := lob.ReadAt(make([]byte, 2), 1); err == nil {
+		t.Error("ReadAt: expected an error for a CLOB")
+	}
+	b, err := ioutil.ReadAll(lob)
+	testErr(err, t)
+	if string(b) != "abcdef" {
+		t.Errorf("Read: expected %q, actual %q", "abcdef", b)
+	}
+}
+
+func TestLobD_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT TO_CLOB('abcdef'), TO_BLOB(HEXTORAW('0102')), CAST(NULL AS CLOB), TO_CLOB('unread') FROM DUAL",
+		ora.OraLobD, ora.OraLobD, ora.OraLobD, ora.OraLobD)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	if !rset.Next() {
+		t.Fatalf("expected a row, actual %v", rset.Err)
+	}
+	clob, blob := rset.Row[0].(ora.LobD), rset.Row[1].(ora.LobD)
+	null, unread := rset.Row[2].(ora.LobD), rset.Row[3].(ora.LobD)
+
+	if clob.IsNull || clob.Length != 6 || clob.ChunkSize == 0 {
+		t.Errorf("CLOB: expected a length of 6 and a chunk size, actual %+v", clob)
+	}
+	b, err := clob.Bytes()
+	testErr(err, t)
+	if string(b) != "abcdef" {
+		t.Errorf("CLOB: expected(%q), actual(%q)", "abcdef", b)
+	}
+	if _, err = clob.Reader(); err == nil {
+		t.Error("CLOB: expected an error reading a LobD twice")
+	}
+	testErr(clob.Close(), t)
+
+	if blob.Length != 2 {
+		t.Errorf("BLOB: expected a length of 2, actual %+v", blob)
+	}
+	r, err := blob.Reader()
+	testErr(err, t)
+	b, err = ioutil.ReadAll(r)
+	testErr(err, t)
+	testErr(r.Close(), t)
+	if string(b) != "\x01\x02" {
+		t.Errorf("BLOB: expected [1 2], actual %v", b)
+	}
+
+	if !null.IsNull {
+		t.Errorf("expected a null LobD, actual %+v", null)
+	}
+	if b, err = null.Bytes(); err != nil || b != nil {
+		t.Errorf("null: expected no bytes, actual %v, %v", b, err)
+	}
+	testErr(null.Close(), t)
+
+	if unread.Length != 6 {
+		t.Errorf("unread: expected a length of 6, actual %+v", unread)
+	}
+	testErr(unread.Close(), t)
+}
+
+func TestLob_Length_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT TO_CLOB('zß水'), TO_BLOB(HEXTORAW('010203')), EMPTY_BLOB(), CAST(NULL AS BLOB) FROM DUAL",
+		ora.OraS, ora.OraBin, ora.OraBin, ora.OraBin)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	if !rset.Next() {
+		t.Fatalf("expected a row, actual %v", rset.Err)
+	}
+	for n, expected := range []struct {
+		length  uint64
+		ok      bool
+		content string
+	}{
+		{3, true, "zß水"}, // in characters for a CLOB
+		{3, true, "\x01\x02\x03"},
+		{0, true, ""}, // an empty LOB isn't opened
+		{0, false, ""},
+	} {
+		lob := rset.Row[n].(ora.Lob)
+		if length, ok := lob.Length(); length != expected.length || ok != expected.ok {
+			t.Errorf("%d. Length: expected %v, %v, actual %v, %v", n, expected.length, expected.ok, length, ok)
+		}
+		if lob.Reader == nil {
+			continue
+		}
+		b, err := ioutil.ReadAll(lob)
+		testErr(err, t)
+		if string(b) != expected.content {
+			t.Errorf("%d. expected(%q), actual(%q)", n, expected.content, b)
+		}
+		testErr(lob.Close(), t)
+	}
+}
+
+func TestSession_FreeTempLobs(t *testing.T) {
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	drvCfg := ora.CfgCopy()
+	lg := &msgLgr{}
+	drvCfg.Log.Logger = lg
+	ora.SetCfg(*drvCfg)
+
+	sesCfg := *testSesCfg
+	sesCfg.TempLobWarning = 2
+	ses, err := testSrv.OpenSes(&sesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	tableName := tableName()
+	_, err = ses.PrepAndExe(fmt.Sprintf("create table %v (c1 blob null)", tableName))
+	testErr(err, t)
+	defer dropTable(tableName, ses, t)
+
+	// the temporary LOBs of the binds of an open Stmt aren't freed
+	stmt, err := ses.Prep(fmt.Sprintf("insert into %v (c1) values (:1)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	for n := 0; n < 2; n++ {
+		_, err = stmt.Exe(ora.Lob{Reader: bytes.NewReader([]byte{1, 2, 3})})
+		testErr(err, t)
+	}
+	if n := ses.NumTempLob(); n == 0 {
+		t.Fatal("expected temporary LOBs of the Stmt kept open")
+	}
+	if msgs := lg.find("temporary LOBs which haven't been freed"); len(msgs) == 0 {
+		t.Errorf("expected a warning at SesCfg.TempLobWarning, actual %q", lg.msgs)
+	}
+	numTemp := ses.NumTempLob()
+	n, err := ses.FreeTempLobs()
+	testErr(err, t)
+	if n != numTemp || ses.NumTempLob() != 0 {
+		t.Fatalf("expected %d freed and none left, actual %d freed and %d left", numTemp, n, ses.NumTempLob())
+	}
+	// closing the Stmt doesn't free them again
+	testErr(stmt.Close(), t)
+
+	// the temporary LOBs of a closed Stmt are freed
+	_, err = ses.PrepAndExe(fmt.Sprintf("insert into %v (c1) values (:1)", tableName), ora.Lob{Reader: bytes.NewReader([]byte{4})})
+	testErr(err, t)
+	if n := ses.NumTempLob(); n != 0 {
+		t.Errorf("expected the temporary LOBs to be freed with their Stmt, actual %d", n)
+	}
+
+	rset, err := ses.PrepAndQry(fmt.Sprintf("select count(*) from %v where dbms_lob.getlength(c1) > 0", tableName))
+	testErr(err, t)
+	row := rset.NextRow()
+	testErr(rset.Err, t)
+	if fmt.Sprint(row) != "[3]" {
+		t.Fatalf("expected 3 rows, actual %v", row)
+	}
+}
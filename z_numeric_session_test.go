@@ -5,6 +5,9 @@
 package ora_test
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"gopkg.in/rana/ora.v3"
@@ -2442,3 +2445,107 @@ func TestWorkload_floatP126Null_session(t *testing.T) {
 func TestBindDefine_floatP126Null_nil_session(t *testing.T) {
 	testBindDefine(nil, floatP126Null, t, nil)
 }
+
+func TestBindDefine_nativeFloats_session(t *testing.T) {
+	tableName := tableName()
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c1 number(38,0) not null, c2 binary_double not null, c3 binary_float not null)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("insert into %v (c1, c2, c3) values (:1, :2, :3)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	// NaN and infinities are bound natively without NativeFloats
+	_, err = stmt.Exe(int64(1), math.NaN(), float32(math.NaN()))
+	testErr(err, t)
+	_, err = stmt.Exe(int64(2), math.Inf(1), float32(math.Inf(-1)))
+	testErr(err, t)
+	cfg := stmt.Cfg()
+	cfg.NativeFloats = true
+	stmt.SetCfg(cfg)
+	_, err = stmt.Exe(int64(3), 0.1, float32(0.1))
+	testErr(err, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("select c2, c3 from %v order by c1", tableName), ora.F64, ora.F32)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	var rows [][]interface{}
+	for rset.Next() {
+		rows = append(rows, []interface{}{rset.Row[0], rset.Row[1]})
+	}
+	testErr(rset.Err, t)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, actual %v", rows)
+	}
+	if f, g := rows[0][0].(float64), rows[0][1].(float32); !math.IsNaN(f) || !math.IsNaN(float64(g)) {
+		t.Errorf("expected NaN, actual %v, %v", f, g)
+	}
+	if f, g := rows[1][0].(float64), rows[1][1].(float32); !math.IsInf(f, 1) || !math.IsInf(float64(g), -1) {
+		t.Errorf("expected +Inf and -Inf, actual %v, %v", f, g)
+	}
+	if f, g := rows[2][0].(float64), rows[2][1].(float32); f != 0.1 || g != float32(0.1) {
+		t.Errorf("expected 0.1 exactly, actual %v, %v", f, g)
+	}
+}
+
+func TestBindSlice_numericExtremes_session(t *testing.T) {
+	// the values of each numeric slice are converted to OCINumbers at once
+	tableName := tableName()
+	cols := make([]string, 10)
+	for n := range cols {
+		cols[n] = fmt.Sprintf("c%d number null", n+1)
+	}
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c0 number(1,0) not null, %v)", tableName, strings.Join(cols, ", ")))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("insert into %v values (:1, :2, :3, :4, :5, :6, :7, :8, :9, :10, :11)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe(
+		[]int64{0, 1, 2},
+		[]int8{math.MinInt8, 0, math.MaxInt8},
+		[]int16{math.MinInt16, 0, math.MaxInt16},
+		[]int32{math.MinInt32, 0, math.MaxInt32},
+		[]int64{math.MinInt64, 0, math.MaxInt64},
+		[]uint8{0, 1, math.MaxUint8},
+		[]uint16{0, 1, math.MaxUint16},
+		[]uint32{0, 1, math.MaxUint32},
+		[]uint64{0, 1, math.MaxUint64},
+		[]float32{-2.25, 0, 1.5},
+		[]float64{-2.25, 0, 1e10},
+	)
+	testErr(err, t)
+
+	for n, expected := range []string{
+		"[-128 0 127]",
+		"[-32768 0 32767]",
+		"[-2147483648 0 2147483647]",
+		"[-9223372036854775808 0 9223372036854775807]",
+		"[0 1 255]",
+		"[0 1 65535]",
+		"[0 1 4294967295]",
+		"[0 1 18446744073709551615]",
+		"[-2.25 0 1.5]",
+		"[-2.25 0 10000000000]",
+	} {
+		rset, err := testSes.PrepAndQry(fmt.Sprintf("select to_char(c%d) from %v order by c0", n+1, tableName))
+		testErr(err, t)
+		var values []string
+		for rset.Next() {
+			values = append(values, rset.Row[0].(string))
+		}
+		testErr(rset.Err, t)
+		if actual := fmt.Sprint(values); actual != expected {
+			t.Errorf("c%d: expected(%v), actual(%v)", n+1, expected, actual)
+		}
+	}
+}
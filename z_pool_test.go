@@ -0,0 +1,170 @@
+// Copyright 2015 Rana Ian. All rights reserved.
+// Use of this source code is governed by The MIT License
+// found in the accompanying LICENSE file.
+
+package ora_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
+)
+
+func TestPool_Check(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	for _, checkSql := range []string{"", "SELECT 1 FROM DUAL"} {
+		cfg := ora.NewPoolCfg()
+		cfg.CheckSql = checkSql
+		pool, err := env.NewPool(testSrvCfg, testSesCfg, cfg)
+		testErr(err, t)
+
+		// no idle session: Check opens one
+		status, err := pool.Check(context.Background())
+		testErr(err, t)
+		if status.Version == "" {
+			t.Errorf("CheckSql %q: expected a server version", checkSql)
+		}
+		if status.OpenSessions != 1 || status.Idle != 1 || status.InUse != 0 {
+			t.Errorf("CheckSql %q: expected one idle session, actual %+v", checkSql, status)
+		}
+
+		// an idle session is reused
+		status, err = pool.Check(context.Background())
+		testErr(err, t)
+		if status.OpenSessions != 1 {
+			t.Errorf("CheckSql %q: expected the idle session to be reused, actual %+v", checkSql, status)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+		if _, err = pool.Check(ctx); err != context.DeadlineExceeded {
+			t.Errorf("CheckSql %q: expected(%v), actual(%v)", checkSql, context.DeadlineExceeded, err)
+		}
+		cancel()
+
+		// a nil ctx is never done
+		if _, err = pool.Check(nil); err != nil {
+			t.Errorf("CheckSql %q: nil ctx: %v", checkSql, err)
+		}
+		ses, err := pool.GetContext(nil, "")
+		testErr(err, t)
+		testErr(pool.Put(ses), t)
+		testErr(pool.Close(), t)
+	}
+}
+
+func TestPool_Drain(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	pool, err := env.NewPool(testSrvCfg, testSesCfg, ora.NewPoolCfg())
+	testErr(err, t)
+	idle, err := pool.Get("")
+	testErr(err, t)
+	out, err := pool.Get("")
+	testErr(err, t)
+	testErr(pool.Put(idle), t)
+
+	drained := make(chan error, 1)
+	go func() { drained <- pool.Drain(context.Background()) }()
+	// Drain fails later callers of Get and waits for the session checked out
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ses, err := pool.Get("")
+		if err != nil {
+			break
+		}
+		pool.Put(ses)
+		if time.Now().After(deadline) {
+			t.Fatal("Get: expected an error once the Pool drains")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err = <-drained:
+		t.Fatalf("Drain returned (%v) with a session checked out", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if idle.IsOpen() {
+		t.Error("expected the idle session to be closed")
+	}
+	testErr(pool.Put(out), t)
+	testErr(<-drained, t)
+	if out.IsOpen() {
+		t.Error("expected the returned session to be closed")
+	}
+
+	// a session not returned before ctx is done is closed
+	pool, err = env.NewPool(testSrvCfg, testSesCfg, ora.NewPoolCfg())
+	testErr(err, t)
+	out, err = pool.Get("")
+	testErr(err, t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err = pool.Drain(ctx); err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("expected(%v), actual(%v)", context.DeadlineExceeded, err)
+	}
+	if out.IsOpen() {
+		t.Error("expected the session checked out to be closed")
+	}
+}
+
+func TestPool_Env(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	numEnv := ora.NumEnv()
+	cfg := ora.NewPoolCfg()
+	cfg.Env = ora.NewEnvCfg()
+	cfg.Env.Events = true
+	pool, err := env.NewPool(testSrvCfg, testSesCfg, cfg)
+	testErr(err, t)
+	// the Pool opens an Env of its own, closed with the Pool
+	if n := ora.NumEnv(); n != numEnv+1 {
+		t.Fatalf("NumEnv: expected(%v), actual(%v)", numEnv+1, n)
+	}
+	ses, err := pool.Get("")
+	testErr(err, t)
+	rset, err := ses.PrepAndQry("SELECT 1 FROM DUAL")
+	testErr(err, t)
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+	testErr(pool.Put(ses), t)
+	testErr(pool.Close(), t)
+	if n := ora.NumEnv(); n != numEnv {
+		t.Fatalf("NumEnv after Close: expected(%v), actual(%v)", numEnv, n)
+	}
+	if !env.IsOpen() {
+		t.Fatal("expected the Env of NewPool to stay open")
+	}
+}
+
+func TestPool_Put_keepsCachedState(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	pool, err := env.NewPool(testSrvCfg, testSesCfg, ora.NewPoolCfg())
+	testErr(err, t)
+	defer pool.Close()
+	ses, err := pool.Get("")
+	testErr(err, t)
+	testErr(pool.Put(ses), t)
+
+	// Put caches the restored current schema, and restoring the isolation
+	// level doesn't clear it
+	again, err := pool.Get("")
+	testErr(err, t)
+	if again != ses {
+		t.Fatal("expected the idle session to be reused")
+	}
+	if again.CurrentSchema() == "" {
+		t.Error("expected the current schema restored by Put to be cached")
+	}
+	testErr(pool.Put(again), t)
+}
@@ -5,7 +5,11 @@
 package ora_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"gopkg.in/rana/ora.v3"
@@ -66,3 +70,127 @@ func Test_cursor1_session(t *testing.T) {
 		testErr(rset.Err, t)
 	}
 }
+
+func TestRset_Next_concurrent_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 1000")
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+
+	// calls to Next are serialized: each row is fetched once
+	var wg sync.WaitGroup
+	counts := make([]int, 4)
+	for n := range counts {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for rset.Next() {
+				counts[n]++
+			}
+		}(n)
+	}
+	wg.Wait()
+	testErr(rset.Err, t)
+	var total int
+	for _, count := range counts {
+		total += count
+	}
+	if total != 1000 {
+		t.Fatalf("expected(%v), actual(%v)", 1000, total)
+	}
+}
+
+func TestRset_ForEachBatch_session(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	qry := func() *ora.Rset {
+		rset, err := ses.PrepAndQry("SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 25 ORDER BY LEVEL")
+		testErr(err, t)
+		return rset
+	}
+
+	var sizes []int
+	var next float64 = 1
+	err = qry().ForEachBatch(context.Background(), 10, func(batch [][]interface{}) error {
+		sizes = append(sizes, len(batch))
+		for _, row := range batch {
+			if row[0] != next {
+				t.Errorf("expected(%v), actual(%v)", next, row[0])
+			}
+			next++
+		}
+		return nil
+	})
+	testErr(err, t)
+	if fmt.Sprint(sizes) != "[10 10 5]" {
+		t.Errorf("batch sizes: expected [10 10 5], actual %v", sizes)
+	}
+
+	// an error of fn stops the batches
+	stop := errors.New("stop")
+	var calls int
+	rset := qry()
+	err = rset.ForEachBatch(context.Background(), 10, func(batch [][]interface{}) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected(%v) after one batch, actual(%v) after %d", stop, err, calls)
+	}
+	if rset.IsOpen() {
+		t.Error("expected the Rset to be closed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = qry().ForEachBatch(ctx, 10, func([][]interface{}) error { return nil }); err != context.Canceled {
+		t.Errorf("expected(%v), actual(%v)", context.Canceled, err)
+	}
+}
+
+func TestRset_wideSelect_session(t *testing.T) {
+	// the columns of a wide select-list are described in one call
+	const numCols = 300
+	items := make([]string, numCols)
+	for n := range items {
+		switch n % 3 {
+		case 0:
+			items[n] = fmt.Sprintf("%d AS N%d", n, n)
+		case 1:
+			items[n] = fmt.Sprintf("'s%d' AS S%d", n, n)
+		default:
+			items[n] = fmt.Sprintf("TO_NCHAR('ß%d') AS U%d", n, n)
+		}
+	}
+	rset, err := testSes.PrepAndQry("SELECT " + strings.Join(items, ", ") + " FROM DUAL")
+	testErr(err, t)
+	if !rset.Next() {
+		t.Fatalf("expected a row, actual %v", rset.Err)
+	}
+	if len(rset.ColumnNames) != numCols {
+		t.Fatalf("expected %d columns, actual %d", numCols, len(rset.ColumnNames))
+	}
+	for n, name := range rset.ColumnNames {
+		var expectedName string
+		var expected interface{}
+		switch n % 3 {
+		case 0:
+			expectedName, expected = fmt.Sprintf("N%d", n), float64(n)
+		case 1:
+			expectedName, expected = fmt.Sprintf("S%d", n), fmt.Sprintf("s%d", n)
+		default:
+			expectedName, expected = fmt.Sprintf("U%d", n), fmt.Sprintf("ß%d", n)
+		}
+		if name != expectedName || rset.Row[n] != expected {
+			t.Fatalf("%d. expected %v=%v, actual %v=%v", n, expectedName, expected, name, rset.Row[n])
+		}
+	}
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+}
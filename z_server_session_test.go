@@ -5,7 +5,9 @@
 package ora_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"gopkg.in/rana/ora.v3"
 )
@@ -62,3 +64,39 @@ func TestServer_Version(t *testing.T) {
 		t.Fatal("Version is empty.")
 	}
 }
+
+func TestServer_NonBlocking(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	srvCfg := *testSrvCfg
+	srvCfg.NonBlocking = true
+	srv, err := env.OpenSrv(&srvCfg)
+	defer srv.Close()
+	testErr(err, t)
+	ses, err := srv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+
+	stmt, err := ses.Prep("BEGIN LOOP NULL; END LOOP; END;")
+	defer stmt.Close()
+	testErr(err, t)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err = stmt.ExeContext(ctx); err == nil {
+		t.Fatal("expected the endless loop to be broken")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("the break took %v", elapsed)
+	}
+
+	// the Ses is reset and usable after the break
+	rset, err := ses.PrepAndQry("SELECT 1 FROM DUAL")
+	testErr(err, t)
+	row := rset.NextRow()
+	testErr(rset.Err, t)
+	if len(row) != 1 || row[0] != float64(1) {
+		t.Fatalf("expected(%v), actual(%v)", 1, row)
+	}
+}
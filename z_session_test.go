@@ -5,8 +5,12 @@
 package ora_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gopkg.in/rana/ora.v3"
 )
@@ -209,3 +213,683 @@ func TestSession_PrepAndQry(t *testing.T) {
 		t.Fatalf("expected(%v), actual(%v)", 9, row[0])
 	}
 }
+
+func TestSession_ResumableMonitor(t *testing.T) {
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	srv, err := env.OpenSrv(testSrvCfg)
+	defer srv.Close()
+	testErr(err, t)
+	sesCfg := *testSesCfg
+	sesCfg.ResumableTimeout = time.Minute
+	sesCfg.OnResumable = func(event ora.ResumableEvent) {}
+	ses, err := srv.OpenSes(&sesCfg)
+	defer ses.Close()
+	testErr(err, t)
+
+	// the monitor connects while the Ses is calling the server
+	var maxSrv, maxSes int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 25; n++ {
+			time.Sleep(100 * time.Millisecond)
+			if num := env.NumSrv(); num > maxSrv {
+				maxSrv = num
+			}
+			if num := srv.NumSes(); num > maxSes {
+				maxSes = num
+			}
+		}
+	}()
+	_, err = ses.PrepAndExe("BEGIN DBMS_LOCK.SLEEP(3); END;")
+	<-done
+	testErr(err, t)
+	if maxSrv != 2 {
+		t.Fatalf("servers: expected(%v), actual(%v)", 2, maxSrv)
+	}
+	if maxSes != 1 {
+		t.Fatalf("sessions of the server: expected(%v), actual(%v)", 1, maxSes)
+	}
+}
+
+func TestSession_RewriteSql(t *testing.T) {
+	var mu sync.Mutex
+	var rewritten []string
+	numRewritten := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(rewritten)
+	}
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	cfg := ora.CfgCopy()
+	cfg.RewriteSql = func(sql string) string {
+		mu.Lock()
+		rewritten = append(rewritten, sql)
+		mu.Unlock()
+		return strings.Replace(sql, "LIMIT_ONE", "FETCH FIRST 1 ROWS ONLY", 1)
+	}
+	ora.SetCfg(*cfg)
+
+	env, err := ora.OpenEnv(nil)
+	defer env.Close()
+	testErr(err, t)
+	srv, err := env.OpenSrv(testSrvCfg)
+	defer srv.Close()
+	testErr(err, t)
+	ses, err := srv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+
+	// statements of the user are rewritten
+	rset, err := ses.PrepAndQry("SELECT 1 FROM DUAL LIMIT_ONE")
+	testErr(err, t)
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+	if n := numRewritten(); n != 1 {
+		t.Fatalf("rewritten statements: expected(%v), actual(%v)", 1, n)
+	}
+
+	// statements of the driver aren't
+	_, err = ses.SaveState()
+	testErr(err, t)
+	if n := numRewritten(); n != 1 {
+		t.Fatalf("rewritten statements after SaveState: expected(%v), actual(%v)", 1, n)
+	}
+
+	// statements prepared by database/sql are
+	var two int64
+	err = testDb.QueryRow("SELECT 2 FROM DUAL LIMIT_ONE").Scan(&two)
+	testErr(err, t)
+	mu.Lock()
+	defer mu.Unlock()
+	if last := rewritten[len(rewritten)-1]; last != "SELECT 2 FROM DUAL LIMIT_ONE" {
+		t.Fatalf("last rewritten statement: expected(%q), actual(%q)", "SELECT 2 FROM DUAL LIMIT_ONE", last)
+	}
+}
+
+func TestSession_PingContext(t *testing.T) {
+	testErr(testSes.PingContext(context.Background()), t)
+	testErr(testDb.PingContext(context.Background()), t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := testSes.PingContext(ctx); err != context.Canceled {
+		t.Fatalf("canceled: expected(%v), actual(%v)", context.Canceled, err)
+	}
+
+	// a ping timing out is broken, and the Ses stays usable
+	cfg := testSes.Cfg()
+	prev := *cfg
+	defer testSes.SetCfg(prev)
+	cfg.PingTimeout = time.Nanosecond
+	testSes.SetCfg(*cfg)
+	if err := testSes.PingContext(context.Background()); err != context.DeadlineExceeded {
+		t.Fatalf("PingTimeout: expected(%v), actual(%v)", context.DeadlineExceeded, err)
+	}
+	testSes.SetCfg(prev)
+	testErr(testSes.PingContext(context.Background()), t)
+}
+
+func TestSession_CallTimeout(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	testErr(err, t)
+	defer ses.Close()
+	cfg := ses.CfgCopy()
+	cfg.CallTimeout = 100 * time.Millisecond
+	ses.SetCfg(*cfg)
+
+	// an execution timing out is broken, and the Ses stays usable
+	stmt, err := ses.Prep("BEGIN FOR n IN 1..1000000000 LOOP NULL; END LOOP; END;")
+	testErr(err, t)
+	defer stmt.Close()
+	if _, err = stmt.Exe(); err != context.DeadlineExceeded {
+		t.Fatalf("CallTimeout: expected(%v), actual(%v)", context.DeadlineExceeded, err)
+	}
+	rset, err := ses.PrepAndQry("SELECT 1 FROM DUAL")
+	testErr(err, t)
+	row := rset.NextRow()
+	testErr(rset.Err, t)
+	if row[0] != float64(1) {
+		t.Fatalf("after CallTimeout: expected(%v), actual(%v)", 1, row[0])
+	}
+}
+
+func TestSession_IsResultCached(t *testing.T) {
+	// the client result cache requires CLIENT_RESULT_CACHE_SIZE on the server
+	sesCfg := *testSesCfg
+	sesCfg.StmtCacheSize = 10
+	ses, err := testSrv.OpenSes(&sesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	var cached bool
+	for n := 0; n < 3; n++ {
+		stmt, err := ses.Prep("SELECT /*+ RESULT_CACHE */ 1 FROM DUAL")
+		testErr(err, t)
+		rset, err := stmt.Qry()
+		testErr(err, t)
+		for rset.Next() {
+		}
+		testErr(rset.Err, t)
+		if cached, err = stmt.IsResultCached(); err != nil {
+			stmt.Close()
+			t.Skipf("IsResultCached: %v", err)
+		}
+		testErr(stmt.Close(), t)
+	}
+	if !cached {
+		t.Log("the query wasn't answered from the client result cache; is CLIENT_RESULT_CACHE_SIZE set?")
+	}
+}
+
+func TestSession_Leaks(t *testing.T) {
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	drvCfg := ora.CfgCopy()
+	drvCfg.Leak.Enabled = true
+	ora.SetCfg(*drvCfg)
+
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	if leaks := ses.Leaks(); len(leaks) != 0 {
+		t.Fatalf("expected no leaks, actual %v", leaks)
+	}
+	tx, err := ses.StartTx()
+	testErr(err, t)
+	stmt, err := ses.Prep("SELECT 1 FROM DUAL")
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	leaks := ses.Leaks()
+	if len(leaks) != 3 {
+		t.Fatalf("expected 3 leaks, actual %v", leaks)
+	}
+	for n, kind := range []string{"Tx", "Stmt", "Rset"} {
+		if leaks[n].Kind != kind || leaks[n].Name == "" {
+			t.Errorf("%d. expected a named %v, actual %v %q", n, kind, leaks[n].Kind, leaks[n].Name)
+		}
+		if !strings.Contains(leaks[n].Stack, "TestSession_Leaks") {
+			t.Errorf("%d. expected the stack of the test, actual\n%v", n, leaks[n].Stack)
+		}
+	}
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+	testErr(stmt.Close(), t)
+	testErr(tx.Rollback(), t)
+	if leaks := ses.Leaks(); len(leaks) != 0 {
+		t.Fatalf("expected no leaks after closing, actual %v", leaks)
+	}
+}
+
+func TestSession_MaxOpenCursors(t *testing.T) {
+	sesCfg := *testSesCfg
+	sesCfg.MaxOpenCursors = 2
+	ses, err := testSrv.OpenSes(&sesCfg)
+	defer ses.Close()
+	testErr(err, t)
+
+	// a Stmt with an open Rset isn't evicted
+	open, err := ses.Prep("SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 3")
+	defer open.Close()
+	testErr(err, t)
+	openRset, err := open.Qry()
+	testErr(err, t)
+	if !openRset.Next() {
+		t.Fatalf("expected a row, actual %v", openRset.Err)
+	}
+
+	stmts := make([]*ora.Stmt, 5)
+	for n := range stmts {
+		stmts[n], err = ses.Prep(fmt.Sprintf("SELECT %d FROM DUAL", n))
+		defer stmts[n].Close()
+		testErr(err, t)
+	}
+	if num := ses.NumStmt(); num != len(stmts)+1 {
+		t.Fatalf("evicted Stmts remain open: expected(%v), actual(%v)", len(stmts)+1, num)
+	}
+	// evicted Stmts are re-prepared on their next use
+	for round := 0; round < 2; round++ {
+		for n, stmt := range stmts {
+			rset, err := stmt.Qry()
+			testErr(err, t)
+			var values []interface{}
+			for rset.Next() {
+				values = append(values, rset.Row[0])
+			}
+			testErr(rset.Err, t)
+			if len(values) != 1 || values[0] != float64(n) {
+				t.Errorf("%d. round %d: expected(%v), actual(%v)", n, round, n, values)
+			}
+		}
+	}
+	var levels int
+	for levels = 1; openRset.Next(); levels++ {
+	}
+	testErr(openRset.Err, t)
+	if levels != 3 {
+		t.Errorf("rows of the Stmt with an open Rset: expected(%v), actual(%v)", 3, levels)
+	}
+}
+
+func TestSession_MaxOpenCursors_closeReprepare(t *testing.T) {
+	sesCfg := *testSesCfg
+	sesCfg.MaxOpenCursors = 1
+	for round := 0; round < 20; round++ {
+		ses, err := testSrv.OpenSes(&sesCfg)
+		testErr(err, t)
+		evicted, err := ses.Prep("SELECT 1 FROM DUAL")
+		testErr(err, t)
+		_, err = ses.Prep("SELECT 2 FROM DUAL") // evicts the first Stmt
+		testErr(err, t)
+
+		// Close races the re-prepare of the evicted Stmt: neither waits for
+		// the other forever
+		done := make(chan struct{}, 2)
+		go func() {
+			evicted.Qry() // fails once the Ses is closed
+			done <- struct{}{}
+		}()
+		go func() {
+			ses.Close()
+			done <- struct{}{}
+		}()
+		for n := 0; n < 2; n++ {
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("round %d: Ses.Close and the re-prepare of an evicted Stmt deadlocked", round)
+			}
+		}
+	}
+}
+
+func TestSession_Break(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	// Break is a no-op when no call is in flight
+	testErr(ses.Break(), t)
+
+	stmt, err := ses.Prep("BEGIN LOOP NULL; END LOOP; END;")
+	defer stmt.Close()
+	testErr(err, t)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(100 * time.Millisecond):
+				if err := ses.Break(); err != nil {
+					t.Errorf("Break: %v", err)
+				}
+			}
+		}
+	}()
+	_, err = stmt.Exe()
+	close(done)
+	<-stopped
+	if err == nil {
+		t.Fatal("expected the endless loop to be broken")
+	}
+
+	testErr(ses.Reset(), t)
+	rset, err := ses.PrepAndQry("SELECT 1 FROM DUAL")
+	testErr(err, t)
+	row := rset.NextRow()
+	testErr(rset.Err, t)
+	if len(row) != 1 || row[0] != float64(1) {
+		t.Fatalf("expected(%v), actual(%v)", 1, row)
+	}
+
+	// a Break racing the end of a call never interrupts the next call
+	query, err := ses.Prep("SELECT COUNT(*) FROM DUAL")
+	defer query.Close()
+	testErr(err, t)
+	for n := 0; n < 100; n++ {
+		broken := make(chan struct{})
+		go func() {
+			defer close(broken)
+			time.Sleep(time.Duration(n%10) * 100 * time.Microsecond)
+			ses.Break()
+		}()
+		if rset, err := query.Qry(); err == nil {
+			rset.NextRow()
+		}
+		<-broken
+		rset, err := query.Qry()
+		if err != nil {
+			t.Fatalf("%d. the call after a Break: %v", n, err)
+		}
+		rset.NextRow()
+		if rset.Err != nil {
+			t.Fatalf("%d. the fetch after a Break: %v", n, rset.Err)
+		}
+	}
+}
+
+func TestSession_LoadCSV(t *testing.T) {
+	tableName := tableName()
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c1 number(38,0) not null, c2 varchar2(4 char) null)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	var progress []uint64
+	cfg := ora.LoadCfg{
+		Table:     tableName,
+		Header:    true,
+		BatchSize: 2,
+		MaxErrors: 1,
+		Progress:  func(rows uint64) { progress = append(progress, rows) },
+	}
+	// the value of row 3 is too long for c2
+	result, err := testSes.LoadCSV(strings.NewReader("c1,c2\n1,a\n2,\n3,toolong\n4,d\n5,e\n"), cfg)
+	testErr(err, t)
+	if result.Rows != 4 || len(result.Errors) != 1 || result.Errors[0].Row != 3 {
+		t.Fatalf("expected 4 rows and row 3 rejected, actual %+v", result)
+	}
+	if fmt.Sprint(progress) != "[2 3 4]" {
+		t.Errorf("progress: expected(%v), actual(%v)", "[2 3 4]", progress)
+	}
+
+	// a second rejected row exceeds MaxErrors
+	cfg.Progress = nil
+	if _, err = testSes.LoadCSV(strings.NewReader("c1,c2\n6,toolong\n7,toolong\n"), cfg); err == nil {
+		t.Fatal("expected an error for more than MaxErrors rejected rows")
+	}
+
+	rows := make(chan []string, 3)
+	rows <- []string{"8", "h"}
+	rows <- []string{"9", "i"}
+	rows <- []string{"10", ""}
+	close(rows)
+	result, err = testSes.LoadRows(rows, ora.LoadCfg{Table: tableName, Cols: []string{"c1", "c2"}})
+	testErr(err, t)
+	if result.Rows != 3 || len(result.Errors) != 0 {
+		t.Fatalf("LoadRows: expected 3 rows, actual %+v", result)
+	}
+
+	rset, err := testSes.PrepAndQry(fmt.Sprintf("select count(*), count(c2) from %v where c1 not in (6, 7)", tableName))
+	testErr(err, t)
+	row := rset.NextRow()
+	testErr(rset.Err, t)
+	if fmt.Sprint(row) != "[7 5]" {
+		t.Fatalf("expected 7 rows with 5 values of c2, actual %v", row)
+	}
+}
+
+func TestSession_TxHooks(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	var calls []string
+	hook := func(name string) ora.TxHook {
+		return func([]byte) { calls = append(calls, name) }
+	}
+	ses.OnCommit(hook("ses commit"))
+	ses.OnRollback(hook("ses rollback"))
+
+	tx, err := ses.StartTx()
+	testErr(err, t)
+	tx.OnCommit(hook("tx commit"))
+	tx.OnRollback(hook("tx rollback"))
+	testErr(tx.Commit(), t)
+	// the hooks of the Tx are called before those of the Ses
+	if fmt.Sprint(calls) != "[tx commit ses commit]" {
+		t.Errorf("Commit: expected [tx commit ses commit], actual %v", calls)
+	}
+
+	calls = nil
+	tx, err = ses.StartTx()
+	testErr(err, t)
+	testErr(tx.Rollback(), t)
+	// the hooks of a Tx end with it
+	if fmt.Sprint(calls) != "[ses rollback]" {
+		t.Errorf("Rollback: expected [ses rollback], actual %v", calls)
+	}
+}
+
+func TestSession_concurrentErrors(t *testing.T) {
+	// each Ses reads its errors from an error handle of its own
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			ses, err := testSrv.OpenSes(testSesCfg)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer ses.Close()
+			code := fmt.Sprintf("ORA-%d", 20100+n)
+			for i := 0; i < 20; i++ {
+				_, err = ses.PrepAndExe(fmt.Sprintf("BEGIN RAISE_APPLICATION_ERROR(-%d, 'session %d'); END;", 20100+n, n))
+				if err == nil || !strings.Contains(err.Error(), code) || !strings.Contains(err.Error(), fmt.Sprintf("session %d", n)) {
+					t.Errorf("session %d: expected %v, actual %v", n, code, err)
+					return
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+}
+
+func TestSession_WithSnapshot(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	defer ses.Close()
+	testErr(err, t)
+	other, err := testSrv.OpenSes(testSesCfg)
+	defer other.Close()
+	testErr(err, t)
+	tableName := tableName()
+	_, err = ses.PrepAndExe(fmt.Sprintf("create table %v (c1 number(10))", tableName))
+	testErr(err, t)
+	defer dropTable(tableName, ses, t)
+	insert := fmt.Sprintf("insert into %v (c1) values (:1)", tableName)
+	_, err = other.PrepAndExe(insert, 1)
+	testErr(err, t)
+	count := func(ses *ora.Ses) int64 {
+		rset, err := ses.PrepAndQry(fmt.Sprintf("select count(*) from %v", tableName))
+		testErr(err, t)
+		row := rset.NextRow()
+		testErr(rset.Err, t)
+		return int64(row[0].(float64))
+	}
+
+	// the queries of fn don't see the changes committed after fn is called
+	err = ses.WithSnapshot(func(ses *ora.Ses) error {
+		if n := count(ses); n != 1 {
+			t.Errorf("expected(1), actual(%v)", n)
+		}
+		_, err := other.PrepAndExe(insert, 2)
+		testErr(err, t)
+		if n := count(ses); n != 1 {
+			t.Errorf("expected(1) after a commit of another session, actual(%v)", n)
+		}
+		return nil
+	})
+	testErr(err, t)
+	if n := count(ses); n != 2 {
+		t.Errorf("expected(2) after WithSnapshot, actual(%v)", n)
+	}
+
+	// the error of fn is returned, and the transaction ended
+	errFn := fmt.Errorf("fn failed")
+	if err = ses.WithSnapshot(func(*ora.Ses) error { return errFn }); err != errFn {
+		t.Errorf("expected(%v), actual(%v)", errFn, err)
+	}
+	if _, err = ses.PrepAndExe(insert, 3); err != nil {
+		t.Fatalf("expected the read-only transaction to be ended: %v", err)
+	}
+
+	// a transaction with changes fails WithSnapshot
+	tx, err := ses.StartTx()
+	testErr(err, t)
+	_, err = ses.PrepAndExe(insert, 4)
+	testErr(err, t)
+	if err = ses.WithSnapshot(func(*ora.Ses) error { return nil }); err == nil {
+		t.Error("expected an error with a transaction with changes")
+	}
+	testErr(tx.Rollback(), t)
+
+	if err = ses.WithSnapshotAt(0, func(*ora.Ses) error { return nil }); err == nil {
+		t.Error("expected an error for SCN 0")
+	}
+	scn, err := ses.CurrentScn()
+	if err != nil {
+		t.Skipf("CurrentScn: %v", err)
+	}
+	_, err = other.PrepAndExe(insert, 5)
+	testErr(err, t)
+	err = ses.WithSnapshotAt(scn, func(ses *ora.Ses) error {
+		// the queries of fn see the data committed when scn was current
+		if n := count(ses); n != 3 {
+			t.Errorf("expected(3) at SCN %v, actual(%v)", scn, n)
+		}
+		return nil
+	})
+	if err != nil && (strings.Contains(err.Error(), "PLS-00201") || strings.Contains(err.Error(), "ORA-01031")) {
+		t.Skipf("WithSnapshotAt: %v", err)
+	}
+	testErr(err, t)
+	if n := count(ses); n != 4 {
+		t.Errorf("expected(4) after WithSnapshotAt, actual(%v)", n)
+	}
+}
+
+func TestSession_InList(t *testing.T) {
+	ids := make([]int, 1500)
+	for n := range ids {
+		ids[n] = 2 * (n + 1)
+	}
+	for i, tc := range []struct {
+		list ora.InList
+		want int64
+	}{
+		{ora.In(ids[:3]), 3},
+		{ora.InList{Values: ids[:3], Collection: true}, 3},
+		{ora.In(ids), 1000}, // over 1000 elements: a collection
+		{ora.In([]int{}), 0},
+	} {
+		rset, err := testSes.PrepAndQry("SELECT COUNT(*) FROM (SELECT LEVEL L FROM DUAL CONNECT BY LEVEL <= 2000) WHERE L IN (:ids)", tc.list)
+		testErr(err, t)
+		row := rset.NextRow()
+		testErr(rset.Err, t)
+		if row[0] != float64(tc.want) {
+			t.Errorf("%d. Collection %t: expected(%v), actual(%v)", i, tc.list.Collection, tc.want, row[0])
+		}
+	}
+
+	// the sql of a collection doesn't depend on its length
+	names := []string{"DUAL", "X", "DUMMY"}
+	for n, want := range []float64{0, 1, 1} {
+		n++
+		rset, err := testSes.PrepAndQry("SELECT COUNT(*) FROM DUAL WHERE DUMMY IN (:names)", ora.InList{Values: names[:n], Collection: true})
+		testErr(err, t)
+		row := rset.NextRow()
+		testErr(rset.Err, t)
+		if row[0] != want {
+			t.Errorf("%v: expected(%v), actual(%v)", names[:n], want, row[0])
+		}
+	}
+}
+
+func TestSession_SetCurrentSchema_cached(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	testErr(err, t)
+	defer ses.Close()
+	testErr(ses.SetCurrentSchema(""), t)
+	schema := ses.CurrentSchema()
+	if schema == "" {
+		t.Fatal("expected a cached current schema")
+	}
+
+	// ALTER SESSION statements of the driver keep the cache
+	testErr(ses.SetIsolationLevel("SERIALIZABLE"), t)
+	testErr(ses.WithParams(map[string]string{"NLS_DATE_FORMAT": "YYYY-MM-DD"}, func() error { return nil }), t)
+	if actual := ses.CurrentSchema(); actual != schema {
+		t.Errorf("after the driver's ALTER SESSION: expected(%v), actual(%v)", schema, actual)
+	}
+
+	// an ALTER SESSION statement of the caller clears it
+	_, err = ses.PrepAndExe("ALTER SESSION SET ISOLATION_LEVEL = READ COMMITTED")
+	testErr(err, t)
+	if actual := ses.CurrentSchema(); actual != "" {
+		t.Errorf("after ALTER SESSION: expected no current schema, actual(%v)", actual)
+	}
+}
+
+func TestSession_RestoreState_unchanged(t *testing.T) {
+	ses, err := testSrv.OpenSes(testSesCfg)
+	testErr(err, t)
+	defer ses.Close()
+	roundTrips := func() float64 {
+		rset, err := ses.PrepAndQry(`SELECT S.VALUE FROM V$MYSTAT S JOIN V$STATNAME N ON N.STATISTIC# = S.STATISTIC#
+WHERE N.NAME = 'SQL*Net roundtrips to/from client'`)
+		testErr(err, t)
+		row := rset.NextRow()
+		testErr(rset.Err, t)
+		return row[0].(float64)
+	}
+	state, err := ses.SaveState()
+	testErr(err, t)
+	testErr(ses.RestoreState(state), t) // prepares the statements of RestoreState
+
+	start := roundTrips()
+	testErr(ses.RestoreState(state), t)
+	unchanged := roundTrips() - start
+
+	_, err = ses.PrepAndExe("ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY'")
+	testErr(err, t)
+	testErr(ses.SetIsolationLevel("SERIALIZABLE"), t)
+	start = roundTrips()
+	testErr(ses.RestoreState(state), t)
+	changed := roundTrips() - start
+	if unchanged >= changed {
+		t.Errorf("round trips restoring an unchanged state: expected less than(%v), actual(%v)", changed, unchanged)
+	}
+
+	restored, err := ses.SaveState()
+	testErr(err, t)
+	if restored.Nls["NLS_DATE_FORMAT"] != state.Nls["NLS_DATE_FORMAT"] {
+		t.Errorf("restored NLS_DATE_FORMAT: expected(%v), actual(%v)", state.Nls["NLS_DATE_FORMAT"], restored.Nls["NLS_DATE_FORMAT"])
+	}
+	if restored.IsolationLevel != "READ COMMITTED" {
+		t.Errorf("restored isolation level: expected(%v), actual(%v)", "READ COMMITTED", restored.IsolationLevel)
+	}
+	if ses.CurrentSchema() != state.CurrentSchema {
+		t.Errorf("cached current schema: expected(%v), actual(%v)", state.CurrentSchema, ses.CurrentSchema())
+	}
+}
+
+func TestSession_QryStream(t *testing.T) {
+	sql := "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 10"
+
+	// a consumer receiving every row needn't close the stream
+	s := testSes.QryStream(context.Background(), sql)
+	n := 0
+	for range s.C {
+		n++
+	}
+	testErr(s.Err(), t)
+	if n != 10 {
+		t.Errorf("rows: expected(%v), actual(%v)", 10, n)
+	}
+
+	// a consumer stopping early closes it
+	s = testSes.QryStream(context.Background(), sql)
+	<-s.C
+	testErr(s.Close(), t)
+	testErr(s.Err(), t)
+	testErr(testSes.Ping(), t)
+}
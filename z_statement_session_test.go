@@ -6,7 +6,12 @@ package ora_test
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
 )
 
 func TestStmt_Exe_table_create_alter_drop(t *testing.T) {
@@ -142,3 +147,288 @@ func TestStmt_Exe_select(t *testing.T) {
 		t.Fatalf("rows affected: expected(%v), actual(%v)", 2, rset.Len())
 	}
 }
+
+func TestStmt_ReExe(t *testing.T) {
+	tableName := tableName()
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c1 number(38,0) not null, c2 varchar2(48 char) not null)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("insert into %v (c1, c2) values (:1, :2)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe(int64(1), "abc")
+	testErr(err, t)
+	for _, params := range [][]interface{}{
+		{int64(2), "def"},    // updated in place
+		{int64(3), "longer"}, // a string of another length is re-bound
+		{int32(4), "ghi"},    // a value of another type is re-bound
+		{int32(5), "jkl"},
+	} {
+		rowsAffected, err := stmt.ReExe(params...)
+		testErr(err, t)
+		if rowsAffected != 1 {
+			t.Fatalf("%v: rows affected: expected(%v), actual(%v)", params, 1, rowsAffected)
+		}
+	}
+
+	stmt, err = testSes.Prep(fmt.Sprintf("select c1, c2 from %v order by c1", tableName), ora.I64, ora.S)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	var actual []string
+	for rset.Next() {
+		actual = append(actual, fmt.Sprintf("%v %v", rset.Row[0], rset.Row[1]))
+	}
+	testErr(rset.Err, t)
+	expected := []string{"1 abc", "2 def", "3 longer", "4 ghi", "5 jkl"}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Fatalf("expected(%v), actual(%v)", expected, actual)
+	}
+}
+
+func TestStmt_Exe_sliceArena(t *testing.T) {
+	tableName := tableName()
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c1 number(38,0) not null, c2 varchar2(48 char) null)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	// the scratch buffers of the slice binds are reused by each execution
+	stmt, err = testSes.Prep(fmt.Sprintf("insert into %v (c1, c2) values (:1, :2)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	for _, rows := range []int{3, 3, 5, 2} {
+		ids := make([]int64, rows)
+		names := make([]ora.String, rows)
+		for n := range ids {
+			ids[n] = int64(rows*10 + n)
+			names[n] = ora.String{Value: fmt.Sprint(ids[n]), IsNull: n == 1}
+		}
+		rowsAffected, err := stmt.Exe(ids, names)
+		testErr(err, t)
+		if rowsAffected != uint64(rows) {
+			t.Fatalf("rows affected: expected(%v), actual(%v)", rows, rowsAffected)
+		}
+	}
+
+	stmt, err = testSes.Prep(fmt.Sprintf("select c1, c2 from %v order by c1", tableName), ora.I64, ora.OraS)
+	defer stmt.Close()
+	testErr(err, t)
+	rset, err := stmt.Qry()
+	testErr(err, t)
+	var rows int
+	for rset.Next() {
+		rows++
+		id, name := rset.Row[0].(int64), rset.Row[1].(ora.String)
+		if isNull := id%10 == 1; name.IsNull != isNull || !isNull && name.Value != fmt.Sprint(id) {
+			t.Errorf("%v: expected(%v), actual(%v)", id, id, name)
+		}
+	}
+	testErr(rset.Err, t)
+	if rows != 3+3+5+2 {
+		t.Fatalf("rows: expected(%v), actual(%v)", 3+3+5+2, rows)
+	}
+}
+
+func TestStmt_Describe(t *testing.T) {
+	tableName := tableName()
+	stmt, err := testSes.Prep(fmt.Sprintf("create table %v (c1 number(10,2) not null, c2 varchar2(48 byte) null)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe()
+	testErr(err, t)
+	defer dropTable(tableName, testSes, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("select c1, c2 as name from %v where c1 = :id or c2 = :name", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	desc, err := stmt.Describe()
+	testErr(err, t)
+	expected := []ora.ColumnInfo{
+		{Name: "C1", Type: "NUMBER", Size: 22, Precision: 10, Scale: 2},
+		{Name: "NAME", Type: "VARCHAR2", Size: 48, Nullable: true},
+	}
+	if fmt.Sprint(desc.Columns) != fmt.Sprint(expected) {
+		t.Errorf("Columns: expected(%v), actual(%v)", expected, desc.Columns)
+	}
+	if fmt.Sprint(desc.BindNames) != "[ID NAME]" || desc.NumBinds != 2 {
+		t.Errorf("binds: expected [ID NAME], actual %v of %v", desc.BindNames, desc.NumBinds)
+	}
+	// the described Stmt may be executed
+	rset, err := stmt.Qry(1, "a")
+	testErr(err, t)
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+
+	stmt, err = testSes.Prep(fmt.Sprintf("insert into %v (c1) values (:1)", tableName))
+	defer stmt.Close()
+	testErr(err, t)
+	desc, err = stmt.Describe()
+	testErr(err, t)
+	if len(desc.Columns) != 0 || desc.NumBinds != 1 {
+		t.Errorf("insert: expected no columns and 1 bind, actual %+v", desc)
+	}
+}
+
+func TestStmt_BindNames(t *testing.T) {
+	for _, tc := range []struct {
+		sql      string
+		expected []ora.BindName
+	}{
+		{"SELECT :x, :Y FROM DUAL", []ora.BindName{{Name: "X"}, {Name: "Y"}}},
+		{"SELECT 1 FROM DUAL", nil},
+		{"BEGIN :a := :b || :a; END;", []ora.BindName{{Name: "A"}, {Name: "B"}, {Name: "A", Duplicate: true}}},
+	} {
+		stmt, err := testSes.Prep(tc.sql)
+		testErr(err, t)
+		names, err := stmt.BindNames()
+		testErr(err, t)
+		if fmt.Sprint(names) != fmt.Sprint(tc.expected) {
+			t.Errorf("%q: expected(%v), actual(%v)", tc.sql, tc.expected, names)
+		}
+		testErr(stmt.Close(), t)
+	}
+}
+
+func TestStmt_Plan(t *testing.T) {
+	stmt, err := testSes.Prep("SELECT * FROM DUAL WHERE DUMMY = :1")
+	defer stmt.Close()
+	testErr(err, t)
+	plan, err := stmt.Plan()
+	testErr(err, t)
+	if len(plan.Rows) == 0 || plan.Rows[0].Id != 0 || plan.Rows[0].ParentId != -1 || plan.Rows[0].Operation != "SELECT STATEMENT" {
+		t.Fatalf("expected a SELECT STATEMENT operation first, actual %+v", plan.Rows)
+	}
+	var accessesDual bool
+	for _, row := range plan.Rows {
+		accessesDual = accessesDual || row.ObjectName == "DUAL"
+	}
+	if !accessesDual || !strings.Contains(plan.Text, "DUAL") {
+		t.Errorf("expected an access of DUAL, actual %+v\n%v", plan.Rows, plan.Text)
+	}
+
+	// the plan of the execution requires SELECT privileges on V$SQL_PLAN
+	rset, err := stmt.Qry("X")
+	testErr(err, t)
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+	if plan, err = stmt.CursorPlan(); err != nil {
+		t.Skipf("CursorPlan: %v", err)
+	}
+	if len(plan.Rows) == 0 || plan.Rows[0].Operation != "SELECT STATEMENT" || plan.Text == "" {
+		t.Errorf("CursorPlan: expected a SELECT STATEMENT operation first, actual %+v\n%v", plan.Rows, plan.Text)
+	}
+}
+
+// msgLgr is a Logger recording its messages.
+type msgLgr struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *msgLgr) Infof(format string, args ...interface{})  { l.add(fmt.Sprintf(format, args...)) }
+func (l *msgLgr) Infoln(args ...interface{})                { l.add(fmt.Sprint(args...)) }
+func (l *msgLgr) Errorf(format string, args ...interface{}) { l.add(fmt.Sprintf(format, args...)) }
+func (l *msgLgr) Errorln(args ...interface{})               { l.add(fmt.Sprint(args...)) }
+
+func (l *msgLgr) add(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+// find returns the messages containing substr.
+func (l *msgLgr) find(substr string) (found []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, msg := range l.msgs {
+		if strings.Contains(msg, substr) {
+			found = append(found, msg)
+		}
+	}
+	return found
+}
+
+func TestStmt_SlowThreshold(t *testing.T) {
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	drvCfg := ora.CfgCopy()
+	lg := &msgLgr{}
+	drvCfg.Log.Logger = lg
+	ora.SetCfg(*drvCfg)
+
+	stmt, err := testSes.Prep("SELECT :1 FROM DUAL")
+	defer stmt.Close()
+	testErr(err, t)
+	cfg := stmt.Cfg()
+	cfg.SlowThreshold = time.Nanosecond // every execution is slow
+	cfg.SlowBindCapture = true
+	stmt.SetCfg(cfg)
+	rset, err := stmt.Qry("x")
+	testErr(err, t)
+	for rset.Next() {
+	}
+	testErr(rset.Err, t)
+	msgs := lg.find("slow execution")
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "SELECT :1 FROM DUAL") {
+		t.Fatalf("expected one slow execution of the query, actual %q", lg.msgs)
+	}
+	// the binds are captured with privileges on V$SESSION and V$SQL_BIND_CAPTURE
+	if !strings.Contains(msgs[0], "sql_id=") && !strings.Contains(msgs[0], "no bind capture") {
+		t.Errorf("expected a SQL_ID or the reason of its absence, actual %q", msgs[0])
+	}
+
+	// a failed execution is logged with its error
+	stmt, err = testSes.Prep("BEGIN RAISE_APPLICATION_ERROR(-20001, 'slow failure'); END;")
+	defer stmt.Close()
+	testErr(err, t)
+	stmt.SetCfg(cfg)
+	if _, err = stmt.Exe(); err == nil {
+		t.Fatal("expected ORA-20001")
+	}
+	if msgs := lg.find("failed: ORA-20001"); len(msgs) != 1 {
+		t.Errorf("expected a slow failed execution, actual %q", lg.msgs)
+	}
+}
+
+func TestStmt_Bind_redactedLog(t *testing.T) {
+	prev := ora.CfgCopy()
+	defer ora.SetCfg(*prev)
+	drvCfg := ora.CfgCopy()
+	lg := &msgLgr{}
+	drvCfg.Log.Logger = lg
+	drvCfg.Log.Stmt.Bind = true
+	drvCfg.Log.Redact.BindNames = []string{"secret"}
+	ora.SetCfg(*drvCfg)
+
+	var out32 int32 = 4242
+	var out64 int64 = 4343
+	stmt, err := testSes.Prep("BEGIN :secret := :secret + 1; :plain := 7; END;")
+	defer stmt.Close()
+	testErr(err, t)
+	_, err = stmt.Exe(&out32, &out64)
+	testErr(err, t)
+	if out32 != 4243 || out64 != 7 {
+		t.Fatalf("expected(4243, 7), actual(%v, %v)", out32, out64)
+	}
+	if msgs := lg.find("Int32Ptr."); len(msgs) == 0 {
+		t.Fatal("expected the bind of the *int32 to be logged")
+	}
+	for _, msg := range lg.msgs {
+		if strings.Contains(msg, "4242") || strings.Contains(msg, "4243") {
+			t.Errorf("the masked bind value is logged: %q", msg)
+		}
+	}
+	if msgs := lg.find("Int64Ptr.bind(2) value=4343"); len(msgs) == 0 {
+		t.Errorf("expected the unmasked bind value to be logged, actual %q", lg.msgs)
+	}
+}
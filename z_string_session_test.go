@@ -6,6 +6,8 @@ package ora_test
 
 import (
 	"testing"
+
+	"gopkg.in/rana/ora.v3"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -737,3 +739,42 @@ func TestWorkload_nclobNull_session(t *testing.T) {
 func TestBindDefine_nclobNull_nil_session(t *testing.T) {
 	testBindDefine(nil, nclobNull, t, nil)
 }
+
+func TestDefine_SBorrow_session(t *testing.T) {
+	sql := "SELECT 'abc', CAST(NULL AS VARCHAR2(10)), CAST('d' AS CHAR(1)) FROM DUAL"
+	// S keeps returning strings
+	rset, err := testSes.PrepAndQry(sql)
+	testErr(err, t)
+	for rset.Next() {
+		if s, ok := rset.Row[0].(string); !ok || s != "abc" {
+			t.Fatalf("S: expected(%q), actual(%#v)", "abc", rset.Row[0])
+		}
+	}
+	testErr(rset.Err, t)
+
+	stmt, err := testSes.Prep(sql, ora.SBorrow, ora.SBorrow, ora.SBorrow)
+	testErr(err, t)
+	defer stmt.Close()
+	rset, err = stmt.Qry()
+	testErr(err, t)
+	for rset.Next() {
+		if b, ok := rset.Row[0].([]byte); !ok || string(b) != "abc" {
+			t.Fatalf("SBorrow: expected(%q), actual(%#v)", "abc", rset.Row[0])
+		}
+		if b, ok := rset.Row[1].([]byte); !ok || b != nil {
+			t.Fatalf("SBorrow NULL: expected nil, actual(%#v)", rset.Row[1])
+		}
+		if b, ok := rset.Row[2].([]byte); !ok || string(b) != "d" {
+			t.Fatalf("SBorrow CHAR(1): expected(%q), actual(%#v)", "d", rset.Row[2])
+		}
+	}
+	testErr(rset.Err, t)
+
+	// LOB columns can't be borrowed
+	stmt, err = testSes.Prep("SELECT TO_CLOB('abc') FROM DUAL", ora.SBorrow)
+	testErr(err, t)
+	defer stmt.Close()
+	if _, err = stmt.Qry(); err == nil {
+		t.Fatal("SBorrow CLOB: expected an error")
+	}
+}
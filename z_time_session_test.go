@@ -6,6 +6,9 @@ package ora_test
 
 import (
 	"testing"
+	"time"
+
+	"gopkg.in/rana/ora.v3"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -271,3 +274,35 @@ func TestWorkload_timestampLtzP9Null_session(t *testing.T) {
 func TestBindDefine_timestampLtzP9Null_nil_session(t *testing.T) {
 	testBindDefine(nil, timestampLtzP9Null, t, nil)
 }
+
+func TestDefine_time_reusedDescriptor_session(t *testing.T) {
+	// the descriptor of a time define is reused by each fetch and Rset
+	stmt, err := testSes.Prep(`SELECT CASE WHEN MOD(LEVEL, 3) = 0 THEN NULL
+		ELSE TIMESTAMP '2000-01-01 00:00:00 +00:00' + NUMTODSINTERVAL(LEVEL, 'SECOND') END
+		FROM DUAL CONNECT BY LEVEL <= 100 ORDER BY LEVEL`, ora.OraT)
+	defer stmt.Close()
+	testErr(err, t)
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for round := 0; round < 2; round++ {
+		rset, err := stmt.Qry()
+		testErr(err, t)
+		var values []ora.Time
+		for rset.Next() {
+			values = append(values, rset.Row[0].(ora.Time))
+		}
+		testErr(rset.Err, t)
+		if len(values) != 100 {
+			t.Fatalf("round %d: expected 100 rows, actual %d", round, len(values))
+		}
+		for n, value := range values {
+			level := n + 1
+			if level%3 == 0 {
+				if !value.IsNull {
+					t.Errorf("round %d, level %d: expected null, actual %v", round, level, value)
+				}
+			} else if expected := start.Add(time.Duration(level) * time.Second); value.IsNull || !value.Value.Equal(expected) {
+				t.Errorf("round %d, level %d: expected(%v), actual(%v)", round, level, expected, value)
+			}
+		}
+	}
+}